@@ -0,0 +1,121 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+var (
+	ErrNotFound   = errors.New("blob not found")
+	ErrInvalidKey = errors.New("invalid blob key")
+)
+
+// Store persists binary objects under slash separated keys and hands out
+// the public URL the object can be fetched from
+type Store interface {
+	Put(c context.Context, key string, r io.Reader, contentType string) (string, error)
+	Get(c context.Context, key string) (io.ReadCloser, error)
+	Delete(c context.Context, key string) error
+	URL(key string) string
+}
+
+// LocalStore keeps blobs on the local filesystem, it is meant for
+// development and single node deployments and serves its files through Handler
+type LocalStore struct {
+	dir     string
+	baseURL string
+}
+
+func NewLocalStore(dir, baseURL string) (*LocalStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &LocalStore{
+		dir:     dir,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+	}, nil
+}
+
+func (s *LocalStore) path(key string) (string, error) {
+	clean := path.Clean("/" + key)
+	if key == "" || clean == "/" || clean != "/"+key {
+		return "", ErrInvalidKey
+	}
+	return filepath.Join(s.dir, filepath.FromSlash(clean)), nil
+}
+
+func (s *LocalStore) Put(c context.Context, key string, r io.Reader, contentType string) (string, error) {
+	p, err := s.path(key)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		return "", err
+	}
+
+	// write to a temporary file first so readers never see a partial blob
+	tmp, err := os.CreateTemp(filepath.Dir(p), ".upload-*")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		return "", err
+	}
+	if err := tmp.Close(); err != nil {
+		return "", err
+	}
+	if err := os.Rename(tmp.Name(), p); err != nil {
+		return "", err
+	}
+	return s.URL(key), nil
+}
+
+func (s *LocalStore) Get(c context.Context, key string) (io.ReadCloser, error) {
+	p, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(p)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	return f, err
+}
+
+func (s *LocalStore) Delete(c context.Context, key string) error {
+	p, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(p); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (s *LocalStore) URL(key string) string {
+	return s.baseURL + "/" + (&url.URL{Path: key}).EscapedPath()
+}
+
+// Handler serves the stored blobs, mount it under the path of baseURL
+func (s *LocalStore) Handler() http.Handler {
+	files := http.FileServer(http.Dir(s.dir))
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// never list directories
+		if r.URL.Path == "" || strings.HasSuffix(r.URL.Path, "/") {
+			http.NotFound(w, r)
+			return
+		}
+		files.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,157 @@
+package client
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/wignn/micro-3/cart/genproto"
+	"github.com/wignn/micro-3/cart/model"
+	"github.com/wignn/micro-3/currency"
+	orderModel "github.com/wignn/micro-3/order/model"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+type CartClient struct {
+	conn    *grpc.ClientConn
+	service genproto.CartServiceClient
+}
+
+func NewClient(url string) (*CartClient, error) {
+	conn, err := grpc.NewClient(url, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		log.Printf("failed to connect to cart service: %v\n", err)
+		return nil, err
+	}
+
+	c := genproto.NewCartServiceClient(conn)
+	return &CartClient{conn: conn, service: c}, nil
+}
+
+func (cl *CartClient) Close() {
+	cl.conn.Close()
+}
+
+func refToProto(ref model.Ref) *genproto.CartRef {
+	return &genproto.CartRef{CartId: ref.ID, AccountId: ref.AccountID}
+}
+
+func addressToProto(a *orderModel.Address) *genproto.Address {
+	if a == nil {
+		return nil
+	}
+	return &genproto.Address{
+		Name:       a.Name,
+		Line1:      a.Line1,
+		Line2:      a.Line2,
+		City:       a.City,
+		Region:     a.Region,
+		PostalCode: a.PostalCode,
+		Country:    a.Country,
+	}
+}
+
+func moneyFromProto(m *genproto.Money) currency.Money {
+	if m == nil {
+		return currency.Money{}
+	}
+	return currency.Money{Amount: m.Amount, Currency: m.Currency}
+}
+
+func cartFromProto(cp *genproto.Cart) *model.Cart {
+	cart := &model.Cart{
+		ID:        cp.Id,
+		AccountID: cp.AccountId,
+		Currency:  cp.Currency,
+		Lines:     []model.Line{},
+		Version:   cp.Version,
+	}
+	cart.CreatedAt = time.Time{}
+	cart.CreatedAt.UnmarshalBinary(cp.CreatedAt)
+	cart.UpdatedAt = time.Time{}
+	cart.UpdatedAt.UnmarshalBinary(cp.UpdatedAt)
+	for _, l := range cp.Lines {
+		line := model.Line{
+			ProductID:    l.ProductId,
+			Quantity:     l.Quantity,
+			Name:         l.Name,
+			UnitPrice:    moneyFromProto(l.UnitPrice),
+			Available:    l.Available,
+			PriceChanged: l.PriceChanged,
+		}
+		line.AddedAt.UnmarshalBinary(l.AddedAt)
+		cart.Lines = append(cart.Lines, line)
+	}
+	return cart
+}
+
+func (cl *CartClient) GetCart(c context.Context, ref model.Ref) (*model.Cart, error) {
+	r, err := cl.service.GetCart(c, &genproto.GetCartRequest{Cart: refToProto(ref)})
+	if err != nil {
+		log.Printf("failed to get cart: %v\n", err)
+		return nil, err
+	}
+	return cartFromProto(r.Cart), nil
+}
+
+// AddLine adds units of a product, a new cart is created when ref selects none
+func (cl *CartClient) AddLine(c context.Context, ref model.Ref, productID string, quantity uint32) (*model.Cart, error) {
+	r, err := cl.service.AddLine(c, &genproto.AddLineRequest{
+		Cart:      refToProto(ref),
+		ProductId: productID,
+		Quantity:  quantity,
+	})
+	if err != nil {
+		log.Printf("failed to add product to cart: %v\n", err)
+		return nil, err
+	}
+	return cartFromProto(r.Cart), nil
+}
+
+func (cl *CartClient) UpdateLine(c context.Context, ref model.Ref, productID string, quantity uint32) (*model.Cart, error) {
+	r, err := cl.service.UpdateLine(c, &genproto.UpdateLineRequest{
+		Cart:      refToProto(ref),
+		ProductId: productID,
+		Quantity:  quantity,
+	})
+	if err != nil {
+		log.Printf("failed to update cart line: %v\n", err)
+		return nil, err
+	}
+	return cartFromProto(r.Cart), nil
+}
+
+func (cl *CartClient) RemoveLine(c context.Context, ref model.Ref, productID string) (*model.Cart, error) {
+	r, err := cl.service.RemoveLine(c, &genproto.RemoveLineRequest{Cart: refToProto(ref), ProductId: productID})
+	if err != nil {
+		log.Printf("failed to remove cart line: %v\n", err)
+		return nil, err
+	}
+	return cartFromProto(r.Cart), nil
+}
+
+func (cl *CartClient) MergeCarts(c context.Context, cartID, accountID string) (*model.Cart, error) {
+	r, err := cl.service.MergeCarts(c, &genproto.MergeCartsRequest{CartId: cartID, AccountId: accountID})
+	if err != nil {
+		log.Printf("failed to merge carts: %v\n", err)
+		return nil, err
+	}
+	return cartFromProto(r.Cart), nil
+}
+
+// Checkout places an order with the cart lines and returns the order id with
+// the emptied cart
+func (cl *CartClient) Checkout(c context.Context, ref model.Ref, coupons []string, address *orderModel.Address, idempotencyKey string) (string, *model.Cart, error) {
+	r, err := cl.service.Checkout(c, &genproto.CheckoutRequest{
+		Cart:            refToProto(ref),
+		IdempotencyKey:  idempotencyKey,
+		Coupons:         coupons,
+		ShippingAddress: addressToProto(address),
+	})
+	if err != nil {
+		log.Printf("failed to check out cart: %v\n", err)
+		return "", nil, err
+	}
+	return r.OrderId, cartFromProto(r.Cart), nil
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/kelseyhightower/envconfig"
+	"github.com/tinrab/retry"
+	"github.com/wignn/micro-3/cart/repository"
+	"github.com/wignn/micro-3/cart/server"
+	"github.com/wignn/micro-3/cart/service"
+	catalog "github.com/wignn/micro-3/catalog/client"
+	"github.com/wignn/micro-3/currency"
+	order "github.com/wignn/micro-3/order/client"
+)
+
+type Config struct {
+	DatabaseURL     string        `envconfig:"DATABASE_URL"`
+	CatalogURL      string        `envconfig:"CATALOG_SERVICE_URL"`
+	OrderURL        string        `envconfig:"ORDER_SERVICE_URL"`
+	RatesFile       string        `envconfig:"RATES_FILE"`
+	DefaultCurrency string        `envconfig:"DEFAULT_CURRENCY" default:"USD"`
+	MaxLineQuantity uint32        `envconfig:"MAX_LINE_QUANTITY" default:"100"`
+	MaxCartLines    int           `envconfig:"MAX_CART_LINES" default:"50"`
+	PriceTTL        time.Duration `envconfig:"PRICE_TTL" default:"5m"`
+	AnonymousTTL    time.Duration `envconfig:"ANONYMOUS_CART_TTL" default:"168h"`
+	AccountTTL      time.Duration `envconfig:"ACCOUNT_CART_TTL" default:"720h"`
+	ExpiryInterval  time.Duration `envconfig:"EXPIRY_INTERVAL" default:"1h"`
+	PORT            int           `envconfig:"PORT" default:"50051"`
+}
+
+func main() {
+	var cfg Config
+	if err := envconfig.Process("", &cfg); err != nil {
+		log.Fatal("Failed to process environment variables:", err)
+	}
+
+	var r repository.CartRepository
+	retry.ForeverSleep(2*time.Second, func(_ int) (err error) {
+		r, err = repository.NewPostgresRepository(cfg.DatabaseURL)
+		if err != nil {
+			log.Println(err)
+		}
+		return
+	})
+	defer r.Close()
+
+	defaultCurrency, err := currency.Normalize(cfg.DefaultCurrency)
+	if err != nil {
+		log.Fatal("Invalid default currency:", err)
+	}
+	rates, err := currency.NewStaticFileProvider(cfg.RatesFile)
+	if err != nil {
+		log.Fatal("Failed to load exchange rates:", err)
+	}
+
+	catalogClient, err := catalog.NewClient(cfg.CatalogURL)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer catalogClient.Close()
+
+	orderClient, err := order.NewClient(cfg.OrderURL)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer orderClient.Close()
+
+	s := service.NewCartService(r, catalogClient, orderClient, currency.NewConverter(rates), service.Options{
+		DefaultCurrency: defaultCurrency,
+		MaxLineQuantity: cfg.MaxLineQuantity,
+		MaxLines:        cfg.MaxCartLines,
+		PriceTTL:        cfg.PriceTTL,
+		AnonymousTTL:    cfg.AnonymousTTL,
+		AccountTTL:      cfg.AccountTTL,
+	})
+	go expire(s, cfg.ExpiryInterval)
+
+	log.Println("listening on port", cfg.PORT)
+	log.Fatal(server.ListenGRPC(s, cfg.PORT))
+}
+
+// expire deletes stale carts at startup and then every interval
+func expire(s service.CartService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), interval)
+		n, err := s.ExpireCarts(ctx)
+		cancel()
+		if err != nil {
+			log.Println("failed to expire carts:", err)
+		} else if n > 0 {
+			log.Printf("expired %d carts\n", n)
+		}
+		<-ticker.C
+	}
+}
@@ -0,0 +1,1006 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v6.31.0
+// source: cart.proto
+
+package genproto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Money is an exact amount in the minor unit of its currency
+type Money struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Amount        int64                  `protobuf:"varint,1,opt,name=amount,proto3" json:"amount,omitempty"`
+	Currency      string                 `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Money) Reset() {
+	*x = Money{}
+	mi := &file_cart_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Money) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Money) ProtoMessage() {}
+
+func (x *Money) ProtoReflect() protoreflect.Message {
+	mi := &file_cart_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Money.ProtoReflect.Descriptor instead.
+func (*Money) Descriptor() ([]byte, []int) {
+	return file_cart_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Money) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *Money) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+type CartLine struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ProductId string                 `protobuf:"bytes,1,opt,name=productId,proto3" json:"productId,omitempty"`
+	Quantity  uint32                 `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Name      string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	UnitPrice *Money                 `protobuf:"bytes,4,opt,name=unitPrice,proto3" json:"unitPrice,omitempty"`
+	Available bool                   `protobuf:"varint,5,opt,name=available,proto3" json:"available,omitempty"`
+	// the catalog price changed since the line was last priced
+	PriceChanged  bool   `protobuf:"varint,6,opt,name=priceChanged,proto3" json:"priceChanged,omitempty"`
+	AddedAt       []byte `protobuf:"bytes,7,opt,name=addedAt,proto3" json:"addedAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CartLine) Reset() {
+	*x = CartLine{}
+	mi := &file_cart_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CartLine) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CartLine) ProtoMessage() {}
+
+func (x *CartLine) ProtoReflect() protoreflect.Message {
+	mi := &file_cart_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CartLine.ProtoReflect.Descriptor instead.
+func (*CartLine) Descriptor() ([]byte, []int) {
+	return file_cart_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CartLine) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *CartLine) GetQuantity() uint32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *CartLine) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CartLine) GetUnitPrice() *Money {
+	if x != nil {
+		return x.UnitPrice
+	}
+	return nil
+}
+
+func (x *CartLine) GetAvailable() bool {
+	if x != nil {
+		return x.Available
+	}
+	return false
+}
+
+func (x *CartLine) GetPriceChanged() bool {
+	if x != nil {
+		return x.PriceChanged
+	}
+	return false
+}
+
+func (x *CartLine) GetAddedAt() []byte {
+	if x != nil {
+		return x.AddedAt
+	}
+	return nil
+}
+
+type Cart struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	AccountId     string                 `protobuf:"bytes,2,opt,name=accountId,proto3" json:"accountId,omitempty"`
+	Currency      string                 `protobuf:"bytes,3,opt,name=currency,proto3" json:"currency,omitempty"`
+	Lines         []*CartLine            `protobuf:"bytes,4,rep,name=lines,proto3" json:"lines,omitempty"`
+	Subtotal      *Money                 `protobuf:"bytes,5,opt,name=subtotal,proto3" json:"subtotal,omitempty"`
+	Version       int64                  `protobuf:"varint,6,opt,name=version,proto3" json:"version,omitempty"`
+	CreatedAt     []byte                 `protobuf:"bytes,7,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	UpdatedAt     []byte                 `protobuf:"bytes,8,opt,name=updatedAt,proto3" json:"updatedAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Cart) Reset() {
+	*x = Cart{}
+	mi := &file_cart_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Cart) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Cart) ProtoMessage() {}
+
+func (x *Cart) ProtoReflect() protoreflect.Message {
+	mi := &file_cart_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Cart.ProtoReflect.Descriptor instead.
+func (*Cart) Descriptor() ([]byte, []int) {
+	return file_cart_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Cart) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Cart) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *Cart) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *Cart) GetLines() []*CartLine {
+	if x != nil {
+		return x.Lines
+	}
+	return nil
+}
+
+func (x *Cart) GetSubtotal() *Money {
+	if x != nil {
+		return x.Subtotal
+	}
+	return nil
+}
+
+func (x *Cart) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Cart) GetCreatedAt() []byte {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Cart) GetUpdatedAt() []byte {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// CartRef selects the cart of an account, or an anonymous cart by id
+type CartRef struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CartId        string                 `protobuf:"bytes,1,opt,name=cartId,proto3" json:"cartId,omitempty"`
+	AccountId     string                 `protobuf:"bytes,2,opt,name=accountId,proto3" json:"accountId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CartRef) Reset() {
+	*x = CartRef{}
+	mi := &file_cart_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CartRef) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CartRef) ProtoMessage() {}
+
+func (x *CartRef) ProtoReflect() protoreflect.Message {
+	mi := &file_cart_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CartRef.ProtoReflect.Descriptor instead.
+func (*CartRef) Descriptor() ([]byte, []int) {
+	return file_cart_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CartRef) GetCartId() string {
+	if x != nil {
+		return x.CartId
+	}
+	return ""
+}
+
+func (x *CartRef) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+type GetCartRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cart          *CartRef               `protobuf:"bytes,1,opt,name=cart,proto3" json:"cart,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCartRequest) Reset() {
+	*x = GetCartRequest{}
+	mi := &file_cart_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCartRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCartRequest) ProtoMessage() {}
+
+func (x *GetCartRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cart_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCartRequest.ProtoReflect.Descriptor instead.
+func (*GetCartRequest) Descriptor() ([]byte, []int) {
+	return file_cart_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetCartRequest) GetCart() *CartRef {
+	if x != nil {
+		return x.Cart
+	}
+	return nil
+}
+
+type CartResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cart          *Cart                  `protobuf:"bytes,1,opt,name=cart,proto3" json:"cart,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CartResponse) Reset() {
+	*x = CartResponse{}
+	mi := &file_cart_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CartResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CartResponse) ProtoMessage() {}
+
+func (x *CartResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cart_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CartResponse.ProtoReflect.Descriptor instead.
+func (*CartResponse) Descriptor() ([]byte, []int) {
+	return file_cart_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CartResponse) GetCart() *Cart {
+	if x != nil {
+		return x.Cart
+	}
+	return nil
+}
+
+type AddLineRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cart          *CartRef               `protobuf:"bytes,1,opt,name=cart,proto3" json:"cart,omitempty"`
+	ProductId     string                 `protobuf:"bytes,2,opt,name=productId,proto3" json:"productId,omitempty"`
+	Quantity      uint32                 `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddLineRequest) Reset() {
+	*x = AddLineRequest{}
+	mi := &file_cart_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddLineRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddLineRequest) ProtoMessage() {}
+
+func (x *AddLineRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cart_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddLineRequest.ProtoReflect.Descriptor instead.
+func (*AddLineRequest) Descriptor() ([]byte, []int) {
+	return file_cart_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *AddLineRequest) GetCart() *CartRef {
+	if x != nil {
+		return x.Cart
+	}
+	return nil
+}
+
+func (x *AddLineRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *AddLineRequest) GetQuantity() uint32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+type UpdateLineRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cart          *CartRef               `protobuf:"bytes,1,opt,name=cart,proto3" json:"cart,omitempty"`
+	ProductId     string                 `protobuf:"bytes,2,opt,name=productId,proto3" json:"productId,omitempty"`
+	Quantity      uint32                 `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateLineRequest) Reset() {
+	*x = UpdateLineRequest{}
+	mi := &file_cart_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateLineRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateLineRequest) ProtoMessage() {}
+
+func (x *UpdateLineRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cart_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateLineRequest.ProtoReflect.Descriptor instead.
+func (*UpdateLineRequest) Descriptor() ([]byte, []int) {
+	return file_cart_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *UpdateLineRequest) GetCart() *CartRef {
+	if x != nil {
+		return x.Cart
+	}
+	return nil
+}
+
+func (x *UpdateLineRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *UpdateLineRequest) GetQuantity() uint32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+type RemoveLineRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cart          *CartRef               `protobuf:"bytes,1,opt,name=cart,proto3" json:"cart,omitempty"`
+	ProductId     string                 `protobuf:"bytes,2,opt,name=productId,proto3" json:"productId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveLineRequest) Reset() {
+	*x = RemoveLineRequest{}
+	mi := &file_cart_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveLineRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveLineRequest) ProtoMessage() {}
+
+func (x *RemoveLineRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cart_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveLineRequest.ProtoReflect.Descriptor instead.
+func (*RemoveLineRequest) Descriptor() ([]byte, []int) {
+	return file_cart_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *RemoveLineRequest) GetCart() *CartRef {
+	if x != nil {
+		return x.Cart
+	}
+	return nil
+}
+
+func (x *RemoveLineRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+type MergeCartsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CartId        string                 `protobuf:"bytes,1,opt,name=cartId,proto3" json:"cartId,omitempty"`
+	AccountId     string                 `protobuf:"bytes,2,opt,name=accountId,proto3" json:"accountId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MergeCartsRequest) Reset() {
+	*x = MergeCartsRequest{}
+	mi := &file_cart_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergeCartsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergeCartsRequest) ProtoMessage() {}
+
+func (x *MergeCartsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cart_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergeCartsRequest.ProtoReflect.Descriptor instead.
+func (*MergeCartsRequest) Descriptor() ([]byte, []int) {
+	return file_cart_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *MergeCartsRequest) GetCartId() string {
+	if x != nil {
+		return x.CartId
+	}
+	return ""
+}
+
+func (x *MergeCartsRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+// Address is where the order is shipped, see the order service
+type Address struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Line1         string                 `protobuf:"bytes,2,opt,name=line1,proto3" json:"line1,omitempty"`
+	Line2         string                 `protobuf:"bytes,3,opt,name=line2,proto3" json:"line2,omitempty"`
+	City          string                 `protobuf:"bytes,4,opt,name=city,proto3" json:"city,omitempty"`
+	Region        string                 `protobuf:"bytes,5,opt,name=region,proto3" json:"region,omitempty"`
+	PostalCode    string                 `protobuf:"bytes,6,opt,name=postalCode,proto3" json:"postalCode,omitempty"`
+	Country       string                 `protobuf:"bytes,7,opt,name=country,proto3" json:"country,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Address) Reset() {
+	*x = Address{}
+	mi := &file_cart_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Address) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Address) ProtoMessage() {}
+
+func (x *Address) ProtoReflect() protoreflect.Message {
+	mi := &file_cart_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Address.ProtoReflect.Descriptor instead.
+func (*Address) Descriptor() ([]byte, []int) {
+	return file_cart_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Address) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Address) GetLine1() string {
+	if x != nil {
+		return x.Line1
+	}
+	return ""
+}
+
+func (x *Address) GetLine2() string {
+	if x != nil {
+		return x.Line2
+	}
+	return ""
+}
+
+func (x *Address) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *Address) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *Address) GetPostalCode() string {
+	if x != nil {
+		return x.PostalCode
+	}
+	return ""
+}
+
+func (x *Address) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+type CheckoutRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Cart           *CartRef               `protobuf:"bytes,1,opt,name=cart,proto3" json:"cart,omitempty"`
+	IdempotencyKey string                 `protobuf:"bytes,2,opt,name=idempotencyKey,proto3" json:"idempotencyKey,omitempty"`
+	// coupons and the address are passed on to the order
+	Coupons         []string `protobuf:"bytes,3,rep,name=coupons,proto3" json:"coupons,omitempty"`
+	ShippingAddress *Address `protobuf:"bytes,4,opt,name=shippingAddress,proto3" json:"shippingAddress,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CheckoutRequest) Reset() {
+	*x = CheckoutRequest{}
+	mi := &file_cart_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckoutRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckoutRequest) ProtoMessage() {}
+
+func (x *CheckoutRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cart_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckoutRequest.ProtoReflect.Descriptor instead.
+func (*CheckoutRequest) Descriptor() ([]byte, []int) {
+	return file_cart_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *CheckoutRequest) GetCart() *CartRef {
+	if x != nil {
+		return x.Cart
+	}
+	return nil
+}
+
+func (x *CheckoutRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *CheckoutRequest) GetCoupons() []string {
+	if x != nil {
+		return x.Coupons
+	}
+	return nil
+}
+
+func (x *CheckoutRequest) GetShippingAddress() *Address {
+	if x != nil {
+		return x.ShippingAddress
+	}
+	return nil
+}
+
+type CheckoutResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=orderId,proto3" json:"orderId,omitempty"`
+	Cart          *Cart                  `protobuf:"bytes,2,opt,name=cart,proto3" json:"cart,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckoutResponse) Reset() {
+	*x = CheckoutResponse{}
+	mi := &file_cart_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckoutResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckoutResponse) ProtoMessage() {}
+
+func (x *CheckoutResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cart_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckoutResponse.ProtoReflect.Descriptor instead.
+func (*CheckoutResponse) Descriptor() ([]byte, []int) {
+	return file_cart_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CheckoutResponse) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *CheckoutResponse) GetCart() *Cart {
+	if x != nil {
+		return x.Cart
+	}
+	return nil
+}
+
+var File_cart_proto protoreflect.FileDescriptor
+
+const file_cart_proto_rawDesc = "" +
+	"\n" +
+	"\n" +
+	"cart.proto\x12\bgenproto\";\n" +
+	"\x05Money\x12\x16\n" +
+	"\x06amount\x18\x01 \x01(\x03R\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\x02 \x01(\tR\bcurrency\"\xe3\x01\n" +
+	"\bCartLine\x12\x1c\n" +
+	"\tproductId\x18\x01 \x01(\tR\tproductId\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\rR\bquantity\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12-\n" +
+	"\tunitPrice\x18\x04 \x01(\v2\x0f.genproto.MoneyR\tunitPrice\x12\x1c\n" +
+	"\tavailable\x18\x05 \x01(\bR\tavailable\x12\"\n" +
+	"\fpriceChanged\x18\x06 \x01(\bR\fpriceChanged\x12\x18\n" +
+	"\aaddedAt\x18\a \x01(\fR\aaddedAt\"\xfd\x01\n" +
+	"\x04Cart\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1c\n" +
+	"\taccountId\x18\x02 \x01(\tR\taccountId\x12\x1a\n" +
+	"\bcurrency\x18\x03 \x01(\tR\bcurrency\x12(\n" +
+	"\x05lines\x18\x04 \x03(\v2\x12.genproto.CartLineR\x05lines\x12+\n" +
+	"\bsubtotal\x18\x05 \x01(\v2\x0f.genproto.MoneyR\bsubtotal\x12\x18\n" +
+	"\aversion\x18\x06 \x01(\x03R\aversion\x12\x1c\n" +
+	"\tcreatedAt\x18\a \x01(\fR\tcreatedAt\x12\x1c\n" +
+	"\tupdatedAt\x18\b \x01(\fR\tupdatedAt\"?\n" +
+	"\aCartRef\x12\x16\n" +
+	"\x06cartId\x18\x01 \x01(\tR\x06cartId\x12\x1c\n" +
+	"\taccountId\x18\x02 \x01(\tR\taccountId\"7\n" +
+	"\x0eGetCartRequest\x12%\n" +
+	"\x04cart\x18\x01 \x01(\v2\x11.genproto.CartRefR\x04cart\"2\n" +
+	"\fCartResponse\x12\"\n" +
+	"\x04cart\x18\x01 \x01(\v2\x0e.genproto.CartR\x04cart\"q\n" +
+	"\x0eAddLineRequest\x12%\n" +
+	"\x04cart\x18\x01 \x01(\v2\x11.genproto.CartRefR\x04cart\x12\x1c\n" +
+	"\tproductId\x18\x02 \x01(\tR\tproductId\x12\x1a\n" +
+	"\bquantity\x18\x03 \x01(\rR\bquantity\"t\n" +
+	"\x11UpdateLineRequest\x12%\n" +
+	"\x04cart\x18\x01 \x01(\v2\x11.genproto.CartRefR\x04cart\x12\x1c\n" +
+	"\tproductId\x18\x02 \x01(\tR\tproductId\x12\x1a\n" +
+	"\bquantity\x18\x03 \x01(\rR\bquantity\"X\n" +
+	"\x11RemoveLineRequest\x12%\n" +
+	"\x04cart\x18\x01 \x01(\v2\x11.genproto.CartRefR\x04cart\x12\x1c\n" +
+	"\tproductId\x18\x02 \x01(\tR\tproductId\"I\n" +
+	"\x11MergeCartsRequest\x12\x16\n" +
+	"\x06cartId\x18\x01 \x01(\tR\x06cartId\x12\x1c\n" +
+	"\taccountId\x18\x02 \x01(\tR\taccountId\"\xaf\x01\n" +
+	"\aAddress\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05line1\x18\x02 \x01(\tR\x05line1\x12\x14\n" +
+	"\x05line2\x18\x03 \x01(\tR\x05line2\x12\x12\n" +
+	"\x04city\x18\x04 \x01(\tR\x04city\x12\x16\n" +
+	"\x06region\x18\x05 \x01(\tR\x06region\x12\x1e\n" +
+	"\n" +
+	"postalCode\x18\x06 \x01(\tR\n" +
+	"postalCode\x12\x18\n" +
+	"\acountry\x18\a \x01(\tR\acountry\"\xb7\x01\n" +
+	"\x0fCheckoutRequest\x12%\n" +
+	"\x04cart\x18\x01 \x01(\v2\x11.genproto.CartRefR\x04cart\x12&\n" +
+	"\x0eidempotencyKey\x18\x02 \x01(\tR\x0eidempotencyKey\x12\x18\n" +
+	"\acoupons\x18\x03 \x03(\tR\acoupons\x12;\n" +
+	"\x0fshippingAddress\x18\x04 \x01(\v2\x11.genproto.AddressR\x0fshippingAddress\"P\n" +
+	"\x10CheckoutResponse\x12\x18\n" +
+	"\aorderId\x18\x01 \x01(\tR\aorderId\x12\"\n" +
+	"\x04cart\x18\x02 \x01(\v2\x0e.genproto.CartR\x04cart2\x93\x03\n" +
+	"\vCartService\x12;\n" +
+	"\aGetCart\x12\x18.genproto.GetCartRequest\x1a\x16.genproto.CartResponse\x12;\n" +
+	"\aAddLine\x12\x18.genproto.AddLineRequest\x1a\x16.genproto.CartResponse\x12A\n" +
+	"\n" +
+	"UpdateLine\x12\x1b.genproto.UpdateLineRequest\x1a\x16.genproto.CartResponse\x12A\n" +
+	"\n" +
+	"RemoveLine\x12\x1b.genproto.RemoveLineRequest\x1a\x16.genproto.CartResponse\x12A\n" +
+	"\n" +
+	"MergeCarts\x12\x1b.genproto.MergeCartsRequest\x1a\x16.genproto.CartResponse\x12A\n" +
+	"\bCheckout\x12\x19.genproto.CheckoutRequest\x1a\x1a.genproto.CheckoutResponseB(Z&github.com/wignn/micro-3/cart/genprotob\x06proto3"
+
+var (
+	file_cart_proto_rawDescOnce sync.Once
+	file_cart_proto_rawDescData []byte
+)
+
+func file_cart_proto_rawDescGZIP() []byte {
+	file_cart_proto_rawDescOnce.Do(func() {
+		file_cart_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_cart_proto_rawDesc), len(file_cart_proto_rawDesc)))
+	})
+	return file_cart_proto_rawDescData
+}
+
+var file_cart_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_cart_proto_goTypes = []any{
+	(*Money)(nil),             // 0: genproto.Money
+	(*CartLine)(nil),          // 1: genproto.CartLine
+	(*Cart)(nil),              // 2: genproto.Cart
+	(*CartRef)(nil),           // 3: genproto.CartRef
+	(*GetCartRequest)(nil),    // 4: genproto.GetCartRequest
+	(*CartResponse)(nil),      // 5: genproto.CartResponse
+	(*AddLineRequest)(nil),    // 6: genproto.AddLineRequest
+	(*UpdateLineRequest)(nil), // 7: genproto.UpdateLineRequest
+	(*RemoveLineRequest)(nil), // 8: genproto.RemoveLineRequest
+	(*MergeCartsRequest)(nil), // 9: genproto.MergeCartsRequest
+	(*Address)(nil),           // 10: genproto.Address
+	(*CheckoutRequest)(nil),   // 11: genproto.CheckoutRequest
+	(*CheckoutResponse)(nil),  // 12: genproto.CheckoutResponse
+}
+var file_cart_proto_depIdxs = []int32{
+	0,  // 0: genproto.CartLine.unitPrice:type_name -> genproto.Money
+	1,  // 1: genproto.Cart.lines:type_name -> genproto.CartLine
+	0,  // 2: genproto.Cart.subtotal:type_name -> genproto.Money
+	3,  // 3: genproto.GetCartRequest.cart:type_name -> genproto.CartRef
+	2,  // 4: genproto.CartResponse.cart:type_name -> genproto.Cart
+	3,  // 5: genproto.AddLineRequest.cart:type_name -> genproto.CartRef
+	3,  // 6: genproto.UpdateLineRequest.cart:type_name -> genproto.CartRef
+	3,  // 7: genproto.RemoveLineRequest.cart:type_name -> genproto.CartRef
+	3,  // 8: genproto.CheckoutRequest.cart:type_name -> genproto.CartRef
+	10, // 9: genproto.CheckoutRequest.shippingAddress:type_name -> genproto.Address
+	2,  // 10: genproto.CheckoutResponse.cart:type_name -> genproto.Cart
+	4,  // 11: genproto.CartService.GetCart:input_type -> genproto.GetCartRequest
+	6,  // 12: genproto.CartService.AddLine:input_type -> genproto.AddLineRequest
+	7,  // 13: genproto.CartService.UpdateLine:input_type -> genproto.UpdateLineRequest
+	8,  // 14: genproto.CartService.RemoveLine:input_type -> genproto.RemoveLineRequest
+	9,  // 15: genproto.CartService.MergeCarts:input_type -> genproto.MergeCartsRequest
+	11, // 16: genproto.CartService.Checkout:input_type -> genproto.CheckoutRequest
+	5,  // 17: genproto.CartService.GetCart:output_type -> genproto.CartResponse
+	5,  // 18: genproto.CartService.AddLine:output_type -> genproto.CartResponse
+	5,  // 19: genproto.CartService.UpdateLine:output_type -> genproto.CartResponse
+	5,  // 20: genproto.CartService.RemoveLine:output_type -> genproto.CartResponse
+	5,  // 21: genproto.CartService.MergeCarts:output_type -> genproto.CartResponse
+	12, // 22: genproto.CartService.Checkout:output_type -> genproto.CheckoutResponse
+	17, // [17:23] is the sub-list for method output_type
+	11, // [11:17] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
+}
+
+func init() { file_cart_proto_init() }
+func file_cart_proto_init() {
+	if File_cart_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_cart_proto_rawDesc), len(file_cart_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   13,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_cart_proto_goTypes,
+		DependencyIndexes: file_cart_proto_depIdxs,
+		MessageInfos:      file_cart_proto_msgTypes,
+	}.Build()
+	File_cart_proto = out.File
+	file_cart_proto_goTypes = nil
+	file_cart_proto_depIdxs = nil
+}
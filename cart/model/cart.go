@@ -0,0 +1,66 @@
+package model
+
+import (
+	"time"
+
+	"github.com/wignn/micro-3/currency"
+)
+
+// Cart holds the products a customer intends to order. Anonymous carts have
+// no AccountID and are merged into the account's cart on login.
+type Cart struct {
+	ID        string
+	AccountID string
+	Currency  string
+	Lines     []Line
+	// Version is bumped on every save, concurrent writers retry on conflict
+	Version   int64
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	// PricedAt is when the lines were last priced from the catalog
+	PricedAt time.Time
+}
+
+// Line is a product in a cart, Name and UnitPrice are copied from the catalog
+// and converted to the cart currency when the cart is priced
+type Line struct {
+	ProductID    string
+	Quantity     uint32
+	Name         string
+	UnitPrice    currency.Money
+	Available    bool
+	PriceChanged bool
+	AddedAt      time.Time
+}
+
+func (l Line) Total() currency.Money {
+	return l.UnitPrice.Mul(int64(l.Quantity))
+}
+
+// Subtotal sums the lines that can be ordered
+func (c *Cart) Subtotal() currency.Money {
+	subtotal := currency.Money{Currency: c.Currency}
+	for _, l := range c.Lines {
+		if l.Available {
+			subtotal.Amount += l.Total().Amount
+		}
+	}
+	return subtotal
+}
+
+// Line returns the line of a product, or nil if the product is not in the cart
+func (c *Cart) Line(productID string) *Line {
+	for i := range c.Lines {
+		if c.Lines[i].ProductID == productID {
+			return &c.Lines[i]
+		}
+	}
+	return nil
+}
+
+// Ref selects a cart, the account's cart when AccountID is set and the
+// anonymous cart ID otherwise
+type Ref struct {
+	ID        string
+	AccountID string
+}
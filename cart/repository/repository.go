@@ -0,0 +1,264 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/wignn/micro-3/cart/model"
+	"github.com/wignn/micro-3/currency"
+)
+
+var (
+	ErrNotFound = errors.New("cart not found")
+	// ErrConflict is returned when the cart was saved by someone else since it was read
+	ErrConflict = errors.New("cart was modified concurrently")
+	// ErrAccountHasCart is returned when a second cart is saved for an account
+	ErrAccountHasCart = errors.New("account already has a cart")
+)
+
+type CartRepository interface {
+	Close()
+	CreateCart(c context.Context, cart *model.Cart) error
+	GetCart(c context.Context, id string) (*model.Cart, error)
+	GetCartForAccount(c context.Context, accountID string) (*model.Cart, error)
+	SaveCart(c context.Context, cart *model.Cart) error
+	MergeCart(c context.Context, into *model.Cart, fromID string) error
+	DeleteStaleCarts(c context.Context, anonymousBefore, accountBefore time.Time) (int64, error)
+}
+
+type postgresRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresRepository(url string) (CartRepository, error) {
+	db, err := sql.Open("postgres", url)
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.Ping()
+	if err != nil {
+		return nil, err
+	}
+	return &postgresRepository{db}, nil
+}
+
+func (r *postgresRepository) Close() {
+	r.db.Close()
+}
+
+// inTx runs fn in a transaction that is committed when fn succeeds
+func (r *postgresRepository) inTx(c context.Context, fn func(tx *sql.Tx) error) (err error) {
+	tx, err := r.db.BeginTx(c, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+	return fn(tx)
+}
+
+func (r *postgresRepository) CreateCart(c context.Context, cart *model.Cart) error {
+	return r.inTx(c, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(
+			c,
+			`INSERT INTO carts(id, account_id, currency, version, created_at, updated_at, priced_at)
+			VALUES($1, $2, $3, $4, $5, $6, $7)`,
+			cart.ID,
+			accountID(cart),
+			cart.Currency,
+			cart.Version,
+			cart.CreatedAt,
+			cart.UpdatedAt,
+			cart.PricedAt,
+		)
+		if err != nil {
+			return accountConflict(err)
+		}
+		return insertLines(c, tx, cart)
+	})
+}
+
+func (r *postgresRepository) GetCart(c context.Context, id string) (*model.Cart, error) {
+	return getCart(c, r.db, "id", id)
+}
+
+func (r *postgresRepository) GetCartForAccount(c context.Context, accountID string) (*model.Cart, error) {
+	return getCart(c, r.db, "account_id", accountID)
+}
+
+// SaveCart replaces the cart and its lines if the cart is still at the
+// version it was read at, and bumps the version
+func (r *postgresRepository) SaveCart(c context.Context, cart *model.Cart) error {
+	err := r.inTx(c, func(tx *sql.Tx) error {
+		return saveCart(c, tx, cart)
+	})
+	if err == nil {
+		cart.Version++
+	}
+	return err
+}
+
+// MergeCart saves the cart the lines were merged into and deletes the cart
+// they came from in one transaction
+func (r *postgresRepository) MergeCart(c context.Context, into *model.Cart, fromID string) error {
+	err := r.inTx(c, func(tx *sql.Tx) error {
+		if err := saveCart(c, tx, into); err != nil {
+			return err
+		}
+		res, err := tx.ExecContext(c, "DELETE FROM carts WHERE id = $1", fromID)
+		if err != nil {
+			return err
+		}
+		if n, err := res.RowsAffected(); err != nil || n == 0 {
+			// merged concurrently, the lines would be added twice
+			if err == nil {
+				err = ErrConflict
+			}
+			return err
+		}
+		return nil
+	})
+	if err == nil {
+		into.Version++
+	}
+	return err
+}
+
+// DeleteStaleCarts deletes the anonymous carts not updated since
+// anonymousBefore and the account carts not updated since accountBefore
+func (r *postgresRepository) DeleteStaleCarts(c context.Context, anonymousBefore, accountBefore time.Time) (int64, error) {
+	res, err := r.db.ExecContext(
+		c,
+		`DELETE FROM carts
+		WHERE (account_id IS NULL AND updated_at < $1)
+		OR (account_id IS NOT NULL AND updated_at < $2)`,
+		anonymousBefore,
+		accountBefore,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+type querier interface {
+	QueryContext(c context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(c context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// getCart reads a cart with its lines, column is either id or account_id
+func getCart(c context.Context, q querier, column, value string) (*model.Cart, error) {
+	cart := &model.Cart{}
+	var account sql.NullString
+	err := q.QueryRowContext(
+		c,
+		`SELECT id, account_id, currency, version, created_at, updated_at, priced_at
+		FROM carts
+		WHERE `+column+` = $1`,
+		value,
+	).Scan(&cart.ID, &account, &cart.Currency, &cart.Version, &cart.CreatedAt, &cart.UpdatedAt, &cart.PricedAt)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	cart.AccountID = account.String
+
+	rows, err := q.QueryContext(
+		c,
+		`SELECT product_id, quantity, name, unit_price_minor, available, price_changed, added_at
+		FROM cart_lines
+		WHERE cart_id = $1
+		ORDER BY added_at, product_id`,
+		cart.ID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	cart.Lines = []model.Line{}
+	for rows.Next() {
+		l := model.Line{UnitPrice: currency.Money{Currency: cart.Currency}}
+		if err := rows.Scan(&l.ProductID, &l.Quantity, &l.Name, &l.UnitPrice.Amount, &l.Available, &l.PriceChanged, &l.AddedAt); err != nil {
+			return nil, err
+		}
+		cart.Lines = append(cart.Lines, l)
+	}
+	return cart, rows.Err()
+}
+
+func saveCart(c context.Context, tx *sql.Tx, cart *model.Cart) error {
+	res, err := tx.ExecContext(
+		c,
+		`UPDATE carts
+		SET account_id = $3, currency = $4, version = version + 1, updated_at = $5, priced_at = $6
+		WHERE id = $1 AND version = $2`,
+		cart.ID,
+		cart.Version,
+		accountID(cart),
+		cart.Currency,
+		cart.UpdatedAt,
+		cart.PricedAt,
+	)
+	if err != nil {
+		return accountConflict(err)
+	}
+	if n, err := res.RowsAffected(); err != nil || n == 0 {
+		if err == nil {
+			err = ErrConflict
+		}
+		return err
+	}
+
+	if _, err := tx.ExecContext(c, "DELETE FROM cart_lines WHERE cart_id = $1", cart.ID); err != nil {
+		return err
+	}
+	return insertLines(c, tx, cart)
+}
+
+func insertLines(c context.Context, tx *sql.Tx, cart *model.Cart) error {
+	for _, l := range cart.Lines {
+		_, err := tx.ExecContext(
+			c,
+			`INSERT INTO cart_lines(cart_id, product_id, quantity, name, unit_price_minor, available, price_changed, added_at)
+			VALUES($1, $2, $3, $4, $5, $6, $7, $8)`,
+			cart.ID,
+			l.ProductID,
+			l.Quantity,
+			l.Name,
+			l.UnitPrice.Amount,
+			l.Available,
+			l.PriceChanged,
+			l.AddedAt,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// accountID stores anonymous carts with a NULL account so that the unique
+// constraint only applies to account carts
+func accountID(cart *model.Cart) sql.NullString {
+	return sql.NullString{String: cart.AccountID, Valid: cart.AccountID != ""}
+}
+
+func accountConflict(err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code.Name() == "unique_violation" && pqErr.Constraint == "carts_account_id_key" {
+		return ErrAccountHasCart
+	}
+	return err
+}
@@ -0,0 +1,172 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+
+	"github.com/wignn/micro-3/cart/genproto"
+	"github.com/wignn/micro-3/cart/model"
+	"github.com/wignn/micro-3/cart/repository"
+	"github.com/wignn/micro-3/cart/service"
+	"github.com/wignn/micro-3/currency"
+	orderModel "github.com/wignn/micro-3/order/model"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+)
+
+type grpcServer struct {
+	service service.CartService
+	genproto.UnimplementedCartServiceServer
+}
+
+func ListenGRPC(s service.CartService, port int) error {
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	if err != nil {
+		return err
+	}
+	serv := grpc.NewServer()
+	genproto.RegisterCartServiceServer(serv, &grpcServer{service: s})
+	reflection.Register(serv)
+	return serv.Serve(lis)
+}
+
+// toStatus maps service errors to grpc codes, errors of the catalog and order
+// services are already statuses and pass through unchanged
+func toStatus(err error) error {
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, repository.ErrConflict),
+		errors.Is(err, repository.ErrAccountHasCart):
+		return status.Error(codes.Aborted, err.Error())
+	case errors.Is(err, service.ErrMissingCart),
+		errors.Is(err, service.ErrMissingAccountID),
+		errors.Is(err, service.ErrMissingProductID),
+		errors.Is(err, service.ErrInvalidQuantity),
+		errors.Is(err, service.ErrQuantityTooLarge):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, service.ErrTooManyLines),
+		errors.Is(err, service.ErrLineNotFound),
+		errors.Is(err, service.ErrProductUnavailable),
+		errors.Is(err, service.ErrAnonymousCheckout),
+		errors.Is(err, service.ErrEmptyCart):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, service.ErrCartOwned):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, currency.ErrUnknownCurrency),
+		errors.Is(err, currency.ErrNoRate):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return err
+}
+
+func refFromProto(r *genproto.CartRef) model.Ref {
+	if r == nil {
+		return model.Ref{}
+	}
+	return model.Ref{ID: r.CartId, AccountID: r.AccountId}
+}
+
+func moneyToProto(m currency.Money) *genproto.Money {
+	return &genproto.Money{Amount: m.Amount, Currency: m.Currency}
+}
+
+func cartToProto(cart *model.Cart) *genproto.Cart {
+	cp := &genproto.Cart{
+		Id:        cart.ID,
+		AccountId: cart.AccountID,
+		Currency:  cart.Currency,
+		Lines:     []*genproto.CartLine{},
+		Subtotal:  moneyToProto(cart.Subtotal()),
+		Version:   cart.Version,
+	}
+	cp.CreatedAt, _ = cart.CreatedAt.MarshalBinary()
+	cp.UpdatedAt, _ = cart.UpdatedAt.MarshalBinary()
+	for _, l := range cart.Lines {
+		line := &genproto.CartLine{
+			ProductId:    l.ProductID,
+			Quantity:     l.Quantity,
+			Name:         l.Name,
+			UnitPrice:    moneyToProto(l.UnitPrice),
+			Available:    l.Available,
+			PriceChanged: l.PriceChanged,
+		}
+		line.AddedAt, _ = l.AddedAt.MarshalBinary()
+		cp.Lines = append(cp.Lines, line)
+	}
+	return cp
+}
+
+func (s *grpcServer) GetCart(c context.Context, r *genproto.GetCartRequest) (*genproto.CartResponse, error) {
+	cart, err := s.service.GetCart(c, refFromProto(r.Cart))
+	if err != nil {
+		log.Println("failed to get cart:", err)
+		return nil, toStatus(err)
+	}
+	return &genproto.CartResponse{Cart: cartToProto(cart)}, nil
+}
+
+func (s *grpcServer) AddLine(c context.Context, r *genproto.AddLineRequest) (*genproto.CartResponse, error) {
+	cart, err := s.service.AddLine(c, refFromProto(r.Cart), r.ProductId, r.Quantity)
+	if err != nil {
+		log.Printf("failed to add product %s to cart: %v\n", r.ProductId, err)
+		return nil, toStatus(err)
+	}
+	return &genproto.CartResponse{Cart: cartToProto(cart)}, nil
+}
+
+func (s *grpcServer) UpdateLine(c context.Context, r *genproto.UpdateLineRequest) (*genproto.CartResponse, error) {
+	cart, err := s.service.UpdateLine(c, refFromProto(r.Cart), r.ProductId, r.Quantity)
+	if err != nil {
+		log.Printf("failed to update product %s in cart: %v\n", r.ProductId, err)
+		return nil, toStatus(err)
+	}
+	return &genproto.CartResponse{Cart: cartToProto(cart)}, nil
+}
+
+func (s *grpcServer) RemoveLine(c context.Context, r *genproto.RemoveLineRequest) (*genproto.CartResponse, error) {
+	cart, err := s.service.RemoveLine(c, refFromProto(r.Cart), r.ProductId)
+	if err != nil {
+		log.Printf("failed to remove product %s from cart: %v\n", r.ProductId, err)
+		return nil, toStatus(err)
+	}
+	return &genproto.CartResponse{Cart: cartToProto(cart)}, nil
+}
+
+func (s *grpcServer) MergeCarts(c context.Context, r *genproto.MergeCartsRequest) (*genproto.CartResponse, error) {
+	cart, err := s.service.MergeCarts(c, r.CartId, r.AccountId)
+	if err != nil {
+		log.Printf("failed to merge cart %s into account %s: %v\n", r.CartId, r.AccountId, err)
+		return nil, toStatus(err)
+	}
+	return &genproto.CartResponse{Cart: cartToProto(cart)}, nil
+}
+
+func (s *grpcServer) Checkout(c context.Context, r *genproto.CheckoutRequest) (*genproto.CheckoutResponse, error) {
+	order, cart, err := s.service.Checkout(c, refFromProto(r.Cart), r.Coupons, addressFromProto(r.ShippingAddress), r.IdempotencyKey)
+	if err != nil {
+		log.Println("failed to check out cart:", err)
+		return nil, toStatus(err)
+	}
+	return &genproto.CheckoutResponse{OrderId: order.ID, Cart: cartToProto(cart)}, nil
+}
+
+func addressFromProto(a *genproto.Address) *orderModel.Address {
+	if a == nil {
+		return nil
+	}
+	return &orderModel.Address{
+		Name:       a.Name,
+		Line1:      a.Line1,
+		Line2:      a.Line2,
+		City:       a.City,
+		Region:     a.Region,
+		PostalCode: a.PostalCode,
+		Country:    a.Country,
+	}
+}
@@ -0,0 +1,396 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/segmentio/ksuid"
+	"github.com/wignn/micro-3/cart/model"
+	"github.com/wignn/micro-3/cart/repository"
+	catalog "github.com/wignn/micro-3/catalog/client"
+	catalogProto "github.com/wignn/micro-3/catalog/genproto"
+	"github.com/wignn/micro-3/currency"
+	order "github.com/wignn/micro-3/order/client"
+	orderModel "github.com/wignn/micro-3/order/model"
+)
+
+var (
+	ErrMissingCart        = errors.New("cart id or account id is required")
+	ErrMissingAccountID   = errors.New("account id is required")
+	ErrMissingProductID   = errors.New("product id is required")
+	ErrInvalidQuantity    = errors.New("quantity must be greater than zero")
+	ErrQuantityTooLarge   = errors.New("quantity exceeds the limit per line")
+	ErrTooManyLines       = errors.New("cart has too many lines")
+	ErrLineNotFound       = errors.New("product is not in the cart")
+	ErrProductUnavailable = errors.New("product is not available")
+	ErrCartOwned          = errors.New("cart belongs to another account")
+	ErrAnonymousCheckout  = errors.New("log in to check out")
+	ErrEmptyCart          = errors.New("cart is empty")
+)
+
+// maxAttempts bounds the retries of a cart update that lost a race
+const maxAttempts = 3
+
+type CartService interface {
+	GetCart(c context.Context, ref model.Ref) (*model.Cart, error)
+	AddLine(c context.Context, ref model.Ref, productID string, quantity uint32) (*model.Cart, error)
+	UpdateLine(c context.Context, ref model.Ref, productID string, quantity uint32) (*model.Cart, error)
+	RemoveLine(c context.Context, ref model.Ref, productID string) (*model.Cart, error)
+	MergeCarts(c context.Context, cartID, accountID string) (*model.Cart, error)
+	Checkout(c context.Context, ref model.Ref, coupons []string, address *orderModel.Address, idempotencyKey string) (*orderModel.Order, *model.Cart, error)
+	ExpireCarts(c context.Context) (int64, error)
+}
+
+// Options configures the cart service
+type Options struct {
+	// DefaultCurrency is the currency new carts are priced in
+	DefaultCurrency string
+	MaxLineQuantity uint32
+	MaxLines        int
+	// PriceTTL is how long prices are kept before a read prices the cart again
+	PriceTTL time.Duration
+	// AnonymousTTL and AccountTTL are how long carts are kept after their last update
+	AnonymousTTL time.Duration
+	AccountTTL   time.Duration
+}
+
+type cartService struct {
+	repository    repository.CartRepository
+	catalogClient *catalog.CatalogClient
+	orderClient   *order.OrderClient
+	converter     *currency.Converter
+	options       Options
+}
+
+func NewCartService(r repository.CartRepository, catalogClient *catalog.CatalogClient, orderClient *order.OrderClient, converter *currency.Converter, options Options) CartService {
+	return &cartService{r, catalogClient, orderClient, converter, options}
+}
+
+// GetCart returns a cart, lines priced longer than PriceTTL ago are priced again
+func (s *cartService) GetCart(c context.Context, ref model.Ref) (*model.Cart, error) {
+	cart, err := s.load(c, ref, false)
+	if err != nil {
+		return nil, err
+	}
+	if time.Since(cart.PricedAt) < s.options.PriceTTL {
+		return cart, nil
+	}
+	if err := s.price(c, cart); err != nil {
+		return nil, err
+	}
+	if err := s.repository.SaveCart(c, cart); err != nil {
+		// the fresh prices are still returned, the next read stores them
+		log.Printf("failed to save prices of cart %s: %v\n", cart.ID, err)
+	}
+	return cart, nil
+}
+
+// AddLine adds quantity units of a product, the cart is created when the
+// reference doesn't select one yet
+func (s *cartService) AddLine(c context.Context, ref model.Ref, productID string, quantity uint32) (*model.Cart, error) {
+	if productID == "" {
+		return nil, ErrMissingProductID
+	}
+	if quantity == 0 {
+		return nil, ErrInvalidQuantity
+	}
+	return s.update(c, ref, true, func(cart *model.Cart) error {
+		l := cart.Line(productID)
+		if l == nil {
+			if len(cart.Lines) >= s.options.MaxLines {
+				return ErrTooManyLines
+			}
+			cart.Lines = append(cart.Lines, model.Line{ProductID: productID, AddedAt: time.Now().UTC()})
+			l = &cart.Lines[len(cart.Lines)-1]
+		}
+		if quantity > s.options.MaxLineQuantity || l.Quantity+quantity > s.options.MaxLineQuantity {
+			return ErrQuantityTooLarge
+		}
+		l.Quantity += quantity
+		l.PriceChanged = false
+
+		if err := s.price(c, cart); err != nil {
+			return err
+		}
+		if !cart.Line(productID).Available {
+			return ErrProductUnavailable
+		}
+		return nil
+	})
+}
+
+// UpdateLine sets the quantity of a product in the cart, zero removes it
+func (s *cartService) UpdateLine(c context.Context, ref model.Ref, productID string, quantity uint32) (*model.Cart, error) {
+	if productID == "" {
+		return nil, ErrMissingProductID
+	}
+	if quantity == 0 {
+		return s.RemoveLine(c, ref, productID)
+	}
+	if quantity > s.options.MaxLineQuantity {
+		return nil, ErrQuantityTooLarge
+	}
+	return s.update(c, ref, false, func(cart *model.Cart) error {
+		l := cart.Line(productID)
+		if l == nil {
+			return ErrLineNotFound
+		}
+		l.Quantity = quantity
+		l.PriceChanged = false
+		return s.price(c, cart)
+	})
+}
+
+// RemoveLine removes a product from the cart, removing a product that is not
+// in the cart is a no-op
+func (s *cartService) RemoveLine(c context.Context, ref model.Ref, productID string) (*model.Cart, error) {
+	if productID == "" {
+		return nil, ErrMissingProductID
+	}
+	return s.update(c, ref, false, func(cart *model.Cart) error {
+		lines := []model.Line{}
+		for _, l := range cart.Lines {
+			if l.ProductID != productID {
+				lines = append(lines, l)
+			}
+		}
+		cart.Lines = lines
+		return s.price(c, cart)
+	})
+}
+
+// MergeCarts moves the lines of an anonymous cart into the account's cart on
+// login, quantities of products in both carts are added. When the account has
+// no cart yet the anonymous cart becomes the account's cart.
+func (s *cartService) MergeCarts(c context.Context, cartID, accountID string) (*model.Cart, error) {
+	if cartID == "" {
+		return nil, ErrMissingCart
+	}
+	if accountID == "" {
+		return nil, ErrMissingAccountID
+	}
+	for attempt := 1; ; attempt++ {
+		cart, err := s.mergeCarts(c, cartID, accountID)
+		retry := errors.Is(err, repository.ErrConflict) || errors.Is(err, repository.ErrAccountHasCart)
+		if !retry || attempt == maxAttempts {
+			return cart, err
+		}
+	}
+}
+
+func (s *cartService) mergeCarts(c context.Context, cartID, accountID string) (*model.Cart, error) {
+	from, err := s.repository.GetCart(c, cartID)
+	if errors.Is(err, repository.ErrNotFound) {
+		// already merged by an earlier login or expired
+		return s.GetCart(c, model.Ref{AccountID: accountID})
+	}
+	if err != nil {
+		return nil, err
+	}
+	if from.AccountID == accountID {
+		return s.GetCart(c, model.Ref{AccountID: accountID})
+	}
+	if from.AccountID != "" {
+		return nil, ErrCartOwned
+	}
+
+	into, err := s.repository.GetCartForAccount(c, accountID)
+	if errors.Is(err, repository.ErrNotFound) {
+		from.AccountID = accountID
+		from.UpdatedAt = time.Now().UTC()
+		if err := s.repository.SaveCart(c, from); err != nil {
+			return nil, err
+		}
+		return from, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, l := range from.Lines {
+		if existing := into.Line(l.ProductID); existing != nil {
+			existing.Quantity = min(existing.Quantity+l.Quantity, s.options.MaxLineQuantity)
+			continue
+		}
+		if len(into.Lines) >= s.options.MaxLines {
+			log.Printf("dropping product %s of cart %s, cart %s is full\n", l.ProductID, from.ID, into.ID)
+			continue
+		}
+		if from.Currency != into.Currency {
+			// priced again below in the currency of the account's cart
+			l.UnitPrice = currency.Money{}
+		}
+		into.Lines = append(into.Lines, l)
+	}
+	if err := s.price(c, into); err != nil {
+		return nil, err
+	}
+	into.UpdatedAt = time.Now().UTC()
+	if err := s.repository.MergeCart(c, into, from.ID); err != nil {
+		return nil, err
+	}
+	return into, nil
+}
+
+// Checkout places an order with the lines of an account's cart, the coupons
+// and the shipping address and empties the cart. Without an idempotency key
+// the key is derived from the cart version, the coupons and the address, so
+// retrying the checkout of an unchanged cart places one order.
+func (s *cartService) Checkout(c context.Context, ref model.Ref, coupons []string, address *orderModel.Address, idempotencyKey string) (*orderModel.Order, *model.Cart, error) {
+	cart, err := s.load(c, ref, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	if cart.AccountID == "" {
+		return nil, nil, ErrAnonymousCheckout
+	}
+	if len(cart.Lines) == 0 {
+		return nil, nil, ErrEmptyCart
+	}
+	if idempotencyKey == "" {
+		idempotencyKey = fmt.Sprintf("cart-%s-%d", cart.ID, cart.Version)
+		if len(coupons) > 0 || address != nil {
+			h := sha256.New()
+			h.Write([]byte(strings.ToUpper(strings.Join(coupons, "\n"))))
+			if address != nil {
+				fmt.Fprintf(h, "\n%+v", *address)
+			}
+			idempotencyKey += "-" + hex.EncodeToString(h.Sum(nil)[:8])
+		}
+	}
+
+	// the order service validates and prices the lines again, its violations
+	// refer to the lines in cart order
+	products := []*orderModel.OrderedProduct{}
+	for _, l := range cart.Lines {
+		products = append(products, &orderModel.OrderedProduct{ID: l.ProductID, Quantity: l.Quantity})
+	}
+	o, err := s.orderClient.PostOrder(c, cart.AccountID, cart.Currency, products, coupons, address, idempotencyKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cart.Lines = []model.Line{}
+	cart.UpdatedAt = time.Now().UTC()
+	if err := s.repository.SaveCart(c, cart); err != nil {
+		// the order is placed, the customer removes the lines by hand
+		log.Printf("failed to empty cart %s after order %s: %v\n", cart.ID, o.ID, err)
+	}
+	return o, cart, nil
+}
+
+// ExpireCarts deletes the carts that were not updated within their TTL
+func (s *cartService) ExpireCarts(c context.Context) (int64, error) {
+	now := time.Now().UTC()
+	return s.repository.DeleteStaleCarts(c, now.Add(-s.options.AnonymousTTL), now.Add(-s.options.AccountTTL))
+}
+
+// load returns the cart selected by ref, with create a missing cart is
+// created in memory and saved by update
+func (s *cartService) load(c context.Context, ref model.Ref, create bool) (*model.Cart, error) {
+	var cart *model.Cart
+	var err error
+	switch {
+	case ref.AccountID != "":
+		cart, err = s.repository.GetCartForAccount(c, ref.AccountID)
+	case ref.ID != "":
+		cart, err = s.repository.GetCart(c, ref.ID)
+	case !create:
+		return nil, ErrMissingCart
+	default:
+		err = repository.ErrNotFound
+	}
+	if err == nil || !create || !errors.Is(err, repository.ErrNotFound) {
+		return cart, err
+	}
+
+	// an expired anonymous cart is replaced by a new one
+	now := time.Now().UTC()
+	return &model.Cart{
+		ID:        ksuid.New().String(),
+		AccountID: ref.AccountID,
+		Currency:  s.options.DefaultCurrency,
+		Lines:     []model.Line{},
+		Version:   -1,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, nil
+}
+
+// update loads the cart, applies fn and saves the cart, it starts over when
+// another request saved the cart in between
+func (s *cartService) update(c context.Context, ref model.Ref, create bool, fn func(cart *model.Cart) error) (*model.Cart, error) {
+	for attempt := 1; ; attempt++ {
+		cart, err := s.load(c, ref, create)
+		if err != nil {
+			return nil, err
+		}
+		if err := fn(cart); err != nil {
+			return nil, err
+		}
+		cart.UpdatedAt = time.Now().UTC()
+		if cart.Version < 0 {
+			cart.Version = 0
+			err = s.repository.CreateCart(c, cart)
+		} else {
+			err = s.repository.SaveCart(c, cart)
+		}
+		retry := errors.Is(err, repository.ErrConflict) || errors.Is(err, repository.ErrAccountHasCart)
+		if err == nil || !retry || attempt == maxAttempts {
+			if err != nil {
+				return nil, err
+			}
+			return cart, nil
+		}
+	}
+}
+
+// price copies the name, price and availability of every line from the
+// catalog, prices are converted to the cart currency. A line whose price
+// differs from the price it was shown at is flagged until its quantity is
+// changed.
+func (s *cartService) price(c context.Context, cart *model.Cart) error {
+	cart.PricedAt = time.Now().UTC()
+	if len(cart.Lines) == 0 {
+		return nil
+	}
+	productIDs := []string{}
+	for _, l := range cart.Lines {
+		productIDs = append(productIDs, l.ProductID)
+	}
+	products, err := s.catalogClient.GetProducts(c, 0, 0, productIDs, "")
+	if err != nil {
+		return err
+	}
+	catalogProducts := map[string]*catalogProto.Product{}
+	for _, p := range products {
+		catalogProducts[p.Id] = p
+	}
+
+	for i := range cart.Lines {
+		l := &cart.Lines[i]
+		p, ok := catalogProducts[l.ProductID]
+		if !ok {
+			// deleted products keep the last known name and price
+			l.Available = false
+			continue
+		}
+		price, err := s.converter.ConvertMoney(c, currency.FromFloat(p.Price, p.Currency), cart.Currency)
+		if err != nil {
+			return err
+		}
+		if l.UnitPrice.Currency != "" && l.UnitPrice != price {
+			l.PriceChanged = true
+		}
+		l.Name = p.Name
+		l.UnitPrice = price
+		l.Available = p.Visible
+	}
+	return nil
+}
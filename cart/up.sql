@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS carts (
+  id CHAR(27) PRIMARY KEY,
+  account_id CHAR(27) UNIQUE,
+  currency CHAR(3) NOT NULL,
+  version BIGINT NOT NULL DEFAULT 0,
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+  updated_at TIMESTAMP WITH TIME ZONE NOT NULL,
+  priced_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS carts_updated_at ON carts (updated_at);
+
+CREATE TABLE IF NOT EXISTS cart_lines (
+  cart_id CHAR(27) REFERENCES carts (id) ON DELETE CASCADE,
+  product_id CHAR(27) NOT NULL,
+  quantity INT NOT NULL CHECK (quantity > 0),
+  name VARCHAR(255) NOT NULL DEFAULT '',
+  unit_price_minor BIGINT NOT NULL DEFAULT 0,
+  available BOOLEAN NOT NULL DEFAULT TRUE,
+  price_changed BOOLEAN NOT NULL DEFAULT FALSE,
+  added_at TIMESTAMP WITH TIME ZONE NOT NULL,
+  PRIMARY KEY (cart_id, product_id)
+);
@@ -0,0 +1,223 @@
+package bulk
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/wignn/micro-3/catalog/model"
+	"github.com/wignn/micro-3/currency"
+)
+
+// Format is the encoding of a single product row
+type Format string
+
+const (
+	CSV    Format = "csv"
+	NDJSON Format = "ndjson"
+)
+
+// Columns are the CSV columns written on export, import accepts them in any order
+var Columns = []string{"id", "name", "description", "price", "currency", "image", "weight_grams", "status"}
+
+var (
+	ErrUnknownFormat  = errors.New("unknown format")
+	ErrMissingHeader  = errors.New("csv header must contain name and price columns")
+	ErrEmptyName      = errors.New("name is required")
+	ErrInvalidPrice   = errors.New("price must be a non-negative number")
+	ErrInvalidWeight  = errors.New("weight_grams must be a non-negative integer")
+	ErrInvalidStatus  = errors.New("status must be draft, published or archived")
+	ErrColumnMismatch = errors.New("row has a different number of columns than the header")
+)
+
+// Row is the wire representation of a product shared by both formats
+type Row struct {
+	ID          string  `json:"id,omitempty"`
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	Price       float64 `json:"price"`
+	Currency    string  `json:"currency,omitempty"`
+	Image       string  `json:"image"`
+	WeightGrams int64   `json:"weight_grams,omitempty"`
+	Status      string  `json:"status,omitempty"`
+	Deleted     bool    `json:"deleted,omitempty"`
+}
+
+// Decoder turns rows into products, for CSV the first row is the header
+type Decoder struct {
+	format Format
+	header map[string]int
+}
+
+func NewDecoder(f Format) (*Decoder, error) {
+	if f != CSV && f != NDJSON {
+		return nil, ErrUnknownFormat
+	}
+	return &Decoder{format: f}, nil
+}
+
+// Decode parses and validates a row, it returns nil without error when the row was a CSV header
+func (d *Decoder) Decode(row string) (*model.Product, error) {
+	var r Row
+	switch d.format {
+	case NDJSON:
+		if err := json.Unmarshal([]byte(row), &r); err != nil {
+			return nil, fmt.Errorf("invalid json: %w", err)
+		}
+	case CSV:
+		record, err := csv.NewReader(strings.NewReader(row)).Read()
+		if err != nil {
+			return nil, fmt.Errorf("invalid csv: %w", err)
+		}
+		if d.header == nil {
+			return nil, d.readHeader(record)
+		}
+		if r, err = d.fromRecord(record); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := validate(&r); err != nil {
+		return nil, err
+	}
+	return &model.Product{
+		ID:          strings.TrimSpace(r.ID),
+		Name:        strings.TrimSpace(r.Name),
+		Description: r.Description,
+		Price:       r.Price,
+		Currency:    r.Currency,
+		Image:       r.Image,
+		WeightGrams: r.WeightGrams,
+		Status:      r.Status,
+	}, nil
+}
+
+func (d *Decoder) readHeader(record []string) error {
+	header := map[string]int{}
+	for i, col := range record {
+		header[strings.ToLower(strings.TrimSpace(col))] = i
+	}
+	_, hasName := header["name"]
+	_, hasPrice := header["price"]
+	if !hasName || !hasPrice {
+		return ErrMissingHeader
+	}
+	d.header = header
+	return nil
+}
+
+func (d *Decoder) fromRecord(record []string) (Row, error) {
+	if len(record) != len(d.header) {
+		return Row{}, ErrColumnMismatch
+	}
+	col := func(name string) string {
+		if i, ok := d.header[name]; ok {
+			return record[i]
+		}
+		return ""
+	}
+	price, err := strconv.ParseFloat(strings.TrimSpace(col("price")), 64)
+	if err != nil {
+		return Row{}, ErrInvalidPrice
+	}
+	var weight int64
+	if w := strings.TrimSpace(col("weight_grams")); w != "" {
+		if weight, err = strconv.ParseInt(w, 10, 64); err != nil {
+			return Row{}, ErrInvalidWeight
+		}
+	}
+	return Row{
+		ID:          col("id"),
+		Name:        col("name"),
+		Description: col("description"),
+		Price:       price,
+		Currency:    col("currency"),
+		Image:       col("image"),
+		WeightGrams: weight,
+		Status:      col("status"),
+	}, nil
+}
+
+func validate(r *Row) error {
+	if strings.TrimSpace(r.Name) == "" {
+		return ErrEmptyName
+	}
+	if r.Price < 0 || math.IsNaN(r.Price) || math.IsInf(r.Price, 0) {
+		return ErrInvalidPrice
+	}
+	if r.WeightGrams < 0 {
+		return ErrInvalidWeight
+	}
+	// an empty status keeps the current one, new products are drafts.
+	// Scheduling needs a publish time, which rows don't have.
+	r.Status = strings.ToLower(strings.TrimSpace(r.Status))
+	switch r.Status {
+	case "", model.ProductDraft, model.ProductPublished, model.ProductArchived:
+	default:
+		return ErrInvalidStatus
+	}
+	// an empty currency keeps the current one or uses the default
+	if strings.TrimSpace(r.Currency) != "" {
+		code, err := currency.Normalize(r.Currency)
+		if err != nil {
+			return err
+		}
+		r.Currency = code
+	}
+	return nil
+}
+
+// Header returns the first row to write on export, NDJSON has none
+func Header(f Format) (string, bool) {
+	if f != CSV {
+		return "", false
+	}
+	return encodeRecord(Columns), true
+}
+
+// Encode writes a product as a single row without the trailing newline
+func Encode(f Format, p *model.Product) (string, error) {
+	switch f {
+	case CSV:
+		return encodeRecord([]string{
+			p.ID,
+			p.Name,
+			p.Description,
+			strconv.FormatFloat(p.Price, 'f', -1, 64),
+			p.Currency,
+			p.Image,
+			strconv.FormatInt(p.WeightGrams, 10),
+			p.Status,
+		}), nil
+	case NDJSON:
+		b, err := json.Marshal(Row{
+			ID:          p.ID,
+			Name:        p.Name,
+			Description: p.Description,
+			Price:       p.Price,
+			Currency:    p.Currency,
+			Image:       p.Image,
+			WeightGrams: p.WeightGrams,
+			Status:      p.Status,
+			Deleted:     p.Deleted,
+		})
+		if err != nil {
+			return "", err
+		}
+		return string(b), nil
+	}
+	return "", ErrUnknownFormat
+}
+
+func encodeRecord(record []string) string {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	w.Write(record)
+	w.Flush()
+	return strings.TrimSuffix(buf.String(), "\n")
+}
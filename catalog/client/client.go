@@ -2,11 +2,15 @@ package client
 
 import (
 	"context"
+	"io"
 	"log"
+	"time"
 
 	"github.com/wignn/micro-3/catalog/genproto"
+	"github.com/wignn/micro-3/catalog/model"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 )
 
 type CatalogClient struct {
@@ -29,11 +33,37 @@ func (cl *CatalogClient) Close() {
 	cl.conn.Close()
 }
 
-func (cl *CatalogClient) PostProduct(c context.Context, name, description string, price float64, image string) (*genproto.Product, error) {
+// publicationToProto encodes a publication, a nil publication sends no status
+// and no times
+func publicationToProto(pub *model.Publication) (status string, publishAt, unpublishAt []byte) {
+	if pub == nil {
+		return "", nil, nil
+	}
+	if pub.PublishAt != nil {
+		publishAt, _ = pub.PublishAt.MarshalBinary()
+	}
+	if pub.UnpublishAt != nil {
+		unpublishAt, _ = pub.UnpublishAt.MarshalBinary()
+	}
+	return pub.Status, publishAt, unpublishAt
+}
 
+func (cl *CatalogClient) PostProduct(c context.Context, name, description string, price float64, currency, image string, weightGrams int64, publication *model.Publication, actor string) (*genproto.Product, error) {
+	status, publishAt, unpublishAt := publicationToProto(publication)
 	r, err := cl.service.PostProduct(
 		c,
-		&genproto.PostProductRequest{Name: name, Description: description, Price: price, Image: image},
+		&genproto.PostProductRequest{
+			Name:        name,
+			Description: description,
+			Price:       price,
+			Currency:    currency,
+			Image:       image,
+			WeightGrams: weightGrams,
+			Actor:       actor,
+			Status:      status,
+			PublishAt:   publishAt,
+			UnpublishAt: unpublishAt,
+		},
 	)
 
 	if err != nil {
@@ -57,6 +87,110 @@ func (cl *CatalogClient) GetProduct(c context.Context, id string) (*genproto.Pro
 	return r.Product, nil
 }
 
+// SearchProducts runs a full text search, the returned search id is passed
+// to RecordSearchClick when a customer opens one of the results
+func (cl *CatalogClient) SearchProducts(c context.Context, skip, take uint64, query string, includeUnpublished bool) ([]*genproto.Product, string, error) {
+	r, err := cl.service.GetProducts(
+		c,
+		&genproto.GetProductsRequest{Skip: skip, Take: take, Query: query, IncludeUnpublished: includeUnpublished},
+	)
+	if err != nil {
+		log.Printf("failed to search products: %v\n", err)
+		return nil, "", err
+	}
+	return r.Products, r.SearchId, nil
+}
+
+// ListProducts returns a page of products, includeUnpublished also lists
+// products customers can't see
+func (cl *CatalogClient) ListProducts(c context.Context, skip, take uint64, includeUnpublished bool) ([]*genproto.Product, error) {
+	r, err := cl.service.GetProducts(
+		c,
+		&genproto.GetProductsRequest{Skip: skip, Take: take, IncludeUnpublished: includeUnpublished},
+	)
+	if err != nil {
+		log.Printf("failed to list products: %v\n", err)
+		return nil, err
+	}
+	return r.Products, nil
+}
+
+func (cl *CatalogClient) RecordSearchClick(c context.Context, searchID, productID string) error {
+	_, err := cl.service.RecordSearchClick(
+		c,
+		&genproto.RecordSearchClickRequest{SearchId: searchID, ProductId: productID},
+	)
+	if err != nil {
+		log.Printf("failed to record search click: %v\n", err)
+	}
+	return err
+}
+
+func (cl *CatalogClient) GetSearchReports(c context.Context, from, to time.Time, take uint64) ([]*genproto.SearchReport, error) {
+	fromBytes, err := from.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	toBytes, err := to.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	r, err := cl.service.GetSearchReports(
+		c,
+		&genproto.GetSearchReportsRequest{From: fromBytes, To: toBytes, Take: take},
+	)
+	if err != nil {
+		log.Printf("failed to get search reports: %v\n", err)
+		return nil, err
+	}
+	return r.Reports, nil
+}
+
+func (cl *CatalogClient) GetSearchSettings(c context.Context) (*genproto.SearchSettings, error) {
+	r, err := cl.service.GetSearchSettings(c, &genproto.GetSearchSettingsRequest{})
+	if err != nil {
+		log.Printf("failed to get search settings: %v\n", err)
+		return nil, err
+	}
+	return r.Settings, nil
+}
+
+func (cl *CatalogClient) UpdateSearchSettings(c context.Context, settings *genproto.SearchSettings, actor string) (*genproto.SearchSettings, error) {
+	r, err := cl.service.UpdateSearchSettings(
+		c,
+		&genproto.UpdateSearchSettingsRequest{Settings: settings, Actor: actor},
+	)
+	if err != nil {
+		log.Printf("failed to update search settings: %v\n", err)
+		return nil, err
+	}
+	return r.Settings, nil
+}
+
+// SetProductInStock updates the stock flag used to rank search results
+func (cl *CatalogClient) SetProductInStock(c context.Context, productID string, inStock bool) error {
+	_, err := cl.service.SetProductInStock(
+		c,
+		&genproto.SetProductInStockRequest{ProductId: productID, InStock: inStock},
+	)
+	if err != nil {
+		log.Printf("failed to set product stock flag: %v\n", err)
+	}
+	return err
+}
+
+// AddProductRating adds a review rating to the average used to rank search results
+func (cl *CatalogClient) AddProductRating(c context.Context, productID string, rating int32) error {
+	_, err := cl.service.AddProductRating(
+		c,
+		&genproto.AddProductRatingRequest{ProductId: productID, Rating: rating},
+	)
+	if err != nil {
+		log.Printf("failed to add product rating: %v\n", err)
+	}
+	return err
+}
+
 func (cl *CatalogClient) GetProducts(c context.Context, skip, take uint64, ids []string, query string) ([]*genproto.Product, error) {
 	r, err := cl.service.GetProducts(
 		c,
@@ -75,13 +209,34 @@ func (cl *CatalogClient) GetProducts(c context.Context, skip, take uint64, ids [
 			Name:        p.Name,
 			Description: p.Description,
 			Price:       p.Price,
+			Currency:    p.Currency,
 			Image:       p.Image,
+			Deleted:     p.Deleted,
+			Images:      p.Images,
+			Version:     p.Version,
+			Status:      p.Status,
+			PublishAt:   p.PublishAt,
+			UnpublishAt: p.UnpublishAt,
+			Visible:     p.Visible,
 		})
 	}
 
 	return products, nil
 }
 
+// GetSimilarProducts returns live products whose name and description resemble the given product
+func (cl *CatalogClient) GetSimilarProducts(c context.Context, id string, take uint64) ([]*genproto.SimilarProduct, error) {
+	r, err := cl.service.GetSimilarProducts(
+		c,
+		&genproto.GetSimilarProductsRequest{Id: id, Take: take},
+	)
+	if err != nil {
+		log.Printf("failed to get similar products: %v\n", err)
+		return nil, err
+	}
+	return r.Products, nil
+}
+
 func (cl *CatalogClient) DeleteProduct(c context.Context, id string) (*genproto.DeleteProductResponse, error) {
 	result, err := cl.service.DeleteProduct(
 		c,
@@ -98,7 +253,14 @@ func (cl *CatalogClient) DeleteProduct(c context.Context, id string) (*genproto.
 	}, nil
 }
 
-func (cl *CatalogClient) EditProduct(c context.Context, id string, name, description string, price float64, image string) (*genproto.Product, error) {
+// EditProduct updates the fields listed in fields, all of them when it is
+// empty, version makes the edit fail if the product changed in the meantime
+func (cl *CatalogClient) EditProduct(c context.Context, id string, name, description string, price float64, currency, image string, weightGrams int64, publication *model.Publication, fields []string, version, actor string) (*genproto.Product, error) {
+	var mask *fieldmaskpb.FieldMask
+	if len(fields) > 0 {
+		mask = &fieldmaskpb.FieldMask{Paths: fields}
+	}
+	status, publishAt, unpublishAt := publicationToProto(publication)
 	r, err := cl.service.EditProduct(
 		c,
 		&genproto.EditProductRequest{
@@ -106,7 +268,15 @@ func (cl *CatalogClient) EditProduct(c context.Context, id string, name, descrip
 			Name:        name,
 			Description: description,
 			Price:       price,
+			Currency:    currency,
 			Image:       image,
+			WeightGrams: weightGrams,
+			Actor:       actor,
+			UpdateMask:  mask,
+			Version:     version,
+			Status:      status,
+			PublishAt:   publishAt,
+			UnpublishAt: unpublishAt,
 		},
 	)
 	if err != nil {
@@ -116,3 +286,196 @@ func (cl *CatalogClient) EditProduct(c context.Context, id string, name, descrip
 
 	return r.Product, nil
 }
+
+func (cl *CatalogClient) RestoreProduct(c context.Context, id string) (*genproto.Product, error) {
+	r, err := cl.service.RestoreProduct(
+		c,
+		&genproto.RestoreProductRequest{Id: id},
+	)
+	if err != nil {
+		log.Printf("failed to restore product: %v\n", err)
+		return nil, err
+	}
+
+	return r.Product, nil
+}
+
+// ImportProducts streams rows returned by next until it reports io.EOF, the
+// price changes of the import are recorded as made by actor
+func (cl *CatalogClient) ImportProducts(c context.Context, format genproto.ProductFormat, actor string, next func() (string, error)) (*genproto.ImportProductsResponse, error) {
+	stream, err := cl.service.ImportProducts(c)
+	if err != nil {
+		log.Printf("failed to import products: %v\n", err)
+		return nil, err
+	}
+
+	for {
+		row, err := next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			stream.CloseSend()
+			return nil, err
+		}
+		if err := stream.Send(&genproto.ImportProductsRequest{Format: format, Row: row, Actor: actor}); err != nil {
+			log.Printf("failed to send import row: %v\n", err)
+			return nil, err
+		}
+	}
+
+	r, err := stream.CloseAndRecv()
+	if err != nil {
+		log.Printf("failed to import products: %v\n", err)
+		return nil, err
+	}
+	return r, nil
+}
+
+// ExportProducts calls fn for every exported row, including the CSV header
+func (cl *CatalogClient) ExportProducts(c context.Context, format genproto.ProductFormat, includeDeleted bool, fn func(row string) error) error {
+	stream, err := cl.service.ExportProducts(c, &genproto.ExportProductsRequest{
+		Format:         format,
+		IncludeDeleted: includeDeleted,
+	})
+	if err != nil {
+		log.Printf("failed to export products: %v\n", err)
+		return err
+	}
+
+	for {
+		r, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			log.Printf("failed to export products: %v\n", err)
+			return err
+		}
+		if err := fn(r.Row); err != nil {
+			return err
+		}
+	}
+}
+
+// uploadChunkSize is the size of the image chunks streamed to the catalog
+const uploadChunkSize = 64 * 1024
+
+// UploadProductImage streams an image to the catalog, a nil position appends it
+func (cl *CatalogClient) UploadProductImage(c context.Context, productID string, position *int32, r io.Reader) (*genproto.Product, error) {
+	stream, err := cl.service.UploadProductImage(c)
+	if err != nil {
+		log.Printf("failed to upload product image: %v\n", err)
+		return nil, err
+	}
+
+	err = stream.Send(&genproto.UploadProductImageRequest{
+		Data: &genproto.UploadProductImageRequest_Info{
+			Info: &genproto.UploadImageInfo{ProductId: productID, Position: position},
+		},
+	})
+	if err != nil {
+		log.Printf("failed to upload product image: %v\n", err)
+		return nil, err
+	}
+
+	buf := make([]byte, uploadChunkSize)
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			chunk := &genproto.UploadProductImageRequest{
+				Data: &genproto.UploadProductImageRequest_Chunk{Chunk: append([]byte{}, buf[:n]...)},
+			}
+			if err := stream.Send(chunk); err != nil {
+				// the server closed the stream, the reason is reported by CloseAndRecv
+				break
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			stream.CloseSend()
+			return nil, err
+		}
+	}
+
+	res, err := stream.CloseAndRecv()
+	if err != nil {
+		log.Printf("failed to upload product image: %v\n", err)
+		return nil, err
+	}
+	return res.Product, nil
+}
+
+func (cl *CatalogClient) DeleteProductImage(c context.Context, productID, imageID string) (*genproto.Product, error) {
+	r, err := cl.service.DeleteProductImage(
+		c,
+		&genproto.DeleteProductImageRequest{ProductId: productID, ImageId: imageID},
+	)
+	if err != nil {
+		log.Printf("failed to delete product image: %v\n", err)
+		return nil, err
+	}
+
+	return r.Product, nil
+}
+
+func (cl *CatalogClient) ReorderProductImages(c context.Context, productID string, imageIDs []string) (*genproto.Product, error) {
+	r, err := cl.service.ReorderProductImages(
+		c,
+		&genproto.ReorderProductImagesRequest{ProductId: productID, ImageIds: imageIDs},
+	)
+	if err != nil {
+		log.Printf("failed to reorder product images: %v\n", err)
+		return nil, err
+	}
+
+	return r.Product, nil
+}
+
+func (cl *CatalogClient) GetPriceHistory(c context.Context, productID string, skip, take uint64) ([]*genproto.PriceChange, error) {
+	r, err := cl.service.GetPriceHistory(
+		c,
+		&genproto.GetPriceHistoryRequest{ProductId: productID, Skip: skip, Take: take},
+	)
+	if err != nil {
+		log.Printf("failed to get price history: %v\n", err)
+		return nil, err
+	}
+
+	return r.Changes, nil
+}
+
+func (cl *CatalogClient) SchedulePriceChange(c context.Context, productID string, price float64, startsAt time.Time, endsAt *time.Time, actor string) (*genproto.PriceSchedule, error) {
+	req := &genproto.SchedulePriceChangeRequest{
+		ProductId: productID,
+		Price:     price,
+		Actor:     actor,
+	}
+	req.StartsAt, _ = startsAt.MarshalBinary()
+	if endsAt != nil {
+		req.EndsAt, _ = endsAt.MarshalBinary()
+	}
+
+	r, err := cl.service.SchedulePriceChange(c, req)
+	if err != nil {
+		log.Printf("failed to schedule price change: %v\n", err)
+		return nil, err
+	}
+
+	return r.Schedule, nil
+}
+
+func (cl *CatalogClient) CancelPriceSchedule(c context.Context, id, actor string) (*genproto.PriceSchedule, error) {
+	r, err := cl.service.CancelPriceSchedule(
+		c,
+		&genproto.CancelPriceScheduleRequest{Id: id, Actor: actor},
+	)
+	if err != nil {
+		log.Printf("failed to cancel price schedule: %v\n", err)
+		return nil, err
+	}
+
+	return r.Schedule, nil
+}
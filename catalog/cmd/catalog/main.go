@@ -1,30 +1,47 @@
 package main
 
 import (
+	"context"
+	"fmt"
 	"log"
+	"net/http"
+	"net/url"
 	"time"
+
 	"github.com/kelseyhightower/envconfig"
 	"github.com/tinrab/retry"
+	"github.com/wignn/micro-3/blob"
 	"github.com/wignn/micro-3/catalog/repository"
 	"github.com/wignn/micro-3/catalog/server"
 	"github.com/wignn/micro-3/catalog/service"
 )
 
 type Config struct {
-	DSN string `envconfig:"DATABASE_URL"`
-	PORT int    `envconfig:"PORT" default:"50051"`
+	DSN                   string        `envconfig:"DATABASE_URL"`
+	PORT                  int           `envconfig:"PORT" default:"50051"`
+	PurgeRetention        time.Duration `envconfig:"PURGE_RETENTION" default:"720h"`
+	PurgeInterval         time.Duration `envconfig:"PURGE_INTERVAL" default:"1h"`
+	ImageDir              string        `envconfig:"IMAGE_DIR" default:"/var/lib/catalog/images"`
+	ImageBaseURL          string        `envconfig:"IMAGE_BASE_URL" default:"http://localhost:8081/images"`
+	ImagePort             int           `envconfig:"IMAGE_PORT" default:"8081"`
+	MaxImageSize          int64         `envconfig:"MAX_IMAGE_SIZE" default:"5242880"`
+	ThumbnailSize         int           `envconfig:"THUMBNAIL_SIZE" default:"320"`
+	MaxImages             int           `envconfig:"MAX_IMAGES_PER_PRODUCT" default:"10"`
+	PriceScheduleInterval time.Duration `envconfig:"PRICE_SCHEDULE_INTERVAL" default:"1m"`
+	PublishInterval       time.Duration `envconfig:"PUBLISH_INTERVAL" default:"1m"`
+	DefaultCurrency       string        `envconfig:"DEFAULT_CURRENCY" default:"USD"`
+	SynonymsDir           string        `envconfig:"SYNONYMS_DIR" default:"/var/lib/catalog/analysis"`
 }
 
-
 func main() {
 	var cfg Config
 	if err := envconfig.Process("", &cfg); err != nil {
 		log.Fatal("Failed to process environment variables:", err)
 	}
-	
+
 	var r repository.CatalogRepository
 	retry.ForeverSleep(2*time.Second, func(_ int) (err error) {
-		r, err = repository.NewElasticRepository(cfg.DSN)
+		r, err = repository.NewElasticRepository(cfg.DSN, cfg.SynonymsDir)
 		if err != nil {
 			log.Println(err)
 		}
@@ -32,7 +49,92 @@ func main() {
 	})
 	defer r.Close()
 
+	images, err := blob.NewLocalStore(cfg.ImageDir, cfg.ImageBaseURL)
+	if err != nil {
+		log.Fatal("Failed to open image store:", err)
+	}
+	go serveImages(images, cfg.ImageBaseURL, cfg.ImagePort)
+
 	log.Println("Listening on port", cfg.PORT)
-	s := service.NewCatalogService(r)
-	log.Fatal(server.ListenGRPC(s, cfg.PORT))
-}
\ No newline at end of file
+	s, err := service.NewCatalogService(r, images, service.ImageConfig{
+		MaxSize:          cfg.MaxImageSize,
+		ThumbnailSize:    cfg.ThumbnailSize,
+		MaxImagesPerItem: cfg.MaxImages,
+	}, cfg.DefaultCurrency)
+	if err != nil {
+		log.Fatal("Invalid default currency:", err)
+	}
+	go purgeDeletedProducts(s, cfg.PurgeRetention, cfg.PurgeInterval)
+	go applyPriceSchedules(s, cfg.PriceScheduleInterval)
+	go applyPublishSchedules(s, cfg.PublishInterval)
+	log.Fatal(server.ListenGRPC(s, cfg.PORT, cfg.MaxImageSize))
+}
+
+// applyPriceSchedules periodically starts and ends scheduled price changes
+func applyPriceSchedules(s service.CatalogService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		applied, err := s.ApplyDuePriceSchedules(context.Background(), time.Now().UTC())
+		if err != nil {
+			log.Println("failed to apply price schedules:", err)
+			continue
+		}
+		if applied > 0 {
+			log.Printf("applied %d price schedules\n", applied)
+		}
+	}
+}
+
+// applyPublishSchedules periodically publishes and archives products whose
+// publish or unpublish time has passed
+func applyPublishSchedules(s service.CatalogService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		updated, err := s.ApplyPublishSchedules(context.Background(), time.Now().UTC())
+		if err != nil {
+			log.Println("failed to apply publish schedules:", err)
+			continue
+		}
+		if updated > 0 {
+			log.Printf("updated the status of %d products\n", updated)
+		}
+	}
+}
+
+// serveImages exposes the local image store under the path of baseURL
+func serveImages(images *blob.LocalStore, baseURL string, port int) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		log.Fatal("Invalid IMAGE_BASE_URL:", err)
+	}
+	prefix := u.Path
+	if prefix == "" || prefix[len(prefix)-1] != '/' {
+		prefix += "/"
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(prefix, http.StripPrefix(prefix, images.Handler()))
+	log.Println("Serving images on port", port)
+	log.Fatal(http.ListenAndServe(fmt.Sprintf(":%d", port), mux))
+}
+
+// purgeDeletedProducts periodically removes products soft deleted longer than retention ago
+func purgeDeletedProducts(s service.CatalogService, retention, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		purged, err := s.PurgeDeletedProducts(context.Background(), retention)
+		if err != nil {
+			log.Println("failed to purge deleted products:", err)
+			continue
+		}
+		if purged > 0 {
+			log.Printf("purged %d deleted products\n", purged)
+		}
+	}
+}
@@ -9,6 +9,7 @@ package genproto
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -21,20 +22,178 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
-type Product struct {
+type ProductFormat int32
+
+const (
+	ProductFormat_CSV    ProductFormat = 0
+	ProductFormat_NDJSON ProductFormat = 1
+)
+
+// Enum value maps for ProductFormat.
+var (
+	ProductFormat_name = map[int32]string{
+		0: "CSV",
+		1: "NDJSON",
+	}
+	ProductFormat_value = map[string]int32{
+		"CSV":    0,
+		"NDJSON": 1,
+	}
+)
+
+func (x ProductFormat) Enum() *ProductFormat {
+	p := new(ProductFormat)
+	*p = x
+	return p
+}
+
+func (x ProductFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ProductFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_catalog_proto_enumTypes[0].Descriptor()
+}
+
+func (ProductFormat) Type() protoreflect.EnumType {
+	return &file_catalog_proto_enumTypes[0]
+}
+
+func (x ProductFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ProductFormat.Descriptor instead.
+func (ProductFormat) EnumDescriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{0}
+}
+
+type ProductImage struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	Price         float64                `protobuf:"fixed64,4,opt,name=price,proto3" json:"price,omitempty"`
-	Image         string                 `protobuf:"bytes,5,opt,name=image,proto3" json:"image,omitempty"`
+	Url           string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	ThumbnailUrl  string                 `protobuf:"bytes,3,opt,name=thumbnailUrl,proto3" json:"thumbnailUrl,omitempty"`
+	ContentType   string                 `protobuf:"bytes,4,opt,name=contentType,proto3" json:"contentType,omitempty"`
+	Size          int64                  `protobuf:"varint,5,opt,name=size,proto3" json:"size,omitempty"`
+	Width         int32                  `protobuf:"varint,6,opt,name=width,proto3" json:"width,omitempty"`
+	Height        int32                  `protobuf:"varint,7,opt,name=height,proto3" json:"height,omitempty"`
+	Position      int32                  `protobuf:"varint,8,opt,name=position,proto3" json:"position,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProductImage) Reset() {
+	*x = ProductImage{}
+	mi := &file_catalog_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductImage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductImage) ProtoMessage() {}
+
+func (x *ProductImage) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductImage.ProtoReflect.Descriptor instead.
+func (*ProductImage) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ProductImage) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ProductImage) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *ProductImage) GetThumbnailUrl() string {
+	if x != nil {
+		return x.ThumbnailUrl
+	}
+	return ""
+}
+
+func (x *ProductImage) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *ProductImage) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *ProductImage) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *ProductImage) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *ProductImage) GetPosition() int32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+type Product struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name        string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Price       float64                `protobuf:"fixed64,4,opt,name=price,proto3" json:"price,omitempty"`
+	Image       string                 `protobuf:"bytes,5,opt,name=image,proto3" json:"image,omitempty"`
+	Deleted     bool                   `protobuf:"varint,6,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	Images      []*ProductImage        `protobuf:"bytes,7,rep,name=images,proto3" json:"images,omitempty"`
+	Currency    string                 `protobuf:"bytes,8,opt,name=currency,proto3" json:"currency,omitempty"`
+	Version     string                 `protobuf:"bytes,9,opt,name=version,proto3" json:"version,omitempty"`
+	// draft, scheduled, published or archived
+	Status      string `protobuf:"bytes,10,opt,name=status,proto3" json:"status,omitempty"`
+	PublishAt   []byte `protobuf:"bytes,11,opt,name=publishAt,proto3" json:"publishAt,omitempty"`
+	UnpublishAt []byte `protobuf:"bytes,12,opt,name=unpublishAt,proto3" json:"unpublishAt,omitempty"`
+	// whether customers can see the product now
+	Visible bool `protobuf:"varint,13,opt,name=visible,proto3" json:"visible,omitempty"`
+	// shipping weight of one unit, zero when unknown
+	WeightGrams   int64 `protobuf:"varint,14,opt,name=weightGrams,proto3" json:"weightGrams,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Product) Reset() {
 	*x = Product{}
-	mi := &file_catalog_proto_msgTypes[0]
+	mi := &file_catalog_proto_msgTypes[1]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -46,7 +205,7 @@ func (x *Product) String() string {
 func (*Product) ProtoMessage() {}
 
 func (x *Product) ProtoReflect() protoreflect.Message {
-	mi := &file_catalog_proto_msgTypes[0]
+	mi := &file_catalog_proto_msgTypes[1]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -59,7 +218,7 @@ func (x *Product) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Product.ProtoReflect.Descriptor instead.
 func (*Product) Descriptor() ([]byte, []int) {
-	return file_catalog_proto_rawDescGZIP(), []int{0}
+	return file_catalog_proto_rawDescGZIP(), []int{1}
 }
 
 func (x *Product) GetId() string {
@@ -97,19 +256,89 @@ func (x *Product) GetImage() string {
 	return ""
 }
 
+func (x *Product) GetDeleted() bool {
+	if x != nil {
+		return x.Deleted
+	}
+	return false
+}
+
+func (x *Product) GetImages() []*ProductImage {
+	if x != nil {
+		return x.Images
+	}
+	return nil
+}
+
+func (x *Product) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *Product) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *Product) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Product) GetPublishAt() []byte {
+	if x != nil {
+		return x.PublishAt
+	}
+	return nil
+}
+
+func (x *Product) GetUnpublishAt() []byte {
+	if x != nil {
+		return x.UnpublishAt
+	}
+	return nil
+}
+
+func (x *Product) GetVisible() bool {
+	if x != nil {
+		return x.Visible
+	}
+	return false
+}
+
+func (x *Product) GetWeightGrams() int64 {
+	if x != nil {
+		return x.WeightGrams
+	}
+	return 0
+}
+
 type PostProductRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
-	Price         float64                `protobuf:"fixed64,3,opt,name=price,proto3" json:"price,omitempty"`
-	Image         string                 `protobuf:"bytes,4,opt,name=image,proto3" json:"image,omitempty"`
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Name        string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Price       float64                `protobuf:"fixed64,3,opt,name=price,proto3" json:"price,omitempty"`
+	Image       string                 `protobuf:"bytes,4,opt,name=image,proto3" json:"image,omitempty"`
+	Actor       string                 `protobuf:"bytes,5,opt,name=actor,proto3" json:"actor,omitempty"`
+	Currency    string                 `protobuf:"bytes,6,opt,name=currency,proto3" json:"currency,omitempty"`
+	// empty publishes the product, or schedules it when publishAt is in the future
+	Status        string `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"`
+	PublishAt     []byte `protobuf:"bytes,8,opt,name=publishAt,proto3" json:"publishAt,omitempty"`
+	UnpublishAt   []byte `protobuf:"bytes,9,opt,name=unpublishAt,proto3" json:"unpublishAt,omitempty"`
+	WeightGrams   int64  `protobuf:"varint,10,opt,name=weightGrams,proto3" json:"weightGrams,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *PostProductRequest) Reset() {
 	*x = PostProductRequest{}
-	mi := &file_catalog_proto_msgTypes[1]
+	mi := &file_catalog_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -121,7 +350,7 @@ func (x *PostProductRequest) String() string {
 func (*PostProductRequest) ProtoMessage() {}
 
 func (x *PostProductRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_catalog_proto_msgTypes[1]
+	mi := &file_catalog_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -134,7 +363,7 @@ func (x *PostProductRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PostProductRequest.ProtoReflect.Descriptor instead.
 func (*PostProductRequest) Descriptor() ([]byte, []int) {
-	return file_catalog_proto_rawDescGZIP(), []int{1}
+	return file_catalog_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *PostProductRequest) GetName() string {
@@ -165,6 +394,48 @@ func (x *PostProductRequest) GetImage() string {
 	return ""
 }
 
+func (x *PostProductRequest) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *PostProductRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *PostProductRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *PostProductRequest) GetPublishAt() []byte {
+	if x != nil {
+		return x.PublishAt
+	}
+	return nil
+}
+
+func (x *PostProductRequest) GetUnpublishAt() []byte {
+	if x != nil {
+		return x.UnpublishAt
+	}
+	return nil
+}
+
+func (x *PostProductRequest) GetWeightGrams() int64 {
+	if x != nil {
+		return x.WeightGrams
+	}
+	return 0
+}
+
 type PostProductResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
@@ -174,7 +445,7 @@ type PostProductResponse struct {
 
 func (x *PostProductResponse) Reset() {
 	*x = PostProductResponse{}
-	mi := &file_catalog_proto_msgTypes[2]
+	mi := &file_catalog_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -186,7 +457,7 @@ func (x *PostProductResponse) String() string {
 func (*PostProductResponse) ProtoMessage() {}
 
 func (x *PostProductResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_catalog_proto_msgTypes[2]
+	mi := &file_catalog_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -199,7 +470,7 @@ func (x *PostProductResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PostProductResponse.ProtoReflect.Descriptor instead.
 func (*PostProductResponse) Descriptor() ([]byte, []int) {
-	return file_catalog_proto_rawDescGZIP(), []int{2}
+	return file_catalog_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *PostProductResponse) GetProduct() *Product {
@@ -218,7 +489,7 @@ type GetProductRequest struct {
 
 func (x *GetProductRequest) Reset() {
 	*x = GetProductRequest{}
-	mi := &file_catalog_proto_msgTypes[3]
+	mi := &file_catalog_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -230,7 +501,7 @@ func (x *GetProductRequest) String() string {
 func (*GetProductRequest) ProtoMessage() {}
 
 func (x *GetProductRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_catalog_proto_msgTypes[3]
+	mi := &file_catalog_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -243,7 +514,7 @@ func (x *GetProductRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetProductRequest.ProtoReflect.Descriptor instead.
 func (*GetProductRequest) Descriptor() ([]byte, []int) {
-	return file_catalog_proto_rawDescGZIP(), []int{3}
+	return file_catalog_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *GetProductRequest) GetId() string {
@@ -262,7 +533,7 @@ type GetProductResponse struct {
 
 func (x *GetProductResponse) Reset() {
 	*x = GetProductResponse{}
-	mi := &file_catalog_proto_msgTypes[4]
+	mi := &file_catalog_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -274,7 +545,7 @@ func (x *GetProductResponse) String() string {
 func (*GetProductResponse) ProtoMessage() {}
 
 func (x *GetProductResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_catalog_proto_msgTypes[4]
+	mi := &file_catalog_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -287,7 +558,7 @@ func (x *GetProductResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetProductResponse.ProtoReflect.Descriptor instead.
 func (*GetProductResponse) Descriptor() ([]byte, []int) {
-	return file_catalog_proto_rawDescGZIP(), []int{4}
+	return file_catalog_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *GetProductResponse) GetProduct() *Product {
@@ -298,18 +569,20 @@ func (x *GetProductResponse) GetProduct() *Product {
 }
 
 type GetProductsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Skip          uint64                 `protobuf:"varint,1,opt,name=skip,proto3" json:"skip,omitempty"`
-	Take          uint64                 `protobuf:"varint,2,opt,name=take,proto3" json:"take,omitempty"`
-	Ids           []string               `protobuf:"bytes,3,rep,name=ids,proto3" json:"ids,omitempty"`
-	Query         string                 `protobuf:"bytes,4,opt,name=query,proto3" json:"query,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Skip  uint64                 `protobuf:"varint,1,opt,name=skip,proto3" json:"skip,omitempty"`
+	Take  uint64                 `protobuf:"varint,2,opt,name=take,proto3" json:"take,omitempty"`
+	Ids   []string               `protobuf:"bytes,3,rep,name=ids,proto3" json:"ids,omitempty"`
+	Query string                 `protobuf:"bytes,4,opt,name=query,proto3" json:"query,omitempty"`
+	// also list draft, scheduled and archived products, for admins
+	IncludeUnpublished bool `protobuf:"varint,5,opt,name=includeUnpublished,proto3" json:"includeUnpublished,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
 }
 
 func (x *GetProductsRequest) Reset() {
 	*x = GetProductsRequest{}
-	mi := &file_catalog_proto_msgTypes[5]
+	mi := &file_catalog_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -321,7 +594,7 @@ func (x *GetProductsRequest) String() string {
 func (*GetProductsRequest) ProtoMessage() {}
 
 func (x *GetProductsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_catalog_proto_msgTypes[5]
+	mi := &file_catalog_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -334,7 +607,7 @@ func (x *GetProductsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetProductsRequest.ProtoReflect.Descriptor instead.
 func (*GetProductsRequest) Descriptor() ([]byte, []int) {
-	return file_catalog_proto_rawDescGZIP(), []int{5}
+	return file_catalog_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *GetProductsRequest) GetSkip() uint64 {
@@ -365,28 +638,36 @@ func (x *GetProductsRequest) GetQuery() string {
 	return ""
 }
 
-type DeleteProductRequest struct {
+func (x *GetProductsRequest) GetIncludeUnpublished() bool {
+	if x != nil {
+		return x.IncludeUnpublished
+	}
+	return false
+}
+
+type GetSimilarProductsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Take          uint64                 `protobuf:"varint,2,opt,name=take,proto3" json:"take,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteProductRequest) Reset() {
-	*x = DeleteProductRequest{}
-	mi := &file_catalog_proto_msgTypes[6]
+func (x *GetSimilarProductsRequest) Reset() {
+	*x = GetSimilarProductsRequest{}
+	mi := &file_catalog_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteProductRequest) String() string {
+func (x *GetSimilarProductsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteProductRequest) ProtoMessage() {}
+func (*GetSimilarProductsRequest) ProtoMessage() {}
 
-func (x *DeleteProductRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_catalog_proto_msgTypes[6]
+func (x *GetSimilarProductsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -397,40 +678,48 @@ func (x *DeleteProductRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteProductRequest.ProtoReflect.Descriptor instead.
-func (*DeleteProductRequest) Descriptor() ([]byte, []int) {
-	return file_catalog_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use GetSimilarProductsRequest.ProtoReflect.Descriptor instead.
+func (*GetSimilarProductsRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *DeleteProductRequest) GetId() string {
+func (x *GetSimilarProductsRequest) GetId() string {
 	if x != nil {
 		return x.Id
 	}
 	return ""
 }
 
-type GetProductsResponse struct {
+func (x *GetSimilarProductsRequest) GetTake() uint64 {
+	if x != nil {
+		return x.Take
+	}
+	return 0
+}
+
+type SimilarProduct struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Products      []*Product             `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
+	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	Score         float64                `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetProductsResponse) Reset() {
-	*x = GetProductsResponse{}
-	mi := &file_catalog_proto_msgTypes[7]
+func (x *SimilarProduct) Reset() {
+	*x = SimilarProduct{}
+	mi := &file_catalog_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetProductsResponse) String() string {
+func (x *SimilarProduct) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetProductsResponse) ProtoMessage() {}
+func (*SimilarProduct) ProtoMessage() {}
 
-func (x *GetProductsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_catalog_proto_msgTypes[7]
+func (x *SimilarProduct) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -441,20 +730,875 @@ func (x *GetProductsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetProductsResponse.ProtoReflect.Descriptor instead.
-func (*GetProductsResponse) Descriptor() ([]byte, []int) {
-	return file_catalog_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use SimilarProduct.ProtoReflect.Descriptor instead.
+func (*SimilarProduct) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *GetProductsResponse) GetProducts() []*Product {
+func (x *SimilarProduct) GetProduct() *Product {
 	if x != nil {
-		return x.Products
+		return x.Product
 	}
 	return nil
 }
 
-type DeleteProductResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+func (x *SimilarProduct) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+type GetSimilarProductsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Products      []*SimilarProduct      `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSimilarProductsResponse) Reset() {
+	*x = GetSimilarProductsResponse{}
+	mi := &file_catalog_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSimilarProductsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSimilarProductsResponse) ProtoMessage() {}
+
+func (x *GetSimilarProductsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSimilarProductsResponse.ProtoReflect.Descriptor instead.
+func (*GetSimilarProductsResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetSimilarProductsResponse) GetProducts() []*SimilarProduct {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
+type DeleteProductRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteProductRequest) Reset() {
+	*x = DeleteProductRequest{}
+	mi := &file_catalog_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteProductRequest) ProtoMessage() {}
+
+func (x *DeleteProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteProductRequest.ProtoReflect.Descriptor instead.
+func (*DeleteProductRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *DeleteProductRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetProductsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Products      []*Product             `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
+	SearchId      string                 `protobuf:"bytes,2,opt,name=searchId,proto3" json:"searchId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProductsResponse) Reset() {
+	*x = GetProductsResponse{}
+	mi := &file_catalog_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProductsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProductsResponse) ProtoMessage() {}
+
+func (x *GetProductsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProductsResponse.ProtoReflect.Descriptor instead.
+func (*GetProductsResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetProductsResponse) GetProducts() []*Product {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
+func (x *GetProductsResponse) GetSearchId() string {
+	if x != nil {
+		return x.SearchId
+	}
+	return ""
+}
+
+type RecordSearchClickRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SearchId      string                 `protobuf:"bytes,1,opt,name=searchId,proto3" json:"searchId,omitempty"`
+	ProductId     string                 `protobuf:"bytes,2,opt,name=productId,proto3" json:"productId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordSearchClickRequest) Reset() {
+	*x = RecordSearchClickRequest{}
+	mi := &file_catalog_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordSearchClickRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordSearchClickRequest) ProtoMessage() {}
+
+func (x *RecordSearchClickRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordSearchClickRequest.ProtoReflect.Descriptor instead.
+func (*RecordSearchClickRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *RecordSearchClickRequest) GetSearchId() string {
+	if x != nil {
+		return x.SearchId
+	}
+	return ""
+}
+
+func (x *RecordSearchClickRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+type RecordSearchClickResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordSearchClickResponse) Reset() {
+	*x = RecordSearchClickResponse{}
+	mi := &file_catalog_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordSearchClickResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordSearchClickResponse) ProtoMessage() {}
+
+func (x *RecordSearchClickResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordSearchClickResponse.ProtoReflect.Descriptor instead.
+func (*RecordSearchClickResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{13}
+}
+
+type GetSearchReportsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	From          []byte                 `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To            []byte                 `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	Take          uint64                 `protobuf:"varint,3,opt,name=take,proto3" json:"take,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSearchReportsRequest) Reset() {
+	*x = GetSearchReportsRequest{}
+	mi := &file_catalog_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSearchReportsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSearchReportsRequest) ProtoMessage() {}
+
+func (x *GetSearchReportsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSearchReportsRequest.ProtoReflect.Descriptor instead.
+func (*GetSearchReportsRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetSearchReportsRequest) GetFrom() []byte {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *GetSearchReportsRequest) GetTo() []byte {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *GetSearchReportsRequest) GetTake() uint64 {
+	if x != nil {
+		return x.Take
+	}
+	return 0
+}
+
+type QueryStats struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	Searches      int64                  `protobuf:"varint,2,opt,name=searches,proto3" json:"searches,omitempty"`
+	ZeroResults   int64                  `protobuf:"varint,3,opt,name=zeroResults,proto3" json:"zeroResults,omitempty"`
+	Clicks        int64                  `protobuf:"varint,4,opt,name=clicks,proto3" json:"clicks,omitempty"`
+	AvgLatencyMs  float64                `protobuf:"fixed64,5,opt,name=avgLatencyMs,proto3" json:"avgLatencyMs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryStats) Reset() {
+	*x = QueryStats{}
+	mi := &file_catalog_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryStats) ProtoMessage() {}
+
+func (x *QueryStats) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryStats.ProtoReflect.Descriptor instead.
+func (*QueryStats) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *QueryStats) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *QueryStats) GetSearches() int64 {
+	if x != nil {
+		return x.Searches
+	}
+	return 0
+}
+
+func (x *QueryStats) GetZeroResults() int64 {
+	if x != nil {
+		return x.ZeroResults
+	}
+	return 0
+}
+
+func (x *QueryStats) GetClicks() int64 {
+	if x != nil {
+		return x.Clicks
+	}
+	return 0
+}
+
+func (x *QueryStats) GetAvgLatencyMs() float64 {
+	if x != nil {
+		return x.AvgLatencyMs
+	}
+	return 0
+}
+
+type SearchReport struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Day               []byte                 `protobuf:"bytes,1,opt,name=day,proto3" json:"day,omitempty"`
+	Searches          int64                  `protobuf:"varint,2,opt,name=searches,proto3" json:"searches,omitempty"`
+	TopQueries        []*QueryStats          `protobuf:"bytes,3,rep,name=topQueries,proto3" json:"topQueries,omitempty"`
+	ZeroResultQueries []*QueryStats          `protobuf:"bytes,4,rep,name=zeroResultQueries,proto3" json:"zeroResultQueries,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *SearchReport) Reset() {
+	*x = SearchReport{}
+	mi := &file_catalog_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchReport) ProtoMessage() {}
+
+func (x *SearchReport) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchReport.ProtoReflect.Descriptor instead.
+func (*SearchReport) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *SearchReport) GetDay() []byte {
+	if x != nil {
+		return x.Day
+	}
+	return nil
+}
+
+func (x *SearchReport) GetSearches() int64 {
+	if x != nil {
+		return x.Searches
+	}
+	return 0
+}
+
+func (x *SearchReport) GetTopQueries() []*QueryStats {
+	if x != nil {
+		return x.TopQueries
+	}
+	return nil
+}
+
+func (x *SearchReport) GetZeroResultQueries() []*QueryStats {
+	if x != nil {
+		return x.ZeroResultQueries
+	}
+	return nil
+}
+
+type GetSearchReportsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reports       []*SearchReport        `protobuf:"bytes,1,rep,name=reports,proto3" json:"reports,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSearchReportsResponse) Reset() {
+	*x = GetSearchReportsResponse{}
+	mi := &file_catalog_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSearchReportsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSearchReportsResponse) ProtoMessage() {}
+
+func (x *GetSearchReportsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSearchReportsResponse.ProtoReflect.Descriptor instead.
+func (*GetSearchReportsResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetSearchReportsResponse) GetReports() []*SearchReport {
+	if x != nil {
+		return x.Reports
+	}
+	return nil
+}
+
+type SearchSettings struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	NameBoost        float64                `protobuf:"fixed64,1,opt,name=nameBoost,proto3" json:"nameBoost,omitempty"`
+	DescriptionBoost float64                `protobuf:"fixed64,2,opt,name=descriptionBoost,proto3" json:"descriptionBoost,omitempty"`
+	PhraseBoost      float64                `protobuf:"fixed64,3,opt,name=phraseBoost,proto3" json:"phraseBoost,omitempty"`
+	InStockBoost     float64                `protobuf:"fixed64,4,opt,name=inStockBoost,proto3" json:"inStockBoost,omitempty"`
+	RatingBoost      float64                `protobuf:"fixed64,5,opt,name=ratingBoost,proto3" json:"ratingBoost,omitempty"`
+	Synonyms         []string               `protobuf:"bytes,6,rep,name=synonyms,proto3" json:"synonyms,omitempty"`
+	Actor            string                 `protobuf:"bytes,7,opt,name=actor,proto3" json:"actor,omitempty"`
+	UpdatedAt        []byte                 `protobuf:"bytes,8,opt,name=updatedAt,proto3" json:"updatedAt,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *SearchSettings) Reset() {
+	*x = SearchSettings{}
+	mi := &file_catalog_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchSettings) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchSettings) ProtoMessage() {}
+
+func (x *SearchSettings) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchSettings.ProtoReflect.Descriptor instead.
+func (*SearchSettings) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *SearchSettings) GetNameBoost() float64 {
+	if x != nil {
+		return x.NameBoost
+	}
+	return 0
+}
+
+func (x *SearchSettings) GetDescriptionBoost() float64 {
+	if x != nil {
+		return x.DescriptionBoost
+	}
+	return 0
+}
+
+func (x *SearchSettings) GetPhraseBoost() float64 {
+	if x != nil {
+		return x.PhraseBoost
+	}
+	return 0
+}
+
+func (x *SearchSettings) GetInStockBoost() float64 {
+	if x != nil {
+		return x.InStockBoost
+	}
+	return 0
+}
+
+func (x *SearchSettings) GetRatingBoost() float64 {
+	if x != nil {
+		return x.RatingBoost
+	}
+	return 0
+}
+
+func (x *SearchSettings) GetSynonyms() []string {
+	if x != nil {
+		return x.Synonyms
+	}
+	return nil
+}
+
+func (x *SearchSettings) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *SearchSettings) GetUpdatedAt() []byte {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type GetSearchSettingsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSearchSettingsRequest) Reset() {
+	*x = GetSearchSettingsRequest{}
+	mi := &file_catalog_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSearchSettingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSearchSettingsRequest) ProtoMessage() {}
+
+func (x *GetSearchSettingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSearchSettingsRequest.ProtoReflect.Descriptor instead.
+func (*GetSearchSettingsRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{19}
+}
+
+type UpdateSearchSettingsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Settings      *SearchSettings        `protobuf:"bytes,1,opt,name=settings,proto3" json:"settings,omitempty"`
+	Actor         string                 `protobuf:"bytes,2,opt,name=actor,proto3" json:"actor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateSearchSettingsRequest) Reset() {
+	*x = UpdateSearchSettingsRequest{}
+	mi := &file_catalog_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateSearchSettingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateSearchSettingsRequest) ProtoMessage() {}
+
+func (x *UpdateSearchSettingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateSearchSettingsRequest.ProtoReflect.Descriptor instead.
+func (*UpdateSearchSettingsRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *UpdateSearchSettingsRequest) GetSettings() *SearchSettings {
+	if x != nil {
+		return x.Settings
+	}
+	return nil
+}
+
+func (x *UpdateSearchSettingsRequest) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+type SearchSettingsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Settings      *SearchSettings        `protobuf:"bytes,1,opt,name=settings,proto3" json:"settings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchSettingsResponse) Reset() {
+	*x = SearchSettingsResponse{}
+	mi := &file_catalog_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchSettingsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchSettingsResponse) ProtoMessage() {}
+
+func (x *SearchSettingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchSettingsResponse.ProtoReflect.Descriptor instead.
+func (*SearchSettingsResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *SearchSettingsResponse) GetSettings() *SearchSettings {
+	if x != nil {
+		return x.Settings
+	}
+	return nil
+}
+
+type SetProductInStockRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=productId,proto3" json:"productId,omitempty"`
+	InStock       bool                   `protobuf:"varint,2,opt,name=inStock,proto3" json:"inStock,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetProductInStockRequest) Reset() {
+	*x = SetProductInStockRequest{}
+	mi := &file_catalog_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetProductInStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetProductInStockRequest) ProtoMessage() {}
+
+func (x *SetProductInStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetProductInStockRequest.ProtoReflect.Descriptor instead.
+func (*SetProductInStockRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *SetProductInStockRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *SetProductInStockRequest) GetInStock() bool {
+	if x != nil {
+		return x.InStock
+	}
+	return false
+}
+
+type AddProductRatingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=productId,proto3" json:"productId,omitempty"`
+	Rating        int32                  `protobuf:"varint,2,opt,name=rating,proto3" json:"rating,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddProductRatingRequest) Reset() {
+	*x = AddProductRatingRequest{}
+	mi := &file_catalog_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddProductRatingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddProductRatingRequest) ProtoMessage() {}
+
+func (x *AddProductRatingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddProductRatingRequest.ProtoReflect.Descriptor instead.
+func (*AddProductRatingRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *AddProductRatingRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *AddProductRatingRequest) GetRating() int32 {
+	if x != nil {
+		return x.Rating
+	}
+	return 0
+}
+
+type SearchSignalResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchSignalResponse) Reset() {
+	*x = SearchSignalResponse{}
+	mi := &file_catalog_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchSignalResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchSignalResponse) ProtoMessage() {}
+
+func (x *SearchSignalResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchSignalResponse.ProtoReflect.Descriptor instead.
+func (*SearchSignalResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{24}
+}
+
+type DeleteProductResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
 	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
 	Success       bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
 	DeletedID     string                 `protobuf:"bytes,3,opt,name=deletedID,proto3" json:"deletedID,omitempty"`
@@ -462,21 +1606,910 @@ type DeleteProductResponse struct {
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteProductResponse) Reset() {
-	*x = DeleteProductResponse{}
-	mi := &file_catalog_proto_msgTypes[8]
+func (x *DeleteProductResponse) Reset() {
+	*x = DeleteProductResponse{}
+	mi := &file_catalog_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteProductResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteProductResponse) ProtoMessage() {}
+
+func (x *DeleteProductResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteProductResponse.ProtoReflect.Descriptor instead.
+func (*DeleteProductResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *DeleteProductResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *DeleteProductResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *DeleteProductResponse) GetDeletedID() string {
+	if x != nil {
+		return x.DeletedID
+	}
+	return ""
+}
+
+type RestoreProductRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreProductRequest) Reset() {
+	*x = RestoreProductRequest{}
+	mi := &file_catalog_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreProductRequest) ProtoMessage() {}
+
+func (x *RestoreProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreProductRequest.ProtoReflect.Descriptor instead.
+func (*RestoreProductRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *RestoreProductRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type EditProductRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name        string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Price       float64                `protobuf:"fixed64,4,opt,name=price,proto3" json:"price,omitempty"`
+	Image       string                 `protobuf:"bytes,5,opt,name=image,proto3" json:"image,omitempty"`
+	Actor       string                 `protobuf:"bytes,6,opt,name=actor,proto3" json:"actor,omitempty"`
+	Currency    string                 `protobuf:"bytes,7,opt,name=currency,proto3" json:"currency,omitempty"`
+	// paths of the fields to update, an empty mask updates name, description,
+	// price and image, and currency when it is set
+	UpdateMask *fieldmaskpb.FieldMask `protobuf:"bytes,8,opt,name=updateMask,proto3" json:"updateMask,omitempty"`
+	// version of the product the edit is based on, empty to skip the check
+	Version string `protobuf:"bytes,9,opt,name=version,proto3" json:"version,omitempty"`
+	// status, publishAt and unpublishAt are replaced together when any of
+	// them is in the mask, empty times clear them
+	Status        string `protobuf:"bytes,10,opt,name=status,proto3" json:"status,omitempty"`
+	PublishAt     []byte `protobuf:"bytes,11,opt,name=publishAt,proto3" json:"publishAt,omitempty"`
+	UnpublishAt   []byte `protobuf:"bytes,12,opt,name=unpublishAt,proto3" json:"unpublishAt,omitempty"`
+	WeightGrams   int64  `protobuf:"varint,13,opt,name=weightGrams,proto3" json:"weightGrams,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EditProductRequest) Reset() {
+	*x = EditProductRequest{}
+	mi := &file_catalog_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EditProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EditProductRequest) ProtoMessage() {}
+
+func (x *EditProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EditProductRequest.ProtoReflect.Descriptor instead.
+func (*EditProductRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *EditProductRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *EditProductRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *EditProductRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *EditProductRequest) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *EditProductRequest) GetImage() string {
+	if x != nil {
+		return x.Image
+	}
+	return ""
+}
+
+func (x *EditProductRequest) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *EditProductRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *EditProductRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+func (x *EditProductRequest) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *EditProductRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *EditProductRequest) GetPublishAt() []byte {
+	if x != nil {
+		return x.PublishAt
+	}
+	return nil
+}
+
+func (x *EditProductRequest) GetUnpublishAt() []byte {
+	if x != nil {
+		return x.UnpublishAt
+	}
+	return nil
+}
+
+func (x *EditProductRequest) GetWeightGrams() int64 {
+	if x != nil {
+		return x.WeightGrams
+	}
+	return 0
+}
+
+type ImportProductsRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Format ProductFormat          `protobuf:"varint,1,opt,name=format,proto3,enum=genproto.ProductFormat" json:"format,omitempty"`
+	Row    string                 `protobuf:"bytes,2,opt,name=row,proto3" json:"row,omitempty"`
+	// recorded on the price changes of the import, read from the first
+	// message like format
+	Actor         string `protobuf:"bytes,3,opt,name=actor,proto3" json:"actor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportProductsRequest) Reset() {
+	*x = ImportProductsRequest{}
+	mi := &file_catalog_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportProductsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportProductsRequest) ProtoMessage() {}
+
+func (x *ImportProductsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportProductsRequest.ProtoReflect.Descriptor instead.
+func (*ImportProductsRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ImportProductsRequest) GetFormat() ProductFormat {
+	if x != nil {
+		return x.Format
+	}
+	return ProductFormat_CSV
+}
+
+func (x *ImportProductsRequest) GetRow() string {
+	if x != nil {
+		return x.Row
+	}
+	return ""
+}
+
+func (x *ImportProductsRequest) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+type ImportError struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Row           uint64                 `protobuf:"varint,1,opt,name=row,proto3" json:"row,omitempty"`
+	Id            string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportError) Reset() {
+	*x = ImportError{}
+	mi := &file_catalog_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportError) ProtoMessage() {}
+
+func (x *ImportError) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportError.ProtoReflect.Descriptor instead.
+func (*ImportError) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ImportError) GetRow() uint64 {
+	if x != nil {
+		return x.Row
+	}
+	return 0
+}
+
+func (x *ImportError) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ImportError) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type ImportProductsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Total         uint64                 `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
+	Imported      uint64                 `protobuf:"varint,2,opt,name=imported,proto3" json:"imported,omitempty"`
+	Failed        uint64                 `protobuf:"varint,3,opt,name=failed,proto3" json:"failed,omitempty"`
+	Errors        []*ImportError         `protobuf:"bytes,4,rep,name=errors,proto3" json:"errors,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportProductsResponse) Reset() {
+	*x = ImportProductsResponse{}
+	mi := &file_catalog_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportProductsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportProductsResponse) ProtoMessage() {}
+
+func (x *ImportProductsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportProductsResponse.ProtoReflect.Descriptor instead.
+func (*ImportProductsResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ImportProductsResponse) GetTotal() uint64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ImportProductsResponse) GetImported() uint64 {
+	if x != nil {
+		return x.Imported
+	}
+	return 0
+}
+
+func (x *ImportProductsResponse) GetFailed() uint64 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *ImportProductsResponse) GetErrors() []*ImportError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+type ExportProductsRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Format         ProductFormat          `protobuf:"varint,1,opt,name=format,proto3,enum=genproto.ProductFormat" json:"format,omitempty"`
+	IncludeDeleted bool                   `protobuf:"varint,2,opt,name=includeDeleted,proto3" json:"includeDeleted,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ExportProductsRequest) Reset() {
+	*x = ExportProductsRequest{}
+	mi := &file_catalog_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportProductsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportProductsRequest) ProtoMessage() {}
+
+func (x *ExportProductsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportProductsRequest.ProtoReflect.Descriptor instead.
+func (*ExportProductsRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ExportProductsRequest) GetFormat() ProductFormat {
+	if x != nil {
+		return x.Format
+	}
+	return ProductFormat_CSV
+}
+
+func (x *ExportProductsRequest) GetIncludeDeleted() bool {
+	if x != nil {
+		return x.IncludeDeleted
+	}
+	return false
+}
+
+type ExportProductsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Row           string                 `protobuf:"bytes,1,opt,name=row,proto3" json:"row,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportProductsResponse) Reset() {
+	*x = ExportProductsResponse{}
+	mi := &file_catalog_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportProductsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportProductsResponse) ProtoMessage() {}
+
+func (x *ExportProductsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportProductsResponse.ProtoReflect.Descriptor instead.
+func (*ExportProductsResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ExportProductsResponse) GetRow() string {
+	if x != nil {
+		return x.Row
+	}
+	return ""
+}
+
+type UploadImageInfo struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ProductId string                 `protobuf:"bytes,1,opt,name=productId,proto3" json:"productId,omitempty"`
+	// zero based, the image is appended when unset
+	Position      *int32 `protobuf:"varint,2,opt,name=position,proto3,oneof" json:"position,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadImageInfo) Reset() {
+	*x = UploadImageInfo{}
+	mi := &file_catalog_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadImageInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadImageInfo) ProtoMessage() {}
+
+func (x *UploadImageInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadImageInfo.ProtoReflect.Descriptor instead.
+func (*UploadImageInfo) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *UploadImageInfo) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *UploadImageInfo) GetPosition() int32 {
+	if x != nil && x.Position != nil {
+		return *x.Position
+	}
+	return 0
+}
+
+// the first message carries the info, the following ones the image bytes
+type UploadProductImageRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Data:
+	//
+	//	*UploadProductImageRequest_Info
+	//	*UploadProductImageRequest_Chunk
+	Data          isUploadProductImageRequest_Data `protobuf_oneof:"data"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadProductImageRequest) Reset() {
+	*x = UploadProductImageRequest{}
+	mi := &file_catalog_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadProductImageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadProductImageRequest) ProtoMessage() {}
+
+func (x *UploadProductImageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadProductImageRequest.ProtoReflect.Descriptor instead.
+func (*UploadProductImageRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *UploadProductImageRequest) GetData() isUploadProductImageRequest_Data {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *UploadProductImageRequest) GetInfo() *UploadImageInfo {
+	if x != nil {
+		if x, ok := x.Data.(*UploadProductImageRequest_Info); ok {
+			return x.Info
+		}
+	}
+	return nil
+}
+
+func (x *UploadProductImageRequest) GetChunk() []byte {
+	if x != nil {
+		if x, ok := x.Data.(*UploadProductImageRequest_Chunk); ok {
+			return x.Chunk
+		}
+	}
+	return nil
+}
+
+type isUploadProductImageRequest_Data interface {
+	isUploadProductImageRequest_Data()
+}
+
+type UploadProductImageRequest_Info struct {
+	Info *UploadImageInfo `protobuf:"bytes,1,opt,name=info,proto3,oneof"`
+}
+
+type UploadProductImageRequest_Chunk struct {
+	Chunk []byte `protobuf:"bytes,2,opt,name=chunk,proto3,oneof"`
+}
+
+func (*UploadProductImageRequest_Info) isUploadProductImageRequest_Data() {}
+
+func (*UploadProductImageRequest_Chunk) isUploadProductImageRequest_Data() {}
+
+type DeleteProductImageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=productId,proto3" json:"productId,omitempty"`
+	ImageId       string                 `protobuf:"bytes,2,opt,name=imageId,proto3" json:"imageId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteProductImageRequest) Reset() {
+	*x = DeleteProductImageRequest{}
+	mi := &file_catalog_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteProductImageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteProductImageRequest) ProtoMessage() {}
+
+func (x *DeleteProductImageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteProductImageRequest.ProtoReflect.Descriptor instead.
+func (*DeleteProductImageRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *DeleteProductImageRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *DeleteProductImageRequest) GetImageId() string {
+	if x != nil {
+		return x.ImageId
+	}
+	return ""
+}
+
+type ReorderProductImagesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=productId,proto3" json:"productId,omitempty"`
+	ImageIds      []string               `protobuf:"bytes,2,rep,name=imageIds,proto3" json:"imageIds,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReorderProductImagesRequest) Reset() {
+	*x = ReorderProductImagesRequest{}
+	mi := &file_catalog_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReorderProductImagesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReorderProductImagesRequest) ProtoMessage() {}
+
+func (x *ReorderProductImagesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReorderProductImagesRequest.ProtoReflect.Descriptor instead.
+func (*ReorderProductImagesRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ReorderProductImagesRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *ReorderProductImagesRequest) GetImageIds() []string {
+	if x != nil {
+		return x.ImageIds
+	}
+	return nil
+}
+
+type PriceChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ProductId     string                 `protobuf:"bytes,2,opt,name=productId,proto3" json:"productId,omitempty"`
+	OldPrice      float64                `protobuf:"fixed64,3,opt,name=oldPrice,proto3" json:"oldPrice,omitempty"`
+	NewPrice      float64                `protobuf:"fixed64,4,opt,name=newPrice,proto3" json:"newPrice,omitempty"`
+	Actor         string                 `protobuf:"bytes,5,opt,name=actor,proto3" json:"actor,omitempty"`
+	Reason        string                 `protobuf:"bytes,6,opt,name=reason,proto3" json:"reason,omitempty"`
+	ScheduleId    string                 `protobuf:"bytes,7,opt,name=scheduleId,proto3" json:"scheduleId,omitempty"`
+	ChangedAt     []byte                 `protobuf:"bytes,8,opt,name=changedAt,proto3" json:"changedAt,omitempty"`
+	Currency      string                 `protobuf:"bytes,9,opt,name=currency,proto3" json:"currency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PriceChange) Reset() {
+	*x = PriceChange{}
+	mi := &file_catalog_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PriceChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PriceChange) ProtoMessage() {}
+
+func (x *PriceChange) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PriceChange.ProtoReflect.Descriptor instead.
+func (*PriceChange) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *PriceChange) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PriceChange) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *PriceChange) GetOldPrice() float64 {
+	if x != nil {
+		return x.OldPrice
+	}
+	return 0
+}
+
+func (x *PriceChange) GetNewPrice() float64 {
+	if x != nil {
+		return x.NewPrice
+	}
+	return 0
+}
+
+func (x *PriceChange) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *PriceChange) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *PriceChange) GetScheduleId() string {
+	if x != nil {
+		return x.ScheduleId
+	}
+	return ""
+}
+
+func (x *PriceChange) GetChangedAt() []byte {
+	if x != nil {
+		return x.ChangedAt
+	}
+	return nil
+}
+
+func (x *PriceChange) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+type PriceSchedule struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ProductId string                 `protobuf:"bytes,2,opt,name=productId,proto3" json:"productId,omitempty"`
+	Price     float64                `protobuf:"fixed64,3,opt,name=price,proto3" json:"price,omitempty"`
+	StartsAt  []byte                 `protobuf:"bytes,4,opt,name=startsAt,proto3" json:"startsAt,omitempty"`
+	// empty when the price is kept after it starts
+	EndsAt        []byte `protobuf:"bytes,5,opt,name=endsAt,proto3" json:"endsAt,omitempty"`
+	Status        string `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	Actor         string `protobuf:"bytes,7,opt,name=actor,proto3" json:"actor,omitempty"`
+	CreatedAt     []byte `protobuf:"bytes,8,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PriceSchedule) Reset() {
+	*x = PriceSchedule{}
+	mi := &file_catalog_proto_msgTypes[38]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteProductResponse) String() string {
+func (x *PriceSchedule) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteProductResponse) ProtoMessage() {}
+func (*PriceSchedule) ProtoMessage() {}
 
-func (x *DeleteProductResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_catalog_proto_msgTypes[8]
+func (x *PriceSchedule) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[38]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -487,58 +2520,91 @@ func (x *DeleteProductResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteProductResponse.ProtoReflect.Descriptor instead.
-func (*DeleteProductResponse) Descriptor() ([]byte, []int) {
-	return file_catalog_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use PriceSchedule.ProtoReflect.Descriptor instead.
+func (*PriceSchedule) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{38}
 }
 
-func (x *DeleteProductResponse) GetMessage() string {
+func (x *PriceSchedule) GetId() string {
 	if x != nil {
-		return x.Message
+		return x.Id
 	}
 	return ""
 }
 
-func (x *DeleteProductResponse) GetSuccess() bool {
+func (x *PriceSchedule) GetProductId() string {
 	if x != nil {
-		return x.Success
+		return x.ProductId
 	}
-	return false
+	return ""
 }
 
-func (x *DeleteProductResponse) GetDeletedID() string {
+func (x *PriceSchedule) GetPrice() float64 {
 	if x != nil {
-		return x.DeletedID
+		return x.Price
+	}
+	return 0
+}
+
+func (x *PriceSchedule) GetStartsAt() []byte {
+	if x != nil {
+		return x.StartsAt
+	}
+	return nil
+}
+
+func (x *PriceSchedule) GetEndsAt() []byte {
+	if x != nil {
+		return x.EndsAt
+	}
+	return nil
+}
+
+func (x *PriceSchedule) GetStatus() string {
+	if x != nil {
+		return x.Status
 	}
 	return ""
 }
 
-type EditProductRequest struct {
+func (x *PriceSchedule) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *PriceSchedule) GetCreatedAt() []byte {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type GetPriceHistoryRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	Price         float64                `protobuf:"fixed64,4,opt,name=price,proto3" json:"price,omitempty"`
-	Image         string                 `protobuf:"bytes,5,opt,name=image,proto3" json:"image,omitempty"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=productId,proto3" json:"productId,omitempty"`
+	Skip          uint64                 `protobuf:"varint,2,opt,name=skip,proto3" json:"skip,omitempty"`
+	Take          uint64                 `protobuf:"varint,3,opt,name=take,proto3" json:"take,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *EditProductRequest) Reset() {
-	*x = EditProductRequest{}
-	mi := &file_catalog_proto_msgTypes[9]
+func (x *GetPriceHistoryRequest) Reset() {
+	*x = GetPriceHistoryRequest{}
+	mi := &file_catalog_proto_msgTypes[39]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *EditProductRequest) String() string {
+func (x *GetPriceHistoryRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*EditProductRequest) ProtoMessage() {}
+func (*GetPriceHistoryRequest) ProtoMessage() {}
 
-func (x *EditProductRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_catalog_proto_msgTypes[9]
+func (x *GetPriceHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[39]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -549,94 +2615,481 @@ func (x *EditProductRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use EditProductRequest.ProtoReflect.Descriptor instead.
-func (*EditProductRequest) Descriptor() ([]byte, []int) {
-	return file_catalog_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use GetPriceHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetPriceHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{39}
 }
 
-func (x *EditProductRequest) GetId() string {
+func (x *GetPriceHistoryRequest) GetProductId() string {
 	if x != nil {
-		return x.Id
+		return x.ProductId
 	}
 	return ""
 }
 
-func (x *EditProductRequest) GetName() string {
+func (x *GetPriceHistoryRequest) GetSkip() uint64 {
 	if x != nil {
-		return x.Name
+		return x.Skip
 	}
-	return ""
+	return 0
 }
 
-func (x *EditProductRequest) GetDescription() string {
+func (x *GetPriceHistoryRequest) GetTake() uint64 {
 	if x != nil {
-		return x.Description
+		return x.Take
+	}
+	return 0
+}
+
+type GetPriceHistoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Changes       []*PriceChange         `protobuf:"bytes,1,rep,name=changes,proto3" json:"changes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPriceHistoryResponse) Reset() {
+	*x = GetPriceHistoryResponse{}
+	mi := &file_catalog_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPriceHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPriceHistoryResponse) ProtoMessage() {}
+
+func (x *GetPriceHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPriceHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetPriceHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *GetPriceHistoryResponse) GetChanges() []*PriceChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+type SchedulePriceChangeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=productId,proto3" json:"productId,omitempty"`
+	Price         float64                `protobuf:"fixed64,2,opt,name=price,proto3" json:"price,omitempty"`
+	StartsAt      []byte                 `protobuf:"bytes,3,opt,name=startsAt,proto3" json:"startsAt,omitempty"`
+	EndsAt        []byte                 `protobuf:"bytes,4,opt,name=endsAt,proto3" json:"endsAt,omitempty"`
+	Actor         string                 `protobuf:"bytes,5,opt,name=actor,proto3" json:"actor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SchedulePriceChangeRequest) Reset() {
+	*x = SchedulePriceChangeRequest{}
+	mi := &file_catalog_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SchedulePriceChangeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SchedulePriceChangeRequest) ProtoMessage() {}
+
+func (x *SchedulePriceChangeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SchedulePriceChangeRequest.ProtoReflect.Descriptor instead.
+func (*SchedulePriceChangeRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *SchedulePriceChangeRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
 	}
 	return ""
 }
 
-func (x *EditProductRequest) GetPrice() float64 {
+func (x *SchedulePriceChangeRequest) GetPrice() float64 {
 	if x != nil {
 		return x.Price
 	}
 	return 0
 }
 
-func (x *EditProductRequest) GetImage() string {
+func (x *SchedulePriceChangeRequest) GetStartsAt() []byte {
 	if x != nil {
-		return x.Image
+		return x.StartsAt
+	}
+	return nil
+}
+
+func (x *SchedulePriceChangeRequest) GetEndsAt() []byte {
+	if x != nil {
+		return x.EndsAt
+	}
+	return nil
+}
+
+func (x *SchedulePriceChangeRequest) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+type CancelPriceScheduleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Actor         string                 `protobuf:"bytes,2,opt,name=actor,proto3" json:"actor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelPriceScheduleRequest) Reset() {
+	*x = CancelPriceScheduleRequest{}
+	mi := &file_catalog_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelPriceScheduleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelPriceScheduleRequest) ProtoMessage() {}
+
+func (x *CancelPriceScheduleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelPriceScheduleRequest.ProtoReflect.Descriptor instead.
+func (*CancelPriceScheduleRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *CancelPriceScheduleRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CancelPriceScheduleRequest) GetActor() string {
+	if x != nil {
+		return x.Actor
 	}
 	return ""
 }
 
+type PriceScheduleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Schedule      *PriceSchedule         `protobuf:"bytes,1,opt,name=schedule,proto3" json:"schedule,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PriceScheduleResponse) Reset() {
+	*x = PriceScheduleResponse{}
+	mi := &file_catalog_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PriceScheduleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PriceScheduleResponse) ProtoMessage() {}
+
+func (x *PriceScheduleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PriceScheduleResponse.ProtoReflect.Descriptor instead.
+func (*PriceScheduleResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *PriceScheduleResponse) GetSchedule() *PriceSchedule {
+	if x != nil {
+		return x.Schedule
+	}
+	return nil
+}
+
 var File_catalog_proto protoreflect.FileDescriptor
 
 const file_catalog_proto_rawDesc = "" +
 	"\n" +
-	"\rcatalog.proto\x12\bgenproto\"{\n" +
+	"\rcatalog.proto\x12\bgenproto\x1a google/protobuf/field_mask.proto\"\xd4\x01\n" +
+	"\fProductImage\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\x12\"\n" +
+	"\fthumbnailUrl\x18\x03 \x01(\tR\fthumbnailUrl\x12 \n" +
+	"\vcontentType\x18\x04 \x01(\tR\vcontentType\x12\x12\n" +
+	"\x04size\x18\x05 \x01(\x03R\x04size\x12\x14\n" +
+	"\x05width\x18\x06 \x01(\x05R\x05width\x12\x16\n" +
+	"\x06height\x18\a \x01(\x05R\x06height\x12\x1a\n" +
+	"\bposition\x18\b \x01(\x05R\bposition\"\x8f\x03\n" +
 	"\aProduct\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
 	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x14\n" +
 	"\x05price\x18\x04 \x01(\x01R\x05price\x12\x14\n" +
-	"\x05image\x18\x05 \x01(\tR\x05image\"v\n" +
+	"\x05image\x18\x05 \x01(\tR\x05image\x12\x18\n" +
+	"\adeleted\x18\x06 \x01(\bR\adeleted\x12.\n" +
+	"\x06images\x18\a \x03(\v2\x16.genproto.ProductImageR\x06images\x12\x1a\n" +
+	"\bcurrency\x18\b \x01(\tR\bcurrency\x12\x18\n" +
+	"\aversion\x18\t \x01(\tR\aversion\x12\x16\n" +
+	"\x06status\x18\n" +
+	" \x01(\tR\x06status\x12\x1c\n" +
+	"\tpublishAt\x18\v \x01(\fR\tpublishAt\x12 \n" +
+	"\vunpublishAt\x18\f \x01(\fR\vunpublishAt\x12\x18\n" +
+	"\avisible\x18\r \x01(\bR\avisible\x12 \n" +
+	"\vweightGrams\x18\x0e \x01(\x03R\vweightGrams\"\xa2\x02\n" +
 	"\x12PostProductRequest\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
 	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x14\n" +
 	"\x05price\x18\x03 \x01(\x01R\x05price\x12\x14\n" +
-	"\x05image\x18\x04 \x01(\tR\x05image\"B\n" +
+	"\x05image\x18\x04 \x01(\tR\x05image\x12\x14\n" +
+	"\x05actor\x18\x05 \x01(\tR\x05actor\x12\x1a\n" +
+	"\bcurrency\x18\x06 \x01(\tR\bcurrency\x12\x16\n" +
+	"\x06status\x18\a \x01(\tR\x06status\x12\x1c\n" +
+	"\tpublishAt\x18\b \x01(\fR\tpublishAt\x12 \n" +
+	"\vunpublishAt\x18\t \x01(\fR\vunpublishAt\x12 \n" +
+	"\vweightGrams\x18\n" +
+	" \x01(\x03R\vweightGrams\"B\n" +
 	"\x13PostProductResponse\x12+\n" +
 	"\aproduct\x18\x01 \x01(\v2\x11.genproto.ProductR\aproduct\"#\n" +
 	"\x11GetProductRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"A\n" +
 	"\x12GetProductResponse\x12+\n" +
-	"\aproduct\x18\x01 \x01(\v2\x11.genproto.ProductR\aproduct\"d\n" +
+	"\aproduct\x18\x01 \x01(\v2\x11.genproto.ProductR\aproduct\"\x94\x01\n" +
 	"\x12GetProductsRequest\x12\x12\n" +
 	"\x04skip\x18\x01 \x01(\x04R\x04skip\x12\x12\n" +
 	"\x04take\x18\x02 \x01(\x04R\x04take\x12\x10\n" +
 	"\x03ids\x18\x03 \x03(\tR\x03ids\x12\x14\n" +
-	"\x05query\x18\x04 \x01(\tR\x05query\"&\n" +
+	"\x05query\x18\x04 \x01(\tR\x05query\x12.\n" +
+	"\x12includeUnpublished\x18\x05 \x01(\bR\x12includeUnpublished\"?\n" +
+	"\x19GetSimilarProductsRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04take\x18\x02 \x01(\x04R\x04take\"S\n" +
+	"\x0eSimilarProduct\x12+\n" +
+	"\aproduct\x18\x01 \x01(\v2\x11.genproto.ProductR\aproduct\x12\x14\n" +
+	"\x05score\x18\x02 \x01(\x01R\x05score\"R\n" +
+	"\x1aGetSimilarProductsResponse\x124\n" +
+	"\bproducts\x18\x01 \x03(\v2\x18.genproto.SimilarProductR\bproducts\"&\n" +
 	"\x14DeleteProductRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"D\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"`\n" +
 	"\x13GetProductsResponse\x12-\n" +
-	"\bproducts\x18\x01 \x03(\v2\x11.genproto.ProductR\bproducts\"i\n" +
+	"\bproducts\x18\x01 \x03(\v2\x11.genproto.ProductR\bproducts\x12\x1a\n" +
+	"\bsearchId\x18\x02 \x01(\tR\bsearchId\"T\n" +
+	"\x18RecordSearchClickRequest\x12\x1a\n" +
+	"\bsearchId\x18\x01 \x01(\tR\bsearchId\x12\x1c\n" +
+	"\tproductId\x18\x02 \x01(\tR\tproductId\"\x1b\n" +
+	"\x19RecordSearchClickResponse\"Q\n" +
+	"\x17GetSearchReportsRequest\x12\x12\n" +
+	"\x04from\x18\x01 \x01(\fR\x04from\x12\x0e\n" +
+	"\x02to\x18\x02 \x01(\fR\x02to\x12\x12\n" +
+	"\x04take\x18\x03 \x01(\x04R\x04take\"\x9c\x01\n" +
+	"\n" +
+	"QueryStats\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x1a\n" +
+	"\bsearches\x18\x02 \x01(\x03R\bsearches\x12 \n" +
+	"\vzeroResults\x18\x03 \x01(\x03R\vzeroResults\x12\x16\n" +
+	"\x06clicks\x18\x04 \x01(\x03R\x06clicks\x12\"\n" +
+	"\favgLatencyMs\x18\x05 \x01(\x01R\favgLatencyMs\"\xb6\x01\n" +
+	"\fSearchReport\x12\x10\n" +
+	"\x03day\x18\x01 \x01(\fR\x03day\x12\x1a\n" +
+	"\bsearches\x18\x02 \x01(\x03R\bsearches\x124\n" +
+	"\n" +
+	"topQueries\x18\x03 \x03(\v2\x14.genproto.QueryStatsR\n" +
+	"topQueries\x12B\n" +
+	"\x11zeroResultQueries\x18\x04 \x03(\v2\x14.genproto.QueryStatsR\x11zeroResultQueries\"L\n" +
+	"\x18GetSearchReportsResponse\x120\n" +
+	"\areports\x18\x01 \x03(\v2\x16.genproto.SearchReportR\areports\"\x92\x02\n" +
+	"\x0eSearchSettings\x12\x1c\n" +
+	"\tnameBoost\x18\x01 \x01(\x01R\tnameBoost\x12*\n" +
+	"\x10descriptionBoost\x18\x02 \x01(\x01R\x10descriptionBoost\x12 \n" +
+	"\vphraseBoost\x18\x03 \x01(\x01R\vphraseBoost\x12\"\n" +
+	"\finStockBoost\x18\x04 \x01(\x01R\finStockBoost\x12 \n" +
+	"\vratingBoost\x18\x05 \x01(\x01R\vratingBoost\x12\x1a\n" +
+	"\bsynonyms\x18\x06 \x03(\tR\bsynonyms\x12\x14\n" +
+	"\x05actor\x18\a \x01(\tR\x05actor\x12\x1c\n" +
+	"\tupdatedAt\x18\b \x01(\fR\tupdatedAt\"\x1a\n" +
+	"\x18GetSearchSettingsRequest\"i\n" +
+	"\x1bUpdateSearchSettingsRequest\x124\n" +
+	"\bsettings\x18\x01 \x01(\v2\x18.genproto.SearchSettingsR\bsettings\x12\x14\n" +
+	"\x05actor\x18\x02 \x01(\tR\x05actor\"N\n" +
+	"\x16SearchSettingsResponse\x124\n" +
+	"\bsettings\x18\x01 \x01(\v2\x18.genproto.SearchSettingsR\bsettings\"R\n" +
+	"\x18SetProductInStockRequest\x12\x1c\n" +
+	"\tproductId\x18\x01 \x01(\tR\tproductId\x12\x18\n" +
+	"\ainStock\x18\x02 \x01(\bR\ainStock\"O\n" +
+	"\x17AddProductRatingRequest\x12\x1c\n" +
+	"\tproductId\x18\x01 \x01(\tR\tproductId\x12\x16\n" +
+	"\x06rating\x18\x02 \x01(\x05R\x06rating\"\x16\n" +
+	"\x14SearchSignalResponse\"i\n" +
 	"\x15DeleteProductResponse\x12\x18\n" +
 	"\amessage\x18\x01 \x01(\tR\amessage\x12\x18\n" +
 	"\asuccess\x18\x02 \x01(\bR\asuccess\x12\x1c\n" +
-	"\tdeletedID\x18\x03 \x01(\tR\tdeletedID\"\x86\x01\n" +
+	"\tdeletedID\x18\x03 \x01(\tR\tdeletedID\"'\n" +
+	"\x15RestoreProductRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x88\x03\n" +
 	"\x12EditProductRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
 	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x14\n" +
 	"\x05price\x18\x04 \x01(\x01R\x05price\x12\x14\n" +
-	"\x05image\x18\x05 \x01(\tR\x05image2\x8f\x03\n" +
+	"\x05image\x18\x05 \x01(\tR\x05image\x12\x14\n" +
+	"\x05actor\x18\x06 \x01(\tR\x05actor\x12\x1a\n" +
+	"\bcurrency\x18\a \x01(\tR\bcurrency\x12:\n" +
+	"\n" +
+	"updateMask\x18\b \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\x12\x18\n" +
+	"\aversion\x18\t \x01(\tR\aversion\x12\x16\n" +
+	"\x06status\x18\n" +
+	" \x01(\tR\x06status\x12\x1c\n" +
+	"\tpublishAt\x18\v \x01(\fR\tpublishAt\x12 \n" +
+	"\vunpublishAt\x18\f \x01(\fR\vunpublishAt\x12 \n" +
+	"\vweightGrams\x18\r \x01(\x03R\vweightGrams\"p\n" +
+	"\x15ImportProductsRequest\x12/\n" +
+	"\x06format\x18\x01 \x01(\x0e2\x17.genproto.ProductFormatR\x06format\x12\x10\n" +
+	"\x03row\x18\x02 \x01(\tR\x03row\x12\x14\n" +
+	"\x05actor\x18\x03 \x01(\tR\x05actor\"I\n" +
+	"\vImportError\x12\x10\n" +
+	"\x03row\x18\x01 \x01(\x04R\x03row\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"\x91\x01\n" +
+	"\x16ImportProductsResponse\x12\x14\n" +
+	"\x05total\x18\x01 \x01(\x04R\x05total\x12\x1a\n" +
+	"\bimported\x18\x02 \x01(\x04R\bimported\x12\x16\n" +
+	"\x06failed\x18\x03 \x01(\x04R\x06failed\x12-\n" +
+	"\x06errors\x18\x04 \x03(\v2\x15.genproto.ImportErrorR\x06errors\"p\n" +
+	"\x15ExportProductsRequest\x12/\n" +
+	"\x06format\x18\x01 \x01(\x0e2\x17.genproto.ProductFormatR\x06format\x12&\n" +
+	"\x0eincludeDeleted\x18\x02 \x01(\bR\x0eincludeDeleted\"*\n" +
+	"\x16ExportProductsResponse\x12\x10\n" +
+	"\x03row\x18\x01 \x01(\tR\x03row\"]\n" +
+	"\x0fUploadImageInfo\x12\x1c\n" +
+	"\tproductId\x18\x01 \x01(\tR\tproductId\x12\x1f\n" +
+	"\bposition\x18\x02 \x01(\x05H\x00R\bposition\x88\x01\x01B\v\n" +
+	"\t_position\"l\n" +
+	"\x19UploadProductImageRequest\x12/\n" +
+	"\x04info\x18\x01 \x01(\v2\x19.genproto.UploadImageInfoH\x00R\x04info\x12\x16\n" +
+	"\x05chunk\x18\x02 \x01(\fH\x00R\x05chunkB\x06\n" +
+	"\x04data\"S\n" +
+	"\x19DeleteProductImageRequest\x12\x1c\n" +
+	"\tproductId\x18\x01 \x01(\tR\tproductId\x12\x18\n" +
+	"\aimageId\x18\x02 \x01(\tR\aimageId\"W\n" +
+	"\x1bReorderProductImagesRequest\x12\x1c\n" +
+	"\tproductId\x18\x01 \x01(\tR\tproductId\x12\x1a\n" +
+	"\bimageIds\x18\x02 \x03(\tR\bimageIds\"\xfb\x01\n" +
+	"\vPriceChange\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1c\n" +
+	"\tproductId\x18\x02 \x01(\tR\tproductId\x12\x1a\n" +
+	"\boldPrice\x18\x03 \x01(\x01R\boldPrice\x12\x1a\n" +
+	"\bnewPrice\x18\x04 \x01(\x01R\bnewPrice\x12\x14\n" +
+	"\x05actor\x18\x05 \x01(\tR\x05actor\x12\x16\n" +
+	"\x06reason\x18\x06 \x01(\tR\x06reason\x12\x1e\n" +
+	"\n" +
+	"scheduleId\x18\a \x01(\tR\n" +
+	"scheduleId\x12\x1c\n" +
+	"\tchangedAt\x18\b \x01(\fR\tchangedAt\x12\x1a\n" +
+	"\bcurrency\x18\t \x01(\tR\bcurrency\"\xd3\x01\n" +
+	"\rPriceSchedule\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1c\n" +
+	"\tproductId\x18\x02 \x01(\tR\tproductId\x12\x14\n" +
+	"\x05price\x18\x03 \x01(\x01R\x05price\x12\x1a\n" +
+	"\bstartsAt\x18\x04 \x01(\fR\bstartsAt\x12\x16\n" +
+	"\x06endsAt\x18\x05 \x01(\fR\x06endsAt\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x12\x14\n" +
+	"\x05actor\x18\a \x01(\tR\x05actor\x12\x1c\n" +
+	"\tcreatedAt\x18\b \x01(\fR\tcreatedAt\"^\n" +
+	"\x16GetPriceHistoryRequest\x12\x1c\n" +
+	"\tproductId\x18\x01 \x01(\tR\tproductId\x12\x12\n" +
+	"\x04skip\x18\x02 \x01(\x04R\x04skip\x12\x12\n" +
+	"\x04take\x18\x03 \x01(\x04R\x04take\"J\n" +
+	"\x17GetPriceHistoryResponse\x12/\n" +
+	"\achanges\x18\x01 \x03(\v2\x15.genproto.PriceChangeR\achanges\"\x9a\x01\n" +
+	"\x1aSchedulePriceChangeRequest\x12\x1c\n" +
+	"\tproductId\x18\x01 \x01(\tR\tproductId\x12\x14\n" +
+	"\x05price\x18\x02 \x01(\x01R\x05price\x12\x1a\n" +
+	"\bstartsAt\x18\x03 \x01(\fR\bstartsAt\x12\x16\n" +
+	"\x06endsAt\x18\x04 \x01(\fR\x06endsAt\x12\x14\n" +
+	"\x05actor\x18\x05 \x01(\tR\x05actor\"B\n" +
+	"\x1aCancelPriceScheduleRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05actor\x18\x02 \x01(\tR\x05actor\"L\n" +
+	"\x15PriceScheduleResponse\x123\n" +
+	"\bschedule\x18\x01 \x01(\v2\x17.genproto.PriceScheduleR\bschedule*$\n" +
+	"\rProductFormat\x12\a\n" +
+	"\x03CSV\x10\x00\x12\n" +
+	"\n" +
+	"\x06NDJSON\x10\x012\xbd\x0e\n" +
 	"\x0eCatalogService\x12J\n" +
 	"\vPostProduct\x12\x1c.genproto.PostProductRequest\x1a\x1d.genproto.PostProductResponse\x12G\n" +
 	"\n" +
 	"GetProduct\x12\x1b.genproto.GetProductRequest\x1a\x1c.genproto.GetProductResponse\x12J\n" +
-	"\vGetProducts\x12\x1c.genproto.GetProductsRequest\x1a\x1d.genproto.GetProductsResponse\x12J\n" +
+	"\vGetProducts\x12\x1c.genproto.GetProductsRequest\x1a\x1d.genproto.GetProductsResponse\x12_\n" +
+	"\x12GetSimilarProducts\x12#.genproto.GetSimilarProductsRequest\x1a$.genproto.GetSimilarProductsResponse\x12\\\n" +
+	"\x11RecordSearchClick\x12\".genproto.RecordSearchClickRequest\x1a#.genproto.RecordSearchClickResponse\x12Y\n" +
+	"\x10GetSearchReports\x12!.genproto.GetSearchReportsRequest\x1a\".genproto.GetSearchReportsResponse\x12Y\n" +
+	"\x11GetSearchSettings\x12\".genproto.GetSearchSettingsRequest\x1a .genproto.SearchSettingsResponse\x12_\n" +
+	"\x14UpdateSearchSettings\x12%.genproto.UpdateSearchSettingsRequest\x1a .genproto.SearchSettingsResponse\x12W\n" +
+	"\x11SetProductInStock\x12\".genproto.SetProductInStockRequest\x1a\x1e.genproto.SearchSignalResponse\x12U\n" +
+	"\x10AddProductRating\x12!.genproto.AddProductRatingRequest\x1a\x1e.genproto.SearchSignalResponse\x12J\n" +
 	"\vEditProduct\x12\x1c.genproto.EditProductRequest\x1a\x1d.genproto.PostProductResponse\x12P\n" +
-	"\rDeleteProduct\x12\x1e.genproto.DeleteProductRequest\x1a\x1f.genproto.DeleteProductResponseB+Z)github.com/wignn/micro-3/catalog/genprotob\x06proto3"
+	"\rDeleteProduct\x12\x1e.genproto.DeleteProductRequest\x1a\x1f.genproto.DeleteProductResponse\x12P\n" +
+	"\x0eRestoreProduct\x12\x1f.genproto.RestoreProductRequest\x1a\x1d.genproto.PostProductResponse\x12U\n" +
+	"\x0eImportProducts\x12\x1f.genproto.ImportProductsRequest\x1a .genproto.ImportProductsResponse(\x01\x12U\n" +
+	"\x0eExportProducts\x12\x1f.genproto.ExportProductsRequest\x1a .genproto.ExportProductsResponse0\x01\x12Z\n" +
+	"\x12UploadProductImage\x12#.genproto.UploadProductImageRequest\x1a\x1d.genproto.PostProductResponse(\x01\x12X\n" +
+	"\x12DeleteProductImage\x12#.genproto.DeleteProductImageRequest\x1a\x1d.genproto.PostProductResponse\x12\\\n" +
+	"\x14ReorderProductImages\x12%.genproto.ReorderProductImagesRequest\x1a\x1d.genproto.PostProductResponse\x12V\n" +
+	"\x0fGetPriceHistory\x12 .genproto.GetPriceHistoryRequest\x1a!.genproto.GetPriceHistoryResponse\x12\\\n" +
+	"\x13SchedulePriceChange\x12$.genproto.SchedulePriceChangeRequest\x1a\x1f.genproto.PriceScheduleResponse\x12\\\n" +
+	"\x13CancelPriceSchedule\x12$.genproto.CancelPriceScheduleRequest\x1a\x1f.genproto.PriceScheduleResponseB+Z)github.com/wignn/micro-3/catalog/genprotob\x06proto3"
 
 var (
 	file_catalog_proto_rawDescOnce sync.Once
@@ -650,38 +3103,122 @@ func file_catalog_proto_rawDescGZIP() []byte {
 	return file_catalog_proto_rawDescData
 }
 
-var file_catalog_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_catalog_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_catalog_proto_msgTypes = make([]protoimpl.MessageInfo, 44)
 var file_catalog_proto_goTypes = []any{
-	(*Product)(nil),               // 0: genproto.Product
-	(*PostProductRequest)(nil),    // 1: genproto.PostProductRequest
-	(*PostProductResponse)(nil),   // 2: genproto.PostProductResponse
-	(*GetProductRequest)(nil),     // 3: genproto.GetProductRequest
-	(*GetProductResponse)(nil),    // 4: genproto.GetProductResponse
-	(*GetProductsRequest)(nil),    // 5: genproto.GetProductsRequest
-	(*DeleteProductRequest)(nil),  // 6: genproto.DeleteProductRequest
-	(*GetProductsResponse)(nil),   // 7: genproto.GetProductsResponse
-	(*DeleteProductResponse)(nil), // 8: genproto.DeleteProductResponse
-	(*EditProductRequest)(nil),    // 9: genproto.EditProductRequest
+	(ProductFormat)(0),                  // 0: genproto.ProductFormat
+	(*ProductImage)(nil),                // 1: genproto.ProductImage
+	(*Product)(nil),                     // 2: genproto.Product
+	(*PostProductRequest)(nil),          // 3: genproto.PostProductRequest
+	(*PostProductResponse)(nil),         // 4: genproto.PostProductResponse
+	(*GetProductRequest)(nil),           // 5: genproto.GetProductRequest
+	(*GetProductResponse)(nil),          // 6: genproto.GetProductResponse
+	(*GetProductsRequest)(nil),          // 7: genproto.GetProductsRequest
+	(*GetSimilarProductsRequest)(nil),   // 8: genproto.GetSimilarProductsRequest
+	(*SimilarProduct)(nil),              // 9: genproto.SimilarProduct
+	(*GetSimilarProductsResponse)(nil),  // 10: genproto.GetSimilarProductsResponse
+	(*DeleteProductRequest)(nil),        // 11: genproto.DeleteProductRequest
+	(*GetProductsResponse)(nil),         // 12: genproto.GetProductsResponse
+	(*RecordSearchClickRequest)(nil),    // 13: genproto.RecordSearchClickRequest
+	(*RecordSearchClickResponse)(nil),   // 14: genproto.RecordSearchClickResponse
+	(*GetSearchReportsRequest)(nil),     // 15: genproto.GetSearchReportsRequest
+	(*QueryStats)(nil),                  // 16: genproto.QueryStats
+	(*SearchReport)(nil),                // 17: genproto.SearchReport
+	(*GetSearchReportsResponse)(nil),    // 18: genproto.GetSearchReportsResponse
+	(*SearchSettings)(nil),              // 19: genproto.SearchSettings
+	(*GetSearchSettingsRequest)(nil),    // 20: genproto.GetSearchSettingsRequest
+	(*UpdateSearchSettingsRequest)(nil), // 21: genproto.UpdateSearchSettingsRequest
+	(*SearchSettingsResponse)(nil),      // 22: genproto.SearchSettingsResponse
+	(*SetProductInStockRequest)(nil),    // 23: genproto.SetProductInStockRequest
+	(*AddProductRatingRequest)(nil),     // 24: genproto.AddProductRatingRequest
+	(*SearchSignalResponse)(nil),        // 25: genproto.SearchSignalResponse
+	(*DeleteProductResponse)(nil),       // 26: genproto.DeleteProductResponse
+	(*RestoreProductRequest)(nil),       // 27: genproto.RestoreProductRequest
+	(*EditProductRequest)(nil),          // 28: genproto.EditProductRequest
+	(*ImportProductsRequest)(nil),       // 29: genproto.ImportProductsRequest
+	(*ImportError)(nil),                 // 30: genproto.ImportError
+	(*ImportProductsResponse)(nil),      // 31: genproto.ImportProductsResponse
+	(*ExportProductsRequest)(nil),       // 32: genproto.ExportProductsRequest
+	(*ExportProductsResponse)(nil),      // 33: genproto.ExportProductsResponse
+	(*UploadImageInfo)(nil),             // 34: genproto.UploadImageInfo
+	(*UploadProductImageRequest)(nil),   // 35: genproto.UploadProductImageRequest
+	(*DeleteProductImageRequest)(nil),   // 36: genproto.DeleteProductImageRequest
+	(*ReorderProductImagesRequest)(nil), // 37: genproto.ReorderProductImagesRequest
+	(*PriceChange)(nil),                 // 38: genproto.PriceChange
+	(*PriceSchedule)(nil),               // 39: genproto.PriceSchedule
+	(*GetPriceHistoryRequest)(nil),      // 40: genproto.GetPriceHistoryRequest
+	(*GetPriceHistoryResponse)(nil),     // 41: genproto.GetPriceHistoryResponse
+	(*SchedulePriceChangeRequest)(nil),  // 42: genproto.SchedulePriceChangeRequest
+	(*CancelPriceScheduleRequest)(nil),  // 43: genproto.CancelPriceScheduleRequest
+	(*PriceScheduleResponse)(nil),       // 44: genproto.PriceScheduleResponse
+	(*fieldmaskpb.FieldMask)(nil),       // 45: google.protobuf.FieldMask
 }
 var file_catalog_proto_depIdxs = []int32{
-	0, // 0: genproto.PostProductResponse.product:type_name -> genproto.Product
-	0, // 1: genproto.GetProductResponse.product:type_name -> genproto.Product
-	0, // 2: genproto.GetProductsResponse.products:type_name -> genproto.Product
-	1, // 3: genproto.CatalogService.PostProduct:input_type -> genproto.PostProductRequest
-	3, // 4: genproto.CatalogService.GetProduct:input_type -> genproto.GetProductRequest
-	5, // 5: genproto.CatalogService.GetProducts:input_type -> genproto.GetProductsRequest
-	9, // 6: genproto.CatalogService.EditProduct:input_type -> genproto.EditProductRequest
-	6, // 7: genproto.CatalogService.DeleteProduct:input_type -> genproto.DeleteProductRequest
-	2, // 8: genproto.CatalogService.PostProduct:output_type -> genproto.PostProductResponse
-	4, // 9: genproto.CatalogService.GetProduct:output_type -> genproto.GetProductResponse
-	7, // 10: genproto.CatalogService.GetProducts:output_type -> genproto.GetProductsResponse
-	2, // 11: genproto.CatalogService.EditProduct:output_type -> genproto.PostProductResponse
-	8, // 12: genproto.CatalogService.DeleteProduct:output_type -> genproto.DeleteProductResponse
-	8, // [8:13] is the sub-list for method output_type
-	3, // [3:8] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	1,  // 0: genproto.Product.images:type_name -> genproto.ProductImage
+	2,  // 1: genproto.PostProductResponse.product:type_name -> genproto.Product
+	2,  // 2: genproto.GetProductResponse.product:type_name -> genproto.Product
+	2,  // 3: genproto.SimilarProduct.product:type_name -> genproto.Product
+	9,  // 4: genproto.GetSimilarProductsResponse.products:type_name -> genproto.SimilarProduct
+	2,  // 5: genproto.GetProductsResponse.products:type_name -> genproto.Product
+	16, // 6: genproto.SearchReport.topQueries:type_name -> genproto.QueryStats
+	16, // 7: genproto.SearchReport.zeroResultQueries:type_name -> genproto.QueryStats
+	17, // 8: genproto.GetSearchReportsResponse.reports:type_name -> genproto.SearchReport
+	19, // 9: genproto.UpdateSearchSettingsRequest.settings:type_name -> genproto.SearchSettings
+	19, // 10: genproto.SearchSettingsResponse.settings:type_name -> genproto.SearchSettings
+	45, // 11: genproto.EditProductRequest.updateMask:type_name -> google.protobuf.FieldMask
+	0,  // 12: genproto.ImportProductsRequest.format:type_name -> genproto.ProductFormat
+	30, // 13: genproto.ImportProductsResponse.errors:type_name -> genproto.ImportError
+	0,  // 14: genproto.ExportProductsRequest.format:type_name -> genproto.ProductFormat
+	34, // 15: genproto.UploadProductImageRequest.info:type_name -> genproto.UploadImageInfo
+	38, // 16: genproto.GetPriceHistoryResponse.changes:type_name -> genproto.PriceChange
+	39, // 17: genproto.PriceScheduleResponse.schedule:type_name -> genproto.PriceSchedule
+	3,  // 18: genproto.CatalogService.PostProduct:input_type -> genproto.PostProductRequest
+	5,  // 19: genproto.CatalogService.GetProduct:input_type -> genproto.GetProductRequest
+	7,  // 20: genproto.CatalogService.GetProducts:input_type -> genproto.GetProductsRequest
+	8,  // 21: genproto.CatalogService.GetSimilarProducts:input_type -> genproto.GetSimilarProductsRequest
+	13, // 22: genproto.CatalogService.RecordSearchClick:input_type -> genproto.RecordSearchClickRequest
+	15, // 23: genproto.CatalogService.GetSearchReports:input_type -> genproto.GetSearchReportsRequest
+	20, // 24: genproto.CatalogService.GetSearchSettings:input_type -> genproto.GetSearchSettingsRequest
+	21, // 25: genproto.CatalogService.UpdateSearchSettings:input_type -> genproto.UpdateSearchSettingsRequest
+	23, // 26: genproto.CatalogService.SetProductInStock:input_type -> genproto.SetProductInStockRequest
+	24, // 27: genproto.CatalogService.AddProductRating:input_type -> genproto.AddProductRatingRequest
+	28, // 28: genproto.CatalogService.EditProduct:input_type -> genproto.EditProductRequest
+	11, // 29: genproto.CatalogService.DeleteProduct:input_type -> genproto.DeleteProductRequest
+	27, // 30: genproto.CatalogService.RestoreProduct:input_type -> genproto.RestoreProductRequest
+	29, // 31: genproto.CatalogService.ImportProducts:input_type -> genproto.ImportProductsRequest
+	32, // 32: genproto.CatalogService.ExportProducts:input_type -> genproto.ExportProductsRequest
+	35, // 33: genproto.CatalogService.UploadProductImage:input_type -> genproto.UploadProductImageRequest
+	36, // 34: genproto.CatalogService.DeleteProductImage:input_type -> genproto.DeleteProductImageRequest
+	37, // 35: genproto.CatalogService.ReorderProductImages:input_type -> genproto.ReorderProductImagesRequest
+	40, // 36: genproto.CatalogService.GetPriceHistory:input_type -> genproto.GetPriceHistoryRequest
+	42, // 37: genproto.CatalogService.SchedulePriceChange:input_type -> genproto.SchedulePriceChangeRequest
+	43, // 38: genproto.CatalogService.CancelPriceSchedule:input_type -> genproto.CancelPriceScheduleRequest
+	4,  // 39: genproto.CatalogService.PostProduct:output_type -> genproto.PostProductResponse
+	6,  // 40: genproto.CatalogService.GetProduct:output_type -> genproto.GetProductResponse
+	12, // 41: genproto.CatalogService.GetProducts:output_type -> genproto.GetProductsResponse
+	10, // 42: genproto.CatalogService.GetSimilarProducts:output_type -> genproto.GetSimilarProductsResponse
+	14, // 43: genproto.CatalogService.RecordSearchClick:output_type -> genproto.RecordSearchClickResponse
+	18, // 44: genproto.CatalogService.GetSearchReports:output_type -> genproto.GetSearchReportsResponse
+	22, // 45: genproto.CatalogService.GetSearchSettings:output_type -> genproto.SearchSettingsResponse
+	22, // 46: genproto.CatalogService.UpdateSearchSettings:output_type -> genproto.SearchSettingsResponse
+	25, // 47: genproto.CatalogService.SetProductInStock:output_type -> genproto.SearchSignalResponse
+	25, // 48: genproto.CatalogService.AddProductRating:output_type -> genproto.SearchSignalResponse
+	4,  // 49: genproto.CatalogService.EditProduct:output_type -> genproto.PostProductResponse
+	26, // 50: genproto.CatalogService.DeleteProduct:output_type -> genproto.DeleteProductResponse
+	4,  // 51: genproto.CatalogService.RestoreProduct:output_type -> genproto.PostProductResponse
+	31, // 52: genproto.CatalogService.ImportProducts:output_type -> genproto.ImportProductsResponse
+	33, // 53: genproto.CatalogService.ExportProducts:output_type -> genproto.ExportProductsResponse
+	4,  // 54: genproto.CatalogService.UploadProductImage:output_type -> genproto.PostProductResponse
+	4,  // 55: genproto.CatalogService.DeleteProductImage:output_type -> genproto.PostProductResponse
+	4,  // 56: genproto.CatalogService.ReorderProductImages:output_type -> genproto.PostProductResponse
+	41, // 57: genproto.CatalogService.GetPriceHistory:output_type -> genproto.GetPriceHistoryResponse
+	44, // 58: genproto.CatalogService.SchedulePriceChange:output_type -> genproto.PriceScheduleResponse
+	44, // 59: genproto.CatalogService.CancelPriceSchedule:output_type -> genproto.PriceScheduleResponse
+	39, // [39:60] is the sub-list for method output_type
+	18, // [18:39] is the sub-list for method input_type
+	18, // [18:18] is the sub-list for extension type_name
+	18, // [18:18] is the sub-list for extension extendee
+	0,  // [0:18] is the sub-list for field type_name
 }
 
 func init() { file_catalog_proto_init() }
@@ -689,18 +3226,24 @@ func file_catalog_proto_init() {
 	if File_catalog_proto != nil {
 		return
 	}
+	file_catalog_proto_msgTypes[33].OneofWrappers = []any{}
+	file_catalog_proto_msgTypes[34].OneofWrappers = []any{
+		(*UploadProductImageRequest_Info)(nil),
+		(*UploadProductImageRequest_Chunk)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_catalog_proto_rawDesc), len(file_catalog_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   10,
+			NumEnums:      1,
+			NumMessages:   44,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_catalog_proto_goTypes,
 		DependencyIndexes: file_catalog_proto_depIdxs,
+		EnumInfos:         file_catalog_proto_enumTypes,
 		MessageInfos:      file_catalog_proto_msgTypes,
 	}.Build()
 	File_catalog_proto = out.File
@@ -19,11 +19,27 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	CatalogService_PostProduct_FullMethodName   = "/genproto.CatalogService/PostProduct"
-	CatalogService_GetProduct_FullMethodName    = "/genproto.CatalogService/GetProduct"
-	CatalogService_GetProducts_FullMethodName   = "/genproto.CatalogService/GetProducts"
-	CatalogService_EditProduct_FullMethodName   = "/genproto.CatalogService/EditProduct"
-	CatalogService_DeleteProduct_FullMethodName = "/genproto.CatalogService/DeleteProduct"
+	CatalogService_PostProduct_FullMethodName          = "/genproto.CatalogService/PostProduct"
+	CatalogService_GetProduct_FullMethodName           = "/genproto.CatalogService/GetProduct"
+	CatalogService_GetProducts_FullMethodName          = "/genproto.CatalogService/GetProducts"
+	CatalogService_GetSimilarProducts_FullMethodName   = "/genproto.CatalogService/GetSimilarProducts"
+	CatalogService_RecordSearchClick_FullMethodName    = "/genproto.CatalogService/RecordSearchClick"
+	CatalogService_GetSearchReports_FullMethodName     = "/genproto.CatalogService/GetSearchReports"
+	CatalogService_GetSearchSettings_FullMethodName    = "/genproto.CatalogService/GetSearchSettings"
+	CatalogService_UpdateSearchSettings_FullMethodName = "/genproto.CatalogService/UpdateSearchSettings"
+	CatalogService_SetProductInStock_FullMethodName    = "/genproto.CatalogService/SetProductInStock"
+	CatalogService_AddProductRating_FullMethodName     = "/genproto.CatalogService/AddProductRating"
+	CatalogService_EditProduct_FullMethodName          = "/genproto.CatalogService/EditProduct"
+	CatalogService_DeleteProduct_FullMethodName        = "/genproto.CatalogService/DeleteProduct"
+	CatalogService_RestoreProduct_FullMethodName       = "/genproto.CatalogService/RestoreProduct"
+	CatalogService_ImportProducts_FullMethodName       = "/genproto.CatalogService/ImportProducts"
+	CatalogService_ExportProducts_FullMethodName       = "/genproto.CatalogService/ExportProducts"
+	CatalogService_UploadProductImage_FullMethodName   = "/genproto.CatalogService/UploadProductImage"
+	CatalogService_DeleteProductImage_FullMethodName   = "/genproto.CatalogService/DeleteProductImage"
+	CatalogService_ReorderProductImages_FullMethodName = "/genproto.CatalogService/ReorderProductImages"
+	CatalogService_GetPriceHistory_FullMethodName      = "/genproto.CatalogService/GetPriceHistory"
+	CatalogService_SchedulePriceChange_FullMethodName  = "/genproto.CatalogService/SchedulePriceChange"
+	CatalogService_CancelPriceSchedule_FullMethodName  = "/genproto.CatalogService/CancelPriceSchedule"
 )
 
 // CatalogServiceClient is the client API for CatalogService service.
@@ -33,8 +49,24 @@ type CatalogServiceClient interface {
 	PostProduct(ctx context.Context, in *PostProductRequest, opts ...grpc.CallOption) (*PostProductResponse, error)
 	GetProduct(ctx context.Context, in *GetProductRequest, opts ...grpc.CallOption) (*GetProductResponse, error)
 	GetProducts(ctx context.Context, in *GetProductsRequest, opts ...grpc.CallOption) (*GetProductsResponse, error)
+	GetSimilarProducts(ctx context.Context, in *GetSimilarProductsRequest, opts ...grpc.CallOption) (*GetSimilarProductsResponse, error)
+	RecordSearchClick(ctx context.Context, in *RecordSearchClickRequest, opts ...grpc.CallOption) (*RecordSearchClickResponse, error)
+	GetSearchReports(ctx context.Context, in *GetSearchReportsRequest, opts ...grpc.CallOption) (*GetSearchReportsResponse, error)
+	GetSearchSettings(ctx context.Context, in *GetSearchSettingsRequest, opts ...grpc.CallOption) (*SearchSettingsResponse, error)
+	UpdateSearchSettings(ctx context.Context, in *UpdateSearchSettingsRequest, opts ...grpc.CallOption) (*SearchSettingsResponse, error)
+	SetProductInStock(ctx context.Context, in *SetProductInStockRequest, opts ...grpc.CallOption) (*SearchSignalResponse, error)
+	AddProductRating(ctx context.Context, in *AddProductRatingRequest, opts ...grpc.CallOption) (*SearchSignalResponse, error)
 	EditProduct(ctx context.Context, in *EditProductRequest, opts ...grpc.CallOption) (*PostProductResponse, error)
 	DeleteProduct(ctx context.Context, in *DeleteProductRequest, opts ...grpc.CallOption) (*DeleteProductResponse, error)
+	RestoreProduct(ctx context.Context, in *RestoreProductRequest, opts ...grpc.CallOption) (*PostProductResponse, error)
+	ImportProducts(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportProductsRequest, ImportProductsResponse], error)
+	ExportProducts(ctx context.Context, in *ExportProductsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportProductsResponse], error)
+	UploadProductImage(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[UploadProductImageRequest, PostProductResponse], error)
+	DeleteProductImage(ctx context.Context, in *DeleteProductImageRequest, opts ...grpc.CallOption) (*PostProductResponse, error)
+	ReorderProductImages(ctx context.Context, in *ReorderProductImagesRequest, opts ...grpc.CallOption) (*PostProductResponse, error)
+	GetPriceHistory(ctx context.Context, in *GetPriceHistoryRequest, opts ...grpc.CallOption) (*GetPriceHistoryResponse, error)
+	SchedulePriceChange(ctx context.Context, in *SchedulePriceChangeRequest, opts ...grpc.CallOption) (*PriceScheduleResponse, error)
+	CancelPriceSchedule(ctx context.Context, in *CancelPriceScheduleRequest, opts ...grpc.CallOption) (*PriceScheduleResponse, error)
 }
 
 type catalogServiceClient struct {
@@ -75,6 +107,76 @@ func (c *catalogServiceClient) GetProducts(ctx context.Context, in *GetProductsR
 	return out, nil
 }
 
+func (c *catalogServiceClient) GetSimilarProducts(ctx context.Context, in *GetSimilarProductsRequest, opts ...grpc.CallOption) (*GetSimilarProductsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetSimilarProductsResponse)
+	err := c.cc.Invoke(ctx, CatalogService_GetSimilarProducts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) RecordSearchClick(ctx context.Context, in *RecordSearchClickRequest, opts ...grpc.CallOption) (*RecordSearchClickResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RecordSearchClickResponse)
+	err := c.cc.Invoke(ctx, CatalogService_RecordSearchClick_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) GetSearchReports(ctx context.Context, in *GetSearchReportsRequest, opts ...grpc.CallOption) (*GetSearchReportsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetSearchReportsResponse)
+	err := c.cc.Invoke(ctx, CatalogService_GetSearchReports_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) GetSearchSettings(ctx context.Context, in *GetSearchSettingsRequest, opts ...grpc.CallOption) (*SearchSettingsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchSettingsResponse)
+	err := c.cc.Invoke(ctx, CatalogService_GetSearchSettings_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) UpdateSearchSettings(ctx context.Context, in *UpdateSearchSettingsRequest, opts ...grpc.CallOption) (*SearchSettingsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchSettingsResponse)
+	err := c.cc.Invoke(ctx, CatalogService_UpdateSearchSettings_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) SetProductInStock(ctx context.Context, in *SetProductInStockRequest, opts ...grpc.CallOption) (*SearchSignalResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchSignalResponse)
+	err := c.cc.Invoke(ctx, CatalogService_SetProductInStock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) AddProductRating(ctx context.Context, in *AddProductRatingRequest, opts ...grpc.CallOption) (*SearchSignalResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchSignalResponse)
+	err := c.cc.Invoke(ctx, CatalogService_AddProductRating_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *catalogServiceClient) EditProduct(ctx context.Context, in *EditProductRequest, opts ...grpc.CallOption) (*PostProductResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(PostProductResponse)
@@ -95,6 +197,111 @@ func (c *catalogServiceClient) DeleteProduct(ctx context.Context, in *DeleteProd
 	return out, nil
 }
 
+func (c *catalogServiceClient) RestoreProduct(ctx context.Context, in *RestoreProductRequest, opts ...grpc.CallOption) (*PostProductResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PostProductResponse)
+	err := c.cc.Invoke(ctx, CatalogService_RestoreProduct_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) ImportProducts(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportProductsRequest, ImportProductsResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &CatalogService_ServiceDesc.Streams[0], CatalogService_ImportProducts_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ImportProductsRequest, ImportProductsResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CatalogService_ImportProductsClient = grpc.ClientStreamingClient[ImportProductsRequest, ImportProductsResponse]
+
+func (c *catalogServiceClient) ExportProducts(ctx context.Context, in *ExportProductsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportProductsResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &CatalogService_ServiceDesc.Streams[1], CatalogService_ExportProducts_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportProductsRequest, ExportProductsResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CatalogService_ExportProductsClient = grpc.ServerStreamingClient[ExportProductsResponse]
+
+func (c *catalogServiceClient) UploadProductImage(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[UploadProductImageRequest, PostProductResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &CatalogService_ServiceDesc.Streams[2], CatalogService_UploadProductImage_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[UploadProductImageRequest, PostProductResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CatalogService_UploadProductImageClient = grpc.ClientStreamingClient[UploadProductImageRequest, PostProductResponse]
+
+func (c *catalogServiceClient) DeleteProductImage(ctx context.Context, in *DeleteProductImageRequest, opts ...grpc.CallOption) (*PostProductResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PostProductResponse)
+	err := c.cc.Invoke(ctx, CatalogService_DeleteProductImage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) ReorderProductImages(ctx context.Context, in *ReorderProductImagesRequest, opts ...grpc.CallOption) (*PostProductResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PostProductResponse)
+	err := c.cc.Invoke(ctx, CatalogService_ReorderProductImages_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) GetPriceHistory(ctx context.Context, in *GetPriceHistoryRequest, opts ...grpc.CallOption) (*GetPriceHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPriceHistoryResponse)
+	err := c.cc.Invoke(ctx, CatalogService_GetPriceHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) SchedulePriceChange(ctx context.Context, in *SchedulePriceChangeRequest, opts ...grpc.CallOption) (*PriceScheduleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PriceScheduleResponse)
+	err := c.cc.Invoke(ctx, CatalogService_SchedulePriceChange_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) CancelPriceSchedule(ctx context.Context, in *CancelPriceScheduleRequest, opts ...grpc.CallOption) (*PriceScheduleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PriceScheduleResponse)
+	err := c.cc.Invoke(ctx, CatalogService_CancelPriceSchedule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CatalogServiceServer is the server API for CatalogService service.
 // All implementations must embed UnimplementedCatalogServiceServer
 // for forward compatibility.
@@ -102,8 +309,24 @@ type CatalogServiceServer interface {
 	PostProduct(context.Context, *PostProductRequest) (*PostProductResponse, error)
 	GetProduct(context.Context, *GetProductRequest) (*GetProductResponse, error)
 	GetProducts(context.Context, *GetProductsRequest) (*GetProductsResponse, error)
+	GetSimilarProducts(context.Context, *GetSimilarProductsRequest) (*GetSimilarProductsResponse, error)
+	RecordSearchClick(context.Context, *RecordSearchClickRequest) (*RecordSearchClickResponse, error)
+	GetSearchReports(context.Context, *GetSearchReportsRequest) (*GetSearchReportsResponse, error)
+	GetSearchSettings(context.Context, *GetSearchSettingsRequest) (*SearchSettingsResponse, error)
+	UpdateSearchSettings(context.Context, *UpdateSearchSettingsRequest) (*SearchSettingsResponse, error)
+	SetProductInStock(context.Context, *SetProductInStockRequest) (*SearchSignalResponse, error)
+	AddProductRating(context.Context, *AddProductRatingRequest) (*SearchSignalResponse, error)
 	EditProduct(context.Context, *EditProductRequest) (*PostProductResponse, error)
 	DeleteProduct(context.Context, *DeleteProductRequest) (*DeleteProductResponse, error)
+	RestoreProduct(context.Context, *RestoreProductRequest) (*PostProductResponse, error)
+	ImportProducts(grpc.ClientStreamingServer[ImportProductsRequest, ImportProductsResponse]) error
+	ExportProducts(*ExportProductsRequest, grpc.ServerStreamingServer[ExportProductsResponse]) error
+	UploadProductImage(grpc.ClientStreamingServer[UploadProductImageRequest, PostProductResponse]) error
+	DeleteProductImage(context.Context, *DeleteProductImageRequest) (*PostProductResponse, error)
+	ReorderProductImages(context.Context, *ReorderProductImagesRequest) (*PostProductResponse, error)
+	GetPriceHistory(context.Context, *GetPriceHistoryRequest) (*GetPriceHistoryResponse, error)
+	SchedulePriceChange(context.Context, *SchedulePriceChangeRequest) (*PriceScheduleResponse, error)
+	CancelPriceSchedule(context.Context, *CancelPriceScheduleRequest) (*PriceScheduleResponse, error)
 	mustEmbedUnimplementedCatalogServiceServer()
 }
 
@@ -123,12 +346,60 @@ func (UnimplementedCatalogServiceServer) GetProduct(context.Context, *GetProduct
 func (UnimplementedCatalogServiceServer) GetProducts(context.Context, *GetProductsRequest) (*GetProductsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetProducts not implemented")
 }
+func (UnimplementedCatalogServiceServer) GetSimilarProducts(context.Context, *GetSimilarProductsRequest) (*GetSimilarProductsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSimilarProducts not implemented")
+}
+func (UnimplementedCatalogServiceServer) RecordSearchClick(context.Context, *RecordSearchClickRequest) (*RecordSearchClickResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecordSearchClick not implemented")
+}
+func (UnimplementedCatalogServiceServer) GetSearchReports(context.Context, *GetSearchReportsRequest) (*GetSearchReportsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSearchReports not implemented")
+}
+func (UnimplementedCatalogServiceServer) GetSearchSettings(context.Context, *GetSearchSettingsRequest) (*SearchSettingsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSearchSettings not implemented")
+}
+func (UnimplementedCatalogServiceServer) UpdateSearchSettings(context.Context, *UpdateSearchSettingsRequest) (*SearchSettingsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateSearchSettings not implemented")
+}
+func (UnimplementedCatalogServiceServer) SetProductInStock(context.Context, *SetProductInStockRequest) (*SearchSignalResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetProductInStock not implemented")
+}
+func (UnimplementedCatalogServiceServer) AddProductRating(context.Context, *AddProductRatingRequest) (*SearchSignalResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddProductRating not implemented")
+}
 func (UnimplementedCatalogServiceServer) EditProduct(context.Context, *EditProductRequest) (*PostProductResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method EditProduct not implemented")
 }
 func (UnimplementedCatalogServiceServer) DeleteProduct(context.Context, *DeleteProductRequest) (*DeleteProductResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteProduct not implemented")
 }
+func (UnimplementedCatalogServiceServer) RestoreProduct(context.Context, *RestoreProductRequest) (*PostProductResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreProduct not implemented")
+}
+func (UnimplementedCatalogServiceServer) ImportProducts(grpc.ClientStreamingServer[ImportProductsRequest, ImportProductsResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method ImportProducts not implemented")
+}
+func (UnimplementedCatalogServiceServer) ExportProducts(*ExportProductsRequest, grpc.ServerStreamingServer[ExportProductsResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method ExportProducts not implemented")
+}
+func (UnimplementedCatalogServiceServer) UploadProductImage(grpc.ClientStreamingServer[UploadProductImageRequest, PostProductResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method UploadProductImage not implemented")
+}
+func (UnimplementedCatalogServiceServer) DeleteProductImage(context.Context, *DeleteProductImageRequest) (*PostProductResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteProductImage not implemented")
+}
+func (UnimplementedCatalogServiceServer) ReorderProductImages(context.Context, *ReorderProductImagesRequest) (*PostProductResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReorderProductImages not implemented")
+}
+func (UnimplementedCatalogServiceServer) GetPriceHistory(context.Context, *GetPriceHistoryRequest) (*GetPriceHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPriceHistory not implemented")
+}
+func (UnimplementedCatalogServiceServer) SchedulePriceChange(context.Context, *SchedulePriceChangeRequest) (*PriceScheduleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SchedulePriceChange not implemented")
+}
+func (UnimplementedCatalogServiceServer) CancelPriceSchedule(context.Context, *CancelPriceScheduleRequest) (*PriceScheduleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelPriceSchedule not implemented")
+}
 func (UnimplementedCatalogServiceServer) mustEmbedUnimplementedCatalogServiceServer() {}
 func (UnimplementedCatalogServiceServer) testEmbeddedByValue()                        {}
 
@@ -204,6 +475,132 @@ func _CatalogService_GetProducts_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CatalogService_GetSimilarProducts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSimilarProductsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).GetSimilarProducts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_GetSimilarProducts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).GetSimilarProducts(ctx, req.(*GetSimilarProductsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_RecordSearchClick_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecordSearchClickRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).RecordSearchClick(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_RecordSearchClick_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).RecordSearchClick(ctx, req.(*RecordSearchClickRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_GetSearchReports_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSearchReportsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).GetSearchReports(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_GetSearchReports_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).GetSearchReports(ctx, req.(*GetSearchReportsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_GetSearchSettings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSearchSettingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).GetSearchSettings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_GetSearchSettings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).GetSearchSettings(ctx, req.(*GetSearchSettingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_UpdateSearchSettings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateSearchSettingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).UpdateSearchSettings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_UpdateSearchSettings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).UpdateSearchSettings(ctx, req.(*UpdateSearchSettingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_SetProductInStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetProductInStockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).SetProductInStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_SetProductInStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).SetProductInStock(ctx, req.(*SetProductInStockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_AddProductRating_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddProductRatingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).AddProductRating(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_AddProductRating_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).AddProductRating(ctx, req.(*AddProductRatingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _CatalogService_EditProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(EditProductRequest)
 	if err := dec(in); err != nil {
@@ -240,6 +637,139 @@ func _CatalogService_DeleteProduct_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CatalogService_RestoreProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreProductRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).RestoreProduct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_RestoreProduct_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).RestoreProduct(ctx, req.(*RestoreProductRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_ImportProducts_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(CatalogServiceServer).ImportProducts(&grpc.GenericServerStream[ImportProductsRequest, ImportProductsResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CatalogService_ImportProductsServer = grpc.ClientStreamingServer[ImportProductsRequest, ImportProductsResponse]
+
+func _CatalogService_ExportProducts_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportProductsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CatalogServiceServer).ExportProducts(m, &grpc.GenericServerStream[ExportProductsRequest, ExportProductsResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CatalogService_ExportProductsServer = grpc.ServerStreamingServer[ExportProductsResponse]
+
+func _CatalogService_UploadProductImage_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(CatalogServiceServer).UploadProductImage(&grpc.GenericServerStream[UploadProductImageRequest, PostProductResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CatalogService_UploadProductImageServer = grpc.ClientStreamingServer[UploadProductImageRequest, PostProductResponse]
+
+func _CatalogService_DeleteProductImage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteProductImageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).DeleteProductImage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_DeleteProductImage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).DeleteProductImage(ctx, req.(*DeleteProductImageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_ReorderProductImages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReorderProductImagesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).ReorderProductImages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_ReorderProductImages_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).ReorderProductImages(ctx, req.(*ReorderProductImagesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_GetPriceHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPriceHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).GetPriceHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_GetPriceHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).GetPriceHistory(ctx, req.(*GetPriceHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_SchedulePriceChange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SchedulePriceChangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).SchedulePriceChange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_SchedulePriceChange_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).SchedulePriceChange(ctx, req.(*SchedulePriceChangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_CancelPriceSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelPriceScheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).CancelPriceSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_CancelPriceSchedule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).CancelPriceSchedule(ctx, req.(*CancelPriceScheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // CatalogService_ServiceDesc is the grpc.ServiceDesc for CatalogService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -259,6 +789,34 @@ var CatalogService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetProducts",
 			Handler:    _CatalogService_GetProducts_Handler,
 		},
+		{
+			MethodName: "GetSimilarProducts",
+			Handler:    _CatalogService_GetSimilarProducts_Handler,
+		},
+		{
+			MethodName: "RecordSearchClick",
+			Handler:    _CatalogService_RecordSearchClick_Handler,
+		},
+		{
+			MethodName: "GetSearchReports",
+			Handler:    _CatalogService_GetSearchReports_Handler,
+		},
+		{
+			MethodName: "GetSearchSettings",
+			Handler:    _CatalogService_GetSearchSettings_Handler,
+		},
+		{
+			MethodName: "UpdateSearchSettings",
+			Handler:    _CatalogService_UpdateSearchSettings_Handler,
+		},
+		{
+			MethodName: "SetProductInStock",
+			Handler:    _CatalogService_SetProductInStock_Handler,
+		},
+		{
+			MethodName: "AddProductRating",
+			Handler:    _CatalogService_AddProductRating_Handler,
+		},
 		{
 			MethodName: "EditProduct",
 			Handler:    _CatalogService_EditProduct_Handler,
@@ -267,7 +825,47 @@ var CatalogService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteProduct",
 			Handler:    _CatalogService_DeleteProduct_Handler,
 		},
+		{
+			MethodName: "RestoreProduct",
+			Handler:    _CatalogService_RestoreProduct_Handler,
+		},
+		{
+			MethodName: "DeleteProductImage",
+			Handler:    _CatalogService_DeleteProductImage_Handler,
+		},
+		{
+			MethodName: "ReorderProductImages",
+			Handler:    _CatalogService_ReorderProductImages_Handler,
+		},
+		{
+			MethodName: "GetPriceHistory",
+			Handler:    _CatalogService_GetPriceHistory_Handler,
+		},
+		{
+			MethodName: "SchedulePriceChange",
+			Handler:    _CatalogService_SchedulePriceChange_Handler,
+		},
+		{
+			MethodName: "CancelPriceSchedule",
+			Handler:    _CatalogService_CancelPriceSchedule_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ImportProducts",
+			Handler:       _CatalogService_ImportProducts_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "ExportProducts",
+			Handler:       _CatalogService_ExportProducts_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "UploadProductImage",
+			Handler:       _CatalogService_UploadProductImage_Handler,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "catalog.proto",
 }
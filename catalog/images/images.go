@@ -0,0 +1,145 @@
+package images
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/gif"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+)
+
+// maxPixels guards against decompression bombs, images are rejected before decoding
+const maxPixels = 40_000_000
+
+var (
+	ErrTooLarge         = errors.New("image exceeds the maximum upload size")
+	ErrUnsupportedType  = errors.New("unsupported image type, use jpeg, png or gif")
+	ErrTooManyPixels    = errors.New("image dimensions are too large")
+	ErrCorruptImage     = errors.New("image could not be decoded")
+	allowedContentTypes = map[string]string{
+		"image/jpeg": "jpg",
+		"image/png":  "png",
+		"image/gif":  "gif",
+	}
+)
+
+// Image is a validated upload together with its generated thumbnail
+type Image struct {
+	Data                 []byte
+	ContentType          string
+	Extension            string
+	Width                int
+	Height               int
+	Thumbnail            []byte
+	ThumbnailContentType string
+	ThumbnailExtension   string
+}
+
+// Process sniffs the content type of data, checks the size limits and
+// renders a thumbnail that fits in a thumbSize x thumbSize box
+func Process(data []byte, maxSize int64, thumbSize int) (*Image, error) {
+	if int64(len(data)) > maxSize {
+		return nil, ErrTooLarge
+	}
+
+	contentType := http.DetectContentType(data)
+	ext, ok := allowedContentTypes[contentType]
+	if !ok {
+		return nil, ErrUnsupportedType
+	}
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrCorruptImage
+	}
+	if cfg.Width*cfg.Height > maxPixels {
+		return nil, ErrTooManyPixels
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrCorruptImage
+	}
+
+	img := &Image{
+		Data:        data,
+		ContentType: contentType,
+		Extension:   ext,
+		Width:       cfg.Width,
+		Height:      cfg.Height,
+	}
+
+	thumb := Resize(src, thumbSize)
+	var buf bytes.Buffer
+	if contentType == "image/jpeg" {
+		err = jpeg.Encode(&buf, thumb, &jpeg.Options{Quality: 85})
+		img.ThumbnailContentType, img.ThumbnailExtension = "image/jpeg", "jpg"
+	} else {
+		// png and gif keep their transparency
+		err = png.Encode(&buf, thumb)
+		img.ThumbnailContentType, img.ThumbnailExtension = "image/png", "png"
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode thumbnail: %w", err)
+	}
+	img.Thumbnail = buf.Bytes()
+	return img, nil
+}
+
+// Resize scales src down so that it fits in a size x size box keeping the
+// aspect ratio, every destination pixel is the average of the source pixels
+// it covers. Images that already fit are copied unchanged.
+func Resize(src image.Image, size int) image.Image {
+	b := src.Bounds()
+	w, h := b.Dx(), b.Dy()
+	if w <= size && h <= size {
+		dst := image.NewNRGBA(image.Rect(0, 0, w, h))
+		draw.Draw(dst, dst.Bounds(), src, b.Min, draw.Src)
+		return dst
+	}
+
+	dw, dh := size, size
+	if w > h {
+		dh = max(1, h*size/w)
+	} else {
+		dw = max(1, w*size/h)
+	}
+
+	dst := image.NewNRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < dh; y++ {
+		y0, y1 := b.Min.Y+y*h/dh, b.Min.Y+(y+1)*h/dh
+		if y1 == y0 {
+			y1 = y0 + 1
+		}
+		for x := 0; x < dw; x++ {
+			x0, x1 := b.Min.X+x*w/dw, b.Min.X+(x+1)*w/dw
+			if x1 == x0 {
+				x1 = x0 + 1
+			}
+
+			var r, g, bl, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					c := color.NRGBA64Model.Convert(src.At(sx, sy)).(color.NRGBA64)
+					r += uint64(c.R)
+					g += uint64(c.G)
+					bl += uint64(c.B)
+					a += uint64(c.A)
+					n++
+				}
+			}
+			dst.Set(x, y, color.NRGBA64{
+				R: uint16(r / n),
+				G: uint16(g / n),
+				B: uint16(bl / n),
+				A: uint16(a / n),
+			})
+		}
+	}
+	return dst
+}
@@ -1,21 +1,112 @@
 package model
 
-// Product represents a product in the catalog
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LegacyCurrency is the currency of products stored before prices had one
+const LegacyCurrency = "USD"
+
+// Product represents a product in the catalog, WeightGrams is the shipping
+// weight of one unit and zero when unknown
 type Product struct {
-	ID          string  `json:"id"`
-	Name        string  `json:"name"`
-	Description string  `json:"description"`
-	Price       float64 `json:"price"`
-	Image       string  `json:"image"`
-	Deleted     bool    `json:"deleted"` 
+	ID          string         `json:"id"`
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	Price       float64        `json:"price"`
+	Currency    string         `json:"currency"`
+	Image       string         `json:"image"`
+	Images      []ProductImage `json:"images"`
+	WeightGrams int64          `json:"weight_grams"`
+	Deleted     bool           `json:"deleted"`
+	DeletedAt   *time.Time     `json:"deleted_at,omitempty"`
+	Status      string         `json:"status"`
+	PublishAt   *time.Time     `json:"publish_at,omitempty"`
+	UnpublishAt *time.Time     `json:"unpublish_at,omitempty"`
+	SeqNo       int64          `json:"-"`
+	PrimaryTerm int64          `json:"-"`
 }
 
-//elasticSearch uses a different structure for indexing documents
-type ProductDocument struct {
-	Name        string  `json:"name"`
-	Description string  `json:"description"`
-	Price       float64 `json:"price"`
-	Image       string  `json:"image"`
-	Deleted     bool    `json:"deleted"`
+var ErrInvalidVersion = errors.New("invalid product version")
+
+// Version identifies the stored revision of a product, it is built from the
+// elasticsearch sequence number and primary term used for optimistic concurrency
+func (p *Product) Version() string {
+	if p.PrimaryTerm == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d.%d", p.SeqNo, p.PrimaryTerm)
+}
+
+// ParseVersion splits a version returned by Product.Version
+func ParseVersion(version string) (seqNo, primaryTerm int64, err error) {
+	seq, term, ok := strings.Cut(version, ".")
+	if !ok {
+		return 0, 0, ErrInvalidVersion
+	}
+	seqNo, err = strconv.ParseInt(seq, 10, 64)
+	if err != nil || seqNo < 0 {
+		return 0, 0, ErrInvalidVersion
+	}
+	primaryTerm, err = strconv.ParseInt(term, 10, 64)
+	if err != nil || primaryTerm <= 0 {
+		return 0, 0, ErrInvalidVersion
+	}
+	return seqNo, primaryTerm, nil
+}
+
+// SimilarProduct is a product found by a more-like-this search with its relevance score
+type SimilarProduct struct {
+	Product *Product
+	Score   float64
+}
+
+// ProductUpdate is a partial product edit, nil fields are left unchanged.
+// When Version is set the update only succeeds if the product was not
+// modified since that version was read.
+type ProductUpdate struct {
+	Name        *string
+	Description *string
+	Price       *float64
+	Currency    *string
+	Image       *string
+	WeightGrams *int64
+	// Publication replaces the status and both publication times together
+	Publication *Publication
+	Version     string
 }
 
+// ProductImage is an uploaded product image, images are ordered by Position
+// and the first one is mirrored into Product.Image
+type ProductImage struct {
+	ID           string `json:"id"`
+	URL          string `json:"url"`
+	ThumbnailURL string `json:"thumbnail_url"`
+	ContentType  string `json:"content_type"`
+	Size         int64  `json:"size"`
+	Width        int    `json:"width"`
+	Height       int    `json:"height"`
+	Position     int    `json:"position"`
+	Key          string `json:"key"`
+	ThumbnailKey string `json:"thumbnail_key"`
+}
+
+// elasticSearch uses a different structure for indexing documents
+type ProductDocument struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	Price       float64        `json:"price"`
+	Currency    string         `json:"currency"`
+	Image       string         `json:"image"`
+	Images      []ProductImage `json:"images,omitempty"`
+	WeightGrams int64          `json:"weight_grams,omitempty"`
+	Deleted     bool           `json:"deleted"`
+	DeletedAt   *time.Time     `json:"deleted_at,omitempty"`
+	Status      string         `json:"status,omitempty"`
+	PublishAt   *time.Time     `json:"publish_at,omitempty"`
+	UnpublishAt *time.Time     `json:"unpublish_at,omitempty"`
+}
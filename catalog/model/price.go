@@ -0,0 +1,45 @@
+package model
+
+import "time"
+
+// PriceChange records a single change of a product price
+type PriceChange struct {
+	ID         string    `json:"id"`
+	ProductID  string    `json:"product_id"`
+	OldPrice   float64   `json:"old_price"`
+	NewPrice   float64   `json:"new_price"`
+	Currency   string    `json:"currency"`
+	Actor      string    `json:"actor"`
+	Reason     string    `json:"reason"`
+	ScheduleID string    `json:"schedule_id,omitempty"`
+	ChangedAt  time.Time `json:"changed_at"`
+}
+
+const (
+	PriceChangeCreated     = "created"
+	PriceChangeEdited      = "edited"
+	PriceChangeImported    = "imported"
+	PriceChangeScheduled   = "scheduled"
+	PriceChangeScheduleEnd = "schedule_ended"
+)
+
+// PriceSchedule is a planned price that is applied at StartsAt and, when
+// EndsAt is set, reverted to the price the product had before
+type PriceSchedule struct {
+	ID            string     `json:"id"`
+	ProductID     string     `json:"product_id"`
+	Price         float64    `json:"price"`
+	PreviousPrice float64    `json:"previous_price"`
+	StartsAt      time.Time  `json:"starts_at"`
+	EndsAt        *time.Time `json:"ends_at,omitempty"`
+	Status        string     `json:"status"`
+	Actor         string     `json:"actor"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+const (
+	PriceSchedulePending   = "pending"
+	PriceScheduleActive    = "active"
+	PriceScheduleCompleted = "completed"
+	PriceScheduleCancelled = "cancelled"
+)
@@ -0,0 +1,39 @@
+package model
+
+import "time"
+
+// Publication statuses of a product. Only published products, and scheduled
+// products whose publish time has passed, are visible to customers.
+const (
+	ProductDraft     = "draft"
+	ProductScheduled = "scheduled"
+	ProductPublished = "published"
+	ProductArchived  = "archived"
+)
+
+// Publication controls when a product is visible to customers
+type Publication struct {
+	Status      string
+	PublishAt   *time.Time
+	UnpublishAt *time.Time
+}
+
+// ProductStatuses lists the valid publication statuses
+var ProductStatuses = []string{ProductDraft, ProductScheduled, ProductPublished, ProductArchived}
+
+// Visible reports whether customers can see the product at now
+func (p *Product) Visible(now time.Time) bool {
+	if p.Deleted {
+		return false
+	}
+	if p.UnpublishAt != nil && !p.UnpublishAt.After(now) {
+		return false
+	}
+	switch p.Status {
+	case ProductPublished:
+		return true
+	case ProductScheduled:
+		return p.PublishAt != nil && !p.PublishAt.After(now)
+	}
+	return false
+}
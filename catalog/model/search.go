@@ -0,0 +1,63 @@
+package model
+
+import "time"
+
+// SearchEvent records a single product search for analytics
+type SearchEvent struct {
+	ID               string     `json:"id"`
+	Query            string     `json:"query"`
+	Terms            []string   `json:"terms"`
+	Results          int64      `json:"results"`
+	LatencyMs        float64    `json:"latency_ms"`
+	ClickedProductID string     `json:"clicked_product_id,omitempty"`
+	ClickedAt        *time.Time `json:"clicked_at,omitempty"`
+	SearchedAt       time.Time  `json:"searched_at"`
+}
+
+// QueryStats aggregates the searches of one normalized query
+type QueryStats struct {
+	Query        string
+	Searches     int64
+	ZeroResults  int64
+	Clicks       int64
+	AvgLatencyMs float64
+}
+
+// SearchReport holds the most frequent queries and the most frequent queries
+// without results of one UTC day
+type SearchReport struct {
+	Day               time.Time
+	Searches          int64
+	TopQueries        []QueryStats
+	ZeroResultQueries []QueryStats
+}
+
+// SearchSettings tunes the relevance of product searches
+type SearchSettings struct {
+	// NameBoost and DescriptionBoost weight matches in each field
+	NameBoost        float64 `json:"name_boost"`
+	DescriptionBoost float64 `json:"description_boost"`
+	// PhraseBoost is added for products whose name contains the query as a phrase
+	PhraseBoost float64 `json:"phrase_boost"`
+	// InStockBoost and RatingBoost raise products that can be ordered and
+	// products with a high average review rating
+	InStockBoost float64 `json:"in_stock_boost"`
+	RatingBoost  float64 `json:"rating_boost"`
+	// Synonyms are rules in the Solr format, "tee, t-shirt" makes both terms
+	// equivalent and "notebook => laptop" rewrites the left side only
+	Synonyms  []string  `json:"synonyms"`
+	Actor     string    `json:"actor"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// DefaultSearchSettings are used until an admin stores settings
+func DefaultSearchSettings() *SearchSettings {
+	return &SearchSettings{
+		NameBoost:        3,
+		DescriptionBoost: 1,
+		PhraseBoost:      2,
+		InStockBoost:     0.5,
+		RatingBoost:      0.2,
+		Synonyms:         []string{},
+	}
+}
@@ -0,0 +1,315 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	elastic "github.com/olivere/elastic/v7"
+	"github.com/wignn/micro-3/catalog/model"
+)
+
+const (
+	searchSettingsIndex = "catalog_search_settings"
+	searchSettingsID    = "current"
+
+	// SynonymsFileName is the synonyms file read by the catalog search analyzer,
+	// elasticsearch resolves it relative to its config directory
+	SynonymsFileName = "catalog_synonyms.txt"
+	searchAnalyzer   = "catalog_search"
+)
+
+var ErrSynonymsReload = errors.New("elasticsearch rejected the synonyms")
+
+// catalogIndexBody creates the product index. The synonym filter is
+// updateable so that new synonyms only need a search analyzer reload,
+// which is why it is used at search time only.
+var catalogIndexBody = map[string]interface{}{
+	"settings": map[string]interface{}{
+		"analysis": catalogAnalysis,
+	},
+	"mappings": map[string]interface{}{
+		"properties": catalogProperties,
+	},
+}
+
+var catalogAnalysis = map[string]interface{}{
+	"filter": map[string]interface{}{
+		"catalog_synonyms": map[string]interface{}{
+			"type":          "synonym_graph",
+			"synonyms_path": "analysis/" + SynonymsFileName,
+			"updateable":    true,
+		},
+	},
+	"analyzer": map[string]interface{}{
+		searchAnalyzer: map[string]interface{}{
+			"tokenizer": "standard",
+			"filter":    []string{"lowercase", "catalog_synonyms"},
+		},
+	},
+}
+
+var catalogProperties = map[string]interface{}{
+	"name":         searchableText(),
+	"description":  searchableText(),
+	"in_stock":     map[string]interface{}{"type": "boolean"},
+	"rating":       map[string]interface{}{"type": "float"},
+	"rating_count": map[string]interface{}{"type": "long"},
+	"rating_sum":   map[string]interface{}{"type": "long"},
+	"status":       map[string]interface{}{"type": "keyword"},
+	"publish_at":   map[string]interface{}{"type": "date"},
+	"unpublish_at": map[string]interface{}{"type": "date"},
+	"weight_grams": map[string]interface{}{"type": "long"},
+}
+
+// searchableText matches the dynamic mapping products were indexed with
+// before, plus the synonym aware search analyzer
+func searchableText() map[string]interface{} {
+	return map[string]interface{}{
+		"type":            "text",
+		"analyzer":        "standard",
+		"search_analyzer": searchAnalyzer,
+		"fields": map[string]interface{}{
+			"keyword": map[string]interface{}{"type": "keyword", "ignore_above": 256},
+		},
+	}
+}
+
+// ensureCatalogIndex creates the product index, an index created before the
+// search analyzer existed is closed for a moment to add it. Fields added to
+// catalogProperties since the index was created are mapped as well.
+func (r *elasticRepository) ensureCatalogIndex(c context.Context) error {
+	exists, err := r.client.IndexExists("catalog").Do(c)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		_, err := r.client.CreateIndex("catalog").BodyJson(catalogIndexBody).Do(c)
+		if err != nil && !elastic.IsStatusCode(err, 400) {
+			return err
+		}
+		return nil
+	}
+
+	settings, err := r.client.IndexGetSettings("catalog").FlatSettings(true).Do(c)
+	if err != nil {
+		return err
+	}
+	hasAnalyzer := false
+	if s, ok := settings["catalog"]; ok {
+		_, hasAnalyzer = s.Settings["index.analysis.analyzer."+searchAnalyzer+".tokenizer"]
+	}
+
+	if !hasAnalyzer {
+		log.Println("adding the search analyzer to the catalog index")
+		if _, err := r.client.CloseIndex("catalog").Do(c); err != nil {
+			return err
+		}
+		_, err = r.client.IndexPutSettings("catalog").
+			BodyJson(map[string]interface{}{"analysis": catalogAnalysis}).
+			Do(c)
+		if _, openErr := r.client.OpenIndex("catalog").Do(c); err == nil {
+			err = openErr
+		}
+		if err != nil {
+			return err
+		}
+	}
+	_, err = r.client.PutMapping().
+		Index("catalog").
+		BodyJson(map[string]interface{}{"properties": catalogProperties}).
+		Do(c)
+	return err
+}
+
+// syncSynonyms rewrites the synonyms file from the stored settings, the file
+// lives on a volume that may have been recreated since the last update
+func (r *elasticRepository) syncSynonyms(c context.Context) error {
+	if err := os.MkdirAll(r.synonymsDir, 0755); err != nil {
+		return err
+	}
+	settings, err := r.GetSearchSettings(c)
+	if err == ErrNotFound {
+		if _, err := os.Stat(r.synonymsPath()); err == nil {
+			return nil
+		}
+		return writeSynonyms(r.synonymsPath(), nil)
+	}
+	if err != nil {
+		return err
+	}
+	return writeSynonyms(r.synonymsPath(), settings.Synonyms)
+}
+
+func (r *elasticRepository) synonymsPath() string {
+	return filepath.Join(r.synonymsDir, SynonymsFileName)
+}
+
+// writeSynonyms replaces the synonyms file atomically so that elasticsearch
+// never reads a partially written file
+func writeSynonyms(path string, rules []string) error {
+	content := strings.Join(rules, "\n")
+	if content != "" {
+		content += "\n"
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, []byte(content), 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// ReplaceSynonyms writes new synonym rules and reloads the search analyzer
+// of the catalog index, the previous rules are restored when elasticsearch
+// rejects the new ones
+func (r *elasticRepository) ReplaceSynonyms(c context.Context, rules []string) error {
+	previous, err := os.ReadFile(r.synonymsPath())
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if err := writeSynonyms(r.synonymsPath(), rules); err != nil {
+		return err
+	}
+	if err := r.reloadSearchAnalyzers(c); err != nil {
+		log.Println("failed to reload synonyms:", err)
+		if err := os.WriteFile(r.synonymsPath(), previous, 0644); err != nil {
+			return err
+		}
+		if err := r.reloadSearchAnalyzers(c); err != nil {
+			log.Println("failed to restore synonyms:", err)
+		}
+		return fmt.Errorf("%w: %v", ErrSynonymsReload, err)
+	}
+	return nil
+}
+
+func (r *elasticRepository) reloadSearchAnalyzers(c context.Context) error {
+	res, err := r.client.PerformRequest(c, elastic.PerformRequestOptions{
+		Method: "POST",
+		Path:   "/catalog/_reload_search_analyzers",
+	})
+	if err != nil {
+		return err
+	}
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("reload returned status %d", res.StatusCode)
+	}
+	var body struct {
+		Shards struct {
+			Failed int `json:"failed"`
+		} `json:"_shards"`
+	}
+	if err := json.Unmarshal(res.Body, &body); err != nil {
+		return err
+	}
+	if body.Shards.Failed > 0 {
+		return fmt.Errorf("reload failed on %d shards", body.Shards.Failed)
+	}
+	return nil
+}
+
+func (r *elasticRepository) GetSearchSettings(c context.Context) (*model.SearchSettings, error) {
+	res, err := r.client.Get().
+		Index(searchSettingsIndex).
+		Id(searchSettingsID).
+		Do(c)
+	if err != nil {
+		if elastic.IsNotFound(err) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	if !res.Found {
+		return nil, ErrNotFound
+	}
+	s := &model.SearchSettings{}
+	if err = json.Unmarshal(res.Source, s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (r *elasticRepository) PutSearchSettings(c context.Context, s *model.SearchSettings) error {
+	_, err := r.client.Index().
+		Index(searchSettingsIndex).
+		Id(searchSettingsID).
+		BodyJson(s).
+		Refresh("wait_for").
+		Do(c)
+	return err
+}
+
+// SetProductInStock stores whether a product can currently be ordered
+func (r *elasticRepository) SetProductInStock(c context.Context, id string, inStock bool) error {
+	_, err := r.client.Update().
+		Index("catalog").
+		Id(id).
+		Doc(map[string]interface{}{"in_stock": inStock}).
+		RetryOnConflict(3).
+		Do(c)
+	if elastic.IsNotFound(err) {
+		return ErrNotFound
+	}
+	return err
+}
+
+// AddProductRating adds a review rating to the average rating of a product
+func (r *elasticRepository) AddProductRating(c context.Context, id string, rating int) error {
+	_, err := r.client.Update().
+		Index("catalog").
+		Id(id).
+		Script(elastic.NewScript(`
+			long count = ctx._source.rating_count == null ? 0 : ctx._source.rating_count;
+			long sum = ctx._source.rating_sum == null ? 0 : ctx._source.rating_sum;
+			ctx._source.rating_count = count + 1;
+			ctx._source.rating_sum = sum + params.rating;
+			ctx._source.rating = (double) ctx._source.rating_sum / ctx._source.rating_count;
+		`).Param("rating", rating)).
+		RetryOnConflict(3).
+		Do(c)
+	if elastic.IsNotFound(err) {
+		return ErrNotFound
+	}
+	return err
+}
+
+// searchQuery matches query on the product name and description weighted by
+// settings, then raises products that are in stock and well rated
+func searchQuery(query string, settings *model.SearchSettings, includeUnpublished bool) elastic.Query {
+	match := elastic.NewBoolQuery().
+		Must(elastic.NewMultiMatchQuery(query).
+			FieldWithBoost("name", settings.NameBoost).
+			FieldWithBoost("description", settings.DescriptionBoost)).
+		MustNot(deletedQuery())
+	if !includeUnpublished {
+		match.Filter(visibleQuery(time.Now().UTC()))
+	}
+	if settings.PhraseBoost > 0 {
+		match.Should(elastic.NewMatchPhraseQuery("name", query).Boost(settings.PhraseBoost))
+	}
+
+	// the text score is multiplied by 1 + the boosts that apply
+	scored := elastic.NewFunctionScoreQuery().
+		Query(match).
+		AddScoreFunc(elastic.NewWeightFactorFunction(1)).
+		ScoreMode("sum").
+		BoostMode("multiply")
+	if settings.InStockBoost > 0 {
+		scored.Add(elastic.NewTermQuery("in_stock", true), elastic.NewWeightFactorFunction(settings.InStockBoost))
+	}
+	if settings.RatingBoost > 0 {
+		scored.AddScoreFunc(elastic.NewFieldValueFactorFunction().
+			Field("rating").
+			Factor(settings.RatingBoost).
+			Modifier("log1p").
+			Missing(0))
+	}
+	return scored
+}
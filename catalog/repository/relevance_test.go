@@ -0,0 +1,268 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"reflect"
+	"testing"
+
+	elastic "github.com/olivere/elastic/v7"
+	"github.com/wignn/micro-3/catalog/model"
+)
+
+func TestSearchQuery(t *testing.T) {
+	settings := func(change func(s *model.SearchSettings)) *model.SearchSettings {
+		s := model.DefaultSearchSettings()
+		change(s)
+		return s
+	}
+	tests := []struct {
+		name     string
+		settings *model.SearchSettings
+		want     string
+	}{
+		{
+			name:     "default settings",
+			settings: model.DefaultSearchSettings(),
+			want: `{"function_score": {
+				"query": {"bool": {
+					"must": {"multi_match": {"query": "running shoes", "fields": ["name^3.000000", "description^1.000000"]}},
+					"must_not": {"term": {"deleted": true}},
+					"should": {"match_phrase": {"name": {"query": "running shoes", "boost": 2}}}
+				}},
+				"functions": [
+					{"weight": 1},
+					{"filter": {"term": {"in_stock": true}}, "weight": 0.5},
+					{"field_value_factor": {"field": "rating", "factor": 0.2, "modifier": "log1p", "missing": 0}}
+				],
+				"score_mode": "sum",
+				"boost_mode": "multiply"
+			}}`,
+		},
+		{
+			name:     "field boosts",
+			settings: settings(func(s *model.SearchSettings) { s.NameBoost, s.DescriptionBoost = 1, 4 }),
+			want: `{"function_score": {
+				"query": {"bool": {
+					"must": {"multi_match": {"query": "running shoes", "fields": ["name^1.000000", "description^4.000000"]}},
+					"must_not": {"term": {"deleted": true}},
+					"should": {"match_phrase": {"name": {"query": "running shoes", "boost": 2}}}
+				}},
+				"functions": [
+					{"weight": 1},
+					{"filter": {"term": {"in_stock": true}}, "weight": 0.5},
+					{"field_value_factor": {"field": "rating", "factor": 0.2, "modifier": "log1p", "missing": 0}}
+				],
+				"score_mode": "sum",
+				"boost_mode": "multiply"
+			}}`,
+		},
+		{
+			name:     "without phrase boost",
+			settings: settings(func(s *model.SearchSettings) { s.PhraseBoost = 0 }),
+			want: `{"function_score": {
+				"query": {"bool": {
+					"must": {"multi_match": {"query": "running shoes", "fields": ["name^3.000000", "description^1.000000"]}},
+					"must_not": {"term": {"deleted": true}}
+				}},
+				"functions": [
+					{"weight": 1},
+					{"filter": {"term": {"in_stock": true}}, "weight": 0.5},
+					{"field_value_factor": {"field": "rating", "factor": 0.2, "modifier": "log1p", "missing": 0}}
+				],
+				"score_mode": "sum",
+				"boost_mode": "multiply"
+			}}`,
+		},
+		{
+			name:     "in-stock boost",
+			settings: settings(func(s *model.SearchSettings) { s.InStockBoost, s.RatingBoost = 1.5, 0 }),
+			want: `{"function_score": {
+				"query": {"bool": {
+					"must": {"multi_match": {"query": "running shoes", "fields": ["name^3.000000", "description^1.000000"]}},
+					"must_not": {"term": {"deleted": true}},
+					"should": {"match_phrase": {"name": {"query": "running shoes", "boost": 2}}}
+				}},
+				"functions": [
+					{"weight": 1},
+					{"filter": {"term": {"in_stock": true}}, "weight": 1.5}
+				],
+				"score_mode": "sum",
+				"boost_mode": "multiply"
+			}}`,
+		},
+		{
+			name:     "rating boost",
+			settings: settings(func(s *model.SearchSettings) { s.InStockBoost, s.RatingBoost = 0, 0.7 }),
+			want: `{"function_score": {
+				"query": {"bool": {
+					"must": {"multi_match": {"query": "running shoes", "fields": ["name^3.000000", "description^1.000000"]}},
+					"must_not": {"term": {"deleted": true}},
+					"should": {"match_phrase": {"name": {"query": "running shoes", "boost": 2}}}
+				}},
+				"functions": [
+					{"weight": 1},
+					{"field_value_factor": {"field": "rating", "factor": 0.7, "modifier": "log1p", "missing": 0}}
+				],
+				"score_mode": "sum",
+				"boost_mode": "multiply"
+			}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src, err := searchQuery("running shoes", tt.settings, true).Source()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, want := normalizeJSON(t, src), normalizeJSON(t, json.RawMessage(tt.want)); !reflect.DeepEqual(got, want) {
+				t.Errorf("query = %s\nwant %s", mustJSON(t, got), mustJSON(t, want))
+			}
+		})
+	}
+}
+
+// rankingFixture holds products that differ in one ranking signal each, every
+// group has its own words so searches only match their group
+var rankingFixture = []fixtureProduct{
+	{"lamp-description", map[string]interface{}{"name": "Reading Light", "description": "A small lamp for the bedside"}},
+	{"lamp-name", map[string]interface{}{"name": "Desk Lamp", "description": "Warm light for the desk"}},
+
+	{"trail-scattered", map[string]interface{}{"name": "Shoes for the trail", "description": "Grippy outsole"}},
+	{"trail-phrase", map[string]interface{}{"name": "Trail Shoes", "description": "Grippy outsole"}},
+
+	{"kettle-out-of-stock", map[string]interface{}{"name": "Steel Kettle", "description": "Boils fast", "in_stock": false}},
+	{"kettle-in-stock", map[string]interface{}{"name": "Steel Kettle", "description": "Boils fast", "in_stock": true}},
+
+	{"mug-unrated", map[string]interface{}{"name": "Enamel Mug", "description": "Camping cup"}},
+	{"mug-low", map[string]interface{}{"name": "Enamel Mug", "description": "Camping cup", "rating": 1.0}},
+	{"mug-high", map[string]interface{}{"name": "Enamel Mug", "description": "Camping cup", "rating": 5.0}},
+
+	{"laptop-sleeve", map[string]interface{}{"name": "Laptop Sleeve", "description": "Padded cover"}},
+
+	{"deleted-lamp", map[string]interface{}{"name": "Desk Lamp", "description": "Desk lamp", "deleted": true}},
+}
+
+type fixtureProduct struct {
+	id  string
+	doc map[string]interface{}
+}
+
+// TestSearchRanking runs searches against a real elasticsearch, which has to
+// be a disposable one: ELASTICSEARCH_URL points at it and
+// ELASTICSEARCH_SYNONYMS_DIR is its config/analysis directory
+func TestSearchRanking(t *testing.T) {
+	r := newElasticRepository(t)
+	c := context.Background()
+	loadFixture(t, r, rankingFixture)
+	if err := r.ReplaceSynonyms(c, []string{"tumbler, mug", "notebook => laptop"}); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := r.ReplaceSynonyms(c, nil); err != nil {
+			t.Error(err)
+		}
+	})
+
+	descriptionFirst := model.DefaultSearchSettings()
+	descriptionFirst.NameBoost, descriptionFirst.DescriptionBoost, descriptionFirst.PhraseBoost = 1, 5, 0
+
+	tests := []struct {
+		name     string
+		query    string
+		settings *model.SearchSettings
+		want     []string
+	}{
+		{"name over description", "lamp", model.DefaultSearchSettings(), []string{"lamp-name", "lamp-description"}},
+		{"description boosted over name", "lamp", descriptionFirst, []string{"lamp-description", "lamp-name"}},
+		{"exact phrase", "trail shoes", model.DefaultSearchSettings(), []string{"trail-phrase", "trail-scattered"}},
+		{"in stock first", "kettle", model.DefaultSearchSettings(), []string{"kettle-in-stock", "kettle-out-of-stock"}},
+		{"better rated first", "mug", model.DefaultSearchSettings(), []string{"mug-high", "mug-low", "mug-unrated"}},
+		{"equivalent synonym", "tumbler", model.DefaultSearchSettings(), []string{"mug-high", "mug-low", "mug-unrated"}},
+		{"mapped synonym", "notebook", model.DefaultSearchSettings(), []string{"laptop-sleeve"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			products, total, err := r.SearchProducts(c, tt.query, tt.settings, true, 0, 10)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := []string{}
+			for _, p := range products {
+				got = append(got, p.ID)
+			}
+			if !reflect.DeepEqual(got, tt.want) || total != int64(len(tt.want)) {
+				t.Errorf("search %q = %v (%d total), want %v", tt.query, got, total, tt.want)
+			}
+		})
+	}
+}
+
+// newElasticRepository connects to the elasticsearch of the integration
+// tests, they are skipped without one. Its catalog index must be empty so
+// that searches only find the fixture.
+func newElasticRepository(t *testing.T) *elasticRepository {
+	t.Helper()
+	url, synonymsDir := os.Getenv("ELASTICSEARCH_URL"), os.Getenv("ELASTICSEARCH_SYNONYMS_DIR")
+	if url == "" || synonymsDir == "" {
+		t.Skip("ELASTICSEARCH_URL and ELASTICSEARCH_SYNONYMS_DIR aren't set")
+	}
+	repo, err := NewElasticRepository(url, synonymsDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := repo.(*elasticRepository)
+	count, err := r.client.Count("catalog").Do(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count > 0 {
+		t.Fatalf("catalog index of %s holds %d products, the tests need a disposable elasticsearch", url, count)
+	}
+	return r
+}
+
+// loadFixture indexes products and removes them once the test is done
+func loadFixture(t *testing.T, r *elasticRepository, fixture []fixtureProduct) {
+	t.Helper()
+	c := context.Background()
+	bulk := r.client.Bulk().Index("catalog").Refresh("true")
+	for _, p := range fixture {
+		bulk.Add(elastic.NewBulkIndexRequest().Id(p.id).Doc(p.doc))
+	}
+	res, err := bulk.Do(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if failed := res.Failed(); len(failed) > 0 {
+		t.Fatalf("indexing %s: %v", failed[0].Id, failed[0].Error.Reason)
+	}
+	t.Cleanup(func() {
+		bulk := r.client.Bulk().Index("catalog").Refresh("true")
+		for _, p := range fixture {
+			bulk.Add(elastic.NewBulkDeleteRequest().Id(p.id))
+		}
+		if _, err := bulk.Do(c); err != nil {
+			t.Error(err)
+		}
+	})
+}
+
+func normalizeJSON(t *testing.T, v interface{}) interface{} {
+	t.Helper()
+	var normalized interface{}
+	if err := json.Unmarshal(mustJSON(t, v), &normalized); err != nil {
+		t.Fatal(err)
+	}
+	return normalized
+}
+
+func mustJSON(t *testing.T, v interface{}) []byte {
+	t.Helper()
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
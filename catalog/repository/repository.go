@@ -1,194 +1,884 @@
 package repository
 
 import (
-    "context"
-    "encoding/json"
-    "errors"
-    "log"
-    "github.com/wignn/micro-3/catalog/model"
-    elastic "github.com/olivere/elastic/v7"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"time"
+
+	elastic "github.com/olivere/elastic/v7"
+	"github.com/wignn/micro-3/catalog/model"
 )
 
 var (
-    ErrNotFound = errors.New("entity not found")
+	ErrNotFound        = errors.New("entity not found")
+	ErrVersionConflict = errors.New("product was modified since the given version was read")
 )
 
 type CatalogRepository interface {
-    Close()
-    PutProduct(c context.Context, p *model.Product) error
-    GetProductByID(c context.Context, id string) (*model.Product, error)
-    ListProducts(c context.Context, skip uint64, take uint64) ([]*model.Product, error)
-    ListProductsWithIDs(ctx context.Context, ids []string) ([]*model.Product, error)
-    EditProduct(c context.Context, id string, name, description string, price float64, image string) (*model.Product, error)
-    SearchProducts(c context.Context, query string, skip uint64, take uint64) ([]*model.Product, error)
-    DeletedProduct(c context.Context, id string) error
+	Close()
+	PutProduct(c context.Context, p *model.Product) error
+	GetProductByID(c context.Context, id string) (*model.Product, error)
+	ListProducts(c context.Context, skip uint64, take uint64, includeUnpublished bool) ([]*model.Product, error)
+	ListProductsWithIDs(ctx context.Context, ids []string) ([]*model.Product, error)
+	EditProduct(c context.Context, id string, u *model.ProductUpdate) (*model.Product, error)
+	SearchProducts(c context.Context, query string, settings *model.SearchSettings, includeUnpublished bool, skip uint64, take uint64) ([]*model.Product, int64, error)
+	SimilarProducts(c context.Context, id string, take uint64) ([]*model.SimilarProduct, error)
+	DeletedProduct(c context.Context, id string) error
+	RestoreProduct(c context.Context, id string) (*model.Product, error)
+	PurgeDeletedProducts(c context.Context, before time.Time) (int64, error)
+	ApplyPublishSchedules(c context.Context, now time.Time) (int64, error)
+	BulkPutProducts(c context.Context, products []*model.Product) ([]error, error)
+	ScrollProducts(c context.Context, includeDeleted bool, fn func(p *model.Product) error) error
+	ScrollPurgeableProducts(c context.Context, before time.Time, fn func(p *model.Product) error) error
+	SetProductImages(c context.Context, id string, images []model.ProductImage, image string) (*model.Product, error)
+	SetProductPrice(c context.Context, id string, price float64) (*model.Product, error)
+	PutPriceChanges(c context.Context, changes []*model.PriceChange) error
+	ListPriceHistory(c context.Context, productID string, skip uint64, take uint64) ([]*model.PriceChange, error)
+	PutPriceSchedule(c context.Context, s *model.PriceSchedule) error
+	GetPriceSchedule(c context.Context, id string) (*model.PriceSchedule, error)
+	ListPriceSchedules(c context.Context, productID string) ([]*model.PriceSchedule, error)
+	ListDuePriceSchedules(c context.Context, now time.Time) ([]*model.PriceSchedule, error)
+	PutSearchEvent(c context.Context, e *model.SearchEvent) error
+	RecordSearchClick(c context.Context, searchID, productID string, at time.Time) error
+	SearchReports(c context.Context, from, to time.Time, take int) ([]*model.SearchReport, error)
+	GetSearchSettings(c context.Context) (*model.SearchSettings, error)
+	PutSearchSettings(c context.Context, s *model.SearchSettings) error
+	ReplaceSynonyms(c context.Context, rules []string) error
+	SetProductInStock(c context.Context, id string, inStock bool) error
+	AddProductRating(c context.Context, id string, rating int) error
+}
+
+const (
+	priceHistoryIndex   = "catalog_price_history"
+	priceSchedulesIndex = "catalog_price_schedules"
+	searchEventsIndex   = "catalog_search_events"
+)
+
+// indexMappings are created on startup for indices that are queried by exact values
+var indexMappings = map[string]string{
+	priceHistoryIndex: `{
+        "mappings": {
+            "properties": {
+                "id":          {"type": "keyword"},
+                "product_id":  {"type": "keyword"},
+                "schedule_id": {"type": "keyword"},
+                "actor":       {"type": "keyword"},
+                "reason":      {"type": "keyword"},
+                "old_price":   {"type": "double"},
+                "new_price":   {"type": "double"},
+                "currency":    {"type": "keyword"},
+                "changed_at":  {"type": "date"}
+            }
+        }
+    }`,
+	priceSchedulesIndex: `{
+        "mappings": {
+            "properties": {
+                "id":             {"type": "keyword"},
+                "product_id":     {"type": "keyword"},
+                "status":         {"type": "keyword"},
+                "actor":          {"type": "keyword"},
+                "price":          {"type": "double"},
+                "previous_price": {"type": "double"},
+                "starts_at":      {"type": "date"},
+                "ends_at":        {"type": "date"},
+                "created_at":     {"type": "date"}
+            }
+        }
+    }`,
+	searchEventsIndex: `{
+        "mappings": {
+            "properties": {
+                "id":                 {"type": "keyword"},
+                "query":              {"type": "keyword"},
+                "terms":              {"type": "keyword"},
+                "results":            {"type": "long"},
+                "latency_ms":         {"type": "double"},
+                "clicked_product_id": {"type": "keyword"},
+                "clicked_at":         {"type": "date"},
+                "searched_at":        {"type": "date"}
+            }
+        }
+    }`,
 }
 
 type elasticRepository struct {
-    client *elastic.Client
+	client      *elastic.Client
+	synonymsDir string
 }
 
-func NewElasticRepository(url string) (CatalogRepository, error) {
-    client, err := elastic.NewClient(
-        elastic.SetURL(url),
-        elastic.SetSniff(false),
-    )
-    if err != nil {
-        return nil, err
-    }
-    return &elasticRepository{client}, nil
+// NewElasticRepository connects to elasticsearch, synonymsDir must be the
+// "analysis" directory of the elasticsearch config, shared with this service
+func NewElasticRepository(url, synonymsDir string) (CatalogRepository, error) {
+	client, err := elastic.NewClient(
+		elastic.SetURL(url),
+		elastic.SetSniff(false),
+	)
+	if err != nil {
+		return nil, err
+	}
+	r := &elasticRepository{client, synonymsDir}
+	c := context.Background()
+	if err := r.createIndices(c); err != nil {
+		return nil, err
+	}
+	// the synonyms file has to exist before the analyzer using it is created
+	if err := r.syncSynonyms(c); err != nil {
+		return nil, err
+	}
+	if err := r.ensureCatalogIndex(c); err != nil {
+		return nil, err
+	}
+	if err := r.reloadSearchAnalyzers(c); err != nil {
+		log.Println("failed to reload synonyms:", err)
+	}
+	return r, nil
+}
+
+func (r *elasticRepository) createIndices(c context.Context) error {
+	for index, mapping := range indexMappings {
+		exists, err := r.client.IndexExists(index).Do(c)
+		if err != nil {
+			return err
+		}
+		if exists {
+			continue
+		}
+		if _, err := r.client.CreateIndex(index).BodyString(mapping).Do(c); err != nil && !elastic.IsStatusCode(err, 400) {
+			return err
+		}
+	}
+	return nil
 }
 
 func (r *elasticRepository) Close() {}
 
+// deletedQuery matches soft deleted products
+func deletedQuery() elastic.Query {
+	return elastic.NewTermQuery("deleted", true)
+}
+
+// visibleQuery matches the products customers can see at now, see model.Product.Visible
+func visibleQuery(now time.Time) elastic.Query {
+	return elastic.NewBoolQuery().
+		Should(
+			elastic.NewTermQuery("status", model.ProductPublished),
+			// documents indexed before the publishing workflow have no status
+			elastic.NewBoolQuery().MustNot(elastic.NewExistsQuery("status")),
+			elastic.NewBoolQuery().
+				Filter(elastic.NewTermQuery("status", model.ProductScheduled)).
+				Filter(elastic.NewRangeQuery("publish_at").Lte(now)),
+		).
+		MinimumNumberShouldMatch(1).
+		MustNot(elastic.NewRangeQuery("unpublish_at").Lte(now))
+}
+
+func productFromDocument(id string, p *model.ProductDocument) *model.Product {
+	currency := p.Currency
+	if currency == "" {
+		// documents indexed before products had a currency were priced in it
+		currency = model.LegacyCurrency
+	}
+	status := p.Status
+	if status == "" {
+		// documents indexed before the publishing workflow were visible
+		status = model.ProductPublished
+	}
+	return &model.Product{
+		ID:          id,
+		Name:        p.Name,
+		Description: p.Description,
+		Price:       p.Price,
+		Currency:    currency,
+		Image:       p.Image,
+		Images:      p.Images,
+		WeightGrams: p.WeightGrams,
+		Deleted:     p.Deleted,
+		DeletedAt:   p.DeletedAt,
+		Status:      status,
+		PublishAt:   p.PublishAt,
+		UnpublishAt: p.UnpublishAt,
+	}
+}
+
+// withVersion stores the sequence number and primary term of the document the product was read from
+func withVersion(p *model.Product, seqNo, primaryTerm *int64) *model.Product {
+	if seqNo != nil && primaryTerm != nil {
+		p.SeqNo, p.PrimaryTerm = *seqNo, *primaryTerm
+	}
+	return p
+}
+
 func (r *elasticRepository) PutProduct(c context.Context, p *model.Product) error {
-    _, err := r.client.Index().
-        Index("catalog").
-        Id(p.ID).
-        BodyJson(model.ProductDocument{
-            Name:        p.Name,
-            Description: p.Description,
-            Price:       p.Price,
-            Image:       p.Image,
-        }).
-        Do(c)
-    return err
+	res, err := r.client.Index().
+		Index("catalog").
+		Id(p.ID).
+		BodyJson(model.ProductDocument{
+			Name:        p.Name,
+			Description: p.Description,
+			Price:       p.Price,
+			Currency:    p.Currency,
+			Image:       p.Image,
+			WeightGrams: p.WeightGrams,
+			Status:      p.Status,
+			PublishAt:   p.PublishAt,
+			UnpublishAt: p.UnpublishAt,
+		}).
+		Do(c)
+	if err != nil {
+		return err
+	}
+	p.SeqNo, p.PrimaryTerm = res.SeqNo, res.PrimaryTerm
+	return nil
 }
 
+// GetProductByID returns a live product, soft deleted products are reported as not found
 func (r *elasticRepository) GetProductByID(c context.Context, id string) (*model.Product, error) {
-    res, err := r.client.Get().
-        Index("catalog").
-        Id(id).
-        Do(c)
-    if err != nil {
-        return nil, err
-    }
-    if !res.Found {
-        return nil, ErrNotFound
-    }
-    p := model.ProductDocument{}
-    if err = json.Unmarshal(res.Source, &p); err != nil {
-        return nil, err
-    }
-    return &model.Product{
-        ID:          res.Id,
-        Name:        p.Name,
-        Description: p.Description,
-        Price:       p.Price,
-        Image:       p.Image,
-    }, nil
-}
-
-func (r *elasticRepository) ListProducts(c context.Context, skip, take uint64) ([]*model.Product, error) {
-    res, err := r.client.Search().
-        Index("catalog").
-        Query(elastic.NewMatchAllQuery()).
-        From(int(skip)).Size(int(take)).
-        Do(c)
-    if err != nil {
-        log.Println(err)
-        return nil, err
-    }
-    var products []*model.Product
-    for _, hit := range res.Hits.Hits {
-        p := model.ProductDocument{}
-        if err = json.Unmarshal(hit.Source, &p); err == nil {
-            products = append(products, &model.Product{
-                ID:          hit.Id,
-                Name:        p.Name,
-                Description: p.Description,
-                Price:       p.Price,
-                Image:       p.Image,
-            })
-        }
-    }
-    return products, nil
+	p, err := r.getProduct(c, id)
+	if err != nil {
+		return nil, err
+	}
+	if p.Deleted {
+		return nil, ErrNotFound
+	}
+	return p, nil
 }
 
+func (r *elasticRepository) getProduct(c context.Context, id string) (*model.Product, error) {
+	res, err := r.client.Get().
+		Index("catalog").
+		Id(id).
+		Do(c)
+	if err != nil {
+		if elastic.IsNotFound(err) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	if !res.Found {
+		return nil, ErrNotFound
+	}
+	p := model.ProductDocument{}
+	if err = json.Unmarshal(res.Source, &p); err != nil {
+		return nil, err
+	}
+	return withVersion(productFromDocument(res.Id, &p), res.SeqNo, res.PrimaryTerm), nil
+}
+
+// ListProducts returns a page of live products, unless includeUnpublished is
+// set only products visible to customers are returned
+func (r *elasticRepository) ListProducts(c context.Context, skip, take uint64, includeUnpublished bool) ([]*model.Product, error) {
+	query := elastic.NewBoolQuery().MustNot(deletedQuery())
+	if !includeUnpublished {
+		query = query.Filter(visibleQuery(time.Now().UTC()))
+	}
+	res, err := r.client.Search().
+		Index("catalog").
+		Query(query).
+		From(int(skip)).Size(int(take)).
+		SeqNoPrimaryTerm(true).
+		Do(c)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	var products []*model.Product
+	for _, hit := range res.Hits.Hits {
+		p := model.ProductDocument{}
+		if err = json.Unmarshal(hit.Source, &p); err == nil {
+			products = append(products, withVersion(productFromDocument(hit.Id, &p), hit.SeqNo, hit.PrimaryTerm))
+		}
+	}
+	return products, nil
+}
+
+// ListProductsWithIDs also returns soft deleted products so that historical
+// records such as past orders can still resolve them
 func (r *elasticRepository) ListProductsWithIDs(c context.Context, ids []string) ([]*model.Product, error) {
-    var items []*elastic.MultiGetItem
-    for _, id := range ids {
-        items = append(items, elastic.NewMultiGetItem().
-            Index("catalog").
-            Id(id))
-    }
-    res, err := r.client.MultiGet().
-        Add(items...).
-        Do(c)
-    if err != nil {
-        log.Println(err)
-        return nil, err
-    }
-    var products []*model.Product
-    for _, doc := range res.Docs {
-        if doc.Found {
-            p := model.ProductDocument{}
-            if err = json.Unmarshal(doc.Source, &p); err == nil {
-                products = append(products, &model.Product{
-                    ID:          doc.Id,
-                    Name:        p.Name,
-                    Description: p.Description,
-                    Price:       p.Price,
-                    Image:       p.Image,
-                })
-            }
-        }
-    }
-    return products, nil
-}
-
-func (r *elasticRepository) SearchProducts(c context.Context, query string, skip, take uint64) ([]*model.Product, error) {
-    res, err := r.client.Search().
-        Index("catalog").
-        Query(elastic.NewMultiMatchQuery(query, "name", "description")).
-        From(int(skip)).Size(int(take)).
-        Do(c)
-    if err != nil {
-        log.Println(err)
-        return nil, err
-    }
-    var products []*model.Product
-    for _, hit := range res.Hits.Hits {
-        p := model.ProductDocument{}
-        if err = json.Unmarshal(hit.Source, &p); err == nil {
-            products = append(products, &model.Product{
-                ID:          hit.Id,
-                Name:        p.Name,
-                Description: p.Description,
-                Price:       p.Price,
-                Image:       p.Image,
-            })
-        }
-    }
-    return products, nil
+	var items []*elastic.MultiGetItem
+	for _, id := range ids {
+		items = append(items, elastic.NewMultiGetItem().
+			Index("catalog").
+			Id(id))
+	}
+	res, err := r.client.MultiGet().
+		Add(items...).
+		Do(c)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	var products []*model.Product
+	for _, doc := range res.Docs {
+		if doc.Found {
+			p := model.ProductDocument{}
+			if err = json.Unmarshal(doc.Source, &p); err == nil {
+				products = append(products, withVersion(productFromDocument(doc.Id, &p), doc.SeqNo, doc.PrimaryTerm))
+			}
+		}
+	}
+	return products, nil
+}
+
+// SearchProducts returns a page of the products matching query and the total number of matches
+func (r *elasticRepository) SearchProducts(c context.Context, query string, settings *model.SearchSettings, includeUnpublished bool, skip, take uint64) ([]*model.Product, int64, error) {
+	res, err := r.client.Search().
+		Index("catalog").
+		Query(searchQuery(query, settings, includeUnpublished)).
+		From(int(skip)).Size(int(take)).
+		SeqNoPrimaryTerm(true).
+		TrackTotalHits(true).
+		Do(c)
+	if err != nil {
+		log.Println(err)
+		return nil, 0, err
+	}
+	var products []*model.Product
+	for _, hit := range res.Hits.Hits {
+		p := model.ProductDocument{}
+		if err = json.Unmarshal(hit.Source, &p); err == nil {
+			products = append(products, withVersion(productFromDocument(hit.Id, &p), hit.SeqNo, hit.PrimaryTerm))
+		}
+	}
+	return products, res.TotalHits(), nil
+}
+
+// SimilarProducts runs a more-like-this query on the name and description of a product
+func (r *elasticRepository) SimilarProducts(c context.Context, id string, take uint64) ([]*model.SimilarProduct, error) {
+	// the frequency limits are lowered so that small catalogs still get matches
+	mlt := elastic.NewMoreLikeThisQuery().
+		Field("name", "description").
+		LikeItems(elastic.NewMoreLikeThisQueryItem().Index("catalog").Id(id)).
+		MinTermFreq(1).
+		MinDocFreq(1).
+		MaxQueryTerms(25)
+	res, err := r.client.Search().
+		Index("catalog").
+		Query(elastic.NewBoolQuery().
+			Must(mlt).
+			MustNot(deletedQuery())).
+		Size(int(take)).
+		Do(c)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	products := []*model.SimilarProduct{}
+	for _, hit := range res.Hits.Hits {
+		p := model.ProductDocument{}
+		if err = json.Unmarshal(hit.Source, &p); err == nil {
+			score := 0.0
+			if hit.Score != nil {
+				score = *hit.Score
+			}
+			products = append(products, &model.SimilarProduct{
+				Product: productFromDocument(hit.Id, &p),
+				Score:   score,
+			})
+		}
+	}
+	return products, nil
 }
 
+// DeletedProduct soft deletes a product by flagging the document, the
+// document itself is only removed by PurgeDeletedProducts
 func (r *elasticRepository) DeletedProduct(c context.Context, id string) error {
-    _, err := r.client.Delete().
-        Index("catalog").
-        Id(id).
-        Do(c)
-    if err != nil {
-        log.Println(err)
-        return err
-    }
-    return nil
-}
-
-func (r *elasticRepository) EditProduct(c context.Context, id string, name, description string, price float64, image string) (*model.Product, error) {
-    _, err := r.client.Update().
-        Index("catalog").
-        Id(id).
-        Doc(model.ProductDocument{
-            Name:        name,
-            Description: description,
-            Price:       price,
-            Image:       image,
-        }).
-        Do(c)
-    if err != nil {
-        log.Println(err)
-        return nil, err
-    }
-    return r.GetProductByID(c, id)
-}
\ No newline at end of file
+	if _, err := r.GetProductByID(c, id); err != nil {
+		return err
+	}
+	now := time.Now().UTC()
+	_, err := r.client.Update().
+		Index("catalog").
+		Id(id).
+		Doc(map[string]interface{}{
+			"deleted":    true,
+			"deleted_at": now,
+		}).
+		Do(c)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+	return nil
+}
+
+func (r *elasticRepository) RestoreProduct(c context.Context, id string) (*model.Product, error) {
+	p, err := r.getProduct(c, id)
+	if err != nil {
+		return nil, err
+	}
+	if !p.Deleted {
+		return p, nil
+	}
+	_, err = r.client.Update().
+		Index("catalog").
+		Id(id).
+		Doc(map[string]interface{}{
+			"deleted":    false,
+			"deleted_at": nil,
+		}).
+		Do(c)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return r.GetProductByID(c, id)
+}
+
+// PurgeDeletedProducts permanently removes products soft deleted before the given time
+func (r *elasticRepository) PurgeDeletedProducts(c context.Context, before time.Time) (int64, error) {
+	res, err := r.client.DeleteByQuery("catalog").
+		Query(purgeableQuery(before)).
+		ProceedOnVersionConflict().
+		Do(c)
+	if err != nil {
+		log.Println(err)
+		return 0, err
+	}
+	return res.Deleted, nil
+}
+
+// purgeableQuery matches products soft deleted before the given time
+func purgeableQuery(before time.Time) elastic.Query {
+	return elastic.NewBoolQuery().
+		Filter(deletedQuery()).
+		Filter(elastic.NewRangeQuery("deleted_at").Lte(before))
+}
+
+// ApplyPublishSchedules publishes scheduled products whose publish time has
+// passed and archives products whose unpublish time has passed
+func (r *elasticRepository) ApplyPublishSchedules(c context.Context, now time.Time) (int64, error) {
+	transitions := []struct {
+		query  elastic.Query
+		status string
+	}{
+		{
+			elastic.NewBoolQuery().
+				Filter(elastic.NewTermQuery("status", model.ProductScheduled)).
+				Filter(elastic.NewRangeQuery("publish_at").Lte(now)),
+			model.ProductPublished,
+		},
+		{
+			elastic.NewBoolQuery().
+				Filter(elastic.NewTermsQuery("status", model.ProductScheduled, model.ProductPublished)).
+				Filter(elastic.NewRangeQuery("unpublish_at").Lte(now)),
+			model.ProductArchived,
+		},
+	}
+
+	var updated int64
+	for _, t := range transitions {
+		res, err := r.client.UpdateByQuery("catalog").
+			Query(elastic.NewBoolQuery().Filter(t.query).MustNot(deletedQuery())).
+			Script(elastic.NewScript("ctx._source.status = params.status").Param("status", t.status)).
+			ProceedOnVersionConflict().
+			Refresh("true").
+			Do(c)
+		if err != nil {
+			log.Println(err)
+			return updated, err
+		}
+		updated += res.Updated
+	}
+	return updated, nil
+}
+
+// EditProduct applies a partial update, only the fields set in u are changed.
+// With a version the update is rejected with ErrVersionConflict when the
+// product was modified after that version was read.
+func (r *elasticRepository) EditProduct(c context.Context, id string, u *model.ProductUpdate) (*model.Product, error) {
+	current, err := r.GetProductByID(c, id)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := map[string]interface{}{}
+	if u.Name != nil {
+		doc["name"] = *u.Name
+	}
+	if u.Description != nil {
+		doc["description"] = *u.Description
+	}
+	if u.Price != nil {
+		doc["price"] = *u.Price
+	}
+	if u.Currency != nil {
+		doc["currency"] = *u.Currency
+	}
+	if u.Image != nil {
+		doc["image"] = *u.Image
+	}
+	if u.WeightGrams != nil {
+		doc["weight_grams"] = *u.WeightGrams
+	}
+	if u.Publication != nil {
+		doc["status"] = u.Publication.Status
+		doc["publish_at"] = u.Publication.PublishAt
+		doc["unpublish_at"] = u.Publication.UnpublishAt
+	}
+
+	update := r.client.Update().
+		Index("catalog").
+		Id(id).
+		Doc(doc)
+	if u.Version != "" {
+		seqNo, primaryTerm, err := model.ParseVersion(u.Version)
+		if err != nil {
+			return nil, err
+		}
+		if seqNo != current.SeqNo || primaryTerm != current.PrimaryTerm {
+			return nil, ErrVersionConflict
+		}
+		update = update.IfSeqNo(seqNo).IfPrimaryTerm(primaryTerm)
+	}
+	if len(doc) == 0 {
+		return current, nil
+	}
+
+	if _, err := update.Do(c); err != nil {
+		if elastic.IsConflict(err) {
+			return nil, ErrVersionConflict
+		}
+		log.Println(err)
+		return nil, err
+	}
+	return r.GetProductByID(c, id)
+}
+
+// BulkPutProducts upserts products in a single bulk request, the returned
+// slice holds the per product error in the same order as products
+func (r *elasticRepository) BulkPutProducts(c context.Context, products []*model.Product) ([]error, error) {
+	errs := make([]error, len(products))
+	if len(products) == 0 {
+		return errs, nil
+	}
+
+	bulk := r.client.Bulk().Index("catalog")
+	for _, p := range products {
+		bulk.Add(elastic.NewBulkUpdateRequest().
+			Id(p.ID).
+			Doc(map[string]interface{}{
+				"name":         p.Name,
+				"description":  p.Description,
+				"price":        p.Price,
+				"currency":     p.Currency,
+				"image":        p.Image,
+				"weight_grams": p.WeightGrams,
+				"status":       p.Status,
+			}).
+			DocAsUpsert(true))
+	}
+	res, err := bulk.Do(c)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	for i, item := range res.Items {
+		if i >= len(errs) {
+			break
+		}
+		for _, result := range item {
+			if result.Error != nil {
+				errs[i] = fmt.Errorf("%s: %s", result.Error.Type, result.Error.Reason)
+			}
+		}
+	}
+	return errs, nil
+}
+
+// ScrollProducts walks every product in the catalog and calls fn for each of them
+func (r *elasticRepository) ScrollProducts(c context.Context, includeDeleted bool, fn func(p *model.Product) error) error {
+	query := elastic.NewBoolQuery()
+	if !includeDeleted {
+		query = query.MustNot(deletedQuery())
+	}
+	return r.scrollProducts(c, query, fn)
+}
+
+// ScrollPurgeableProducts calls fn for each product PurgeDeletedProducts
+// would remove with the same time
+func (r *elasticRepository) ScrollPurgeableProducts(c context.Context, before time.Time, fn func(p *model.Product) error) error {
+	return r.scrollProducts(c, purgeableQuery(before), fn)
+}
+
+func (r *elasticRepository) scrollProducts(c context.Context, query elastic.Query, fn func(p *model.Product) error) error {
+	scroll := r.client.Scroll("catalog").
+		Query(query).
+		Size(500)
+	defer scroll.Clear(context.Background())
+
+	for {
+		res, err := scroll.Do(c)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+		for _, hit := range res.Hits.Hits {
+			p := model.ProductDocument{}
+			if err := json.Unmarshal(hit.Source, &p); err != nil {
+				continue
+			}
+			if err := fn(productFromDocument(hit.Id, &p)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// SetProductImages replaces the image list of a product together with its primary image
+func (r *elasticRepository) SetProductImages(c context.Context, id string, images []model.ProductImage, image string) (*model.Product, error) {
+	if images == nil {
+		images = []model.ProductImage{}
+	}
+	_, err := r.client.Update().
+		Index("catalog").
+		Id(id).
+		Doc(map[string]interface{}{
+			"images": images,
+			"image":  image,
+		}).
+		Do(c)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return r.GetProductByID(c, id)
+}
+
+// SetProductPrice only updates the price of a live product
+func (r *elasticRepository) SetProductPrice(c context.Context, id string, price float64) (*model.Product, error) {
+	if _, err := r.GetProductByID(c, id); err != nil {
+		return nil, err
+	}
+	_, err := r.client.Update().
+		Index("catalog").
+		Id(id).
+		Doc(map[string]interface{}{"price": price}).
+		Do(c)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return r.GetProductByID(c, id)
+}
+
+func (r *elasticRepository) PutPriceChanges(c context.Context, changes []*model.PriceChange) error {
+	if len(changes) == 0 {
+		return nil
+	}
+	bulk := r.client.Bulk().Index(priceHistoryIndex)
+	for _, change := range changes {
+		bulk.Add(elastic.NewBulkIndexRequest().Id(change.ID).Doc(change))
+	}
+	res, err := bulk.Do(c)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+	if failed := res.Failed(); len(failed) > 0 {
+		return fmt.Errorf("failed to record %d price changes: %s", len(failed), failed[0].Error.Reason)
+	}
+	return nil
+}
+
+// ListPriceHistory returns the price changes of a product, newest first
+func (r *elasticRepository) ListPriceHistory(c context.Context, productID string, skip, take uint64) ([]*model.PriceChange, error) {
+	res, err := r.client.Search().
+		Index(priceHistoryIndex).
+		Query(elastic.NewTermQuery("product_id", productID)).
+		Sort("changed_at", false).
+		From(int(skip)).Size(int(take)).
+		Do(c)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	changes := []*model.PriceChange{}
+	for _, hit := range res.Hits.Hits {
+		change := &model.PriceChange{}
+		if err = json.Unmarshal(hit.Source, change); err == nil {
+			changes = append(changes, change)
+		}
+	}
+	return changes, nil
+}
+
+func (r *elasticRepository) PutPriceSchedule(c context.Context, s *model.PriceSchedule) error {
+	_, err := r.client.Index().
+		Index(priceSchedulesIndex).
+		Id(s.ID).
+		BodyJson(s).
+		Refresh("wait_for").
+		Do(c)
+	return err
+}
+
+func (r *elasticRepository) GetPriceSchedule(c context.Context, id string) (*model.PriceSchedule, error) {
+	res, err := r.client.Get().
+		Index(priceSchedulesIndex).
+		Id(id).
+		Do(c)
+	if err != nil {
+		if elastic.IsNotFound(err) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	if !res.Found {
+		return nil, ErrNotFound
+	}
+	s := &model.PriceSchedule{}
+	if err = json.Unmarshal(res.Source, s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (r *elasticRepository) ListPriceSchedules(c context.Context, productID string) ([]*model.PriceSchedule, error) {
+	return r.searchPriceSchedules(c, elastic.NewTermQuery("product_id", productID))
+}
+
+// ListDuePriceSchedules returns pending schedules that should start and
+// active schedules that should end at now
+func (r *elasticRepository) ListDuePriceSchedules(c context.Context, now time.Time) ([]*model.PriceSchedule, error) {
+	return r.searchPriceSchedules(c, elastic.NewBoolQuery().
+		Should(
+			elastic.NewBoolQuery().
+				Filter(elastic.NewTermQuery("status", model.PriceSchedulePending)).
+				Filter(elastic.NewRangeQuery("starts_at").Lte(now)),
+			elastic.NewBoolQuery().
+				Filter(elastic.NewTermQuery("status", model.PriceScheduleActive)).
+				Filter(elastic.NewRangeQuery("ends_at").Lte(now)),
+		).
+		MinimumNumberShouldMatch(1))
+}
+
+func (r *elasticRepository) searchPriceSchedules(c context.Context, query elastic.Query) ([]*model.PriceSchedule, error) {
+	res, err := r.client.Search().
+		Index(priceSchedulesIndex).
+		Query(query).
+		Sort("starts_at", true).
+		Size(1000).
+		Do(c)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	schedules := []*model.PriceSchedule{}
+	for _, hit := range res.Hits.Hits {
+		s := &model.PriceSchedule{}
+		if err = json.Unmarshal(hit.Source, s); err == nil {
+			schedules = append(schedules, s)
+		}
+	}
+	return schedules, nil
+}
+
+func (r *elasticRepository) PutSearchEvent(c context.Context, e *model.SearchEvent) error {
+	_, err := r.client.Index().
+		Index(searchEventsIndex).
+		Id(e.ID).
+		BodyJson(e).
+		Do(c)
+	return err
+}
+
+// RecordSearchClick stores the product that was opened from the results of a search
+func (r *elasticRepository) RecordSearchClick(c context.Context, searchID, productID string, at time.Time) error {
+	_, err := r.client.Update().
+		Index(searchEventsIndex).
+		Id(searchID).
+		Doc(map[string]interface{}{
+			"clicked_product_id": productID,
+			"clicked_at":         at,
+		}).
+		Do(c)
+	if elastic.IsNotFound(err) {
+		return ErrNotFound
+	}
+	return err
+}
+
+// SearchReports aggregates the searches between from and to per UTC day,
+// newest day first, with the take most frequent queries and zero result queries
+func (r *elasticRepository) SearchReports(c context.Context, from, to time.Time, take int) ([]*model.SearchReport, error) {
+	zeroResults := elastic.NewTermQuery("results", 0)
+	queryStats := func() *elastic.TermsAggregation {
+		return elastic.NewTermsAggregation().
+			Field("query").
+			Size(take).
+			SubAggregation("zero_results", elastic.NewFilterAggregation().Filter(zeroResults)).
+			SubAggregation("clicks", elastic.NewFilterAggregation().Filter(elastic.NewExistsQuery("clicked_product_id"))).
+			SubAggregation("latency", elastic.NewAvgAggregation().Field("latency_ms"))
+	}
+	res, err := r.client.Search().
+		Index(searchEventsIndex).
+		Query(elastic.NewRangeQuery("searched_at").Gte(from).Lt(to)).
+		Size(0).
+		Aggregation("days", elastic.NewDateHistogramAggregation().
+			Field("searched_at").
+			CalendarInterval("day").
+			TimeZone("UTC").
+			MinDocCount(1).
+			Order("_key", false).
+			SubAggregation("top", queryStats()).
+			SubAggregation("zero", elastic.NewFilterAggregation().
+				Filter(zeroResults).
+				SubAggregation("queries", queryStats()))).
+		Do(c)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	reports := []*model.SearchReport{}
+	days, ok := res.Aggregations.DateHistogram("days")
+	if !ok {
+		return reports, nil
+	}
+	for _, day := range days.Buckets {
+		report := &model.SearchReport{
+			Day:               time.UnixMilli(int64(day.Key)).UTC(),
+			Searches:          day.DocCount,
+			TopQueries:        []model.QueryStats{},
+			ZeroResultQueries: []model.QueryStats{},
+		}
+		if top, ok := day.Terms("top"); ok {
+			report.TopQueries = queryStatsFromBuckets(top)
+		}
+		if zero, ok := day.Filter("zero"); ok {
+			if queries, ok := zero.Terms("queries"); ok {
+				report.ZeroResultQueries = queryStatsFromBuckets(queries)
+			}
+		}
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
+
+func queryStatsFromBuckets(items *elastic.AggregationBucketKeyItems) []model.QueryStats {
+	stats := []model.QueryStats{}
+	for _, b := range items.Buckets {
+		q := model.QueryStats{Searches: b.DocCount}
+		q.Query, _ = b.Key.(string)
+		if zero, ok := b.Filter("zero_results"); ok {
+			q.ZeroResults = zero.DocCount
+		}
+		if clicks, ok := b.Filter("clicks"); ok {
+			q.Clicks = clicks.DocCount
+		}
+		if latency, ok := b.Avg("latency"); ok && latency.Value != nil {
+			q.AvgLatencyMs = *latency.Value
+		}
+		stats = append(stats, q)
+	}
+	return stats
+}
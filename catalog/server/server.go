@@ -1,46 +1,123 @@
 package server
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"log"
 	"net"
+	"time"
+
+	"github.com/wignn/micro-3/catalog/bulk"
 	"github.com/wignn/micro-3/catalog/genproto"
+	"github.com/wignn/micro-3/catalog/images"
 	"github.com/wignn/micro-3/catalog/model"
 	"github.com/wignn/micro-3/catalog/service"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
 )
 
+// importBatchSize is the number of rows sent to Elasticsearch per bulk request
+const importBatchSize = 500
+
+var ErrUnknownUpdatePath = errors.New("update mask contains an unknown field")
+
 type grpcServer struct {
-	service service.CatalogService
+	service      service.CatalogService
+	maxImageSize int64
 	genproto.UnimplementedCatalogServiceServer
 }
 
-func ListenGRPC(s service.CatalogService, port int) error {
+func ListenGRPC(s service.CatalogService, port int, maxImageSize int64) error {
 	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
 	if err != nil {
 		return err
 	}
 	serv := grpc.NewServer()
-	genproto.RegisterCatalogServiceServer(serv, &grpcServer{service: s})
+	genproto.RegisterCatalogServiceServer(serv, &grpcServer{service: s, maxImageSize: maxImageSize})
 	reflection.Register(serv)
 	return serv.Serve(lis)
 }
 
-func (s *grpcServer) PostProduct(c context.Context, r *genproto.PostProductRequest) (*genproto.PostProductResponse, error) {
-	p, err := s.service.PostProduct(c, r.Name, r.Description, r.Price, r.Image)
-	if err != nil {
-		log.Println(err)
-		return nil, err
+func productToProto(p *model.Product) *genproto.Product {
+	productImages := []*genproto.ProductImage{}
+	for _, img := range p.Images {
+		productImages = append(productImages, &genproto.ProductImage{
+			Id:           img.ID,
+			Url:          img.URL,
+			ThumbnailUrl: img.ThumbnailURL,
+			ContentType:  img.ContentType,
+			Size:         img.Size,
+			Width:        int32(img.Width),
+			Height:       int32(img.Height),
+			Position:     int32(img.Position),
+		})
 	}
-	return &genproto.PostProductResponse{Product: &genproto.Product{
+	res := &genproto.Product{
 		Id:          p.ID,
 		Name:        p.Name,
 		Description: p.Description,
 		Price:       p.Price,
+		Currency:    p.Currency,
 		Image:       p.Image,
-	}}, nil
+		WeightGrams: p.WeightGrams,
+		Deleted:     p.Deleted,
+		Version:     p.Version(),
+		Images:      productImages,
+		Status:      p.Status,
+		Visible:     p.Visible(time.Now().UTC()),
+	}
+	res.PublishAt = timeToProto(p.PublishAt)
+	res.UnpublishAt = timeToProto(p.UnpublishAt)
+	return res
+}
+
+// timeToProto encodes an optional time, nil is sent as empty bytes
+func timeToProto(t *time.Time) []byte {
+	if t == nil {
+		return nil
+	}
+	b, _ := t.MarshalBinary()
+	return b
+}
+
+// timeFromProto decodes an optional time, empty bytes are nil
+func timeFromProto(b []byte, field string) (*time.Time, error) {
+	if len(b) == 0 {
+		return nil, nil
+	}
+	t := &time.Time{}
+	if err := t.UnmarshalBinary(b); err != nil {
+		return nil, fmt.Errorf("invalid %s", field)
+	}
+	return t, nil
+}
+
+func publicationFromProto(status string, publishAt, unpublishAt []byte) (*model.Publication, error) {
+	pub := &model.Publication{Status: status}
+	var err error
+	if pub.PublishAt, err = timeFromProto(publishAt, "publish time"); err != nil {
+		return nil, err
+	}
+	if pub.UnpublishAt, err = timeFromProto(unpublishAt, "unpublish time"); err != nil {
+		return nil, err
+	}
+	return pub, nil
+}
+
+func (s *grpcServer) PostProduct(c context.Context, r *genproto.PostProductRequest) (*genproto.PostProductResponse, error) {
+	pub, err := publicationFromProto(r.Status, r.PublishAt, r.UnpublishAt)
+	if err != nil {
+		return nil, err
+	}
+	p, err := s.service.PostProduct(c, r.Name, r.Description, r.Price, r.Currency, r.Image, r.WeightGrams, pub, r.Actor)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return &genproto.PostProductResponse{Product: productToProto(p)}, nil
 }
 
 func (s *grpcServer) GetProduct(c context.Context, r *genproto.GetProductRequest) (*genproto.GetProductResponse, error) {
@@ -49,28 +126,23 @@ func (s *grpcServer) GetProduct(c context.Context, r *genproto.GetProductRequest
 		log.Println(err)
 		return nil, err
 	}
-	
+
 	return &genproto.GetProductResponse{
-		Product: &genproto.Product{
-			Id:          p.ID,
-			Name:        p.Name,
-			Description: p.Description,
-			Price:       p.Price,
-			Image:       p.Image,
-		},
+		Product: productToProto(p),
 	}, nil
 }
 
 func (s *grpcServer) GetProducts(c context.Context, r *genproto.GetProductsRequest) (*genproto.GetProductsResponse, error) {
 	var res []*model.Product
+	var searchID string
 	var err error
 
 	if r.Query != "" {
-		res, err = s.service.SearchProducts(c, r.Query, r.Skip, r.Take)
+		res, searchID, err = s.service.SearchProducts(c, r.Query, r.Skip, r.Take, r.IncludeUnpublished)
 	} else if len(r.Ids) != 0 {
 		res, err = s.service.GetProductsByIDs(c, r.Ids)
 	} else {
-		res, err = s.service.GetProducts(c, r.Skip, r.Take)
+		res, err = s.service.GetProducts(c, r.Skip, r.Take, r.IncludeUnpublished)
 	}
 	if err != nil {
 		log.Println(err)
@@ -81,38 +153,194 @@ func (s *grpcServer) GetProducts(c context.Context, r *genproto.GetProductsReque
 	for _, p := range res {
 		products = append(
 			products,
-			&genproto.Product{
-				Id:          p.ID,
-				Name:        p.Name,
-				Description: p.Description,
-				Price:       p.Price,
-				Image:       p.Image,
-			},
+			productToProto(p),
 		)
 	}
-	return &genproto.GetProductsResponse{Products: products}, nil
+	return &genproto.GetProductsResponse{Products: products, SearchId: searchID}, nil
+}
+
+func (s *grpcServer) RecordSearchClick(c context.Context, r *genproto.RecordSearchClickRequest) (*genproto.RecordSearchClickResponse, error) {
+	if err := s.service.RecordSearchClick(c, r.SearchId, r.ProductId); err != nil {
+		log.Printf("failed to record click on search %s: %v\n", r.SearchId, err)
+		return nil, err
+	}
+	return &genproto.RecordSearchClickResponse{}, nil
+}
+
+func (s *grpcServer) GetSearchReports(c context.Context, r *genproto.GetSearchReportsRequest) (*genproto.GetSearchReportsResponse, error) {
+	var from, to time.Time
+	if err := from.UnmarshalBinary(r.From); err != nil {
+		return nil, errors.New("invalid report start time")
+	}
+	if err := to.UnmarshalBinary(r.To); err != nil {
+		return nil, errors.New("invalid report end time")
+	}
+
+	reports, err := s.service.GetSearchReports(c, from, to, int(r.Take))
+	if err != nil {
+		log.Println("failed to get search reports:", err)
+		return nil, err
+	}
+
+	res := []*genproto.SearchReport{}
+	for _, report := range reports {
+		day := &genproto.SearchReport{
+			Searches:          report.Searches,
+			TopQueries:        queryStatsToProto(report.TopQueries),
+			ZeroResultQueries: queryStatsToProto(report.ZeroResultQueries),
+		}
+		day.Day, _ = report.Day.MarshalBinary()
+		res = append(res, day)
+	}
+	return &genproto.GetSearchReportsResponse{Reports: res}, nil
+}
+
+func searchSettingsToProto(settings *model.SearchSettings) *genproto.SearchSettingsResponse {
+	res := &genproto.SearchSettings{
+		NameBoost:        settings.NameBoost,
+		DescriptionBoost: settings.DescriptionBoost,
+		PhraseBoost:      settings.PhraseBoost,
+		InStockBoost:     settings.InStockBoost,
+		RatingBoost:      settings.RatingBoost,
+		Synonyms:         settings.Synonyms,
+		Actor:            settings.Actor,
+	}
+	if !settings.UpdatedAt.IsZero() {
+		res.UpdatedAt, _ = settings.UpdatedAt.MarshalBinary()
+	}
+	return &genproto.SearchSettingsResponse{Settings: res}
+}
+
+func (s *grpcServer) GetSearchSettings(c context.Context, r *genproto.GetSearchSettingsRequest) (*genproto.SearchSettingsResponse, error) {
+	settings, err := s.service.GetSearchSettings(c)
+	if err != nil {
+		log.Println("failed to get search settings:", err)
+		return nil, err
+	}
+	return searchSettingsToProto(settings), nil
+}
+
+func (s *grpcServer) UpdateSearchSettings(c context.Context, r *genproto.UpdateSearchSettingsRequest) (*genproto.SearchSettingsResponse, error) {
+	if r.Settings == nil {
+		return nil, errors.New("settings are required")
+	}
+	settings, err := s.service.UpdateSearchSettings(c, &model.SearchSettings{
+		NameBoost:        r.Settings.NameBoost,
+		DescriptionBoost: r.Settings.DescriptionBoost,
+		PhraseBoost:      r.Settings.PhraseBoost,
+		InStockBoost:     r.Settings.InStockBoost,
+		RatingBoost:      r.Settings.RatingBoost,
+		Synonyms:         r.Settings.Synonyms,
+	}, r.Actor)
+	if err != nil {
+		log.Println("failed to update search settings:", err)
+		return nil, err
+	}
+	return searchSettingsToProto(settings), nil
+}
+
+func (s *grpcServer) SetProductInStock(c context.Context, r *genproto.SetProductInStockRequest) (*genproto.SearchSignalResponse, error) {
+	if err := s.service.SetProductInStock(c, r.ProductId, r.InStock); err != nil {
+		log.Printf("failed to set stock flag of product %s: %v\n", r.ProductId, err)
+		return nil, err
+	}
+	return &genproto.SearchSignalResponse{}, nil
+}
+
+func (s *grpcServer) AddProductRating(c context.Context, r *genproto.AddProductRatingRequest) (*genproto.SearchSignalResponse, error) {
+	if err := s.service.AddProductRating(c, r.ProductId, int(r.Rating)); err != nil {
+		log.Printf("failed to add rating to product %s: %v\n", r.ProductId, err)
+		return nil, err
+	}
+	return &genproto.SearchSignalResponse{}, nil
+}
+
+func queryStatsToProto(stats []model.QueryStats) []*genproto.QueryStats {
+	res := []*genproto.QueryStats{}
+	for _, q := range stats {
+		res = append(res, &genproto.QueryStats{
+			Query:        q.Query,
+			Searches:     q.Searches,
+			ZeroResults:  q.ZeroResults,
+			Clicks:       q.Clicks,
+			AvgLatencyMs: q.AvgLatencyMs,
+		})
+	}
+	return res
+}
+
+func (s *grpcServer) GetSimilarProducts(c context.Context, r *genproto.GetSimilarProductsRequest) (*genproto.GetSimilarProductsResponse, error) {
+	similar, err := s.service.GetSimilarProducts(c, r.Id, r.Take)
+	if err != nil {
+		log.Printf("failed to get products similar to %s: %v\n", r.Id, err)
+		return nil, err
+	}
+	products := []*genproto.SimilarProduct{}
+	for _, p := range similar {
+		products = append(products, &genproto.SimilarProduct{
+			Product: productToProto(p.Product),
+			Score:   p.Score,
+		})
+	}
+	return &genproto.GetSimilarProductsResponse{Products: products}, nil
+}
+
+// productUpdate picks the fields named in the update mask from the request
+func productUpdate(r *genproto.EditProductRequest) (*model.ProductUpdate, error) {
+	u := &model.ProductUpdate{Version: r.Version}
+	paths := r.GetUpdateMask().GetPaths()
+	if len(paths) == 0 {
+		// requests without a mask replace the whole product as before
+		paths = []string{"name", "description", "price", "image"}
+		if r.Currency != "" {
+			paths = append(paths, "currency")
+		}
+	}
+	for _, path := range paths {
+		switch path {
+		case "name":
+			u.Name = &r.Name
+		case "description":
+			u.Description = &r.Description
+		case "price":
+			u.Price = &r.Price
+		case "currency":
+			u.Currency = &r.Currency
+		case "image":
+			u.Image = &r.Image
+		case "weightGrams":
+			u.WeightGrams = &r.WeightGrams
+		case "status", "publishAt", "unpublishAt":
+			pub, err := publicationFromProto(r.Status, r.PublishAt, r.UnpublishAt)
+			if err != nil {
+				return nil, err
+			}
+			u.Publication = pub
+		default:
+			return nil, fmt.Errorf("%w: %s", ErrUnknownUpdatePath, path)
+		}
+	}
+	return u, nil
 }
 
 func (s *grpcServer) EditProduct(c context.Context, r *genproto.EditProductRequest) (*genproto.PostProductResponse, error) {
-	p, err := s.service.EditProduct(c, r.Id, r.Name, r.Description, r.Price, r.Image)
+	u, err := productUpdate(r)
+	if err != nil {
+		return nil, err
+	}
+	p, err := s.service.EditProduct(c, r.Id, u, r.Actor)
 	if err != nil {
 		log.Println("failed to edit product:", err)
-		return nil, err 
-}
-	return &genproto.PostProductResponse{Product: &genproto.Product{
-		Id:          p.ID,
-		Name:        p.Name,
-		Description: p.Description,
-		Price:       p.Price,
-		Image:       p.Image,
-	}}, nil
+		return nil, err
+	}
+	return &genproto.PostProductResponse{Product: productToProto(p)}, nil
 }
 
 func (s *grpcServer) DeleteProduct(c context.Context, r *genproto.DeleteProductRequest) (*genproto.DeleteProductResponse, error) {
 	err := s.service.DeleteProduct(c, r.Id)
 	if err != nil {
 		log.Printf("failed to delete product with ID %s: %v\n", r.Id, err)
-		return nil,  err
+		return nil, err
 	}
 
 	return &genproto.DeleteProductResponse{
@@ -121,3 +349,252 @@ func (s *grpcServer) DeleteProduct(c context.Context, r *genproto.DeleteProductR
 		Success:   true,
 	}, nil
 }
+
+func (s *grpcServer) RestoreProduct(c context.Context, r *genproto.RestoreProductRequest) (*genproto.PostProductResponse, error) {
+	p, err := s.service.RestoreProduct(c, r.Id)
+	if err != nil {
+		log.Printf("failed to restore product with ID %s: %v\n", r.Id, err)
+		return nil, err
+	}
+	return &genproto.PostProductResponse{Product: productToProto(p)}, nil
+}
+
+func bulkFormat(f genproto.ProductFormat) bulk.Format {
+	if f == genproto.ProductFormat_NDJSON {
+		return bulk.NDJSON
+	}
+	return bulk.CSV
+}
+
+func (s *grpcServer) ImportProducts(stream genproto.CatalogService_ImportProductsServer) error {
+	res := &genproto.ImportProductsResponse{}
+	var decoder *bulk.Decoder
+	var actor string
+	var batch []*model.Product
+	var batchRows []uint64
+
+	flush := func() error {
+		errs, err := s.service.ImportProducts(stream.Context(), batch, actor)
+		if err != nil {
+			return err
+		}
+		for i, err := range errs {
+			if err != nil {
+				res.Failed++
+				res.Errors = append(res.Errors, &genproto.ImportError{
+					Row:     batchRows[i],
+					Id:      batch[i].ID,
+					Message: err.Error(),
+				})
+				continue
+			}
+			res.Imported++
+		}
+		batch, batchRows = nil, nil
+		return nil
+	}
+
+	var row uint64
+	for {
+		r, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			log.Println("failed to receive import row:", err)
+			return err
+		}
+		row++
+
+		if decoder == nil {
+			if decoder, err = bulk.NewDecoder(bulkFormat(r.Format)); err != nil {
+				return err
+			}
+			actor = r.Actor
+		}
+		if r.Row == "" {
+			continue
+		}
+
+		p, err := decoder.Decode(r.Row)
+		if err == bulk.ErrMissingHeader {
+			return err
+		}
+		if err != nil {
+			res.Total++
+			res.Failed++
+			res.Errors = append(res.Errors, &genproto.ImportError{Row: row, Message: err.Error()})
+			continue
+		}
+		if p == nil {
+			// csv header
+			continue
+		}
+
+		res.Total++
+		batch = append(batch, p)
+		batchRows = append(batchRows, row)
+		if len(batch) >= importBatchSize {
+			if err := flush(); err != nil {
+				log.Println("failed to import products:", err)
+				return err
+			}
+		}
+	}
+
+	if err := flush(); err != nil {
+		log.Println("failed to import products:", err)
+		return err
+	}
+	return stream.SendAndClose(res)
+}
+
+func (s *grpcServer) ExportProducts(r *genproto.ExportProductsRequest, stream genproto.CatalogService_ExportProductsServer) error {
+	format := bulkFormat(r.Format)
+	if header, ok := bulk.Header(format); ok {
+		if err := stream.Send(&genproto.ExportProductsResponse{Row: header}); err != nil {
+			return err
+		}
+	}
+
+	err := s.service.ExportProducts(stream.Context(), r.IncludeDeleted, func(p *model.Product) error {
+		row, err := bulk.Encode(format, p)
+		if err != nil {
+			return err
+		}
+		return stream.Send(&genproto.ExportProductsResponse{Row: row})
+	})
+	if err != nil {
+		log.Println("failed to export products:", err)
+		return err
+	}
+	return nil
+}
+
+func (s *grpcServer) UploadProductImage(stream genproto.CatalogService_UploadProductImageServer) error {
+	var info *genproto.UploadImageInfo
+	var data bytes.Buffer
+	for {
+		r, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			log.Println("failed to receive image chunk:", err)
+			return err
+		}
+
+		switch d := r.Data.(type) {
+		case *genproto.UploadProductImageRequest_Info:
+			info = d.Info
+		case *genproto.UploadProductImageRequest_Chunk:
+			if int64(data.Len()+len(d.Chunk)) > s.maxImageSize {
+				return images.ErrTooLarge
+			}
+			data.Write(d.Chunk)
+		}
+	}
+	if info == nil {
+		return errors.New("image upload is missing the product info")
+	}
+
+	position := -1
+	if info.Position != nil {
+		position = int(*info.Position)
+	}
+	p, err := s.service.AddProductImage(stream.Context(), info.ProductId, position, data.Bytes())
+	if err != nil {
+		log.Printf("failed to upload image for product %s: %v\n", info.ProductId, err)
+		return err
+	}
+	return stream.SendAndClose(&genproto.PostProductResponse{Product: productToProto(p)})
+}
+
+func (s *grpcServer) DeleteProductImage(c context.Context, r *genproto.DeleteProductImageRequest) (*genproto.PostProductResponse, error) {
+	p, err := s.service.DeleteProductImage(c, r.ProductId, r.ImageId)
+	if err != nil {
+		log.Printf("failed to delete image %s of product %s: %v\n", r.ImageId, r.ProductId, err)
+		return nil, err
+	}
+	return &genproto.PostProductResponse{Product: productToProto(p)}, nil
+}
+
+func (s *grpcServer) ReorderProductImages(c context.Context, r *genproto.ReorderProductImagesRequest) (*genproto.PostProductResponse, error) {
+	p, err := s.service.ReorderProductImages(c, r.ProductId, r.ImageIds)
+	if err != nil {
+		log.Printf("failed to reorder images of product %s: %v\n", r.ProductId, err)
+		return nil, err
+	}
+	return &genproto.PostProductResponse{Product: productToProto(p)}, nil
+}
+
+func priceScheduleToProto(schedule *model.PriceSchedule) *genproto.PriceSchedule {
+	p := &genproto.PriceSchedule{
+		Id:        schedule.ID,
+		ProductId: schedule.ProductID,
+		Price:     schedule.Price,
+		Status:    schedule.Status,
+		Actor:     schedule.Actor,
+	}
+	p.StartsAt, _ = schedule.StartsAt.MarshalBinary()
+	p.CreatedAt, _ = schedule.CreatedAt.MarshalBinary()
+	if schedule.EndsAt != nil {
+		p.EndsAt, _ = schedule.EndsAt.MarshalBinary()
+	}
+	return p
+}
+
+func (s *grpcServer) GetPriceHistory(c context.Context, r *genproto.GetPriceHistoryRequest) (*genproto.GetPriceHistoryResponse, error) {
+	history, err := s.service.GetPriceHistory(c, r.ProductId, r.Skip, r.Take)
+	if err != nil {
+		log.Printf("failed to get price history of product %s: %v\n", r.ProductId, err)
+		return nil, err
+	}
+
+	changes := []*genproto.PriceChange{}
+	for _, h := range history {
+		change := &genproto.PriceChange{
+			Id:         h.ID,
+			ProductId:  h.ProductID,
+			OldPrice:   h.OldPrice,
+			NewPrice:   h.NewPrice,
+			Currency:   h.Currency,
+			Actor:      h.Actor,
+			Reason:     h.Reason,
+			ScheduleId: h.ScheduleID,
+		}
+		change.ChangedAt, _ = h.ChangedAt.MarshalBinary()
+		changes = append(changes, change)
+	}
+	return &genproto.GetPriceHistoryResponse{Changes: changes}, nil
+}
+
+func (s *grpcServer) SchedulePriceChange(c context.Context, r *genproto.SchedulePriceChangeRequest) (*genproto.PriceScheduleResponse, error) {
+	var startsAt time.Time
+	if err := startsAt.UnmarshalBinary(r.StartsAt); err != nil {
+		return nil, errors.New("invalid schedule start time")
+	}
+	var endsAt *time.Time
+	if len(r.EndsAt) != 0 {
+		endsAt = &time.Time{}
+		if err := endsAt.UnmarshalBinary(r.EndsAt); err != nil {
+			return nil, errors.New("invalid schedule end time")
+		}
+	}
+
+	schedule, err := s.service.SchedulePriceChange(c, r.ProductId, r.Price, startsAt, endsAt, r.Actor)
+	if err != nil {
+		log.Printf("failed to schedule price change for product %s: %v\n", r.ProductId, err)
+		return nil, err
+	}
+	return &genproto.PriceScheduleResponse{Schedule: priceScheduleToProto(schedule)}, nil
+}
+
+func (s *grpcServer) CancelPriceSchedule(c context.Context, r *genproto.CancelPriceScheduleRequest) (*genproto.PriceScheduleResponse, error) {
+	schedule, err := s.service.CancelPriceSchedule(c, r.Id, r.Actor)
+	if err != nil {
+		log.Printf("failed to cancel price schedule %s: %v\n", r.Id, err)
+		return nil, err
+	}
+	return &genproto.PriceScheduleResponse{Schedule: priceScheduleToProto(schedule)}, nil
+}
@@ -0,0 +1,201 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/segmentio/ksuid"
+	"github.com/wignn/micro-3/catalog/model"
+	"github.com/wignn/micro-3/catalog/repository"
+)
+
+var (
+	ErrInvalidPrice          = errors.New("price must not be negative")
+	ErrInvalidSchedule       = errors.New("schedule must end after it starts and end in the future")
+	ErrOverlappingSchedule   = errors.New("product already has a price schedule in that period")
+	ErrScheduleNotCancelable = errors.New("only pending or active price schedules can be cancelled")
+)
+
+func newPriceChange(p *model.Product, oldPrice float64, actor, reason string) *model.PriceChange {
+	return &model.PriceChange{
+		ID:        ksuid.New().String(),
+		ProductID: p.ID,
+		OldPrice:  oldPrice,
+		NewPrice:  p.Price,
+		Currency:  p.Currency,
+		Actor:     actor,
+		Reason:    reason,
+		ChangedAt: time.Now().UTC(),
+	}
+}
+
+// recordPriceChanges writes the price history, a failure is logged but does
+// not fail the product update that already happened
+func (s *catalogService) recordPriceChanges(c context.Context, changes ...*model.PriceChange) {
+	if err := s.repository.PutPriceChanges(c, changes); err != nil {
+		log.Println("failed to record price changes:", err)
+	}
+}
+
+func (s *catalogService) GetPriceHistory(c context.Context, productID string, skip uint64, take uint64) ([]*model.PriceChange, error) {
+	if take == 0 || take > 100 {
+		take = 100
+	}
+	return s.repository.ListPriceHistory(c, productID, skip, take)
+}
+
+func (s *catalogService) SchedulePriceChange(c context.Context, productID string, price float64, startsAt time.Time, endsAt *time.Time, actor string) (*model.PriceSchedule, error) {
+	if price < 0 {
+		return nil, ErrInvalidPrice
+	}
+	now := time.Now().UTC()
+	if endsAt != nil && (!endsAt.After(startsAt) || !endsAt.After(now)) {
+		return nil, ErrInvalidSchedule
+	}
+	if _, err := s.repository.GetProductByID(c, productID); err != nil {
+		return nil, err
+	}
+
+	schedules, err := s.repository.ListPriceSchedules(c, productID)
+	if err != nil {
+		return nil, err
+	}
+	for _, other := range schedules {
+		if other.Status != model.PriceSchedulePending && other.Status != model.PriceScheduleActive {
+			continue
+		}
+		if overlaps(startsAt, endsAt, other.StartsAt, other.EndsAt) {
+			return nil, ErrOverlappingSchedule
+		}
+	}
+
+	schedule := &model.PriceSchedule{
+		ID:        ksuid.New().String(),
+		ProductID: productID,
+		Price:     price,
+		StartsAt:  startsAt.UTC(),
+		EndsAt:    endsAt,
+		Status:    model.PriceSchedulePending,
+		Actor:     actor,
+		CreatedAt: now,
+	}
+	if err := s.repository.PutPriceSchedule(c, schedule); err != nil {
+		return nil, err
+	}
+	return schedule, nil
+}
+
+// overlaps reports whether two periods intersect, a nil end means open ended
+func overlaps(aStart time.Time, aEnd *time.Time, bStart time.Time, bEnd *time.Time) bool {
+	aEndsBeforeB := aEnd != nil && !aEnd.After(bStart)
+	bEndsBeforeA := bEnd != nil && !bEnd.After(aStart)
+	return !aEndsBeforeB && !bEndsBeforeA
+}
+
+// CancelPriceSchedule stops a schedule, an active schedule reverts the price right away
+func (s *catalogService) CancelPriceSchedule(c context.Context, id, actor string) (*model.PriceSchedule, error) {
+	schedule, err := s.repository.GetPriceSchedule(c, id)
+	if err != nil {
+		return nil, err
+	}
+
+	switch schedule.Status {
+	case model.PriceSchedulePending:
+	case model.PriceScheduleActive:
+		if err := s.endPriceSchedule(c, schedule, actor); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, ErrScheduleNotCancelable
+	}
+
+	schedule.Status = model.PriceScheduleCancelled
+	if err := s.repository.PutPriceSchedule(c, schedule); err != nil {
+		return nil, err
+	}
+	return schedule, nil
+}
+
+// ApplyDuePriceSchedules starts and ends the schedules that are due at now and
+// returns how many of them were processed
+func (s *catalogService) ApplyDuePriceSchedules(c context.Context, now time.Time) (int, error) {
+	schedules, err := s.repository.ListDuePriceSchedules(c, now)
+	if err != nil {
+		return 0, err
+	}
+
+	applied := 0
+	for _, schedule := range schedules {
+		var err error
+		switch schedule.Status {
+		case model.PriceSchedulePending:
+			err = s.startPriceSchedule(c, schedule, now)
+		case model.PriceScheduleActive:
+			err = s.endPriceSchedule(c, schedule, schedule.Actor)
+		}
+		if err == nil {
+			err = s.repository.PutPriceSchedule(c, schedule)
+		}
+		if err != nil {
+			log.Printf("failed to apply price schedule %s: %v\n", schedule.ID, err)
+			continue
+		}
+		applied++
+	}
+	return applied, nil
+}
+
+func (s *catalogService) startPriceSchedule(c context.Context, schedule *model.PriceSchedule, now time.Time) error {
+	p, err := s.repository.GetProductByID(c, schedule.ProductID)
+	if err == repository.ErrNotFound {
+		// the product was deleted before the schedule started
+		schedule.Status = model.PriceScheduleCancelled
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	schedule.PreviousPrice = p.Price
+	updated, err := s.repository.SetProductPrice(c, p.ID, schedule.Price)
+	if err != nil {
+		return err
+	}
+	change := newPriceChange(updated, p.Price, schedule.Actor, model.PriceChangeScheduled)
+	change.ScheduleID = schedule.ID
+	s.recordPriceChanges(c, change)
+
+	schedule.Status = model.PriceScheduleActive
+	if schedule.EndsAt == nil {
+		schedule.Status = model.PriceScheduleCompleted
+	} else if !schedule.EndsAt.After(now) {
+		return s.endPriceSchedule(c, schedule, schedule.Actor)
+	}
+	return nil
+}
+
+// endPriceSchedule reverts the price, unless it was changed by someone else in the meantime
+func (s *catalogService) endPriceSchedule(c context.Context, schedule *model.PriceSchedule, actor string) error {
+	schedule.Status = model.PriceScheduleCompleted
+	p, err := s.repository.GetProductByID(c, schedule.ProductID)
+	if err == repository.ErrNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if p.Price != schedule.Price {
+		return nil
+	}
+
+	updated, err := s.repository.SetProductPrice(c, p.ID, schedule.PreviousPrice)
+	if err != nil {
+		return err
+	}
+	change := newPriceChange(updated, p.Price, actor, model.PriceChangeScheduleEnd)
+	change.ScheduleID = schedule.ID
+	s.recordPriceChanges(c, change)
+	return nil
+}
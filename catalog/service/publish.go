@@ -0,0 +1,62 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/wignn/micro-3/catalog/model"
+)
+
+var (
+	ErrInvalidStatus      = errors.New("status must be draft, scheduled, published or archived")
+	ErrInvalidPublication = errors.New("scheduled products need a publish time and must be unpublished after they are published")
+)
+
+// normalizePublication validates a publication and resolves its status.
+// Without a status a product is published, or scheduled when its publish
+// time is in the future. A nil publication publishes the product right away
+// as products were before the publishing workflow.
+func normalizePublication(p *model.Publication, now time.Time) (*model.Publication, error) {
+	if p == nil {
+		return &model.Publication{Status: model.ProductPublished}, nil
+	}
+	pub := &model.Publication{Status: strings.ToLower(strings.TrimSpace(p.Status))}
+	if p.PublishAt != nil {
+		publishAt := p.PublishAt.UTC()
+		pub.PublishAt = &publishAt
+	}
+	if p.UnpublishAt != nil {
+		unpublishAt := p.UnpublishAt.UTC()
+		pub.UnpublishAt = &unpublishAt
+	}
+
+	scheduled := pub.PublishAt != nil && pub.PublishAt.After(now)
+	switch pub.Status {
+	case "", model.ProductPublished:
+		pub.Status = model.ProductPublished
+		if scheduled {
+			pub.Status = model.ProductScheduled
+		}
+	case model.ProductScheduled:
+		if pub.PublishAt == nil {
+			return nil, ErrInvalidPublication
+		}
+	case model.ProductDraft, model.ProductArchived:
+	default:
+		return nil, ErrInvalidStatus
+	}
+
+	if pub.PublishAt != nil && pub.UnpublishAt != nil && !pub.UnpublishAt.After(*pub.PublishAt) {
+		return nil, ErrInvalidPublication
+	}
+	return pub, nil
+}
+
+// ApplyPublishSchedules moves scheduled products to published and products
+// past their unpublish time to archived. Visibility doesn't wait for this, it
+// keeps the stored status in line with what customers see.
+func (s *catalogService) ApplyPublishSchedules(c context.Context, now time.Time) (int64, error) {
+	return s.repository.ApplyPublishSchedules(c, now)
+}
@@ -0,0 +1,172 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wignn/micro-3/catalog/model"
+	"github.com/wignn/micro-3/catalog/repository"
+)
+
+const (
+	// searchSettingsTTL bounds how long other catalog instances keep using
+	// boosts that were changed on one of them
+	searchSettingsTTL = 30 * time.Second
+
+	maxSearchBoost = 100
+	maxSynonyms    = 5000
+)
+
+var (
+	ErrInvalidBoost    = errors.New("boosts must be between 0 and 100 and name or description must be boosted")
+	ErrInvalidSynonym  = errors.New("synonym rules must list at least two terms separated by commas or map terms with =>")
+	ErrTooManySynonyms = errors.New("too many synonym rules")
+	ErrInvalidRating   = errors.New("rating must be between 1 and 5")
+)
+
+// settingsCache keeps the search settings between searches
+type settingsCache struct {
+	mu       sync.Mutex
+	settings *model.SearchSettings
+	loadedAt time.Time
+}
+
+// searchSettings returns the current search settings, the defaults are used
+// when none were stored or they can't be loaded
+func (s *catalogService) searchSettings(c context.Context) *model.SearchSettings {
+	s.settings.mu.Lock()
+	defer s.settings.mu.Unlock()
+
+	if s.settings.settings != nil && time.Since(s.settings.loadedAt) < searchSettingsTTL {
+		return s.settings.settings
+	}
+	settings, err := s.repository.GetSearchSettings(c)
+	if err != nil {
+		if err != repository.ErrNotFound {
+			log.Println("failed to load search settings:", err)
+		}
+		if s.settings.settings != nil {
+			return s.settings.settings
+		}
+		settings = model.DefaultSearchSettings()
+	}
+	s.settings.settings, s.settings.loadedAt = settings, time.Now()
+	return settings
+}
+
+func (s *catalogService) GetSearchSettings(c context.Context) (*model.SearchSettings, error) {
+	settings, err := s.repository.GetSearchSettings(c)
+	if err == repository.ErrNotFound {
+		return model.DefaultSearchSettings(), nil
+	}
+	return settings, err
+}
+
+// UpdateSearchSettings stores new boosts and synonyms, the synonyms are
+// applied by reloading the search analyzer so searches keep working meanwhile
+func (s *catalogService) UpdateSearchSettings(c context.Context, settings *model.SearchSettings, actor string) (*model.SearchSettings, error) {
+	if err := validateBoosts(settings); err != nil {
+		return nil, err
+	}
+	synonyms, err := normalizeSynonyms(settings.Synonyms)
+	if err != nil {
+		return nil, err
+	}
+	settings.Synonyms = synonyms
+	settings.Actor = actor
+	settings.UpdatedAt = time.Now().UTC()
+
+	if err := s.repository.ReplaceSynonyms(c, settings.Synonyms); err != nil {
+		return nil, err
+	}
+	if err := s.repository.PutSearchSettings(c, settings); err != nil {
+		return nil, err
+	}
+
+	s.settings.mu.Lock()
+	s.settings.settings, s.settings.loadedAt = settings, time.Now()
+	s.settings.mu.Unlock()
+	return settings, nil
+}
+
+func validateBoosts(s *model.SearchSettings) error {
+	for _, boost := range []float64{s.NameBoost, s.DescriptionBoost, s.PhraseBoost, s.InStockBoost, s.RatingBoost} {
+		if boost < 0 || boost > maxSearchBoost {
+			return ErrInvalidBoost
+		}
+	}
+	if s.NameBoost == 0 && s.DescriptionBoost == 0 {
+		return ErrInvalidBoost
+	}
+	return nil
+}
+
+// normalizeSynonyms lowercases synonym rules and checks their format, empty
+// rules and duplicates are dropped
+func normalizeSynonyms(rules []string) ([]string, error) {
+	if len(rules) > maxSynonyms {
+		return nil, ErrTooManySynonyms
+	}
+	normalized := []string{}
+	seen := map[string]bool{}
+	for _, rule := range rules {
+		rule = strings.ToLower(strings.TrimSpace(rule))
+		if rule == "" {
+			continue
+		}
+		if strings.ContainsAny(rule, "\n\r#") {
+			return nil, ErrInvalidSynonym
+		}
+
+		from, to, mapping := strings.Cut(rule, "=>")
+		terms, err := synonymTerms(from)
+		if err != nil {
+			return nil, err
+		}
+		if mapping {
+			targets, err := synonymTerms(to)
+			if err != nil {
+				return nil, err
+			}
+			rule = strings.Join(terms, ", ") + " => " + strings.Join(targets, ", ")
+		} else {
+			if len(terms) < 2 {
+				return nil, ErrInvalidSynonym
+			}
+			rule = strings.Join(terms, ", ")
+		}
+
+		if !seen[rule] {
+			seen[rule] = true
+			normalized = append(normalized, rule)
+		}
+	}
+	return normalized, nil
+}
+
+func synonymTerms(list string) ([]string, error) {
+	terms := []string{}
+	for _, term := range strings.Split(list, ",") {
+		term = strings.Join(strings.Fields(term), " ")
+		if term == "" || strings.Contains(term, "=>") {
+			return nil, ErrInvalidSynonym
+		}
+		terms = append(terms, term)
+	}
+	return terms, nil
+}
+
+func (s *catalogService) SetProductInStock(c context.Context, id string, inStock bool) error {
+	return s.repository.SetProductInStock(c, id, inStock)
+}
+
+func (s *catalogService) AddProductRating(c context.Context, id string, rating int) error {
+	if rating < 1 || rating > 5 {
+		return ErrInvalidRating
+	}
+	return s.repository.AddProductRating(c, id, rating)
+}
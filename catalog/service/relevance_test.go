@@ -0,0 +1,73 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/wignn/micro-3/catalog/model"
+)
+
+func TestValidateBoosts(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(s *model.SearchSettings)
+		want   error
+	}{
+		{"defaults", func(s *model.SearchSettings) {}, nil},
+		{"description only", func(s *model.SearchSettings) { s.NameBoost = 0 }, nil},
+		{"no text field", func(s *model.SearchSettings) { s.NameBoost, s.DescriptionBoost = 0, 0 }, ErrInvalidBoost},
+		{"negative", func(s *model.SearchSettings) { s.RatingBoost = -1 }, ErrInvalidBoost},
+		{"too large", func(s *model.SearchSettings) { s.PhraseBoost = maxSearchBoost + 1 }, ErrInvalidBoost},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := model.DefaultSearchSettings()
+			tt.change(s)
+			if err := validateBoosts(s); err != tt.want {
+				t.Errorf("validateBoosts = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeSynonyms(t *testing.T) {
+	tests := []struct {
+		name  string
+		rules []string
+		want  []string
+		err   error
+	}{
+		{
+			name:  "equivalent terms",
+			rules: []string{"  Tee ,T-Shirt", "sneakers,  running   shoes"},
+			want:  []string{"tee, t-shirt", "sneakers, running shoes"},
+		},
+		{
+			name:  "mapping",
+			rules: []string{"Notebook=>Laptop", "ipod, i-pod => ipod"},
+			want:  []string{"notebook => laptop", "ipod, i-pod => ipod"},
+		},
+		{
+			name:  "empty and duplicate rules are dropped",
+			rules: []string{"", "tee, t-shirt", "TEE,t-shirt", "  "},
+			want:  []string{"tee, t-shirt"},
+		},
+		{name: "single term", rules: []string{"tee"}, err: ErrInvalidSynonym},
+		{name: "empty term", rules: []string{"tee,,t-shirt"}, err: ErrInvalidSynonym},
+		{name: "chained mapping", rules: []string{"a => b => c"}, err: ErrInvalidSynonym},
+		{name: "comment", rules: []string{"tee, t-shirt # shirts"}, err: ErrInvalidSynonym},
+		{name: "new line", rules: []string{"tee, t-shirt\nnotebook => laptop"}, err: ErrInvalidSynonym},
+		{name: "too many", rules: make([]string, maxSynonyms+1), err: ErrTooManySynonyms},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := normalizeSynonyms(tt.rules)
+			if err != tt.err {
+				t.Fatalf("normalizeSynonyms error = %v, want %v", err, tt.err)
+			}
+			if err == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("normalizeSynonyms = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
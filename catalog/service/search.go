@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/segmentio/ksuid"
+	"github.com/wignn/micro-3/catalog/model"
+)
+
+const (
+	// searchEventTimeout bounds recording a search event after the search returned
+	searchEventTimeout = 5 * time.Second
+
+	maxSearchReportDays    = 90
+	defaultSearchReportTop = 10
+	maxSearchReportTop     = 100
+)
+
+var (
+	ErrMissingSearchClick  = errors.New("search id and product id are required")
+	ErrInvalidReportPeriod = errors.New("report period must end after it starts and span at most 90 days")
+)
+
+// NormalizeQuery lowercases a search query and splits it into terms on
+// anything that is not a letter or a digit, so "Red  Shoes!" and "red shoes"
+// are reported as the same query
+func NormalizeQuery(query string) (string, []string) {
+	terms := strings.FieldsFunc(strings.ToLower(query), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+	return strings.Join(terms, " "), terms
+}
+
+// SearchProducts searches the catalog and records the search for analytics,
+// the returned id identifies the search when a result is clicked
+func (s *catalogService) SearchProducts(c context.Context, query string, skip uint64, take uint64, includeUnpublished bool) ([]*model.Product, string, error) {
+	if skip > 100 || (skip == 100 && take > 100) {
+		take = 100
+	}
+	start := time.Now()
+	products, total, err := s.repository.SearchProducts(c, query, s.searchSettings(c), includeUnpublished, skip, take)
+	if err != nil {
+		return nil, "", err
+	}
+
+	normalized, terms := NormalizeQuery(query)
+	e := &model.SearchEvent{
+		ID:         ksuid.New().String(),
+		Query:      normalized,
+		Terms:      terms,
+		Results:    total,
+		LatencyMs:  float64(time.Since(start).Microseconds()) / 1000,
+		SearchedAt: start.UTC(),
+	}
+	go s.recordSearchEvent(e)
+	return products, e.ID, nil
+}
+
+// recordSearchEvent stores a search event without holding up the search, a
+// failure is logged and the event is lost
+func (s *catalogService) recordSearchEvent(e *model.SearchEvent) {
+	c, cancel := context.WithTimeout(context.Background(), searchEventTimeout)
+	defer cancel()
+	if err := s.repository.PutSearchEvent(c, e); err != nil {
+		log.Println("failed to record search event:", err)
+	}
+}
+
+func (s *catalogService) RecordSearchClick(c context.Context, searchID, productID string) error {
+	if searchID == "" || productID == "" {
+		return ErrMissingSearchClick
+	}
+	return s.repository.RecordSearchClick(c, searchID, productID, time.Now().UTC())
+}
+
+// GetSearchReports returns the daily search reports of [from, to), each
+// listing at most take queries
+func (s *catalogService) GetSearchReports(c context.Context, from, to time.Time, take int) ([]*model.SearchReport, error) {
+	if !to.After(from) || to.Sub(from) > maxSearchReportDays*24*time.Hour {
+		return nil, ErrInvalidReportPeriod
+	}
+	if take <= 0 {
+		take = defaultSearchReportTop
+	}
+	if take > maxSearchReportTop {
+		take = maxSearchReportTop
+	}
+	return s.repository.SearchReports(c, from, to, take)
+}
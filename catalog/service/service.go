@@ -1,43 +1,118 @@
 package service
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
 
 	"github.com/segmentio/ksuid"
+	"github.com/wignn/micro-3/blob"
+	"github.com/wignn/micro-3/catalog/images"
 	"github.com/wignn/micro-3/catalog/model"
 	"github.com/wignn/micro-3/catalog/repository"
+	"github.com/wignn/micro-3/currency"
 )
 
+var (
+	ErrTooManyImages     = errors.New("product already has the maximum number of images")
+	ErrImageNotFound     = errors.New("image not found")
+	ErrInvalidImageOrder = errors.New("image order must list every image of the product exactly once")
+	ErrInvalidWeight     = errors.New("weight must not be negative")
+)
+
+// ImageConfig limits product image uploads
+type ImageConfig struct {
+	MaxSize          int64
+	ThumbnailSize    int
+	MaxImagesPerItem int
+}
+
 type CatalogService interface {
-	PostProduct(c context.Context, name, description string, price float64, image string) (*model.Product, error)
+	PostProduct(c context.Context, name, description string, price float64, currency, image string, weightGrams int64, publication *model.Publication, actor string) (*model.Product, error)
 	GetProduct(c context.Context, id string) (*model.Product, error)
-	GetProducts(c context.Context, skip uint64, take uint64) ([]*model.Product, error)
+	GetProducts(c context.Context, skip uint64, take uint64, includeUnpublished bool) ([]*model.Product, error)
 	GetProductsByIDs(c context.Context, ids []string) ([]*model.Product, error)
-	SearchProducts(c context.Context, query string, skip uint64, take uint64) ([]*model.Product, error)
-	EditProduct(c context.Context, id, name, description string, price float64, image string) (*model.Product, error)
+	SearchProducts(c context.Context, query string, skip uint64, take uint64, includeUnpublished bool) ([]*model.Product, string, error)
+	RecordSearchClick(c context.Context, searchID, productID string) error
+	GetSearchReports(c context.Context, from, to time.Time, take int) ([]*model.SearchReport, error)
+	GetSearchSettings(c context.Context) (*model.SearchSettings, error)
+	UpdateSearchSettings(c context.Context, settings *model.SearchSettings, actor string) (*model.SearchSettings, error)
+	SetProductInStock(c context.Context, id string, inStock bool) error
+	AddProductRating(c context.Context, id string, rating int) error
+	GetSimilarProducts(c context.Context, id string, take uint64) ([]*model.SimilarProduct, error)
+	EditProduct(c context.Context, id string, u *model.ProductUpdate, actor string) (*model.Product, error)
 	DeleteProduct(c context.Context, id string) error
+	RestoreProduct(c context.Context, id string) (*model.Product, error)
+	PurgeDeletedProducts(c context.Context, retention time.Duration) (int64, error)
+	ApplyPublishSchedules(c context.Context, now time.Time) (int64, error)
+	ImportProducts(c context.Context, products []*model.Product, actor string) ([]error, error)
+	ExportProducts(c context.Context, includeDeleted bool, fn func(p *model.Product) error) error
+	AddProductImage(c context.Context, productID string, position int, data []byte) (*model.Product, error)
+	DeleteProductImage(c context.Context, productID, imageID string) (*model.Product, error)
+	ReorderProductImages(c context.Context, productID string, imageIDs []string) (*model.Product, error)
+	GetPriceHistory(c context.Context, productID string, skip uint64, take uint64) ([]*model.PriceChange, error)
+	SchedulePriceChange(c context.Context, productID string, price float64, startsAt time.Time, endsAt *time.Time, actor string) (*model.PriceSchedule, error)
+	CancelPriceSchedule(c context.Context, id, actor string) (*model.PriceSchedule, error)
+	ApplyDuePriceSchedules(c context.Context, now time.Time) (int, error)
 }
 
 type catalogService struct {
-	repository repository.CatalogRepository
+	repository      repository.CatalogRepository
+	blobs           blob.Store
+	images          ImageConfig
+	defaultCurrency string
+	settings        *settingsCache
 }
 
-func NewCatalogService(r repository.CatalogRepository) CatalogService {
-	return &catalogService{r}
+// NewCatalogService creates the service, products posted without a currency are priced in defaultCurrency
+func NewCatalogService(r repository.CatalogRepository, blobs blob.Store, images ImageConfig, defaultCurrency string) (CatalogService, error) {
+	code, err := currency.Normalize(defaultCurrency)
+	if err != nil {
+		return nil, err
+	}
+	return &catalogService{r, blobs, images, code, &settingsCache{}}, nil
 }
 
-func (s *catalogService) PostProduct(c context.Context, name, description string, price float64, image string) (*model.Product, error) {
+// normalizeCurrency validates a currency code and falls back to fallback when it is empty
+func normalizeCurrency(code, fallback string) (string, error) {
+	if code == "" {
+		return fallback, nil
+	}
+	return currency.Normalize(code)
+}
+
+func (s *catalogService) PostProduct(c context.Context, name, description string, price float64, code, image string, weightGrams int64, publication *model.Publication, actor string) (*model.Product, error) {
+	if weightGrams < 0 {
+		return nil, ErrInvalidWeight
+	}
+	code, err := normalizeCurrency(code, s.defaultCurrency)
+	if err != nil {
+		return nil, err
+	}
+	pub, err := normalizePublication(publication, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
 	p := &model.Product{
 		ID:          ksuid.New().String(),
 		Name:        name,
 		Description: description,
 		Price:       price,
+		Currency:    code,
 		Image:       image,
+		WeightGrams: weightGrams,
+		Status:      pub.Status,
+		PublishAt:   pub.PublishAt,
+		UnpublishAt: pub.UnpublishAt,
 	}
 
 	if err := s.repository.PutProduct(c, p); err != nil {
 		return nil, err
 	}
+	s.recordPriceChanges(c, newPriceChange(p, 0, actor, model.PriceChangeCreated))
 	return p, nil
 }
 
@@ -45,22 +120,25 @@ func (s *catalogService) GetProduct(c context.Context, id string) (*model.Produc
 	return s.repository.GetProductByID(c, id)
 }
 
-func (s *catalogService) GetProducts(c context.Context, skip uint64, take uint64) ([]*model.Product, error) {
+func (s *catalogService) GetProducts(c context.Context, skip uint64, take uint64, includeUnpublished bool) ([]*model.Product, error) {
 	if skip > 100 || (skip == 100 && take > 100) {
 		take = 100
 	}
-	return s.repository.ListProducts(c, skip, take)
+	return s.repository.ListProducts(c, skip, take, includeUnpublished)
 }
 
 func (s *catalogService) GetProductsByIDs(c context.Context, ids []string) ([]*model.Product, error) {
 	return s.repository.ListProductsWithIDs(c, ids)
 }
 
-func (s *catalogService) SearchProducts(c context.Context, query string, skip uint64, take uint64) ([]*model.Product, error) {
-	if skip > 100 || (skip == 100 && take > 100) {
+func (s *catalogService) GetSimilarProducts(c context.Context, id string, take uint64) ([]*model.SimilarProduct, error) {
+	if id == "" {
+		return nil, repository.ErrNotFound
+	}
+	if take == 0 || take > 100 {
 		take = 100
 	}
-	return s.repository.SearchProducts(c, query, skip, take)
+	return s.repository.SimilarProducts(c, id, take)
 }
 
 func (s *catalogService) DeleteProduct(c context.Context, id string) error {
@@ -70,9 +148,260 @@ func (s *catalogService) DeleteProduct(c context.Context, id string) error {
 	return s.repository.DeletedProduct(c, id)
 }
 
-func (s *catalogService) EditProduct(c context.Context, id, name, description string, price float64, image string) (*model.Product, error) {
+func (s *catalogService) RestoreProduct(c context.Context, id string) (*model.Product, error) {
 	if id == "" {
 		return nil, repository.ErrNotFound
 	}
-	return s.repository.EditProduct(c, id, name, description, price, image)
+	return s.repository.RestoreProduct(c, id)
+}
+
+// PurgeDeletedProducts permanently removes products that were soft deleted longer than retention ago
+func (s *catalogService) PurgeDeletedProducts(c context.Context, retention time.Duration) (int64, error) {
+	before := time.Now().UTC().Add(-retention)
+
+	// images are not part of the index, remove their blobs first
+	err := s.repository.ScrollPurgeableProducts(c, before, func(p *model.Product) error {
+		for _, img := range p.Images {
+			s.deleteBlobs(c, img)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return s.repository.PurgeDeletedProducts(c, before)
+}
+
+// EditProduct partially updates a product, see model.ProductUpdate
+func (s *catalogService) EditProduct(c context.Context, id string, u *model.ProductUpdate, actor string) (*model.Product, error) {
+	if id == "" {
+		return nil, repository.ErrNotFound
+	}
+	old, err := s.repository.GetProductByID(c, id)
+	if err != nil {
+		return nil, err
+	}
+	if u.Price != nil && *u.Price < 0 {
+		return nil, ErrInvalidPrice
+	}
+	if u.WeightGrams != nil && *u.WeightGrams < 0 {
+		return nil, ErrInvalidWeight
+	}
+	if u.Currency != nil {
+		code, err := normalizeCurrency(*u.Currency, old.Currency)
+		if err != nil {
+			return nil, err
+		}
+		u.Currency = &code
+	}
+	if u.Publication != nil {
+		u.Publication, err = normalizePublication(u.Publication, time.Now().UTC())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	p, err := s.repository.EditProduct(c, id, u)
+	if err != nil {
+		return nil, err
+	}
+	if old.Price != p.Price || old.Currency != p.Currency {
+		s.recordPriceChanges(c, newPriceChange(p, old.Price, actor, model.PriceChangeEdited))
+	}
+	return p, nil
+}
+
+// ImportProducts upserts a batch of products, products without an ID are created.
+// Products without a currency keep their current one or get the default currency.
+func (s *catalogService) ImportProducts(c context.Context, products []*model.Product, actor string) ([]error, error) {
+	ids := []string{}
+	for _, p := range products {
+		if p.ID == "" {
+			p.ID = ksuid.New().String()
+		} else {
+			ids = append(ids, p.ID)
+		}
+	}
+
+	existing := map[string]*model.Product{}
+	if len(ids) > 0 {
+		found, err := s.repository.ListProductsWithIDs(c, ids)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range found {
+			existing[p.ID] = p
+		}
+	}
+	// fields a row leaves empty keep their current value, new products are
+	// drafts in the default currency
+	for _, p := range products {
+		old, ok := existing[p.ID]
+		if !ok {
+			old = &model.Product{Currency: s.defaultCurrency, Status: model.ProductDraft}
+		}
+		if p.Currency == "" {
+			p.Currency = old.Currency
+		}
+		if p.Status == "" {
+			p.Status = old.Status
+		}
+		if p.WeightGrams == 0 {
+			p.WeightGrams = old.WeightGrams
+		}
+	}
+
+	errs, err := s.repository.BulkPutProducts(c, products)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := []*model.PriceChange{}
+	for i, p := range products {
+		if errs[i] != nil {
+			continue
+		}
+		old, ok := existing[p.ID]
+		if ok && old.Price == p.Price && old.Currency == p.Currency {
+			continue
+		}
+		var oldPrice float64
+		if ok {
+			oldPrice = old.Price
+		}
+		changes = append(changes, newPriceChange(p, oldPrice, actor, model.PriceChangeImported))
+	}
+	s.recordPriceChanges(c, changes...)
+	return errs, nil
+}
+
+func (s *catalogService) ExportProducts(c context.Context, includeDeleted bool, fn func(p *model.Product) error) error {
+	return s.repository.ScrollProducts(c, includeDeleted, fn)
+}
+
+// AddProductImage validates and stores an uploaded image and its thumbnail and
+// inserts it at position, a negative or out of range position appends it
+func (s *catalogService) AddProductImage(c context.Context, productID string, position int, data []byte) (*model.Product, error) {
+	p, err := s.repository.GetProductByID(c, productID)
+	if err != nil {
+		return nil, err
+	}
+	if len(p.Images) >= s.images.MaxImagesPerItem {
+		return nil, ErrTooManyImages
+	}
+
+	img, err := images.Process(data, s.images.MaxSize, s.images.ThumbnailSize)
+	if err != nil {
+		return nil, err
+	}
+
+	id := ksuid.New().String()
+	productImage := model.ProductImage{
+		ID:           id,
+		ContentType:  img.ContentType,
+		Size:         int64(len(img.Data)),
+		Width:        img.Width,
+		Height:       img.Height,
+		Key:          fmt.Sprintf("products/%s/%s.%s", productID, id, img.Extension),
+		ThumbnailKey: fmt.Sprintf("products/%s/%s_thumb.%s", productID, id, img.ThumbnailExtension),
+	}
+	if productImage.URL, err = s.blobs.Put(c, productImage.Key, bytes.NewReader(img.Data), img.ContentType); err != nil {
+		return nil, err
+	}
+	if productImage.ThumbnailURL, err = s.blobs.Put(c, productImage.ThumbnailKey, bytes.NewReader(img.Thumbnail), img.ThumbnailContentType); err != nil {
+		s.deleteBlobs(c, productImage)
+		return nil, err
+	}
+
+	list := append([]model.ProductImage{}, p.Images...)
+	if position < 0 || position > len(list) {
+		position = len(list)
+	}
+	list = append(list[:position], append([]model.ProductImage{productImage}, list[position:]...)...)
+
+	updated, err := s.saveImages(c, p, list)
+	if err != nil {
+		s.deleteBlobs(c, productImage)
+		return nil, err
+	}
+	return updated, nil
+}
+
+func (s *catalogService) DeleteProductImage(c context.Context, productID, imageID string) (*model.Product, error) {
+	p, err := s.repository.GetProductByID(c, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	var removed *model.ProductImage
+	list := []model.ProductImage{}
+	for i := range p.Images {
+		if p.Images[i].ID == imageID {
+			removed = &p.Images[i]
+			continue
+		}
+		list = append(list, p.Images[i])
+	}
+	if removed == nil {
+		return nil, ErrImageNotFound
+	}
+
+	updated, err := s.saveImages(c, p, list)
+	if err != nil {
+		return nil, err
+	}
+	s.deleteBlobs(c, *removed)
+	return updated, nil
+}
+
+func (s *catalogService) ReorderProductImages(c context.Context, productID string, imageIDs []string) (*model.Product, error) {
+	p, err := s.repository.GetProductByID(c, productID)
+	if err != nil {
+		return nil, err
+	}
+	if len(imageIDs) != len(p.Images) {
+		return nil, ErrInvalidImageOrder
+	}
+
+	byID := map[string]model.ProductImage{}
+	for _, img := range p.Images {
+		byID[img.ID] = img
+	}
+	list := []model.ProductImage{}
+	for _, id := range imageIDs {
+		img, ok := byID[id]
+		if !ok {
+			return nil, ErrInvalidImageOrder
+		}
+		delete(byID, id)
+		list = append(list, img)
+	}
+
+	return s.saveImages(c, p, list)
+}
+
+// saveImages renumbers the images and keeps the primary image in sync with the first one
+func (s *catalogService) saveImages(c context.Context, p *model.Product, list []model.ProductImage) (*model.Product, error) {
+	for i := range list {
+		list[i].Position = i
+	}
+
+	primary := p.Image
+	if len(list) > 0 {
+		primary = list[0].URL
+	} else if len(p.Images) > 0 && p.Image == p.Images[0].URL {
+		primary = ""
+	}
+	return s.repository.SetProductImages(c, p.ID, list, primary)
+}
+
+func (s *catalogService) deleteBlobs(c context.Context, img model.ProductImage) {
+	for _, key := range []string{img.Key, img.ThumbnailKey} {
+		if key == "" {
+			continue
+		}
+		if err := s.blobs.Delete(c, key); err != nil {
+			log.Printf("failed to delete image blob %s: %v\n", key, err)
+		}
+	}
 }
@@ -0,0 +1,62 @@
+// Package currency holds ISO 4217 currency codes, exchange rates and the
+// rounding rules shared by the services that deal with prices.
+//
+// Rounding rules: an amount is converted with full float64 precision and then
+// rounded once, half to even, to the minor unit of the target currency (two
+// decimals for USD, none for JPY). When converting an order, every unit price
+// is converted and rounded first and the total is the sum of the rounded line
+// amounts, so displayed lines always add up to the displayed total.
+//
+// Stored amounts are Money, an integer number of minor units, so that sums,
+// quantities, taxes and discounts are computed exactly.
+package currency
+
+import (
+	"errors"
+	"math"
+	"strings"
+)
+
+var ErrUnknownCurrency = errors.New("unknown currency code")
+
+// minorUnits is the number of decimals of each supported currency
+var minorUnits = map[string]int{
+	"AUD": 2,
+	"CAD": 2,
+	"CHF": 2,
+	"CNY": 2,
+	"EUR": 2,
+	"GBP": 2,
+	"IDR": 2,
+	"JPY": 0,
+	"KRW": 0,
+	"MYR": 2,
+	"PHP": 2,
+	"SGD": 2,
+	"THB": 2,
+	"USD": 2,
+	"VND": 0,
+}
+
+// Normalize upper cases a currency code and checks that it is supported
+func Normalize(code string) (string, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if _, ok := minorUnits[code]; !ok {
+		return "", ErrUnknownCurrency
+	}
+	return code, nil
+}
+
+// MinorUnits returns the number of decimals of a currency, 2 for unknown codes
+func MinorUnits(code string) int {
+	if d, ok := minorUnits[code]; ok {
+		return d
+	}
+	return 2
+}
+
+// Round rounds amount half to even to the minor unit of the currency
+func Round(amount float64, code string) float64 {
+	scale := math.Pow10(MinorUnits(code))
+	return math.RoundToEven(amount*scale) / scale
+}
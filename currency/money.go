@@ -0,0 +1,138 @@
+package currency
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrCurrencyMismatch = errors.New("amounts are in different currencies")
+	ErrInvalidAmount    = errors.New("amount must be a decimal number with at most the minor digits of its currency")
+)
+
+// Money is an exact amount in the minor unit of its currency, 1234 USD is
+// $12.34 and 1234 JPY is ¥1234. Arithmetic on Money never goes through
+// float64, only converting from or to a float rounds.
+type Money struct {
+	Amount   int64
+	Currency string
+}
+
+// ParseMoney reads a decimal amount in major units such as "12.34", it is
+// rejected rather than rounded when it has more decimals than the currency
+func ParseMoney(amount, code string) (Money, error) {
+	code, err := Normalize(code)
+	if err != nil {
+		return Money{}, err
+	}
+	amount = strings.TrimSpace(amount)
+	sign := int64(1)
+	if strings.HasPrefix(amount, "-") {
+		sign, amount = -1, amount[1:]
+	}
+	whole, frac, _ := strings.Cut(amount, ".")
+	digits := MinorUnits(code)
+	if whole == "" || len(frac) > digits || strings.ContainsAny(whole+frac, "+-") {
+		return Money{}, ErrInvalidAmount
+	}
+	frac += strings.Repeat("0", digits-len(frac))
+	n, err := strconv.ParseInt(whole+frac, 10, 64)
+	if err != nil {
+		return Money{}, ErrInvalidAmount
+	}
+	return Money{Amount: sign * n, Currency: code}, nil
+}
+
+// FromFloat rounds amount half to even to the minor unit of the currency
+func FromFloat(amount float64, code string) Money {
+	scale := math.Pow10(MinorUnits(code))
+	return Money{Amount: int64(math.RoundToEven(amount * scale)), Currency: code}
+}
+
+// Float returns the amount in major units, it is meant for display and for
+// APIs that still take floats
+func (m Money) Float() float64 {
+	return float64(m.Amount) / math.Pow10(MinorUnits(m.Currency))
+}
+
+// Mul multiplies the amount by a quantity, the result is exact
+func (m Money) Mul(n int64) Money {
+	return Money{Amount: m.Amount * n, Currency: m.Currency}
+}
+
+func (m Money) Add(o Money) (Money, error) {
+	if m.Currency != o.Currency {
+		return Money{}, ErrCurrencyMismatch
+	}
+	return Money{Amount: m.Amount + o.Amount, Currency: m.Currency}, nil
+}
+
+func (m Money) Sub(o Money) (Money, error) {
+	if m.Currency != o.Currency {
+		return Money{}, ErrCurrencyMismatch
+	}
+	return Money{Amount: m.Amount - o.Amount, Currency: m.Currency}, nil
+}
+
+// MulRatio multiplies the amount by num/den and rounds half to even to the
+// minor unit, taxes and discounts are applied with it: 11% tax is
+// MulRatio(11, 100) and a 12.5% discount is MulRatio(125, 1000)
+func (m Money) MulRatio(num, den int64) Money {
+	if den == 0 {
+		panic("currency: MulRatio with a zero denominator")
+	}
+	r := new(big.Rat).SetFrac(
+		new(big.Int).Mul(big.NewInt(m.Amount), big.NewInt(num)),
+		big.NewInt(den),
+	)
+	return Money{Amount: roundHalfEven(r), Currency: m.Currency}
+}
+
+func roundHalfEven(r *big.Rat) int64 {
+	q, rem := new(big.Int).QuoRem(r.Num(), r.Denom(), new(big.Int))
+	// twice the remainder against the denominator tells below, at or above half
+	twice := new(big.Int).Abs(rem)
+	twice.Lsh(twice, 1)
+	cmp := twice.Cmp(r.Denom())
+	if cmp > 0 || (cmp == 0 && q.Bit(0) == 1) {
+		if rem.Sign() < 0 {
+			q.Sub(q, big.NewInt(1))
+		} else {
+			q.Add(q, big.NewInt(1))
+		}
+	}
+	return q.Int64()
+}
+
+// Exchange converts an amount at rate and rounds it to the minor unit of to
+func Exchange(m Money, rate float64, to string) Money {
+	if m.Currency == to {
+		return m
+	}
+	return FromFloat(m.Float()*rate, to)
+}
+
+// Decimal formats the amount in major units with all its minor digits, 1234
+// USD is "12.34", it is exact unlike Float
+func (m Money) Decimal() string {
+	digits := MinorUnits(m.Currency)
+	s := strconv.FormatInt(m.Amount, 10)
+	if digits == 0 {
+		return s
+	}
+	sign := ""
+	if strings.HasPrefix(s, "-") {
+		sign, s = "-", s[1:]
+	}
+	if len(s) <= digits {
+		s = strings.Repeat("0", digits-len(s)+1) + s
+	}
+	return sign + s[:len(s)-digits] + "." + s[len(s)-digits:]
+}
+
+func (m Money) String() string {
+	return m.Decimal() + " " + m.Currency
+}
@@ -0,0 +1,168 @@
+package currency
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+var ErrNoRate = errors.New("no exchange rate for currency")
+
+// Table holds the price of one unit of Base in every other currency
+type Table struct {
+	Base      string             `json:"base"`
+	Rates     map[string]float64 `json:"rates"`
+	UpdatedAt time.Time          `json:"updated_at"`
+}
+
+// Rate returns how many units of to one unit of from is worth
+func (t *Table) Rate(from, to string) (float64, error) {
+	if from == to {
+		return 1, nil
+	}
+	fromRate, err := t.baseRate(from)
+	if err != nil {
+		return 0, err
+	}
+	toRate, err := t.baseRate(to)
+	if err != nil {
+		return 0, err
+	}
+	return toRate / fromRate, nil
+}
+
+func (t *Table) baseRate(code string) (float64, error) {
+	if code == t.Base {
+		return 1, nil
+	}
+	rate, ok := t.Rates[code]
+	if !ok || rate <= 0 {
+		return 0, fmt.Errorf("%w %s", ErrNoRate, code)
+	}
+	return rate, nil
+}
+
+// Provider supplies the current exchange rates
+type Provider interface {
+	Rates(c context.Context) (*Table, error)
+}
+
+//go:embed rates.json
+var defaultRates []byte
+
+// StaticFileProvider reads the rates from a JSON file and reloads it when the
+// file changes, without a path it serves the rates bundled with the package.
+// It is meant for local development, production should use a live provider.
+type StaticFileProvider struct {
+	path    string
+	mu      sync.Mutex
+	table   *Table
+	modTime time.Time
+}
+
+func NewStaticFileProvider(path string) (*StaticFileProvider, error) {
+	p := &StaticFileProvider{path: path}
+	if _, err := p.Rates(context.Background()); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (p *StaticFileProvider) Rates(c context.Context) (*Table, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.path == "" {
+		if p.table == nil {
+			table, err := parseTable(defaultRates)
+			if err != nil {
+				return nil, err
+			}
+			p.table = table
+		}
+		return p.table, nil
+	}
+
+	info, err := os.Stat(p.path)
+	if err != nil {
+		if p.table != nil {
+			// keep serving the last good rates
+			return p.table, nil
+		}
+		return nil, err
+	}
+	if p.table != nil && !info.ModTime().After(p.modTime) {
+		return p.table, nil
+	}
+
+	data, err := os.ReadFile(p.path)
+	if err != nil {
+		return nil, err
+	}
+	table, err := parseTable(data)
+	if err != nil {
+		if p.table != nil {
+			return p.table, nil
+		}
+		return nil, err
+	}
+	p.table, p.modTime = table, info.ModTime()
+	return table, nil
+}
+
+func parseTable(data []byte) (*Table, error) {
+	table := &Table{}
+	if err := json.Unmarshal(data, table); err != nil {
+		return nil, fmt.Errorf("invalid exchange rate table: %w", err)
+	}
+	base, err := Normalize(table.Base)
+	if err != nil {
+		return nil, err
+	}
+	table.Base = base
+	return table, nil
+}
+
+// Converter converts amounts between currencies using the rates of a provider
+type Converter struct {
+	provider Provider
+}
+
+func NewConverter(p Provider) *Converter {
+	return &Converter{p}
+}
+
+// Rate returns the exchange rate between two currencies
+func (cv *Converter) Rate(c context.Context, from, to string) (float64, error) {
+	if from == to {
+		return 1, nil
+	}
+	table, err := cv.provider.Rates(c)
+	if err != nil {
+		return 0, err
+	}
+	return table.Rate(from, to)
+}
+
+// Convert converts amount and rounds it to the minor unit of to
+func (cv *Converter) Convert(c context.Context, amount float64, from, to string) (float64, error) {
+	rate, err := cv.Rate(c, from, to)
+	if err != nil {
+		return 0, err
+	}
+	return Round(amount*rate, to), nil
+}
+
+// ConvertMoney converts an amount and rounds it to the minor unit of to
+func (cv *Converter) ConvertMoney(c context.Context, m Money, to string) (Money, error) {
+	rate, err := cv.Rate(c, m.Currency, to)
+	if err != nil {
+		return Money{}, err
+	}
+	return Exchange(m, rate, to), nil
+}
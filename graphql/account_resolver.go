@@ -10,36 +10,53 @@ type accountResolver struct {
 	server *GraphQLServer
 }
 
-func (r *accountResolver) Orders(c context.Context, o *Account) ([]*Order, error) {
+func (r *accountResolver) Orders(c context.Context, o *Account, currency *string) ([]*Order, error) {
 	c, cancel := context.WithTimeout(c, 3*time.Second)
 	defer cancel()
 
+	display, err := displayCurrency(currency)
+	if err != nil {
+		return nil, err
+	}
+
 	orderList, err := r.server.orderClient.GetOrdersForAccount(c, o.ID)
 	if err != nil {
 		log.Println(err)
 		return nil, err
 	}
 
-	var orders []*Order
-	for _, o := range orderList {
-		var products []*OrderedProduct
-		for _, p := range o.Products {
-			products = append(products, &OrderedProduct{
-				ID:          p.ID,
-				Name:        p.Name,
-				Description: p.Description,
-				Price:       p.Price,
-				Quantity:    int(p.Quantity),
-			})
+	orders := []*Order{}
+	for i := range orderList {
+		o := &orderList[i]
+		if err := r.server.convertOrder(c, o, display); err != nil {
+			log.Println(err)
+			return nil, err
 		}
-		orders = append(orders, &Order{
-			ID:         o.ID,
-			CreatedAt:  o.CreatedAt,
-			TotalPrice: o.TotalPrice,
-			Products:   products,
-			
-		})
+		orders = append(orders, orderFromModel(o))
 	}
 
 	return orders, nil
 }
+
+func (r *accountResolver) RecommendedProducts(c context.Context, o *Account, limit *int) ([]*Product, error) {
+	c, cancel := context.WithTimeout(c, 3*time.Second)
+	defer cancel()
+
+	take, err := recommendationLimit(limit)
+	if err != nil {
+		return nil, err
+	}
+
+	recs, err := r.server.recommendationClient.GetRecommendationsForAccount(c, o.ID, take)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	products, err := r.server.recommendedProducts(c, recs)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return products, nil
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"net/http"
+)
+
+// adminKeyHeader carries the admin API key of back office requests
+const adminKeyHeader = "X-Admin-Key"
+
+var ErrForbidden = errors.New("admin access required")
+
+type adminContextKey struct{}
+
+// withAdmin marks requests carrying the admin API key as admin requests, every
+// request is a regular one when no key is configured
+func withAdmin(key string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		given := r.Header.Get(adminKeyHeader)
+		if key != "" && subtle.ConstantTimeCompare([]byte(given), []byte(key)) == 1 {
+			r = r.WithContext(context.WithValue(r.Context(), adminContextKey{}, true))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func isAdmin(c context.Context) bool {
+	admin, _ := c.Value(adminContextKey{}).(bool)
+	return admin
+}
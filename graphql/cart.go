@@ -0,0 +1,147 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"math"
+	"time"
+
+	cartModel "github.com/wignn/micro-3/cart/model"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var ErrCartNotFound = errors.New("cart not found")
+
+// cartRef selects the account's cart when accountId is given and the
+// anonymous cart cartId otherwise
+func cartRef(cartID, accountID *string) cartModel.Ref {
+	return cartModel.Ref{ID: valueOrEmpty(cartID), AccountID: valueOrEmpty(accountID)}
+}
+
+func cartFromModel(cart *cartModel.Cart) *Cart {
+	lines := []*CartLine{}
+	for _, l := range cart.Lines {
+		lines = append(lines, &CartLine{
+			ProductID:    l.ProductID,
+			Name:         l.Name,
+			Quantity:     int(l.Quantity),
+			UnitPrice:    moneyFromModel(l.UnitPrice),
+			LineTotal:    moneyFromModel(l.Total()),
+			Available:    l.Available,
+			PriceChanged: l.PriceChanged,
+		})
+	}
+	res := &Cart{
+		ID:        cart.ID,
+		Currency:  cart.Currency,
+		Lines:     lines,
+		Subtotal:  moneyFromModel(cart.Subtotal()),
+		UpdatedAt: cart.UpdatedAt,
+	}
+	if cart.AccountID != "" {
+		res.AccountID = &cart.AccountID
+	}
+	return res
+}
+
+func cartError(op string, err error) error {
+	if status.Code(err) == codes.NotFound {
+		return ErrCartNotFound
+	}
+	return handleError(op, err)
+}
+
+// Cart returns null when the account or the anonymous cart has no cart
+func (r *queryResolver) Cart(c context.Context, id *string, accountID *string) (*Cart, error) {
+	c, cancel := context.WithTimeout(c, 3*time.Second)
+	defer cancel()
+
+	cart, err := r.server.cartClient.GetCart(c, cartRef(id, accountID))
+	if status.Code(err) == codes.NotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, handleError("Cart", err)
+	}
+	return cartFromModel(cart), nil
+}
+
+// AddToCart adds one unit unless quantity is given, without cartId and
+// accountId a new anonymous cart is created
+func (r *mutationResolver) AddToCart(c context.Context, cartID *string, accountID *string, productID string, quantity *int) (*Cart, error) {
+	c, cancel := context.WithTimeout(c, 3*time.Second)
+	defer cancel()
+
+	n := 1
+	if quantity != nil {
+		n = *quantity
+	}
+	if n <= 0 || n > math.MaxUint32 {
+		return nil, ErrInvalidParameter
+	}
+	cart, err := r.server.cartClient.AddLine(c, cartRef(cartID, accountID), productID, uint32(n))
+	if err != nil {
+		return nil, cartError("AddToCart", err)
+	}
+	return cartFromModel(cart), nil
+}
+
+// UpdateCartLine sets the quantity of a product, zero removes it
+func (r *mutationResolver) UpdateCartLine(c context.Context, cartID *string, accountID *string, productID string, quantity int) (*Cart, error) {
+	c, cancel := context.WithTimeout(c, 3*time.Second)
+	defer cancel()
+
+	if quantity < 0 || quantity > math.MaxUint32 {
+		return nil, ErrInvalidParameter
+	}
+	cart, err := r.server.cartClient.UpdateLine(c, cartRef(cartID, accountID), productID, uint32(quantity))
+	if err != nil {
+		return nil, cartError("UpdateCartLine", err)
+	}
+	return cartFromModel(cart), nil
+}
+
+func (r *mutationResolver) RemoveFromCart(c context.Context, cartID *string, accountID *string, productID string) (*Cart, error) {
+	c, cancel := context.WithTimeout(c, 3*time.Second)
+	defer cancel()
+
+	cart, err := r.server.cartClient.RemoveLine(c, cartRef(cartID, accountID), productID)
+	if err != nil {
+		return nil, cartError("RemoveFromCart", err)
+	}
+	return cartFromModel(cart), nil
+}
+
+// MergeCart moves an anonymous cart into the account's cart, login does the
+// same when it is given the cart id
+func (r *mutationResolver) MergeCart(c context.Context, cartID string, accountID string) (*Cart, error) {
+	c, cancel := context.WithTimeout(c, 3*time.Second)
+	defer cancel()
+
+	cart, err := r.server.cartClient.MergeCarts(c, cartID, accountID)
+	if err != nil {
+		return nil, cartError("MergeCart", err)
+	}
+	return cartFromModel(cart), nil
+}
+
+// CheckoutCart places an order with the lines of an account's cart, line,
+// coupon and address violations are reported like createOrder reports them
+func (r *mutationResolver) CheckoutCart(c context.Context, cartID *string, accountID *string, idempotencyKey *string, coupons []string, shippingAddress *AddressInput) (*Order, error) {
+	c, cancel := context.WithTimeout(c, 3*time.Second)
+	defer cancel()
+
+	orderID, _, err := r.server.cartClient.Checkout(c, cartRef(cartID, accountID), coupons, addressFromInput(shippingAddress), valueOrEmpty(idempotencyKey))
+	if status.Code(err) == codes.NotFound {
+		return nil, ErrCartNotFound
+	}
+	if err != nil {
+		return nil, orderError("CheckoutCart", err)
+	}
+	o, err := r.server.orderClient.GetOrder(c, orderID)
+	if err != nil {
+		return nil, handleError("CheckoutCart.GetOrder", err)
+	}
+	return orderFromModel(o), nil
+}
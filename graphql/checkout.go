@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	orderModel "github.com/wignn/micro-3/order/model"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var ErrCheckoutNotFound = errors.New("checkout not found")
+
+func checkoutFromModel(co *orderModel.Checkout) *Checkout {
+	steps := []*CheckoutStep{}
+	for _, step := range co.Steps {
+		s := &CheckoutStep{
+			Name:      step.Name,
+			Status:    step.Status,
+			UpdatedAt: step.UpdatedAt,
+		}
+		if step.Error != "" {
+			s.Error = &step.Error
+		}
+		steps = append(steps, s)
+	}
+	res := &Checkout{
+		ID:        co.ID,
+		OrderID:   co.OrderID,
+		Status:    co.Status,
+		Steps:     steps,
+		CreatedAt: co.CreatedAt,
+		UpdatedAt: co.UpdatedAt,
+	}
+	if co.Error != "" {
+		res.Error = &co.Error
+	}
+	if co.PaymentIntentID != "" {
+		res.PaymentIntentID = &co.PaymentIntentID
+	}
+	return res
+}
+
+// Checkout places an order and authorizes its payment, a checkout waiting
+// for the payment provider is followed with checkoutStatus
+func (r *mutationResolver) Checkout(c context.Context, in OrderInput, paymentMethod string, idempotencyKey *string) (*Checkout, error) {
+	c, cancel := context.WithTimeout(c, 3*time.Second)
+	defer cancel()
+
+	var products []*orderModel.OrderedProduct
+	for i, p := range in.Products {
+		if p.Quantity < 0 || p.Quantity > math.MaxUint32 {
+			return nil, invalidOrderError("invalid order: quantity can't be negative", fmt.Sprintf("products[%d].quantity", i), "quantity can't be negative")
+		}
+		products = append(products, &orderModel.OrderedProduct{
+			ID:       p.ID,
+			Quantity: uint32(p.Quantity),
+		})
+	}
+
+	co, err := r.server.orderClient.Checkout(c, in.AccountID, valueOrEmpty(in.Currency), products, in.Coupons, addressFromInput(in.ShippingAddress), paymentMethod, valueOrEmpty(idempotencyKey))
+	if err != nil {
+		return nil, orderError("Checkout", err)
+	}
+	return checkoutFromModel(co), nil
+}
+
+func (r *queryResolver) CheckoutStatus(c context.Context, id string) (*Checkout, error) {
+	c, cancel := context.WithTimeout(c, 3*time.Second)
+	defer cancel()
+
+	co, err := r.server.orderClient.GetCheckoutStatus(c, id)
+	if status.Code(err) == codes.NotFound {
+		return nil, ErrCheckoutNotFound
+	}
+	if err != nil {
+		return nil, handleError("CheckoutStatus", err)
+	}
+	return checkoutFromModel(co), nil
+}
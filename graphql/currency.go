@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+
+	"github.com/wignn/micro-3/currency"
+	orderModel "github.com/wignn/micro-3/order/model"
+)
+
+// displayCurrency validates the currency requested for display, an empty
+// code keeps prices in the currency they are stored in
+func displayCurrency(code *string) (string, error) {
+	if code == nil || *code == "" {
+		return "", nil
+	}
+	return currency.Normalize(*code)
+}
+
+// convertProducts converts product prices to the display currency
+func (s *GraphQLServer) convertProducts(c context.Context, products []*Product, to string) error {
+	if to == "" {
+		return nil
+	}
+	for _, p := range products {
+		price, err := s.converter.Convert(c, p.Price, p.Currency, to)
+		if err != nil {
+			return err
+		}
+		p.Price, p.Currency = price, to
+	}
+	return nil
+}
+
+// convertOrder converts the line prices, discounts, taxes and shipping of an
+// order to the display currency, the tax and the total are recomputed from
+// the rounded amounts so that they add up
+func (s *GraphQLServer) convertOrder(c context.Context, o *orderModel.Order, to string) error {
+	if to == "" || to == o.Currency {
+		return nil
+	}
+	rate, err := s.converter.Rate(c, o.Currency, to)
+	if err != nil {
+		return err
+	}
+	o.Currency = to
+	o.Shipping = currency.Exchange(o.Shipping, rate, to)
+	o.ShippingTax = currency.Exchange(o.ShippingTax, rate, to)
+	o.Tax = o.ShippingTax
+	total := o.ShippingCharge()
+	for i := range o.Products {
+		p := &o.Products[i]
+		p.Price = currency.Exchange(p.Price, rate, to)
+		p.Tax = currency.Exchange(p.Tax, rate, to)
+		for j := range p.Discounts {
+			p.Discounts[j].Amount = currency.Exchange(p.Discounts[j].Amount, rate, to)
+		}
+		o.Tax.Amount += p.Tax.Amount
+		if total, err = total.Add(o.LineCharge(*p)); err != nil {
+			return err
+		}
+	}
+	o.TotalPrice = total
+	return nil
+}
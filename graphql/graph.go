@@ -4,20 +4,28 @@ import (
 	"github.com/99designs/gqlgen/graphql"
 	account "github.com/wignn/micro-3/account/client"
 	auth "github.com/wignn/micro-3/auth/client"
+	cart "github.com/wignn/micro-3/cart/client"
 	catalog "github.com/wignn/micro-3/catalog/client"
+	"github.com/wignn/micro-3/currency"
+	inventory "github.com/wignn/micro-3/inventory/client"
 	order "github.com/wignn/micro-3/order/client"
+	recommendation "github.com/wignn/micro-3/recommendation/client"
 	review "github.com/wignn/micro-3/review/client"
 )
 
 type GraphQLServer struct {
-	accountClient *account.AccountClient
-	catalogClient *catalog.CatalogClient
-	orderClient   *order.OrderClient
-	authClient    *auth.AuthClient
-	reviewClient  *review.ReviewClient
+	accountClient        *account.AccountClient
+	catalogClient        *catalog.CatalogClient
+	orderClient          *order.OrderClient
+	authClient           *auth.AuthClient
+	cartClient           *cart.CartClient
+	reviewClient         *review.ReviewClient
+	inventoryClient      *inventory.InventoryClient
+	recommendationClient *recommendation.RecommendationClient
+	converter            *currency.Converter
 }
 
-func NewGraphQLServer(accountUrl, catalogUrl, orderUrl, reviewUrl, authUrl, cartUrl string) (*GraphQLServer, error) {
+func NewGraphQLServer(accountUrl, catalogUrl, orderUrl, reviewUrl, authUrl, cartUrl, inventoryUrl, recommendationUrl, ratesFile string) (*GraphQLServer, error) {
 	accoutClient, err := account.NewClient(accountUrl)
 	if err != nil {
 		return nil, err
@@ -47,13 +55,40 @@ func NewGraphQLServer(accountUrl, catalogUrl, orderUrl, reviewUrl, authUrl, cart
 		return nil, err
 	}
 
+	cartClient, err := cart.NewClient(cartUrl)
+	if err != nil {
+		authClient.Close()
+		return nil, err
+	}
+
+	inventoryClient, err := inventory.NewClient(inventoryUrl)
+	if err != nil {
+		cartClient.Close()
+		return nil, err
+	}
+
+	recommendationClient, err := recommendation.NewClient(recommendationUrl)
+	if err != nil {
+		inventoryClient.Close()
+		return nil, err
+	}
+
+	rates, err := currency.NewStaticFileProvider(ratesFile)
+	if err != nil {
+		recommendationClient.Close()
+		return nil, err
+	}
 
 	return &GraphQLServer{
 		accoutClient,
 		catalogClient,
 		orderClient,
 		authClient,
+		cartClient,
 		reviewClient,
+		inventoryClient,
+		recommendationClient,
+		currency.NewConverter(rates),
 	}, nil
 }
 
@@ -75,6 +110,18 @@ func (s *GraphQLServer) Account() AccountResolver {
 	}
 }
 
+func (s *GraphQLServer) Order() OrderResolver {
+	return &orderResolver{
+		server: s,
+	}
+}
+
+func (s *GraphQLServer) Product() ProductResolver {
+	return &productResolver{
+		server: s,
+	}
+}
+
 func (s *GraphQLServer) ToExecutableSchema() (graphql.ExecutableSchema, error) {
 	return NewExecutableSchema(Config{
 		Resolvers: s,
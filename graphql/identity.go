@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var ErrNotOwner = errors.New("order belongs to another account")
+
+type identityContextKey struct{}
+
+// withIdentity marks requests carrying a valid access token of the auth
+// service with the email it was issued to, requests without one are
+// anonymous and every request is when no secret is configured
+func withIdentity(secret string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if secret != "" && ok {
+			if email, err := parseAccessToken(secret, token); err == nil {
+				r = r.WithContext(context.WithValue(r.Context(), identityContextKey{}, email))
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// parseAccessToken checks an access token issued by the auth service and
+// returns its email
+func parseAccessToken(secret, token string) (string, error) {
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(token, claims, func(t *jwt.Token) (interface{}, error) {
+		return []byte(secret), nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithExpirationRequired())
+	if err != nil {
+		return "", err
+	}
+	email, _ := claims["email"].(string)
+	if email == "" {
+		return "", errors.New("access token has no email")
+	}
+	return email, nil
+}
+
+// callerEmail is the email of the account that signed the request in, empty
+// for anonymous requests
+func callerEmail(c context.Context) string {
+	email, _ := c.Value(identityContextKey{}).(string)
+	return email
+}
+
+// ownsAccount tells whether the caller is signed in as the account
+func (s *GraphQLServer) ownsAccount(c context.Context, accountID string) (bool, error) {
+	email := callerEmail(c)
+	if email == "" {
+		return false, nil
+	}
+	a, err := s.accountClient.GetAccount(c, accountID)
+	if err != nil {
+		return false, err
+	}
+	return strings.EqualFold(a.Email, email), nil
+}
+
+// requestActor names who made a change in audit trails: the email of the
+// signed in account, "admin" for the admin key, empty for anonymous requests
+func requestActor(c context.Context) string {
+	if email := callerEmail(c); email != "" {
+		return email
+	}
+	if isAdmin(c) {
+		return "admin"
+	}
+	return ""
+}
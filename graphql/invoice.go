@@ -0,0 +1,29 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	orderModel "github.com/wignn/micro-3/order/model"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// InvoiceURL returns the PDF invoice of a paid order, null until the order
+// service has issued it
+func (r *orderResolver) InvoiceURL(c context.Context, o *Order) (*string, error) {
+	if !orderModel.Invoiceable(o.Status) {
+		return nil, nil
+	}
+	c, cancel := context.WithTimeout(c, 3*time.Second)
+	defer cancel()
+
+	inv, err := r.server.orderClient.GetInvoice(c, o.ID)
+	if status.Code(err) == codes.NotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, handleError("InvoiceURL", err)
+	}
+	return &inv.PDFURL, nil
+}
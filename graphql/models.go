@@ -1,10 +1,20 @@
 package main
+
+import (
+	"time"
+
+	catalog "github.com/wignn/micro-3/catalog/genproto"
+	"github.com/wignn/micro-3/currency"
+	inventoryModel "github.com/wignn/micro-3/inventory/model"
+	orderModel "github.com/wignn/micro-3/order/model"
+)
+
 type Account struct {
-	ID     string  `json:"id"`
-	Name   string  `json:"name"`
-	Email  string  `json:"email,omitempty"`
-	Password string `json:"password,omitempty"`
-	Orders []Order `json:"orders"`
+	ID       string  `json:"id"`
+	Name     string  `json:"name"`
+	Email    string  `json:"email,omitempty"`
+	Password string  `json:"password,omitempty"`
+	Orders   []Order `json:"orders"`
 }
 
 type AccountResponse struct {
@@ -13,3 +23,265 @@ type AccountResponse struct {
 	Email  string  `json:"email,omitempty"`
 	Orders []Order `json:"orders,omitempty"`
 }
+
+type Product struct {
+	ID          string          `json:"id"`
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	Price       float64         `json:"price"`
+	Currency    string          `json:"currency"`
+	Image       string          `json:"image"`
+	Images      []*ProductImage `json:"images"`
+	Version     string          `json:"version"`
+	Status      string          `json:"status"`
+	PublishAt   *time.Time      `json:"publishAt,omitempty"`
+	UnpublishAt *time.Time      `json:"unpublishAt,omitempty"`
+	WeightGrams int             `json:"weightGrams"`
+	Visible     bool            `json:"-"`
+}
+
+func productFromProto(p *catalog.Product) *Product {
+	images := []*ProductImage{}
+	for _, img := range p.Images {
+		images = append(images, &ProductImage{
+			ID:           img.Id,
+			URL:          img.Url,
+			ThumbnailURL: img.ThumbnailUrl,
+			ContentType:  img.ContentType,
+			Size:         int(img.Size),
+			Width:        int(img.Width),
+			Height:       int(img.Height),
+			Position:     int(img.Position),
+		})
+	}
+	return &Product{
+		ID:          p.Id,
+		Name:        p.Name,
+		Description: p.Description,
+		Price:       p.Price,
+		Currency:    p.Currency,
+		Image:       p.Image,
+		Images:      images,
+		Version:     p.Version,
+		Status:      p.Status,
+		PublishAt:   timeFromProto(p.PublishAt),
+		UnpublishAt: timeFromProto(p.UnpublishAt),
+		WeightGrams: int(p.WeightGrams),
+		Visible:     p.Visible,
+	}
+}
+
+// timeFromProto decodes an optional time, empty bytes are nil
+func timeFromProto(b []byte) *time.Time {
+	if len(b) == 0 {
+		return nil
+	}
+	t := &time.Time{}
+	t.UnmarshalBinary(b)
+	return t
+}
+
+func priceScheduleFromProto(s *catalog.PriceSchedule) *PriceSchedule {
+	schedule := &PriceSchedule{
+		ID:        s.Id,
+		ProductID: s.ProductId,
+		Price:     s.Price,
+		Status:    s.Status,
+		Actor:     s.Actor,
+	}
+	schedule.StartsAt.UnmarshalBinary(s.StartsAt)
+	schedule.CreatedAt.UnmarshalBinary(s.CreatedAt)
+	if len(s.EndsAt) != 0 {
+		schedule.EndsAt = &time.Time{}
+		schedule.EndsAt.UnmarshalBinary(s.EndsAt)
+	}
+	return schedule
+}
+
+func searchReportFromProto(r *catalog.SearchReport) *SearchReport {
+	report := &SearchReport{
+		Searches:          int(r.Searches),
+		TopQueries:        queryStatsFromProto(r.TopQueries),
+		ZeroResultQueries: queryStatsFromProto(r.ZeroResultQueries),
+	}
+	report.Day.UnmarshalBinary(r.Day)
+	return report
+}
+
+func queryStatsFromProto(stats []*catalog.QueryStats) []*SearchQueryStats {
+	res := []*SearchQueryStats{}
+	for _, q := range stats {
+		res = append(res, &SearchQueryStats{
+			Query:        q.Query,
+			Searches:     int(q.Searches),
+			ZeroResults:  int(q.ZeroResults),
+			Clicks:       int(q.Clicks),
+			AvgLatencyMs: q.AvgLatencyMs,
+		})
+	}
+	return res
+}
+
+func searchSettingsFromProto(s *catalog.SearchSettings) *SearchSettings {
+	settings := &SearchSettings{
+		NameBoost:        s.NameBoost,
+		DescriptionBoost: s.DescriptionBoost,
+		PhraseBoost:      s.PhraseBoost,
+		InStockBoost:     s.InStockBoost,
+		RatingBoost:      s.RatingBoost,
+		Synonyms:         s.Synonyms,
+		Actor:            s.Actor,
+	}
+	if settings.Synonyms == nil {
+		settings.Synonyms = []string{}
+	}
+	if len(s.UpdatedAt) != 0 {
+		settings.UpdatedAt = &time.Time{}
+		settings.UpdatedAt.UnmarshalBinary(s.UpdatedAt)
+	}
+	return settings
+}
+
+func orderFromModel(o *orderModel.Order) *Order {
+	products := []*OrderedProduct{}
+	for _, p := range o.Products {
+		discounts := []*Discount{}
+		for _, d := range p.Discounts {
+			discount := &Discount{
+				PromotionID: d.PromotionID,
+				Name:        d.Name,
+				Amount:      moneyFromModel(d.Amount),
+			}
+			if d.Code != "" {
+				discount.Code = &d.Code
+			}
+			discounts = append(discounts, discount)
+		}
+		products = append(products, &OrderedProduct{
+			ID:          p.ID,
+			Name:        p.Name,
+			Description: p.Description,
+			Price:       p.Price.Float(),
+			UnitPrice:   moneyFromModel(p.Price),
+			LineTotal:   moneyFromModel(p.LineTotal()),
+			Quantity:    int(p.Quantity),
+			Discounts:   discounts,
+			NetTotal:    moneyFromModel(p.NetTotal()),
+			Tax:         moneyFromModel(p.Tax),
+		})
+	}
+	return &Order{
+		ID:              o.ID,
+		CreatedAt:       o.CreatedAt,
+		TotalPrice:      o.TotalPrice.Float(),
+		Subtotal:        moneyFromModel(o.Subtotal()),
+		Discount:        moneyFromModel(o.Discount()),
+		Shipping:        moneyFromModel(o.Shipping),
+		Tax:             moneyFromModel(o.Tax),
+		TaxInclusive:    o.TaxInclusive,
+		Total:           moneyFromModel(o.TotalPrice),
+		Currency:        o.Currency,
+		Status:          o.Status,
+		Products:        products,
+		ShippingAddress: addressFromModel(o.ShippingAddress),
+	}
+}
+
+func quoteFromModel(o *orderModel.Order) *OrderQuote {
+	order := orderFromModel(o)
+	return &OrderQuote{
+		Subtotal:        order.Subtotal,
+		Discount:        order.Discount,
+		Shipping:        order.Shipping,
+		Tax:             order.Tax,
+		TaxInclusive:    order.TaxInclusive,
+		Total:           order.Total,
+		Currency:        order.Currency,
+		Products:        order.Products,
+		ShippingAddress: order.ShippingAddress,
+	}
+}
+
+func addressFromModel(a *orderModel.Address) *Address {
+	if a == nil {
+		return nil
+	}
+	return &Address{
+		Name:       a.Name,
+		Line1:      a.Line1,
+		Line2:      a.Line2,
+		City:       a.City,
+		Region:     a.Region,
+		PostalCode: a.PostalCode,
+		Country:    a.Country,
+	}
+}
+
+// addressFromInput returns nil without an address, the order service
+// validates it
+func addressFromInput(in *AddressInput) *orderModel.Address {
+	if in == nil {
+		return nil
+	}
+	return &orderModel.Address{
+		Name:       valueOrEmpty(in.Name),
+		Line1:      valueOrEmpty(in.Line1),
+		Line2:      valueOrEmpty(in.Line2),
+		City:       valueOrEmpty(in.City),
+		Region:     valueOrEmpty(in.Region),
+		PostalCode: valueOrEmpty(in.PostalCode),
+		Country:    in.Country,
+	}
+}
+
+func refundFromModel(r *orderModel.Refund) *Refund {
+	lines := []*RefundLine{}
+	for _, l := range r.Lines {
+		lines = append(lines, &RefundLine{
+			ProductID: l.ProductID,
+			Quantity:  int(l.Quantity),
+			Amount:    moneyFromModel(l.Amount),
+		})
+	}
+	return &Refund{
+		ID:        r.ID,
+		Amount:    moneyFromModel(r.Amount),
+		Reason:    r.Reason,
+		Actor:     r.Actor,
+		CreatedAt: r.CreatedAt,
+		Lines:     lines,
+	}
+}
+
+func shipmentFromModel(s *orderModel.Shipment) *Shipment {
+	lines := []*ShipmentLine{}
+	for _, l := range s.Lines {
+		lines = append(lines, &ShipmentLine{ProductID: l.ProductID, Quantity: int(l.Quantity)})
+	}
+	return &Shipment{
+		ID:             s.ID,
+		Carrier:        s.Carrier,
+		TrackingNumber: s.TrackingNumber,
+		Status:         s.Status,
+		Address:        addressFromModel(&s.Address),
+		Lines:          lines,
+		ShippedAt:      s.ShippedAt,
+		DeliveredAt:    s.DeliveredAt,
+		CreatedAt:      s.CreatedAt,
+		UpdatedAt:      s.UpdatedAt,
+	}
+}
+
+func moneyFromModel(m currency.Money) *Money {
+	return &Money{Amount: m.Decimal(), Currency: m.Currency}
+}
+
+func stockFromModel(s *inventoryModel.Stock) *Stock {
+	return &Stock{
+		ProductID:   s.ProductID,
+		WarehouseID: s.WarehouseID,
+		OnHand:      int(s.OnHand),
+		Reserved:    int(s.Reserved),
+		Available:   int(s.Available()),
+	}
+}
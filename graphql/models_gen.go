@@ -12,12 +12,89 @@ type AccountInput struct {
 	Password string `json:"password"`
 }
 
+type Address struct {
+	Name       string `json:"name"`
+	Line1      string `json:"line1"`
+	Line2      string `json:"line2"`
+	City       string `json:"city"`
+	Region     string `json:"region"`
+	PostalCode string `json:"postalCode"`
+	Country    string `json:"country"`
+}
+
+type AddressInput struct {
+	Name       *string `json:"name,omitempty"`
+	Line1      *string `json:"line1,omitempty"`
+	Line2      *string `json:"line2,omitempty"`
+	City       *string `json:"city,omitempty"`
+	Region     *string `json:"region,omitempty"`
+	PostalCode *string `json:"postalCode,omitempty"`
+	Country    string  `json:"country"`
+}
+
+type Cart struct {
+	ID        string      `json:"id"`
+	AccountID *string     `json:"accountId,omitempty"`
+	Currency  string      `json:"currency"`
+	Lines     []*CartLine `json:"lines"`
+	Subtotal  *Money      `json:"subtotal"`
+	UpdatedAt time.Time   `json:"updatedAt"`
+}
+
+type CartLine struct {
+	ProductID    string `json:"productId"`
+	Name         string `json:"name"`
+	Quantity     int    `json:"quantity"`
+	UnitPrice    *Money `json:"unitPrice"`
+	LineTotal    *Money `json:"lineTotal"`
+	Available    bool   `json:"available"`
+	PriceChanged bool   `json:"priceChanged"`
+}
+
+type Checkout struct {
+	ID              string          `json:"id"`
+	OrderID         string          `json:"orderId"`
+	Status          string          `json:"status"`
+	Error           *string         `json:"error,omitempty"`
+	PaymentIntentID *string         `json:"paymentIntentId,omitempty"`
+	Steps           []*CheckoutStep `json:"steps"`
+	CreatedAt       time.Time       `json:"createdAt"`
+	UpdatedAt       time.Time       `json:"updatedAt"`
+}
+
+type CheckoutStep struct {
+	Name      string    `json:"name"`
+	Status    string    `json:"status"`
+	Error     *string   `json:"error,omitempty"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
 type DeleteResponse struct {
 	DeletedID string `json:"deletedId"`
 	Success   bool   `json:"success"`
 	Message   string `json:"message"`
 }
 
+type Discount struct {
+	PromotionID string  `json:"promotionId"`
+	Code        *string `json:"code,omitempty"`
+	Name        string  `json:"name"`
+	Amount      *Money  `json:"amount"`
+}
+
+type EditProductInput struct {
+	Name        *string  `json:"name,omitempty"`
+	Description *string  `json:"description,omitempty"`
+	Price       *float64 `json:"price,omitempty"`
+	Currency    *string  `json:"currency,omitempty"`
+	Image       *string  `json:"image,omitempty"`
+	WeightGrams *int     `json:"weightGrams,omitempty"`
+	// status, publishAt and unpublishAt are replaced together when any of them is set, omitted times are cleared
+	Status      *string    `json:"status,omitempty"`
+	PublishAt   *time.Time `json:"publishAt,omitempty"`
+	UnpublishAt *time.Time `json:"unpublishAt,omitempty"`
+}
+
 type EditeAccountInput struct {
 	Name     *string `json:"name,omitempty"`
 	Email    *string `json:"email,omitempty"`
@@ -29,20 +106,59 @@ type LoginInput struct {
 	Password string `json:"password"`
 }
 
+type Money struct {
+	Amount   string `json:"amount"`
+	Currency string `json:"currency"`
+}
+
 type Mutation struct {
 }
 
 type Order struct {
-	ID         string            `json:"id"`
-	CreatedAt  time.Time         `json:"createdAt"`
-	TotalPrice float64           `json:"totalPrice"`
-	Products   []*OrderedProduct `json:"products"`
-	Status     string            `json:"status"`
+	ID           string            `json:"id"`
+	CreatedAt    time.Time         `json:"createdAt"`
+	TotalPrice   float64           `json:"totalPrice"`
+	Subtotal     *Money            `json:"subtotal"`
+	Discount     *Money            `json:"discount"`
+	Shipping     *Money            `json:"shipping"`
+	Tax          *Money            `json:"tax"`
+	TaxInclusive bool              `json:"taxInclusive"`
+	Total        *Money            `json:"total"`
+	Currency     string            `json:"currency"`
+	Products     []*OrderedProduct `json:"products"`
+	// null for orders that aren't shipped
+	ShippingAddress *Address             `json:"shippingAddress,omitempty"`
+	Status          string               `json:"status"`
+	StatusHistory   []*OrderStatusChange `json:"statusHistory"`
+	Refunds         []*Refund            `json:"refunds"`
+	Shipments       []*Shipment          `json:"shipments"`
+	// PDF of the invoice, issued the first time it is requested; null until the order is paid
+	InvoiceURL *string `json:"invoiceUrl,omitempty"`
+}
+
+type OrderConnection struct {
+	Orders      []*Order `json:"orders"`
+	EndCursor   *string  `json:"endCursor,omitempty"`
+	HasNextPage bool     `json:"hasNextPage"`
+}
+
+type OrderFilter struct {
+	AccountID     *string    `json:"accountId,omitempty"`
+	Status        *string    `json:"status,omitempty"`
+	CreatedAfter  *time.Time `json:"createdAfter,omitempty"`
+	CreatedBefore *time.Time `json:"createdBefore,omitempty"`
+	MinTotal      *string    `json:"minTotal,omitempty"`
+	MaxTotal      *string    `json:"maxTotal,omitempty"`
+	TotalCurrency *string    `json:"totalCurrency,omitempty"`
 }
 
 type OrderInput struct {
 	AccountID string               `json:"accountId"`
 	Products  []*OrderProductInput `json:"products"`
+	Currency  *string              `json:"currency,omitempty"`
+	Coupons   []string             `json:"coupons,omitempty"`
+	// orders without an address aren't shipped
+	ShippingAddress *AddressInput `json:"shippingAddress,omitempty"`
 }
 
 type OrderProductInput struct {
@@ -50,12 +166,38 @@ type OrderProductInput struct {
 	Quantity int    `json:"quantity"`
 }
 
+type OrderQuote struct {
+	Subtotal        *Money            `json:"subtotal"`
+	Discount        *Money            `json:"discount"`
+	Shipping        *Money            `json:"shipping"`
+	Tax             *Money            `json:"tax"`
+	TaxInclusive    bool              `json:"taxInclusive"`
+	Total           *Money            `json:"total"`
+	Currency        string            `json:"currency"`
+	Products        []*OrderedProduct `json:"products"`
+	ShippingAddress *Address          `json:"shippingAddress,omitempty"`
+}
+
+type OrderStatusChange struct {
+	From      string    `json:"from"`
+	To        string    `json:"to"`
+	Actor     string    `json:"actor"`
+	Reason    string    `json:"reason"`
+	ChangedAt time.Time `json:"changedAt"`
+}
+
 type OrderedProduct struct {
-	ID          string  `json:"id"`
-	Name        string  `json:"name"`
-	Description string  `json:"description"`
-	Price       float64 `json:"price"`
-	Quantity    int     `json:"quantity"`
+	ID          string      `json:"id"`
+	Name        string      `json:"name"`
+	Description string      `json:"description"`
+	Price       float64     `json:"price"`
+	UnitPrice   *Money      `json:"unitPrice"`
+	LineTotal   *Money      `json:"lineTotal"`
+	Quantity    int         `json:"quantity"`
+	Discounts   []*Discount `json:"discounts"`
+	NetTotal    *Money      `json:"netTotal"`
+	// tax charged on the net total
+	Tax *Money `json:"tax"`
 }
 
 type PaginationInput struct {
@@ -63,24 +205,124 @@ type PaginationInput struct {
 	Take *int `json:"take,omitempty"`
 }
 
-type Product struct {
-	ID          string  `json:"id"`
-	Name        string  `json:"name"`
-	Description string  `json:"description"`
-	Price       float64 `json:"price"`
-	Image       string  `json:"image"`
+type PriceChange struct {
+	ID         string    `json:"id"`
+	OldPrice   float64   `json:"oldPrice"`
+	NewPrice   float64   `json:"newPrice"`
+	Currency   string    `json:"currency"`
+	Actor      string    `json:"actor"`
+	Reason     string    `json:"reason"`
+	ScheduleID *string   `json:"scheduleId,omitempty"`
+	ChangedAt  time.Time `json:"changedAt"`
+}
+
+type PriceSchedule struct {
+	ID        string     `json:"id"`
+	ProductID string     `json:"productId"`
+	Price     float64    `json:"price"`
+	StartsAt  time.Time  `json:"startsAt"`
+	EndsAt    *time.Time `json:"endsAt,omitempty"`
+	Status    string     `json:"status"`
+	Actor     string     `json:"actor"`
+	CreatedAt time.Time  `json:"createdAt"`
+}
+
+type ProductImage struct {
+	ID           string `json:"id"`
+	URL          string `json:"url"`
+	ThumbnailURL string `json:"thumbnailUrl"`
+	ContentType  string `json:"contentType"`
+	Size         int    `json:"size"`
+	Width        int    `json:"width"`
+	Height       int    `json:"height"`
+	Position     int    `json:"position"`
 }
 
 type ProductInput struct {
 	Name        string  `json:"name"`
 	Description string  `json:"description"`
 	Price       float64 `json:"price"`
+	Currency    *string `json:"currency,omitempty"`
 	Image       string  `json:"image"`
+	WeightGrams *int    `json:"weightGrams,omitempty"`
+	// draft, scheduled, published or archived; without it the product is published, or scheduled when publishAt is in the future
+	Status      *string    `json:"status,omitempty"`
+	PublishAt   *time.Time `json:"publishAt,omitempty"`
+	UnpublishAt *time.Time `json:"unpublishAt,omitempty"`
+}
+
+type Promotion struct {
+	ID                string     `json:"id"`
+	Code              *string    `json:"code,omitempty"`
+	Name              string     `json:"name"`
+	Kind              string     `json:"kind"`
+	PercentOff        int        `json:"percentOff"`
+	AmountOff         *Money     `json:"amountOff,omitempty"`
+	BuyQuantity       int        `json:"buyQuantity"`
+	GetQuantity       int        `json:"getQuantity"`
+	ProductIds        []string   `json:"productIds"`
+	MinSpend          *Money     `json:"minSpend,omitempty"`
+	StartsAt          *time.Time `json:"startsAt,omitempty"`
+	EndsAt            *time.Time `json:"endsAt,omitempty"`
+	MaxUses           int        `json:"maxUses"`
+	MaxUsesPerAccount int        `json:"maxUsesPerAccount"`
+	Stackable         bool       `json:"stackable"`
+	Priority          int        `json:"priority"`
+	Active            bool       `json:"active"`
+	Uses              int        `json:"uses"`
+	CreatedAt         time.Time  `json:"createdAt"`
+}
+
+type PromotionInput struct {
+	Code              *string    `json:"code,omitempty"`
+	Name              string     `json:"name"`
+	Kind              string     `json:"kind"`
+	PercentOff        *int       `json:"percentOff,omitempty"`
+	AmountOff         *string    `json:"amountOff,omitempty"`
+	MinSpend          *string    `json:"minSpend,omitempty"`
+	Currency          *string    `json:"currency,omitempty"`
+	BuyQuantity       *int       `json:"buyQuantity,omitempty"`
+	GetQuantity       *int       `json:"getQuantity,omitempty"`
+	ProductIds        []string   `json:"productIds,omitempty"`
+	StartsAt          *time.Time `json:"startsAt,omitempty"`
+	EndsAt            *time.Time `json:"endsAt,omitempty"`
+	MaxUses           *int       `json:"maxUses,omitempty"`
+	MaxUsesPerAccount *int       `json:"maxUsesPerAccount,omitempty"`
+	Stackable         *bool      `json:"stackable,omitempty"`
+	Priority          *int       `json:"priority,omitempty"`
 }
 
 type Query struct {
 }
 
+type QuoteInput struct {
+	AccountID       *string              `json:"accountId,omitempty"`
+	Products        []*OrderProductInput `json:"products"`
+	Currency        *string              `json:"currency,omitempty"`
+	Coupons         []string             `json:"coupons,omitempty"`
+	ShippingAddress *AddressInput        `json:"shippingAddress,omitempty"`
+}
+
+type Refund struct {
+	ID        string        `json:"id"`
+	Amount    *Money        `json:"amount"`
+	Reason    string        `json:"reason"`
+	Actor     string        `json:"actor"`
+	CreatedAt time.Time     `json:"createdAt"`
+	Lines     []*RefundLine `json:"lines"`
+}
+
+type RefundLine struct {
+	ProductID string `json:"productId"`
+	Quantity  int    `json:"quantity"`
+	Amount    *Money `json:"amount"`
+}
+
+type RefundLineInput struct {
+	ProductID string `json:"productId"`
+	Quantity  int    `json:"quantity"`
+}
+
 type Review struct {
 	ID        string    `json:"id"`
 	Content   *string   `json:"content,omitempty"`
@@ -97,6 +339,72 @@ type ReviewInput struct {
 	Rating    int     `json:"rating"`
 }
 
+type SearchQueryStats struct {
+	Query        string  `json:"query"`
+	Searches     int     `json:"searches"`
+	ZeroResults  int     `json:"zeroResults"`
+	Clicks       int     `json:"clicks"`
+	AvgLatencyMs float64 `json:"avgLatencyMs"`
+}
+
+type SearchReport struct {
+	Day               time.Time           `json:"day"`
+	Searches          int                 `json:"searches"`
+	TopQueries        []*SearchQueryStats `json:"topQueries"`
+	ZeroResultQueries []*SearchQueryStats `json:"zeroResultQueries"`
+}
+
+type SearchSettings struct {
+	NameBoost        float64    `json:"nameBoost"`
+	DescriptionBoost float64    `json:"descriptionBoost"`
+	PhraseBoost      float64    `json:"phraseBoost"`
+	InStockBoost     float64    `json:"inStockBoost"`
+	RatingBoost      float64    `json:"ratingBoost"`
+	Synonyms         []string   `json:"synonyms"`
+	Actor            string     `json:"actor"`
+	UpdatedAt        *time.Time `json:"updatedAt,omitempty"`
+}
+
+type SearchSettingsInput struct {
+	NameBoost        float64  `json:"nameBoost"`
+	DescriptionBoost float64  `json:"descriptionBoost"`
+	PhraseBoost      float64  `json:"phraseBoost"`
+	InStockBoost     float64  `json:"inStockBoost"`
+	RatingBoost      float64  `json:"ratingBoost"`
+	Synonyms         []string `json:"synonyms"`
+}
+
+type Shipment struct {
+	ID             string          `json:"id"`
+	Carrier        string          `json:"carrier"`
+	TrackingNumber string          `json:"trackingNumber"`
+	Status         string          `json:"status"`
+	Address        *Address        `json:"address"`
+	Lines          []*ShipmentLine `json:"lines"`
+	ShippedAt      *time.Time      `json:"shippedAt,omitempty"`
+	DeliveredAt    *time.Time      `json:"deliveredAt,omitempty"`
+	CreatedAt      time.Time       `json:"createdAt"`
+	UpdatedAt      time.Time       `json:"updatedAt"`
+}
+
+type ShipmentLine struct {
+	ProductID string `json:"productId"`
+	Quantity  int    `json:"quantity"`
+}
+
+type ShipmentLineInput struct {
+	ProductID string `json:"productId"`
+	Quantity  int    `json:"quantity"`
+}
+
+type Stock struct {
+	ProductID   string `json:"productId"`
+	WarehouseID string `json:"warehouseId"`
+	OnHand      int    `json:"onHand"`
+	Reserved    int    `json:"reserved"`
+	Available   int    `json:"available"`
+}
+
 type Token struct {
 	AccessToken  string `json:"accessToken"`
 	RefreshToken string `json:"refreshToken"`
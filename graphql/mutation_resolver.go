@@ -4,12 +4,20 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	productModel "github.com/wignn/micro-3/order/model"
+	"log"
+	"math"
+
 	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	catalog "github.com/wignn/micro-3/catalog/genproto"
+	catalogModel "github.com/wignn/micro-3/catalog/model"
+	productModel "github.com/wignn/micro-3/order/model"
 )
 
 var (
 	ErrInvalidParameter = errors.New("invalid parameter")
+	ErrProductNotFound  = errors.New("product not found")
 )
 
 type mutationResolver struct {
@@ -36,28 +44,37 @@ func (r *mutationResolver) CreateProduct(c context.Context, in ProductInput) (*P
 	c, cancel := context.WithTimeout(c, 3*time.Second)
 	defer cancel()
 
-	p, err := r.server.catalogClient.PostProduct(c, in.Name, in.Description, in.Price, in.Image)
+	weight := 0
+	if in.WeightGrams != nil {
+		if *in.WeightGrams < 0 {
+			return nil, ErrInvalidParameter
+		}
+		weight = *in.WeightGrams
+	}
+	publication := &catalogModel.Publication{
+		Status:      valueOrEmpty(in.Status),
+		PublishAt:   in.PublishAt,
+		UnpublishAt: in.UnpublishAt,
+	}
+	p, err := r.server.catalogClient.PostProduct(c, in.Name, in.Description, in.Price, valueOrEmpty(in.Currency), in.Image, int64(weight), publication, requestActor(c))
 	if err != nil {
 		return nil, handleError("CreateProduct", err)
 	}
 
-	return &Product{
-		ID:          p.Id,
-		Name:        p.Name,
-		Description: p.Description,
-		Price:       p.Price,
-		Image:       p.Image,
-	}, nil
+	return productFromProto(p), nil
 }
 
-func (r *mutationResolver) CreateOrder(ctx context.Context, in OrderInput) (*Order, error) {
+// CreateOrder places an order, retries sending the same idempotencyKey get
+// the order placed by the first request
+func (r *mutationResolver) CreateOrder(ctx context.Context, in OrderInput, idempotencyKey *string) (*Order, error) {
 	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
 	defer cancel()
 
+	// zero quantities and duplicate products are reported by the order service
 	var products []*productModel.OrderedProduct
-	for _, p := range in.Products {
-		if p.Quantity <= 0 {
-			return nil, ErrInvalidParameter
+	for i, p := range in.Products {
+		if p.Quantity < 0 || p.Quantity > math.MaxUint32 {
+			return nil, invalidOrderError("invalid order: quantity can't be negative", fmt.Sprintf("products[%d].quantity", i), "quantity can't be negative")
 		}
 		products = append(products, &productModel.OrderedProduct{
 			ID:       p.ID,
@@ -65,33 +82,12 @@ func (r *mutationResolver) CreateOrder(ctx context.Context, in OrderInput) (*Ord
 		})
 	}
 
-	o, err := r.server.orderClient.PostOrder(ctx, in.AccountID, products)
+	o, err := r.server.orderClient.PostOrder(ctx, in.AccountID, valueOrEmpty(in.Currency), products, in.Coupons, addressFromInput(in.ShippingAddress), valueOrEmpty(idempotencyKey))
 	if err != nil {
-		return nil, handleError("CreateOrder.PostOrder", err)
+		return nil, orderError("CreateOrder.PostOrder", err)
 	}
 
-	var orderProducts []*OrderedProduct
-	for _, p := range o.Products {
-		prodDetail, err := r.server.catalogClient.GetProduct(ctx, p.ID)
-		if err != nil {
-			handleError(fmt.Sprintf("GetProduct ID=%s", p.ID), err)
-			continue
-		}
-		orderProducts = append(orderProducts, &OrderedProduct{
-			ID:          prodDetail.Id,
-			Name:        prodDetail.Name,
-			Description: prodDetail.Description,
-			Price:       prodDetail.Price,
-			Quantity:    int(p.Quantity),
-		})
-	}
-
-	return &Order{
-		ID:         o.ID,
-		CreatedAt:  o.CreatedAt,
-		TotalPrice: o.TotalPrice,
-		Products:   orderProducts,
-	}, nil
+	return orderFromModel(o), nil
 }
 
 func (r *mutationResolver) CreateReview(ctx context.Context, in ReviewInput) (*Review, error) {
@@ -106,10 +102,14 @@ func (r *mutationResolver) CreateReview(ctx context.Context, in ReviewInput) (*R
 	if err != nil {
 		return nil, handleError("CreateReview", err)
 	}
+	// the rating only ranks search results, a failure doesn't fail the review
+	if err := r.server.catalogClient.AddProductRating(ctx, in.ProductID, int32(review.Rating)); err != nil {
+		log.Println(err)
+	}
 
-	product, err := r.server.catalogClient.GetProduct(ctx, in.ProductID);
+	product, err := r.server.catalogClient.GetProduct(ctx, in.ProductID)
 	if err != nil {
-		return  nil, handleError("CreateReview", err)
+		return nil, handleError("CreateReview", err)
 	}
 
 	account, err := r.server.accountClient.GetAccount(ctx, in.AccountID)
@@ -122,15 +122,10 @@ func (r *mutationResolver) CreateReview(ctx context.Context, in ReviewInput) (*R
 		Rating:    int(review.Rating),
 		Content:   &review.Content,
 		CreatedAt: review.CreatedAt,
-		Product: &Product{
-			ID: product.Id,
-			Name: product.Name,
-		Price: float64(product.Price),
-			Image: product.Image,
-		},
+		Product:   productFromProto(product),
 		Account: &Account{
-			ID: account.ID,
-			Name: account.Name,
+			ID:    account.ID,
+			Name:  account.Name,
 			Email: account.Email,
 		},
 	}, nil
@@ -157,7 +152,66 @@ func (r *mutationResolver) DeleteProduct(c context.Context, id string) (*DeleteR
 	}, nil
 }
 
-func (r *mutationResolver) Login(c context.Context, in LoginInput) (*AuthResponse, error) {
+func (r *mutationResolver) RestoreProduct(c context.Context, id string) (*Product, error) {
+	c, cancel := context.WithTimeout(c, 3*time.Second)
+	defer cancel()
+
+	p, err := r.server.catalogClient.RestoreProduct(c, id)
+	if err != nil {
+		return nil, handleError("RestoreProduct", err)
+	}
+
+	return productFromProto(p), nil
+}
+
+func (r *mutationResolver) UploadProductImage(c context.Context, productID string, file graphql.Upload, position *int) (*Product, error) {
+	c, cancel := context.WithTimeout(c, 30*time.Second)
+	defer cancel()
+
+	var pos *int32
+	if position != nil {
+		if *position < 0 {
+			return nil, ErrInvalidParameter
+		}
+		p := int32(*position)
+		pos = &p
+	}
+
+	p, err := r.server.catalogClient.UploadProductImage(c, productID, pos, file.File)
+	if err != nil {
+		return nil, handleError("UploadProductImage", err)
+	}
+
+	return productFromProto(p), nil
+}
+
+func (r *mutationResolver) DeleteProductImage(c context.Context, productID string, imageID string) (*Product, error) {
+	c, cancel := context.WithTimeout(c, 3*time.Second)
+	defer cancel()
+
+	p, err := r.server.catalogClient.DeleteProductImage(c, productID, imageID)
+	if err != nil {
+		return nil, handleError("DeleteProductImage", err)
+	}
+
+	return productFromProto(p), nil
+}
+
+func (r *mutationResolver) ReorderProductImages(c context.Context, productID string, imageIDs []string) (*Product, error) {
+	c, cancel := context.WithTimeout(c, 3*time.Second)
+	defer cancel()
+
+	p, err := r.server.catalogClient.ReorderProductImages(c, productID, imageIDs)
+	if err != nil {
+		return nil, handleError("ReorderProductImages", err)
+	}
+
+	return productFromProto(p), nil
+}
+
+// Login signs a customer in, the anonymous cart cartId is merged into the
+// account's cart
+func (r *mutationResolver) Login(c context.Context, in LoginInput, cartID *string) (*AuthResponse, error) {
 	c, cancel := context.WithTimeout(c, 3*time.Second)
 	defer cancel()
 
@@ -166,6 +220,13 @@ func (r *mutationResolver) Login(c context.Context, in LoginInput) (*AuthRespons
 		return nil, handleError("Login", err)
 	}
 
+	if cartID != nil && *cartID != "" {
+		// a cart that can't be merged stays anonymous, it doesn't fail the login
+		if _, err := r.server.cartClient.MergeCarts(c, *cartID, token.Auth.Id); err != nil {
+			log.Printf("[ERROR] Login.MergeCarts: %v\n", err)
+		}
+	}
+
 	return &AuthResponse{
 		ID:    token.Auth.Id,
 		Email: token.Auth.Email,
@@ -193,22 +254,141 @@ func (r *mutationResolver) RefreshToken(c context.Context, refreshToken string)
 	}, nil
 }
 
-func (r *mutationResolver) EditProduct(c context.Context, id string, in ProductInput) (*Product, error) {
+func (r *mutationResolver) EditProduct(c context.Context, id string, in EditProductInput, version *string) (*Product, error) {
 	c, cancel := context.WithTimeout(c, 3*time.Second)
 	defer cancel()
 
-	p, err := r.server.catalogClient.EditProduct(c, id, in.Name, in.Description, in.Price, in.Image)
+	// only the fields present in the input are updated
+	fields := []string{}
+	if in.Name != nil {
+		fields = append(fields, "name")
+	}
+	if in.Description != nil {
+		fields = append(fields, "description")
+	}
+	if in.Price != nil {
+		if *in.Price < 0 {
+			return nil, ErrInvalidParameter
+		}
+		fields = append(fields, "price")
+	}
+	if in.Currency != nil {
+		fields = append(fields, "currency")
+	}
+	if in.Image != nil {
+		fields = append(fields, "image")
+	}
+	if in.WeightGrams != nil {
+		if *in.WeightGrams < 0 {
+			return nil, ErrInvalidParameter
+		}
+		fields = append(fields, "weightGrams")
+	}
+	var publication *catalogModel.Publication
+	if in.Status != nil || in.PublishAt != nil || in.UnpublishAt != nil {
+		publication = &catalogModel.Publication{
+			Status:      valueOrEmpty(in.Status),
+			PublishAt:   in.PublishAt,
+			UnpublishAt: in.UnpublishAt,
+		}
+		fields = append(fields, "status", "publishAt", "unpublishAt")
+	}
+	if len(fields) == 0 {
+		return nil, ErrInvalidParameter
+	}
+
+	price := 0.0
+	if in.Price != nil {
+		price = *in.Price
+	}
+	weight := 0
+	if in.WeightGrams != nil {
+		weight = *in.WeightGrams
+	}
+
+	p, err := r.server.catalogClient.EditProduct(c, id, valueOrEmpty(in.Name), valueOrEmpty(in.Description), price, valueOrEmpty(in.Currency), valueOrEmpty(in.Image), int64(weight), publication, fields, valueOrEmpty(version), requestActor(c))
 	if err != nil {
 		return nil, handleError("EditProduct", err)
 	}
 
-	return &Product{
-		ID:          p.Id,
-		Name:        p.Name,
-		Description: p.Description,
-		Price:       p.Price,
-		Image:       p.Image,
-	}, nil
+	return productFromProto(p), nil
+}
+
+func (r *mutationResolver) SchedulePriceChange(c context.Context, productID string, price float64, startsAt time.Time, endsAt *time.Time) (*PriceSchedule, error) {
+	c, cancel := context.WithTimeout(c, 3*time.Second)
+	defer cancel()
+
+	if price < 0 {
+		return nil, ErrInvalidParameter
+	}
+
+	s, err := r.server.catalogClient.SchedulePriceChange(c, productID, price, startsAt, endsAt, requestActor(c))
+	if err != nil {
+		return nil, handleError("SchedulePriceChange", err)
+	}
+
+	return priceScheduleFromProto(s), nil
+}
+
+func (r *mutationResolver) CancelPriceSchedule(c context.Context, id string) (*PriceSchedule, error) {
+	c, cancel := context.WithTimeout(c, 3*time.Second)
+	defer cancel()
+
+	s, err := r.server.catalogClient.CancelPriceSchedule(c, id, requestActor(c))
+	if err != nil {
+		return nil, handleError("CancelPriceSchedule", err)
+	}
+
+	return priceScheduleFromProto(s), nil
+}
+
+func (r *mutationResolver) SetStock(c context.Context, productID string, warehouseID *string, onHand int) (*Stock, error) {
+	c, cancel := context.WithTimeout(c, 3*time.Second)
+	defer cancel()
+
+	if !isAdmin(c) {
+		return nil, ErrForbidden
+	}
+	if onHand < 0 || onHand > math.MaxUint32 {
+		return nil, ErrInvalidParameter
+	}
+
+	s, err := r.server.inventoryClient.SetStock(c, productID, valueOrEmpty(warehouseID), uint32(onHand))
+	if err != nil {
+		return nil, handleError("SetStock", err)
+	}
+	return stockFromModel(s), nil
+}
+
+func (r *mutationResolver) RecordSearchClick(c context.Context, searchID string, productID string) (bool, error) {
+	c, cancel := context.WithTimeout(c, 3*time.Second)
+	defer cancel()
+
+	if err := r.server.catalogClient.RecordSearchClick(c, searchID, productID); err != nil {
+		return false, handleError("RecordSearchClick", err)
+	}
+	return true, nil
+}
+
+func (r *mutationResolver) UpdateSearchSettings(c context.Context, in SearchSettingsInput) (*SearchSettings, error) {
+	c, cancel := context.WithTimeout(c, 10*time.Second)
+	defer cancel()
+
+	if !isAdmin(c) {
+		return nil, ErrForbidden
+	}
+	settings, err := r.server.catalogClient.UpdateSearchSettings(c, &catalog.SearchSettings{
+		NameBoost:        in.NameBoost,
+		DescriptionBoost: in.DescriptionBoost,
+		PhraseBoost:      in.PhraseBoost,
+		InStockBoost:     in.InStockBoost,
+		RatingBoost:      in.RatingBoost,
+		Synonyms:         in.Synonyms,
+	}, requestActor(c))
+	if err != nil {
+		return nil, handleError("UpdateSearchSettings", err)
+	}
+	return searchSettingsFromProto(settings), nil
 }
 
 func (r *mutationResolver) DeleteAccount(c context.Context, id string) (*DeleteResponse, error) {
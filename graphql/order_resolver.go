@@ -0,0 +1,229 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	"github.com/wignn/micro-3/currency"
+	orderModel "github.com/wignn/micro-3/order/model"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var ErrOrderNotFound = errors.New("order not found")
+
+type orderResolver struct {
+	server *GraphQLServer
+}
+
+func (r *orderResolver) StatusHistory(c context.Context, o *Order) ([]*OrderStatusChange, error) {
+	c, cancel := context.WithTimeout(c, 3*time.Second)
+	defer cancel()
+
+	history, err := r.server.orderClient.GetOrderStatusHistory(c, o.ID)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	changes := []*OrderStatusChange{}
+	for _, h := range history {
+		changes = append(changes, &OrderStatusChange{
+			From:      h.From,
+			To:        h.To,
+			Actor:     h.Actor,
+			Reason:    h.Reason,
+			ChangedAt: h.ChangedAt,
+		})
+	}
+	return changes, nil
+}
+
+// QuoteOrder prices a basket with its discounts, shipping and tax without
+// placing an order, violations are reported like createOrder reports them
+func (r *queryResolver) QuoteOrder(c context.Context, in QuoteInput) (*OrderQuote, error) {
+	c, cancel := context.WithTimeout(c, 3*time.Second)
+	defer cancel()
+
+	var products []*orderModel.OrderedProduct
+	for i, p := range in.Products {
+		if p.Quantity < 0 || p.Quantity > math.MaxUint32 {
+			return nil, invalidOrderError("invalid order: quantity can't be negative", fmt.Sprintf("products[%d].quantity", i), "quantity can't be negative")
+		}
+		products = append(products, &orderModel.OrderedProduct{
+			ID:       p.ID,
+			Quantity: uint32(p.Quantity),
+		})
+	}
+
+	quote, err := r.server.orderClient.QuoteOrder(c, valueOrEmpty(in.AccountID), valueOrEmpty(in.Currency), products, in.Coupons, addressFromInput(in.ShippingAddress))
+	if err != nil {
+		return nil, orderError("QuoteOrder", err)
+	}
+	return quoteFromModel(quote), nil
+}
+
+// UpdateOrderStatus moves an order along its lifecycle, only admins may do so
+func (r *mutationResolver) UpdateOrderStatus(c context.Context, id string, status string, reason *string) (*Order, error) {
+	c, cancel := context.WithTimeout(c, 3*time.Second)
+	defer cancel()
+
+	if !isAdmin(c) {
+		return nil, ErrForbidden
+	}
+	o, err := r.server.orderClient.UpdateOrderStatus(c, id, status, requestActor(c), valueOrEmpty(reason))
+	if err != nil {
+		return nil, handleError("UpdateOrderStatus", err)
+	}
+	return orderFromModel(o), nil
+}
+
+func (r *queryResolver) Order(c context.Context, id string, currency *string) (*Order, error) {
+	c, cancel := context.WithTimeout(c, 3*time.Second)
+	defer cancel()
+
+	display, err := displayCurrency(currency)
+	if err != nil {
+		return nil, err
+	}
+	o, err := r.server.orderClient.GetOrder(c, id)
+	if status.Code(err) == codes.NotFound {
+		return nil, ErrOrderNotFound
+	}
+	if err != nil {
+		return nil, handleError("Order", err)
+	}
+	if err := r.server.convertOrder(c, o, display); err != nil {
+		return nil, handleError("Order", err)
+	}
+	return orderFromModel(o), nil
+}
+
+// Orders lists orders newest first, customers must list the orders of an
+// account while admins may list every order
+func (r *queryResolver) Orders(c context.Context, in *OrderFilter, after *string, first *int, currency *string) (*OrderConnection, error) {
+	c, cancel := context.WithTimeout(c, 3*time.Second)
+	defer cancel()
+
+	display, err := displayCurrency(currency)
+	if err != nil {
+		return nil, err
+	}
+	filter, err := orderFilter(in)
+	if err != nil {
+		return nil, err
+	}
+	// customers only list the orders of the account they are signed in as
+	if !isAdmin(c) {
+		if filter.AccountID == "" {
+			return nil, ErrForbidden
+		}
+		owner, err := r.server.ownsAccount(c, filter.AccountID)
+		if err != nil {
+			return nil, handleError("Orders", err)
+		}
+		if !owner {
+			return nil, ErrForbidden
+		}
+	}
+	take := 0
+	if first != nil {
+		if *first < 0 {
+			return nil, ErrInvalidParameter
+		}
+		take = *first
+	}
+
+	page, err := r.server.orderClient.ListOrders(c, filter, valueOrEmpty(after), take)
+	if err != nil {
+		return nil, handleError("Orders", err)
+	}
+	res := &OrderConnection{Orders: []*Order{}, HasNextPage: page.HasNextPage}
+	if page.EndCursor != "" {
+		res.EndCursor = &page.EndCursor
+	}
+	for _, o := range page.Orders {
+		if err := r.server.convertOrder(c, o, display); err != nil {
+			return nil, handleError("Orders", err)
+		}
+		res.Orders = append(res.Orders, orderFromModel(o))
+	}
+	return res, nil
+}
+
+func orderFilter(in *OrderFilter) (orderModel.OrderFilter, error) {
+	filter := orderModel.OrderFilter{}
+	if in == nil {
+		return filter, nil
+	}
+	filter.AccountID = valueOrEmpty(in.AccountID)
+	filter.Status = valueOrEmpty(in.Status)
+	filter.CreatedAfter = in.CreatedAfter
+	filter.CreatedBefore = in.CreatedBefore
+	if in.MinTotal == nil && in.MaxTotal == nil {
+		return filter, nil
+	}
+	if in.TotalCurrency == nil {
+		return filter, ErrInvalidParameter
+	}
+	for _, bound := range []struct {
+		amount *string
+		dst    **currency.Money
+	}{{in.MinTotal, &filter.MinTotal}, {in.MaxTotal, &filter.MaxTotal}} {
+		if bound.amount == nil {
+			continue
+		}
+		m, err := currency.ParseMoney(*bound.amount, *in.TotalCurrency)
+		if err != nil {
+			return filter, err
+		}
+		*bound.dst = &m
+	}
+	return filter, nil
+}
+
+// orderError passes the line violations reported by the order service on to
+// the client in the violations extension, other errors go through handleError
+func orderError(op string, err error) error {
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.InvalidArgument {
+		return handleError(op, err)
+	}
+	log.Printf("[ERROR] %s: %v\n", op, err)
+	violations := []map[string]interface{}{}
+	for _, d := range st.Details() {
+		if br, ok := d.(*errdetails.BadRequest); ok {
+			for _, v := range br.FieldViolations {
+				violations = append(violations, map[string]interface{}{
+					"field":       v.Field,
+					"description": v.Description,
+				})
+			}
+		}
+	}
+	return &gqlerror.Error{
+		Message: st.Message(),
+		Extensions: map[string]interface{}{
+			"code":       "INVALID_ARGUMENT",
+			"violations": violations,
+		},
+	}
+}
+
+func invalidOrderError(message, field, description string) error {
+	return &gqlerror.Error{
+		Message: message,
+		Extensions: map[string]interface{}{
+			"code": "INVALID_ARGUMENT",
+			"violations": []map[string]interface{}{
+				{"field": field, "description": description},
+			},
+		},
+	}
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+type productResolver struct {
+	server *GraphQLServer
+}
+
+func (r *productResolver) PriceHistory(c context.Context, p *Product, pagination *PaginationInput) ([]*PriceChange, error) {
+	c, cancel := context.WithTimeout(c, 3*time.Second)
+	defer cancel()
+
+	skip, take := uint64(0), uint64(0)
+	if pagination != nil {
+		skip, take = pagination.bounds()
+	}
+
+	history, err := r.server.catalogClient.GetPriceHistory(c, p.ID, skip, take)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	changes := []*PriceChange{}
+	for _, h := range history {
+		change := &PriceChange{
+			ID:       h.Id,
+			OldPrice: h.OldPrice,
+			NewPrice: h.NewPrice,
+			Currency: h.Currency,
+			Actor:    h.Actor,
+			Reason:   h.Reason,
+		}
+		if h.ScheduleId != "" {
+			change.ScheduleID = &h.ScheduleId
+		}
+		change.ChangedAt.UnmarshalBinary(h.ChangedAt)
+		changes = append(changes, change)
+	}
+	return changes, nil
+}
+
+func (r *productResolver) Related(c context.Context, p *Product, limit *int) ([]*Product, error) {
+	c, cancel := context.WithTimeout(c, 3*time.Second)
+	defer cancel()
+
+	take, err := recommendationLimit(limit)
+	if err != nil {
+		return nil, err
+	}
+
+	recs, err := r.server.recommendationClient.GetRelatedProducts(c, p.ID, take)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	products, err := r.server.recommendedProducts(c, recs)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return products, nil
+}
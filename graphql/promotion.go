@@ -0,0 +1,148 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/wignn/micro-3/currency"
+	orderModel "github.com/wignn/micro-3/order/model"
+)
+
+func promotionFromModel(p *orderModel.Promotion) *Promotion {
+	res := &Promotion{
+		ID:                p.ID,
+		Name:              p.Name,
+		Kind:              p.Kind,
+		PercentOff:        int(p.PercentOff),
+		BuyQuantity:       int(p.BuyQuantity),
+		GetQuantity:       int(p.GetQuantity),
+		ProductIds:        p.ProductIDs,
+		StartsAt:          p.StartsAt,
+		EndsAt:            p.EndsAt,
+		MaxUses:           int(p.MaxUses),
+		MaxUsesPerAccount: int(p.MaxUsesPerAccount),
+		Stackable:         p.Stackable,
+		Priority:          int(p.Priority),
+		Active:            p.Active,
+		Uses:              int(p.Uses),
+		CreatedAt:         p.CreatedAt,
+	}
+	if res.ProductIds == nil {
+		res.ProductIds = []string{}
+	}
+	if p.Code != "" {
+		res.Code = &p.Code
+	}
+	if p.AmountOff.Amount > 0 {
+		res.AmountOff = moneyFromModel(p.AmountOff)
+	}
+	if p.MinSpend.Amount > 0 {
+		res.MinSpend = moneyFromModel(p.MinSpend)
+	}
+	return res
+}
+
+func promotionToModel(in PromotionInput) (*orderModel.Promotion, error) {
+	p := &orderModel.Promotion{
+		Code:       valueOrEmpty(in.Code),
+		Name:       in.Name,
+		Kind:       in.Kind,
+		ProductIDs: in.ProductIds,
+		StartsAt:   in.StartsAt,
+		EndsAt:     in.EndsAt,
+	}
+	for _, n := range []struct {
+		value *int
+		dst   *uint32
+	}{
+		{in.PercentOff, &p.PercentOff},
+		{in.BuyQuantity, &p.BuyQuantity},
+		{in.GetQuantity, &p.GetQuantity},
+		{in.MaxUses, &p.MaxUses},
+		{in.MaxUsesPerAccount, &p.MaxUsesPerAccount},
+	} {
+		if n.value == nil {
+			continue
+		}
+		if *n.value < 0 {
+			return nil, ErrInvalidParameter
+		}
+		*n.dst = uint32(*n.value)
+	}
+	if in.Stackable != nil {
+		p.Stackable = *in.Stackable
+	}
+	if in.Priority != nil {
+		p.Priority = int32(*in.Priority)
+	}
+	for _, amount := range []struct {
+		value *string
+		dst   *currency.Money
+	}{{in.AmountOff, &p.AmountOff}, {in.MinSpend, &p.MinSpend}} {
+		if amount.value == nil {
+			continue
+		}
+		if in.Currency == nil {
+			return nil, ErrInvalidParameter
+		}
+		m, err := currency.ParseMoney(*amount.value, *in.Currency)
+		if err != nil {
+			return nil, err
+		}
+		*amount.dst = m
+	}
+	return p, nil
+}
+
+// Promotions lists every promotion, newest first, only admins may list them
+func (r *queryResolver) Promotions(c context.Context) ([]*Promotion, error) {
+	c, cancel := context.WithTimeout(c, 3*time.Second)
+	defer cancel()
+
+	if !isAdmin(c) {
+		return nil, ErrForbidden
+	}
+	promotions, err := r.server.orderClient.ListPromotions(c)
+	if err != nil {
+		return nil, handleError("Promotions", err)
+	}
+	res := []*Promotion{}
+	for _, p := range promotions {
+		res = append(res, promotionFromModel(p))
+	}
+	return res, nil
+}
+
+// CreatePromotion creates an active promotion, only admins may create them
+func (r *mutationResolver) CreatePromotion(c context.Context, in PromotionInput) (*Promotion, error) {
+	c, cancel := context.WithTimeout(c, 3*time.Second)
+	defer cancel()
+
+	if !isAdmin(c) {
+		return nil, ErrForbidden
+	}
+	p, err := promotionToModel(in)
+	if err != nil {
+		return nil, err
+	}
+	p, err = r.server.orderClient.CreatePromotion(c, p)
+	if err != nil {
+		return nil, handleError("CreatePromotion", err)
+	}
+	return promotionFromModel(p), nil
+}
+
+// SetPromotionActive turns a promotion on or off, only admins may change them
+func (r *mutationResolver) SetPromotionActive(c context.Context, id string, active bool) (*Promotion, error) {
+	c, cancel := context.WithTimeout(c, 3*time.Second)
+	defer cancel()
+
+	if !isAdmin(c) {
+		return nil, ErrForbidden
+	}
+	p, err := r.server.orderClient.SetPromotionActive(c, id, active)
+	if err != nil {
+		return nil, handleError("SetPromotionActive", err)
+	}
+	return promotionFromModel(p), nil
+}
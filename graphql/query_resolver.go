@@ -4,6 +4,9 @@ import (
 	"context"
 	"log"
 	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	catalog "github.com/wignn/micro-3/catalog/genproto"
 )
 
 type queryResolver struct {
@@ -51,24 +54,31 @@ func (r *queryResolver) Accounts(ctx context.Context, pagination *PaginationInpu
 	return accounts, nil
 }
 
-func (r *queryResolver) Products(c context.Context, pagination *PaginationInput, query *string, id *string) ([]*Product, error) {
+func (r *queryResolver) Products(c context.Context, pagination *PaginationInput, query *string, id *string, currency *string) ([]*Product, error) {
 	c, cancel := context.WithTimeout(c, 3*time.Second)
 	defer cancel()
 
+	display, err := displayCurrency(currency)
+	if err != nil {
+		return nil, err
+	}
+
 	if id != nil {
-		r, err := r.server.catalogClient.GetProduct(c, *id)
+		p, err := r.server.catalogClient.GetProduct(c, *id)
 		if err != nil {
 			log.Println(err)
 			return nil, err
 		}
-		
-		return []*Product{{
-			ID:          r.Id,
-			Name:        r.Name,
-			Description: r.Description,
-			Price:       r.Price,
-			Image:       r.Image,
-		}}, nil
+		if !p.Visible && !isAdmin(c) {
+			return nil, ErrProductNotFound
+		}
+
+		products := []*Product{productFromProto(p)}
+		if err := r.server.convertProducts(c, products, display); err != nil {
+			log.Println(err)
+			return nil, err
+		}
+		return products, nil
 	}
 
 	skip, take := uint64(0), uint64(0)
@@ -78,42 +88,48 @@ func (r *queryResolver) Products(c context.Context, pagination *PaginationInput,
 	}
 
 	q := ""
-	
+
 	if query != nil {
 		q = *query
 	}
-	
-	productList, err := r.server.catalogClient.GetProducts(c, skip, take, nil, q)
-	
+
+	var productList []*catalog.Product
+	if q != "" {
+		var searchID string
+		productList, searchID, err = r.server.catalogClient.SearchProducts(c, skip, take, q, isAdmin(c))
+		if err == nil {
+			// clients report clicks on the results with recordSearchClick
+			graphql.RegisterExtension(c, "searchId", searchID)
+		}
+	} else {
+		// only admins see draft, scheduled and archived products
+		productList, err = r.server.catalogClient.ListProducts(c, skip, take, isAdmin(c))
+	}
+
 	if err != nil {
 		log.Println(err)
 		return nil, err
 	}
 
-	var products []*Product
+	products := []*Product{}
 
 	for _, a := range productList {
-		products = append(products,
-			&Product{
-				ID:          a.Id,
-				Name:        a.Name,
-				Description: a.Description,
-				Price:       a.Price,
-				Image:       a.Image,
-			},
-		)
+		products = append(products, productFromProto(a))
+	}
+	if err := r.server.convertProducts(c, products, display); err != nil {
+		log.Println(err)
+		return nil, err
 	}
 	return products, nil
 }
 
-
 func (r *queryResolver) Reviews(c context.Context, pagination *PaginationInput, id *string) ([]*Review, error) {
 	c, cancel := context.WithTimeout(c, 3*time.Second)
 	defer cancel()
 
 	if pagination == nil {
 		rv, err := r.server.reviewClient.GetReview(c, *id)
-		
+
 		if err != nil {
 			log.Println("GetReview error:", err)
 			return nil, err
@@ -127,14 +143,14 @@ func (r *queryResolver) Reviews(c context.Context, pagination *PaginationInput,
 		}
 
 		prod, err := r.server.catalogClient.GetProduct(c, rv.ProductId)
-		
+
 		if err != nil {
 			log.Println("GetProduct error:", err)
 			return nil, err
 		}
 
 		var createdAt time.Time
-		
+
 		if err := createdAt.UnmarshalBinary(rv.CreatedAt); err != nil {
 			log.Println("UnmarshalBinary error on review ID", rv.Id, ":", err)
 			return nil, err
@@ -150,12 +166,7 @@ func (r *queryResolver) Reviews(c context.Context, pagination *PaginationInput,
 				Name:  acc.Name,
 				Email: acc.Email,
 			},
-			Product: &Product{
-				ID:    prod.Id,
-				Name:  prod.Name,
-				Description: prod.Description,
-				Price: float64(prod.Price),
-			},
+			Product: productFromProto(prod),
 		}}, nil
 	}
 
@@ -181,7 +192,7 @@ func (r *queryResolver) Reviews(c context.Context, pagination *PaginationInput,
 		}
 
 		prod, err := r.server.catalogClient.GetProduct(c, rv.ProductId)
-		
+
 		if err != nil {
 			log.Println("GetProduct error on review ID", rv.Id, ":", err)
 			continue
@@ -197,18 +208,69 @@ func (r *queryResolver) Reviews(c context.Context, pagination *PaginationInput,
 				Name:  acc.Name,
 				Email: acc.Email,
 			},
-			Product: &Product{
-				ID:    prod.Id,
-				Name:  prod.Name,
-				Description: prod.Description,
-				Price: float64(prod.Price),
-			},
+			Product: productFromProto(prod),
 		})
 	}
 
 	return reviews, nil
 }
 
+func (r *queryResolver) Stock(c context.Context, productIds []string) ([]*Stock, error) {
+	c, cancel := context.WithTimeout(c, 3*time.Second)
+	defer cancel()
+
+	stockList, err := r.server.inventoryClient.GetStock(c, productIds)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	stock := []*Stock{}
+	for _, s := range stockList {
+		stock = append(stock, stockFromModel(s))
+	}
+	return stock, nil
+}
+
+func (r *queryResolver) SearchReports(c context.Context, from time.Time, to time.Time, limit *int) ([]*SearchReport, error) {
+	c, cancel := context.WithTimeout(c, 3*time.Second)
+	defer cancel()
+
+	if !isAdmin(c) {
+		return nil, ErrForbidden
+	}
+	take := uint64(0)
+	if limit != nil {
+		if *limit <= 0 {
+			return nil, ErrInvalidParameter
+		}
+		take = uint64(*limit)
+	}
+
+	reportList, err := r.server.catalogClient.GetSearchReports(c, from, to, take)
+	if err != nil {
+		return nil, handleError("SearchReports", err)
+	}
+
+	reports := []*SearchReport{}
+	for _, report := range reportList {
+		reports = append(reports, searchReportFromProto(report))
+	}
+	return reports, nil
+}
+func (r *queryResolver) SearchSettings(c context.Context) (*SearchSettings, error) {
+	c, cancel := context.WithTimeout(c, 3*time.Second)
+	defer cancel()
+
+	if !isAdmin(c) {
+		return nil, ErrForbidden
+	}
+	settings, err := r.server.catalogClient.GetSearchSettings(c)
+	if err != nil {
+		return nil, handleError("SearchSettings", err)
+	}
+	return searchSettingsFromProto(settings), nil
+}
 
 func (p PaginationInput) bounds() (uint64, uint64) {
 	skipValue := uint64(0)
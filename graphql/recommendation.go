@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+
+	recommendationModel "github.com/wignn/micro-3/recommendation/model"
+)
+
+// defaultRecommendationLimit is used when a query doesn't pass a limit
+const defaultRecommendationLimit = 10
+
+func recommendationLimit(limit *int) (uint64, error) {
+	if limit == nil {
+		return defaultRecommendationLimit, nil
+	}
+	if *limit <= 0 {
+		return 0, ErrInvalidParameter
+	}
+	return uint64(*limit), nil
+}
+
+// recommendedProducts loads the recommended products from the catalog keeping
+// the recommendation order, products customers can't see are skipped
+func (s *GraphQLServer) recommendedProducts(c context.Context, recs []recommendationModel.Recommendation) ([]*Product, error) {
+	products := []*Product{}
+	if len(recs) == 0 {
+		return products, nil
+	}
+
+	ids := []string{}
+	for _, rec := range recs {
+		ids = append(ids, rec.ProductID)
+	}
+	productList, err := s.catalogClient.GetProducts(c, 0, uint64(len(ids)), ids, "")
+	if err != nil {
+		return nil, err
+	}
+
+	byID := map[string]*Product{}
+	for _, p := range productList {
+		if p.Visible {
+			byID[p.Id] = productFromProto(p)
+		}
+	}
+	for _, id := range ids {
+		if p, ok := byID[id]; ok {
+			products = append(products, p)
+		}
+	}
+	return products, nil
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"log"
+	"math"
+	"time"
+
+	orderModel "github.com/wignn/micro-3/order/model"
+)
+
+func (r *orderResolver) Refunds(c context.Context, o *Order) ([]*Refund, error) {
+	c, cancel := context.WithTimeout(c, 3*time.Second)
+	defer cancel()
+
+	refunds, err := r.server.orderClient.GetOrderRefunds(c, o.ID)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	res := []*Refund{}
+	for _, refund := range refunds {
+		res = append(res, refundFromModel(refund))
+	}
+	return res, nil
+}
+
+// CancelOrder cancels an order, admins may cancel any order and customers
+// signed in with an access token their own
+func (r *mutationResolver) CancelOrder(c context.Context, id string, reason *string) (*Order, error) {
+	c, cancel := context.WithTimeout(c, 3*time.Second)
+	defer cancel()
+
+	if !isAdmin(c) {
+		if callerEmail(c) == "" {
+			return nil, ErrForbidden
+		}
+		o, err := r.server.orderClient.GetOrder(c, id)
+		if err != nil {
+			return nil, handleError("CancelOrder", err)
+		}
+		owner, err := r.server.ownsAccount(c, o.AccountID)
+		if err != nil {
+			return nil, handleError("CancelOrder", err)
+		}
+		if !owner {
+			return nil, ErrNotOwner
+		}
+	}
+	o, err := r.server.orderClient.CancelOrder(c, id, valueOrEmpty(reason), requestActor(c))
+	if err != nil {
+		return nil, handleError("CancelOrder", err)
+	}
+	return orderFromModel(o), nil
+}
+
+// RefundOrder refunds lines of an order, without lines the whole order is
+// refunded, only admins may refund
+func (r *mutationResolver) RefundOrder(c context.Context, id string, lines []*RefundLineInput, reason *string) (*Refund, error) {
+	c, cancel := context.WithTimeout(c, 3*time.Second)
+	defer cancel()
+
+	if !isAdmin(c) {
+		return nil, ErrForbidden
+	}
+	refundLines := []orderModel.RefundLine{}
+	for _, l := range lines {
+		if l.Quantity <= 0 || l.Quantity > math.MaxUint32 {
+			return nil, ErrInvalidParameter
+		}
+		refundLines = append(refundLines, orderModel.RefundLine{ProductID: l.ProductID, Quantity: uint32(l.Quantity)})
+	}
+
+	_, refund, err := r.server.orderClient.RefundOrder(c, id, refundLines, valueOrEmpty(reason), requestActor(c))
+	if err != nil {
+		return nil, handleError("RefundOrder", err)
+	}
+	return refundFromModel(refund), nil
+}
+
+// PurgeOrder deletes an order for good, only admins may purge
+func (r *mutationResolver) PurgeOrder(c context.Context, id string) (*DeleteResponse, error) {
+	c, cancel := context.WithTimeout(c, 3*time.Second)
+	defer cancel()
+
+	if !isAdmin(c) {
+		return nil, ErrForbidden
+	}
+	res, err := r.server.orderClient.DeleteOrder(c, id)
+	if err != nil {
+		return nil, handleError("PurgeOrder", err)
+	}
+	return &DeleteResponse{
+		DeletedID: id,
+		Success:   res.Success,
+		Message:   res.Message,
+	}, nil
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"log"
+	"math"
+	"time"
+
+	orderModel "github.com/wignn/micro-3/order/model"
+)
+
+func (r *orderResolver) Shipments(c context.Context, o *Order) ([]*Shipment, error) {
+	c, cancel := context.WithTimeout(c, 3*time.Second)
+	defer cancel()
+
+	shipments, err := r.server.orderClient.GetOrderShipments(c, o.ID)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	res := []*Shipment{}
+	for _, s := range shipments {
+		res = append(res, shipmentFromModel(s))
+	}
+	return res, nil
+}
+
+// CreateShipment packs lines of a paid order into a shipment, without lines
+// every unit not shipped yet is packed, only admins may ship
+func (r *mutationResolver) CreateShipment(c context.Context, orderID string, carrier string, trackingNumber *string, lines []*ShipmentLineInput, shippingAddress *AddressInput) (*Shipment, error) {
+	c, cancel := context.WithTimeout(c, 3*time.Second)
+	defer cancel()
+
+	if !isAdmin(c) {
+		return nil, ErrForbidden
+	}
+	shipmentLines := []orderModel.ShipmentLine{}
+	for _, l := range lines {
+		if l.Quantity <= 0 || l.Quantity > math.MaxUint32 {
+			return nil, ErrInvalidParameter
+		}
+		shipmentLines = append(shipmentLines, orderModel.ShipmentLine{ProductID: l.ProductID, Quantity: uint32(l.Quantity)})
+	}
+
+	_, shipment, err := r.server.orderClient.CreateShipment(c, orderID, carrier, valueOrEmpty(trackingNumber), shipmentLines, addressFromInput(shippingAddress), requestActor(c))
+	if err != nil {
+		return nil, orderError("CreateShipment", err)
+	}
+	return shipmentFromModel(shipment), nil
+}
+
+// UpdateShipment changes the status, carrier or tracking number of a
+// shipment, the order status follows its shipments. Only admins may update.
+func (r *mutationResolver) UpdateShipment(c context.Context, id string, status *string, carrier *string, trackingNumber *string, at *time.Time) (*Shipment, error) {
+	c, cancel := context.WithTimeout(c, 3*time.Second)
+	defer cancel()
+
+	if !isAdmin(c) {
+		return nil, ErrForbidden
+	}
+	var changedAt time.Time
+	if at != nil {
+		changedAt = *at
+	}
+	_, shipment, err := r.server.orderClient.UpdateShipment(c, id, valueOrEmpty(status), valueOrEmpty(carrier), valueOrEmpty(trackingNumber), changedAt, requestActor(c))
+	if err != nil {
+		return nil, handleError("UpdateShipment", err)
+	}
+	return shipmentFromModel(shipment), nil
+}
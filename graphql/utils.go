@@ -12,3 +12,10 @@ func handleError(context string, err error) error {
 	log.Printf("[ERROR] %s: %v\n", context, err)
 	return fmt.Errorf("%s: %w", context, err)
 }
+
+func valueOrEmpty(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
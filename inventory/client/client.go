@@ -0,0 +1,123 @@
+package client
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/wignn/micro-3/inventory/genproto"
+	"github.com/wignn/micro-3/inventory/model"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+type InventoryClient struct {
+	conn    *grpc.ClientConn
+	service genproto.InventoryServiceClient
+}
+
+func NewClient(url string) (*InventoryClient, error) {
+	conn, err := grpc.NewClient(url, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		log.Printf("failed to connect to inventory service: %v\n", err)
+		return nil, err
+	}
+
+	c := genproto.NewInventoryServiceClient(conn)
+	return &InventoryClient{conn: conn, service: c}, nil
+}
+
+func (cl *InventoryClient) Close() {
+	cl.conn.Close()
+}
+
+func stockFromProto(s *genproto.Stock) *model.Stock {
+	return &model.Stock{
+		ProductID:   s.ProductId,
+		WarehouseID: s.WarehouseId,
+		OnHand:      s.OnHand,
+		Reserved:    s.Reserved,
+	}
+}
+
+func reservationFromProto(r *genproto.Reservation) *model.Reservation {
+	res := &model.Reservation{
+		OrderID: r.OrderId,
+		Status:  r.Status,
+		Items:   []model.ReservationItem{},
+	}
+	res.CreatedAt = time.Time{}
+	res.CreatedAt.UnmarshalBinary(r.CreatedAt)
+	res.UpdatedAt = time.Time{}
+	res.UpdatedAt.UnmarshalBinary(r.UpdatedAt)
+	for _, item := range r.Items {
+		res.Items = append(res.Items, model.ReservationItem{
+			ProductID:   item.ProductId,
+			WarehouseID: item.WarehouseId,
+			Quantity:    item.Quantity,
+		})
+	}
+	return res
+}
+
+func (cl *InventoryClient) SetStock(c context.Context, productID, warehouseID string, onHand uint32) (*model.Stock, error) {
+	r, err := cl.service.SetStock(c, &genproto.SetStockRequest{
+		ProductId:   productID,
+		WarehouseId: warehouseID,
+		OnHand:      onHand,
+	})
+	if err != nil {
+		log.Printf("failed to set stock: %v\n", err)
+		return nil, err
+	}
+	return stockFromProto(r.Stock), nil
+}
+
+func (cl *InventoryClient) GetStock(c context.Context, productIDs []string) ([]*model.Stock, error) {
+	r, err := cl.service.GetStock(c, &genproto.GetStockRequest{ProductIds: productIDs})
+	if err != nil {
+		log.Printf("failed to get stock: %v\n", err)
+		return nil, err
+	}
+	stock := []*model.Stock{}
+	for _, s := range r.Stock {
+		stock = append(stock, stockFromProto(s))
+	}
+	return stock, nil
+}
+
+// Reserve holds stock for an order, calling it again for the same order
+// returns the existing reservation
+func (cl *InventoryClient) Reserve(c context.Context, orderID string, items []model.ReservationItem) (*model.Reservation, error) {
+	protoItems := []*genproto.ReservationItem{}
+	for _, item := range items {
+		protoItems = append(protoItems, &genproto.ReservationItem{
+			ProductId: item.ProductID,
+			Quantity:  item.Quantity,
+		})
+	}
+	r, err := cl.service.Reserve(c, &genproto.ReserveRequest{OrderId: orderID, Items: protoItems})
+	if err != nil {
+		log.Printf("failed to reserve stock: %v\n", err)
+		return nil, err
+	}
+	return reservationFromProto(r.Reservation), nil
+}
+
+func (cl *InventoryClient) Commit(c context.Context, orderID string) (*model.Reservation, error) {
+	r, err := cl.service.Commit(c, &genproto.OrderReservationRequest{OrderId: orderID})
+	if err != nil {
+		log.Printf("failed to commit reservation: %v\n", err)
+		return nil, err
+	}
+	return reservationFromProto(r.Reservation), nil
+}
+
+func (cl *InventoryClient) Release(c context.Context, orderID string) (*model.Reservation, error) {
+	r, err := cl.service.Release(c, &genproto.OrderReservationRequest{OrderId: orderID})
+	if err != nil {
+		log.Printf("failed to release reservation: %v\n", err)
+		return nil, err
+	}
+	return reservationFromProto(r.Reservation), nil
+}
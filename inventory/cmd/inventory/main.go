@@ -0,0 +1,46 @@
+package main
+
+import (
+	"log"
+	"time"
+
+	"github.com/kelseyhightower/envconfig"
+	"github.com/tinrab/retry"
+	catalog "github.com/wignn/micro-3/catalog/client"
+	"github.com/wignn/micro-3/inventory/repository"
+	"github.com/wignn/micro-3/inventory/server"
+	"github.com/wignn/micro-3/inventory/service"
+)
+
+type Config struct {
+	DatabaseURL string `envconfig:"DATABASE_URL"`
+	CatalogURL  string `envconfig:"CATALOG_SERVICE_URL"`
+	PORT        int    `envconfig:"PORT" default:"50051"`
+}
+
+func main() {
+	var cfg Config
+	if err := envconfig.Process("", &cfg); err != nil {
+		log.Fatal("Failed to process environment variables:", err)
+	}
+
+	var r repository.InventoryRepository
+	retry.ForeverSleep(2*time.Second, func(_ int) (err error) {
+		r, err = repository.NewPostgresRepository(cfg.DatabaseURL)
+		if err != nil {
+			log.Println(err)
+		}
+		return
+	})
+	defer r.Close()
+
+	catalogClient, err := catalog.NewClient(cfg.CatalogURL)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer catalogClient.Close()
+
+	log.Println("listening on port", cfg.PORT)
+	s := service.NewInventoryService(r, catalogClient)
+	log.Fatal(server.ListenGRPC(s, cfg.PORT))
+}
@@ -0,0 +1,54 @@
+package model
+
+import "time"
+
+// DefaultWarehouse is used for stock set without a warehouse
+const DefaultWarehouse = "default"
+
+// Stock is the quantity of a product held in a warehouse, Reserved units
+// belong to placed orders and are not available for new ones
+type Stock struct {
+	ProductID   string
+	WarehouseID string
+	OnHand      uint32
+	Reserved    uint32
+}
+
+func (s *Stock) Available() uint32 {
+	return s.OnHand - s.Reserved
+}
+
+// Availability tells whether a product had units available in any warehouse
+// before and after a stock write, both read in the transaction of the write
+type Availability struct {
+	ProductID string
+	Before    bool
+	After     bool
+}
+
+// Changed tells whether the write made the product go in or out of stock
+func (a Availability) Changed() bool {
+	return a.Before != a.After
+}
+
+const (
+	ReservationReserved  = "reserved"
+	ReservationCommitted = "committed"
+	ReservationReleased  = "released"
+)
+
+// Reservation holds stock for an order until it is committed, which removes
+// the units from stock, or released, which makes them available again
+type Reservation struct {
+	OrderID   string
+	Status    string
+	Items     []ReservationItem
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type ReservationItem struct {
+	ProductID   string
+	WarehouseID string
+	Quantity    uint32
+}
@@ -0,0 +1,410 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/wignn/micro-3/inventory/model"
+)
+
+var (
+	ErrNotFound             = errors.New("reservation not found")
+	ErrInsufficientStock    = errors.New("insufficient stock")
+	ErrBelowReserved        = errors.New("stock can't be set below the reserved quantity")
+	ErrReservationReleased  = errors.New("reservation was already released")
+	ErrReservationCommitted = errors.New("reservation was already committed")
+)
+
+type InventoryRepository interface {
+	Close()
+	SetStock(c context.Context, productID, warehouseID string, onHand uint32) (*model.Stock, *model.Availability, error)
+	GetStock(c context.Context, productIDs []string) ([]*model.Stock, error)
+	GetReservation(c context.Context, orderID string) (*model.Reservation, error)
+	Reserve(c context.Context, orderID string, items []model.ReservationItem) (*model.Reservation, []model.Availability, error)
+	Commit(c context.Context, orderID string) (*model.Reservation, error)
+	Release(c context.Context, orderID string) (*model.Reservation, []model.Availability, error)
+}
+
+type postgresRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresRepository(url string) (InventoryRepository, error) {
+	db, err := sql.Open("postgres", url)
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.Ping()
+	if err != nil {
+		return nil, err
+	}
+	return &postgresRepository{db}, nil
+}
+
+func (r *postgresRepository) Close() {
+	r.db.Close()
+}
+
+// inTx runs fn in a transaction that is committed when fn succeeds
+func (r *postgresRepository) inTx(c context.Context, fn func(tx *sql.Tx) error) (err error) {
+	tx, err := r.db.BeginTx(c, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+	return fn(tx)
+}
+
+// SetStock sets the units of a product held in a warehouse and returns its
+// availability around the change
+func (r *postgresRepository) SetStock(c context.Context, productID, warehouseID string, onHand uint32) (*model.Stock, *model.Availability, error) {
+	s := &model.Stock{ProductID: productID, WarehouseID: warehouseID}
+	var availability []model.Availability
+	err := r.inTx(c, func(tx *sql.Tx) error {
+		return trackAvailability(c, tx, []string{productID}, &availability, func() error {
+			return tx.QueryRowContext(
+				c,
+				`INSERT INTO stock(product_id, warehouse_id, on_hand) VALUES($1, $2, $3)
+				ON CONFLICT (product_id, warehouse_id) DO UPDATE SET on_hand = EXCLUDED.on_hand
+				RETURNING on_hand, reserved`,
+				productID,
+				warehouseID,
+				onHand,
+			).Scan(&s.OnHand, &s.Reserved)
+		})
+	})
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "check_violation" {
+			return nil, nil, ErrBelowReserved
+		}
+		return nil, nil, err
+	}
+	return s, &availability[0], nil
+}
+
+func (r *postgresRepository) GetStock(c context.Context, productIDs []string) ([]*model.Stock, error) {
+	rows, err := r.db.QueryContext(
+		c,
+		`SELECT product_id, warehouse_id, on_hand, reserved
+		FROM stock
+		WHERE product_id = ANY($1)
+		ORDER BY product_id, warehouse_id`,
+		pq.Array(productIDs),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	stock := []*model.Stock{}
+	for rows.Next() {
+		s := &model.Stock{}
+		if err := rows.Scan(&s.ProductID, &s.WarehouseID, &s.OnHand, &s.Reserved); err != nil {
+			return nil, err
+		}
+		stock = append(stock, s)
+	}
+	return stock, rows.Err()
+}
+
+func (r *postgresRepository) GetReservation(c context.Context, orderID string) (*model.Reservation, error) {
+	return getReservation(c, r.db, orderID, false)
+}
+
+type querier interface {
+	QueryContext(c context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(c context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// getReservation reads a reservation with its items, forUpdate locks the
+// reservation row until the end of the transaction
+func getReservation(c context.Context, q querier, orderID string, forUpdate bool) (*model.Reservation, error) {
+	query := "SELECT order_id, status, created_at, updated_at FROM reservations WHERE order_id = $1"
+	if forUpdate {
+		query += " FOR UPDATE"
+	}
+	res := &model.Reservation{}
+	err := q.QueryRowContext(c, query, orderID).Scan(&res.OrderID, &res.Status, &res.CreatedAt, &res.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := q.QueryContext(
+		c,
+		`SELECT product_id, warehouse_id, quantity
+		FROM reservation_items
+		WHERE order_id = $1
+		ORDER BY product_id, warehouse_id`,
+		orderID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res.Items = []model.ReservationItem{}
+	for rows.Next() {
+		item := model.ReservationItem{}
+		if err := rows.Scan(&item.ProductID, &item.WarehouseID, &item.Quantity); err != nil {
+			return nil, err
+		}
+		res.Items = append(res.Items, item)
+	}
+	return res, rows.Err()
+}
+
+// Reserve holds stock for every item of an order, taking it from the
+// warehouses with the most available units first. Either every item is
+// reserved or none is. Reserving an order that already has a reservation
+// returns the existing one unchanged and no availability.
+func (r *postgresRepository) Reserve(c context.Context, orderID string, items []model.ReservationItem) (*model.Reservation, []model.Availability, error) {
+	// merge duplicated products and lock stock rows in a stable order to avoid deadlocks
+	quantities := map[string]uint32{}
+	productIDs := []string{}
+	for _, item := range items {
+		if _, ok := quantities[item.ProductID]; !ok {
+			productIDs = append(productIDs, item.ProductID)
+		}
+		quantities[item.ProductID] += item.Quantity
+	}
+	sort.Strings(productIDs)
+
+	var availability []model.Availability
+	err := r.inTx(c, func(tx *sql.Tx) error {
+		now := time.Now().UTC()
+		res, err := tx.ExecContext(
+			c,
+			`INSERT INTO reservations(order_id, status, created_at, updated_at) VALUES($1, $2, $3, $3)
+			ON CONFLICT (order_id) DO NOTHING`,
+			orderID,
+			model.ReservationReserved,
+			now,
+		)
+		if err != nil {
+			return err
+		}
+		if n, err := res.RowsAffected(); err != nil || n == 0 {
+			// a reservation for the order already exists
+			return err
+		}
+
+		return trackAvailability(c, tx, productIDs, &availability, func() error {
+			for _, productID := range productIDs {
+				if err := reserveProduct(c, tx, orderID, productID, quantities[productID]); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	res, err := r.GetReservation(c, orderID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return res, availability, nil
+}
+
+func reserveProduct(c context.Context, tx *sql.Tx, orderID, productID string, quantity uint32) error {
+	rows, err := tx.QueryContext(
+		c,
+		`SELECT warehouse_id, on_hand - reserved
+		FROM stock
+		WHERE product_id = $1 AND on_hand > reserved
+		ORDER BY on_hand - reserved DESC, warehouse_id
+		FOR UPDATE`,
+		productID,
+	)
+	if err != nil {
+		return err
+	}
+
+	allocations := []model.ReservationItem{}
+	remaining := quantity
+	for rows.Next() && remaining > 0 {
+		var warehouseID string
+		var available uint32
+		if err := rows.Scan(&warehouseID, &available); err != nil {
+			rows.Close()
+			return err
+		}
+		take := min(available, remaining)
+		allocations = append(allocations, model.ReservationItem{
+			ProductID:   productID,
+			WarehouseID: warehouseID,
+			Quantity:    take,
+		})
+		remaining -= take
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if remaining > 0 {
+		return fmt.Errorf("%w for product %s", ErrInsufficientStock, productID)
+	}
+
+	for _, a := range allocations {
+		if _, err := tx.ExecContext(
+			c,
+			"UPDATE stock SET reserved = reserved + $3 WHERE product_id = $1 AND warehouse_id = $2",
+			a.ProductID,
+			a.WarehouseID,
+			a.Quantity,
+		); err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(
+			c,
+			"INSERT INTO reservation_items(order_id, product_id, warehouse_id, quantity) VALUES($1, $2, $3, $4)",
+			orderID,
+			a.ProductID,
+			a.WarehouseID,
+			a.Quantity,
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Commit removes the reserved units from stock, committing twice is a no-op.
+// The available units don't change.
+func (r *postgresRepository) Commit(c context.Context, orderID string) (*model.Reservation, error) {
+	res, _, err := r.transition(c, orderID, model.ReservationCommitted,
+		`UPDATE stock s
+		SET on_hand = s.on_hand - i.quantity, reserved = s.reserved - i.quantity
+		FROM reservation_items i
+		WHERE i.order_id = $1 AND s.product_id = i.product_id AND s.warehouse_id = i.warehouse_id`)
+	return res, err
+}
+
+// Release makes the reserved units available again, releasing twice is a no-op
+func (r *postgresRepository) Release(c context.Context, orderID string) (*model.Reservation, []model.Availability, error) {
+	return r.transition(c, orderID, model.ReservationReleased,
+		`UPDATE stock s
+		SET reserved = s.reserved - i.quantity
+		FROM reservation_items i
+		WHERE i.order_id = $1 AND s.product_id = i.product_id AND s.warehouse_id = i.warehouse_id`)
+}
+
+// transition moves a reserved reservation to status and applies stockUpdate,
+// a reservation already in status returns no availability
+func (r *postgresRepository) transition(c context.Context, orderID, status, stockUpdate string) (*model.Reservation, []model.Availability, error) {
+	var res *model.Reservation
+	var availability []model.Availability
+	err := r.inTx(c, func(tx *sql.Tx) error {
+		var err error
+		res, err = getReservation(c, tx, orderID, true)
+		if err != nil {
+			return err
+		}
+		if res.Status == status {
+			return nil
+		}
+		switch res.Status {
+		case model.ReservationReleased:
+			return ErrReservationReleased
+		case model.ReservationCommitted:
+			return ErrReservationCommitted
+		}
+
+		productIDs := []string{}
+		for _, item := range res.Items {
+			productIDs = append(productIDs, item.ProductID)
+		}
+		err = trackAvailability(c, tx, productIDs, &availability, func() error {
+			_, err := tx.ExecContext(c, stockUpdate, orderID)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		res.Status = status
+		res.UpdatedAt = time.Now().UTC()
+		_, err = tx.ExecContext(
+			c,
+			"UPDATE reservations SET status = $2, updated_at = $3 WHERE order_id = $1",
+			orderID,
+			res.Status,
+			res.UpdatedAt,
+		)
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return res, availability, nil
+}
+
+// trackAvailability runs change with the stock rows of products locked, so
+// that no other write moves them in between, and stores their availability
+// before and after it
+func trackAvailability(c context.Context, tx *sql.Tx, productIDs []string, availability *[]model.Availability, change func() error) error {
+	before, err := lockAvailability(c, tx, productIDs)
+	if err != nil {
+		return err
+	}
+	if err := change(); err != nil {
+		return err
+	}
+	after, err := lockAvailability(c, tx, productIDs)
+	if err != nil {
+		return err
+	}
+	*availability = []model.Availability{}
+	seen := map[string]bool{}
+	for _, id := range productIDs {
+		if !seen[id] {
+			seen[id] = true
+			*availability = append(*availability, model.Availability{ProductID: id, Before: before[id], After: after[id]})
+		}
+	}
+	return nil
+}
+
+// lockAvailability locks the stock rows of products, in the order every
+// write locks them, and tells which have units available in any warehouse
+func lockAvailability(c context.Context, tx *sql.Tx, productIDs []string) (map[string]bool, error) {
+	rows, err := tx.QueryContext(
+		c,
+		`SELECT product_id, on_hand > reserved
+		FROM stock
+		WHERE product_id = ANY($1)
+		ORDER BY product_id, warehouse_id
+		FOR UPDATE`,
+		pq.Array(productIDs),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	available := map[string]bool{}
+	for rows.Next() {
+		var productID string
+		var inStock bool
+		if err := rows.Scan(&productID, &inStock); err != nil {
+			return nil, err
+		}
+		available[productID] = available[productID] || inStock
+	}
+	return available, rows.Err()
+}
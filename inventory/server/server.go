@@ -0,0 +1,136 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+
+	"github.com/wignn/micro-3/inventory/genproto"
+	"github.com/wignn/micro-3/inventory/model"
+	"github.com/wignn/micro-3/inventory/repository"
+	"github.com/wignn/micro-3/inventory/service"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+)
+
+type grpcServer struct {
+	service service.InventoryService
+	genproto.UnimplementedInventoryServiceServer
+}
+
+func ListenGRPC(s service.InventoryService, port int) error {
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	if err != nil {
+		return err
+	}
+	serv := grpc.NewServer()
+	genproto.RegisterInventoryServiceServer(serv, &grpcServer{service: s})
+	reflection.Register(serv)
+	return serv.Serve(lis)
+}
+
+// toStatus maps service errors to grpc codes so that callers such as the
+// order service can tell a missing reservation or stock shortage from a failure
+func toStatus(err error) error {
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, repository.ErrInsufficientStock),
+		errors.Is(err, repository.ErrBelowReserved),
+		errors.Is(err, repository.ErrReservationReleased),
+		errors.Is(err, repository.ErrReservationCommitted):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, service.ErrMissingOrderID),
+		errors.Is(err, service.ErrMissingProductID),
+		errors.Is(err, service.ErrInvalidQuantity):
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return err
+}
+
+func stockToProto(s *model.Stock) *genproto.Stock {
+	return &genproto.Stock{
+		ProductId:   s.ProductID,
+		WarehouseId: s.WarehouseID,
+		OnHand:      s.OnHand,
+		Reserved:    s.Reserved,
+	}
+}
+
+func reservationToProto(r *model.Reservation) *genproto.ReservationResponse {
+	res := &genproto.Reservation{
+		OrderId: r.OrderID,
+		Status:  r.Status,
+		Items:   []*genproto.ReservationItem{},
+	}
+	res.CreatedAt, _ = r.CreatedAt.MarshalBinary()
+	res.UpdatedAt, _ = r.UpdatedAt.MarshalBinary()
+	for _, item := range r.Items {
+		res.Items = append(res.Items, &genproto.ReservationItem{
+			ProductId:   item.ProductID,
+			WarehouseId: item.WarehouseID,
+			Quantity:    item.Quantity,
+		})
+	}
+	return &genproto.ReservationResponse{Reservation: res}
+}
+
+func (s *grpcServer) SetStock(c context.Context, r *genproto.SetStockRequest) (*genproto.StockResponse, error) {
+	stock, err := s.service.SetStock(c, r.ProductId, r.WarehouseId, r.OnHand)
+	if err != nil {
+		log.Printf("failed to set stock of product %s: %v\n", r.ProductId, err)
+		return nil, toStatus(err)
+	}
+	return &genproto.StockResponse{Stock: stockToProto(stock)}, nil
+}
+
+func (s *grpcServer) GetStock(c context.Context, r *genproto.GetStockRequest) (*genproto.GetStockResponse, error) {
+	stock, err := s.service.GetStock(c, r.ProductIds)
+	if err != nil {
+		log.Println("failed to get stock:", err)
+		return nil, toStatus(err)
+	}
+	res := &genproto.GetStockResponse{Stock: []*genproto.Stock{}}
+	for _, st := range stock {
+		res.Stock = append(res.Stock, stockToProto(st))
+	}
+	return res, nil
+}
+
+func (s *grpcServer) Reserve(c context.Context, r *genproto.ReserveRequest) (*genproto.ReservationResponse, error) {
+	items := []model.ReservationItem{}
+	for _, item := range r.Items {
+		items = append(items, model.ReservationItem{
+			ProductID: item.ProductId,
+			Quantity:  item.Quantity,
+		})
+	}
+	res, err := s.service.Reserve(c, r.OrderId, items)
+	if err != nil {
+		log.Printf("failed to reserve stock for order %s: %v\n", r.OrderId, err)
+		return nil, toStatus(err)
+	}
+	return reservationToProto(res), nil
+}
+
+func (s *grpcServer) Commit(c context.Context, r *genproto.OrderReservationRequest) (*genproto.ReservationResponse, error) {
+	res, err := s.service.Commit(c, r.OrderId)
+	if err != nil {
+		log.Printf("failed to commit reservation of order %s: %v\n", r.OrderId, err)
+		return nil, toStatus(err)
+	}
+	return reservationToProto(res), nil
+}
+
+func (s *grpcServer) Release(c context.Context, r *genproto.OrderReservationRequest) (*genproto.ReservationResponse, error) {
+	res, err := s.service.Release(c, r.OrderId)
+	if err != nil {
+		log.Printf("failed to release reservation of order %s: %v\n", r.OrderId, err)
+		return nil, toStatus(err)
+	}
+	return reservationToProto(res), nil
+}
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/tinrab/retry"
+	catalog "github.com/wignn/micro-3/catalog/client"
+	"github.com/wignn/micro-3/inventory/model"
+	"github.com/wignn/micro-3/inventory/repository"
+)
+
+var (
+	ErrMissingOrderID   = errors.New("order id is required")
+	ErrMissingProductID = errors.New("product id is required")
+	ErrInvalidQuantity  = errors.New("quantity must be greater than zero")
+)
+
+type InventoryService interface {
+	SetStock(c context.Context, productID, warehouseID string, onHand uint32) (*model.Stock, error)
+	GetStock(c context.Context, productIDs []string) ([]*model.Stock, error)
+	Reserve(c context.Context, orderID string, items []model.ReservationItem) (*model.Reservation, error)
+	Commit(c context.Context, orderID string) (*model.Reservation, error)
+	Release(c context.Context, orderID string) (*model.Reservation, error)
+}
+
+type inventoryService struct {
+	repository    repository.InventoryRepository
+	catalogClient *catalog.CatalogClient
+}
+
+func NewInventoryService(r repository.InventoryRepository, catalogClient *catalog.CatalogClient) InventoryService {
+	return &inventoryService{r, catalogClient}
+}
+
+func (s *inventoryService) SetStock(c context.Context, productID, warehouseID string, onHand uint32) (*model.Stock, error) {
+	if productID == "" {
+		return nil, ErrMissingProductID
+	}
+	warehouseID = strings.TrimSpace(warehouseID)
+	if warehouseID == "" {
+		warehouseID = model.DefaultWarehouse
+	}
+	stock, availability, err := s.repository.SetStock(c, productID, warehouseID, onHand)
+	if err != nil {
+		return nil, err
+	}
+	// setting stock also gives products without a flag yet their first one
+	s.setInStock(c, availability.ProductID, availability.After)
+	return stock, nil
+}
+
+func (s *inventoryService) GetStock(c context.Context, productIDs []string) ([]*model.Stock, error) {
+	if len(productIDs) == 0 {
+		return []*model.Stock{}, nil
+	}
+	return s.repository.GetStock(c, productIDs)
+}
+
+// Reserve is idempotent by order id, retrying a reservation returns the
+// reservation made by the first call
+func (s *inventoryService) Reserve(c context.Context, orderID string, items []model.ReservationItem) (*model.Reservation, error) {
+	if orderID == "" {
+		return nil, ErrMissingOrderID
+	}
+	for _, item := range items {
+		if item.ProductID == "" {
+			return nil, ErrMissingProductID
+		}
+		if item.Quantity == 0 {
+			return nil, ErrInvalidQuantity
+		}
+	}
+	res, availability, err := s.repository.Reserve(c, orderID, items)
+	if err != nil {
+		return nil, err
+	}
+	s.trackAvailability(c, availability)
+	return res, nil
+}
+
+func (s *inventoryService) Commit(c context.Context, orderID string) (*model.Reservation, error) {
+	if orderID == "" {
+		return nil, ErrMissingOrderID
+	}
+	return s.repository.Commit(c, orderID)
+}
+
+func (s *inventoryService) Release(c context.Context, orderID string) (*model.Reservation, error) {
+	if orderID == "" {
+		return nil, ErrMissingOrderID
+	}
+	res, availability, err := s.repository.Release(c, orderID)
+	if err != nil {
+		return nil, err
+	}
+	s.trackAvailability(c, availability)
+	return res, nil
+}
+
+// trackAvailability tells the catalog about products whose available units
+// crossed zero in a stock write, committing a reservation doesn't change them
+func (s *inventoryService) trackAvailability(c context.Context, availability []model.Availability) {
+	for _, a := range availability {
+		if a.Changed() {
+			s.setInStock(c, a.ProductID, a.After)
+		}
+	}
+}
+
+// setInStock updates the stock flag of a product in the catalog. The stock
+// is already written and the flag only ranks search results, so failures
+// are retried a few times and then logged.
+func (s *inventoryService) setInStock(c context.Context, productID string, inStock bool) {
+	err := retry.DoSleep(3, 200*time.Millisecond, func(_ int) error {
+		return s.catalogClient.SetProductInStock(c, productID, inStock)
+	})
+	if err != nil {
+		log.Printf("gave up setting the stock flag of product %s to %t: %v\n", productID, inStock, err)
+	}
+}
@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS stock (
+  product_id CHAR(27) NOT NULL,
+  warehouse_id VARCHAR(64) NOT NULL,
+  on_hand INT NOT NULL DEFAULT 0 CHECK (on_hand >= 0),
+  reserved INT NOT NULL DEFAULT 0 CHECK (reserved >= 0 AND reserved <= on_hand),
+  PRIMARY KEY (product_id, warehouse_id)
+);
+
+CREATE TABLE IF NOT EXISTS reservations (
+  order_id CHAR(27) PRIMARY KEY,
+  status VARCHAR(16) NOT NULL,
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+  updated_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS reservation_items (
+  order_id CHAR(27) REFERENCES reservations (order_id) ON DELETE CASCADE,
+  product_id CHAR(27) NOT NULL,
+  warehouse_id VARCHAR(64) NOT NULL,
+  quantity INT NOT NULL CHECK (quantity > 0),
+  PRIMARY KEY (order_id, product_id, warehouse_id)
+);
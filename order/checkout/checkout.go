@@ -0,0 +1,408 @@
+// Package checkout places orders as sagas: stock is reserved, the order is
+// created, the payment is authorized and everything is confirmed. Progress
+// is stored after every step so that a checkout interrupted by a crash is
+// resumed by whichever order instance claims it next, and the steps that ran
+// before a failure are compensated in reverse order.
+package checkout
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/segmentio/ksuid"
+	"github.com/wignn/micro-3/currency"
+	inventory "github.com/wignn/micro-3/inventory/client"
+	inventoryModel "github.com/wignn/micro-3/inventory/model"
+	"github.com/wignn/micro-3/order/model"
+	"github.com/wignn/micro-3/order/repository"
+	"github.com/wignn/micro-3/order/service"
+	payment "github.com/wignn/micro-3/payment/client"
+	paymentModel "github.com/wignn/micro-3/payment/model"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	ErrMissingPaymentMethod = errors.New("missing payment method")
+	ErrPaymentDeclined      = errors.New("payment was declined")
+	ErrPaymentTimeout       = errors.New("payment was not confirmed in time")
+)
+
+// actor is recorded on the order status changes made by checkouts
+const actor = "checkout"
+
+// errAwaitingPayment stops a checkout until the provider confirms the authorization
+var errAwaitingPayment = errors.New("awaiting payment confirmation")
+
+// forwardError is a failure after the payment was captured, the checkout
+// can't be undone anymore and the step is retried until it succeeds
+type forwardError struct {
+	err error
+}
+
+func (e *forwardError) Error() string { return e.err.Error() }
+func (e *forwardError) Unwrap() error { return e.err }
+
+type Options struct {
+	// Lease is how long a running checkout is held before another instance
+	// may take it over
+	Lease time.Duration
+	// Timeout bounds one run of a checkout
+	Timeout time.Duration
+	// PaymentTimeout is how long a pending authorization is waited for
+	PaymentTimeout time.Duration
+}
+
+type Orchestrator struct {
+	repository      repository.OrderRepository
+	service         service.OrderService
+	inventoryClient *inventory.InventoryClient
+	paymentClient   *payment.PaymentClient
+	options         Options
+}
+
+func NewOrchestrator(r repository.OrderRepository, s service.OrderService, inventoryClient *inventory.InventoryClient, paymentClient *payment.PaymentClient, options Options) *Orchestrator {
+	return &Orchestrator{r, s, inventoryClient, paymentClient, options}
+}
+
+// Start stores a checkout of co.Products and runs it. The returned error is
+// the failure that made the checkout compensate, the checkout is returned
+// in every case once stored. A payment method adds the payment steps.
+func (o *Orchestrator) Start(c context.Context, co *model.Checkout) (*model.Checkout, error) {
+	now := time.Now().UTC()
+	co.ID = ksuid.New().String()
+	co.OrderID = ksuid.New().String()
+	co.Status = model.CheckoutRunning
+	co.LeaseOwner = ksuid.New().String()
+	co.CreatedAt, co.UpdatedAt = now, now
+	names := []string{model.StepReserveStock, model.StepCreateOrder}
+	if co.PaymentMethod != "" {
+		names = append(names, model.StepAuthorizePayment, model.StepConfirm)
+	}
+	co.Steps = []model.CheckoutStep{}
+	for _, name := range names {
+		co.Steps = append(co.Steps, model.CheckoutStep{Name: name, Status: model.StepPending, UpdatedAt: now})
+	}
+	if err := o.repository.CreateCheckout(c, co, now.Add(o.options.Lease)); err != nil {
+		return nil, err
+	}
+
+	// the checkout must reach a consistent state even if the caller gives up
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(c), o.options.Timeout)
+	defer cancel()
+	return co, o.run(ctx, co)
+}
+
+func (o *Orchestrator) GetCheckout(c context.Context, id string) (*model.Checkout, error) {
+	return o.repository.GetCheckout(c, id)
+}
+
+func (o *Orchestrator) GetCheckoutForOrder(c context.Context, orderID string) (*model.Checkout, error) {
+	return o.repository.GetCheckoutForOrder(c, orderID)
+}
+
+// Resume runs at most limit unfinished checkouts whose lease ran out: those
+// interrupted by a crash and those waiting for their payment or a retry
+func (o *Orchestrator) Resume(c context.Context, limit int) (int, error) {
+	n := 0
+	for n < limit {
+		// claimed one at a time so that the lease doesn't run out while
+		// earlier checkouts are run
+		checkouts, err := o.repository.ClaimCheckouts(c, 1, time.Now().UTC().Add(o.options.Lease), ksuid.New().String())
+		if err != nil {
+			return n, err
+		}
+		if len(checkouts) == 0 {
+			break
+		}
+		co := checkouts[0]
+		ctx, cancel := context.WithTimeout(c, o.options.Timeout)
+		if err := o.run(ctx, co); err != nil {
+			log.Printf("checkout %s stopped in status %s: %v\n", co.ID, co.Status, err)
+		}
+		cancel()
+		n++
+	}
+	return n, nil
+}
+
+// run advances a checkout until it is finished or has to wait, the error is
+// the failure of the step that made it compensate or stop
+func (o *Orchestrator) run(c context.Context, co *model.Checkout) error {
+	var cause error
+	for !co.Finished() {
+		switch co.Status {
+		case model.CheckoutRunning:
+			step := co.NextStep()
+			if step == nil {
+				co.Status = model.CheckoutCompleted
+				return o.save(c, co)
+			}
+			err := o.do(c, co, step.Name)
+			var forward *forwardError
+			switch {
+			case errors.Is(err, errAwaitingPayment):
+				// the payment timeout counts from here
+				o.setStep(step, model.StepPending, nil)
+				co.Status = model.CheckoutAwaitingPayment
+				return o.save(c, co)
+			case errors.As(err, &forward):
+				log.Printf("checkout %s will retry %s: %v\n", co.ID, step.Name, err)
+				o.setStep(step, step.Status, err)
+				if saveErr := o.save(c, co); saveErr != nil {
+					return errors.Join(err, saveErr)
+				}
+				return err
+			case err != nil:
+				o.fail(co, step, err)
+				cause = err
+			default:
+				o.setStep(step, model.StepDone, nil)
+			}
+			if err := o.save(c, co); err != nil {
+				return err
+			}
+
+		case model.CheckoutAwaitingPayment:
+			err := o.checkPayment(c, co)
+			if errors.Is(err, errAwaitingPayment) {
+				return o.save(c, co)
+			}
+			if err != nil {
+				return err
+			}
+			if co.Status == model.CheckoutCompensating {
+				cause = errors.New(co.Error)
+			}
+			if err := o.save(c, co); err != nil {
+				return err
+			}
+
+		case model.CheckoutCompensating:
+			if err := o.compensate(c, co); err != nil {
+				log.Printf("checkout %s could not be compensated yet: %v\n", co.ID, err)
+				if saveErr := o.save(c, co); saveErr != nil {
+					err = errors.Join(err, saveErr)
+				}
+				if cause != nil {
+					return cause
+				}
+				return err
+			}
+			co.Status = model.CheckoutFailed
+			if err := o.save(c, co); err != nil {
+				return err
+			}
+		}
+	}
+	return cause
+}
+
+// do runs a step, steps may run again after a crash and must be idempotent
+func (o *Orchestrator) do(c context.Context, co *model.Checkout, step string) error {
+	switch step {
+	case model.StepReserveStock:
+		items := []inventoryModel.ReservationItem{}
+		for _, p := range co.Products {
+			items = append(items, inventoryModel.ReservationItem{ProductID: p.ID, Quantity: p.Quantity})
+		}
+		_, err := o.inventoryClient.Reserve(c, co.OrderID, items)
+		return err
+
+	case model.StepCreateOrder:
+		if _, err := o.service.GetOrder(c, co.OrderID); !errors.Is(err, repository.ErrNotFound) {
+			// created before a crash
+			return err
+		}
+		var key *model.IdempotencyKey
+		if co.IdempotencyKey != "" {
+			key = &model.IdempotencyKey{AccountID: co.AccountID, Key: co.IdempotencyKey, Fingerprint: co.Fingerprint}
+		}
+		_, err := o.service.PostOrder(c, co.OrderID, co.AccountID, co.Currency, co.Products, co.Coupons, co.ShippingAddress, key)
+		return err
+
+	case model.StepAuthorizePayment:
+		if co.PaymentIntentID == "" {
+			intent, err := o.paymentClient.CreatePaymentIntent(c, co.OrderID)
+			if err != nil {
+				return err
+			}
+			// stored before authorizing so that compensation finds it
+			co.PaymentIntentID = intent.ID
+			if err := o.save(c, co); err != nil {
+				return err
+			}
+		}
+		intent, err := o.paymentClient.AuthorizePayment(c, co.PaymentIntentID, co.PaymentMethod)
+		if err != nil {
+			return err
+		}
+		return authorization(intent)
+
+	case model.StepConfirm:
+		return o.confirm(c, co)
+	}
+	return fmt.Errorf("unknown checkout step %s", step)
+}
+
+// authorization tells whether an authorization went through, failed or is pending
+func authorization(intent *paymentModel.PaymentIntent) error {
+	switch intent.Status {
+	case paymentModel.IntentAuthorized, paymentModel.IntentCaptured:
+		return nil
+	case paymentModel.IntentPending:
+		return errAwaitingPayment
+	case paymentModel.IntentFailed:
+		return fmt.Errorf("%w: %s", ErrPaymentDeclined, intent.FailureReason)
+	}
+	return fmt.Errorf("%w: payment is %s", ErrPaymentDeclined, intent.Status)
+}
+
+// confirm captures the authorized amount, marks the order paid and commits the
+// reserved stock. A refused capture is compensated, once the payment is
+// captured the remaining work is retried until it succeeds.
+func (o *Orchestrator) confirm(c context.Context, co *model.Checkout) error {
+	if _, err := o.paymentClient.CapturePayment(c, co.PaymentIntentID, currency.Money{}); err != nil {
+		if status.Code(err) == codes.FailedPrecondition {
+			intent, getErr := o.paymentClient.GetPaymentIntent(c, co.PaymentIntentID)
+			if getErr == nil && intent.Status != paymentModel.IntentCaptured {
+				return err
+			}
+		}
+		// the capture may have gone through
+		return &forwardError{err}
+	}
+
+	order, err := o.service.GetOrder(c, co.OrderID)
+	if err != nil {
+		return &forwardError{err}
+	}
+	if order.Status == model.OrderPending {
+		// the payment service marks the order paid too, whichever is first wins
+		_, err := o.service.UpdateOrderStatus(c, co.OrderID, model.OrderPaid, actor, "checkout "+co.ID+" confirmed")
+		if err != nil && !errors.Is(err, repository.ErrInvalidTransition) {
+			return &forwardError{err}
+		}
+	}
+
+	_, err = o.inventoryClient.Commit(c, co.OrderID)
+	switch status.Code(err) {
+	case codes.OK:
+	case codes.NotFound, codes.FailedPrecondition:
+		// released by a cancellation in the meantime, nothing to commit
+		log.Printf("Not committing stock of order %s: %v\n", co.OrderID, err)
+	default:
+		return &forwardError{err}
+	}
+	return nil
+}
+
+// checkPayment moves a checkout waiting for its payment on once the
+// provider confirmed or declined the authorization
+func (o *Orchestrator) checkPayment(c context.Context, co *model.Checkout) error {
+	step := co.Step(model.StepAuthorizePayment)
+	intent, err := o.paymentClient.GetPaymentIntent(c, co.PaymentIntentID)
+	if err != nil {
+		return err
+	}
+	err = authorization(intent)
+	switch {
+	case errors.Is(err, errAwaitingPayment) && time.Since(step.UpdatedAt) < o.options.PaymentTimeout:
+		return err
+	case errors.Is(err, errAwaitingPayment):
+		o.fail(co, step, ErrPaymentTimeout)
+	case err != nil:
+		o.fail(co, step, err)
+	default:
+		o.setStep(step, model.StepDone, nil)
+		co.Status = model.CheckoutRunning
+	}
+	return nil
+}
+
+// compensate undoes the steps that ran, latest first
+func (o *Orchestrator) compensate(c context.Context, co *model.Checkout) error {
+	for i := len(co.Steps) - 1; i >= 0; i-- {
+		step := &co.Steps[i]
+		if step.Status != model.StepDone && step.Status != model.StepFailed {
+			continue
+		}
+		if err := o.undo(c, co, step.Name); err != nil {
+			return err
+		}
+		o.setStep(step, model.StepCompensated, nil)
+		if err := o.save(c, co); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// undo compensates a step, a failed step is undone too as it may have
+// partly happened before failing
+func (o *Orchestrator) undo(c context.Context, co *model.Checkout, step string) error {
+	switch step {
+	case model.StepReserveStock:
+		_, err := o.inventoryClient.Release(c, co.OrderID)
+		if code := status.Code(err); code == codes.NotFound || code == codes.FailedPrecondition {
+			return nil
+		}
+		return err
+
+	case model.StepCreateOrder:
+		_, err := o.service.CancelOrder(c, co.OrderID, "checkout "+co.ID+" failed", actor)
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil
+		}
+		return err
+
+	case model.StepAuthorizePayment:
+		if co.PaymentIntentID == "" {
+			return nil
+		}
+		_, err := o.paymentClient.VoidPayment(c, co.PaymentIntentID)
+		if status.Code(err) == codes.FailedPrecondition {
+			// nothing to void when the authorization failed
+			intent, getErr := o.paymentClient.GetPaymentIntent(c, co.PaymentIntentID)
+			if getErr == nil && !paymentModel.Active(intent.Status) {
+				return nil
+			}
+		}
+		return err
+	}
+	return nil
+}
+
+func (o *Orchestrator) fail(co *model.Checkout, step *model.CheckoutStep, err error) {
+	o.setStep(step, model.StepFailed, err)
+	co.Status = model.CheckoutCompensating
+	co.Error = fmt.Sprintf("%s: %v", step.Name, err)
+}
+
+func (o *Orchestrator) setStep(step *model.CheckoutStep, stepStatus string, err error) {
+	step.Status = stepStatus
+	step.Error = ""
+	if err != nil {
+		step.Error = err.Error()
+	}
+	step.UpdatedAt = time.Now().UTC()
+}
+
+// save stores the checkout and extends its lease. A checkout that stopped
+// unfinished, waiting for its payment or to retry a step, is picked up by
+// Resume once the lease ran out.
+func (o *Orchestrator) save(c context.Context, co *model.Checkout) error {
+	co.UpdatedAt = time.Now().UTC()
+	lockedUntil := co.UpdatedAt
+	if !co.Finished() {
+		lockedUntil = lockedUntil.Add(o.options.Lease)
+	}
+	if err := o.repository.SaveCheckout(c, co, lockedUntil); err != nil {
+		log.Printf("failed to save checkout %s: %v\n", co.ID, err)
+		return err
+	}
+	return nil
+}
@@ -2,9 +2,11 @@ package client
 
 import (
 	"context"
+	"io"
 	"log"
 	"time"
 
+	"github.com/wignn/micro-3/currency"
 	"github.com/wignn/micro-3/order/genproto"
 	"github.com/wignn/micro-3/order/model"
 	"google.golang.org/grpc"
@@ -31,44 +33,66 @@ func (c *OrderClient) Close() {
 func (c *OrderClient) PostOrder(
 	ctx context.Context,
 	accountID string,
+	currency string,
 	products []*model.OrderedProduct,
+	coupons []string,
+	address *model.Address,
+	idempotencyKey string,
 ) (*model.Order, error) {
-	protoProducts := []*genproto.PostOrderRequest_OrderProduct{}
-	for _, p := range products {
-		protoProducts = append(protoProducts, &genproto.PostOrderRequest_OrderProduct{
-			ProductId: p.ID,
-			Quantity:  p.Quantity,
-		})
-	}
 	r, err := c.service.PostOrder(
 		ctx,
 		&genproto.PostOrderRequest{
-			AccountId: accountID,
-			Products:  protoProducts,
+			AccountId:       accountID,
+			Currency:        currency,
+			Products:        productsToProto(products),
+			IdempotencyKey:  idempotencyKey,
+			Coupons:         coupons,
+			ShippingAddress: addressToProto(address),
 		},
 	)
 	if err != nil {
 		return nil, err
 	}
 
-	// Create response order
-	newOrder := r.Order
-	newOrderCreatedAt := time.Time{}
-	newOrderCreatedAt.UnmarshalBinary(newOrder.CreatedAt)
+	return orderFromProto(r.Order), nil
+}
 
-	// Convert []*model.OrderedProduct to []model.OrderedProduct
-	orderProducts := make([]model.OrderedProduct, len(products))
-	for i, p := range products {
-		orderProducts[i] = *p
+// QuoteOrder prices products like PostOrder without placing an order,
+// accountID may be empty and the address only needs a country
+func (c *OrderClient) QuoteOrder(
+	ctx context.Context,
+	accountID string,
+	currency string,
+	products []*model.OrderedProduct,
+	coupons []string,
+	address *model.Address,
+) (*model.Order, error) {
+	r, err := c.service.QuoteOrder(
+		ctx,
+		&genproto.QuoteOrderRequest{
+			AccountId:       accountID,
+			Currency:        currency,
+			Products:        productsToProto(products),
+			Coupons:         coupons,
+			ShippingAddress: addressToProto(address),
+		},
+	)
+	if err != nil {
+		log.Printf("failed to quote order: %v\n", err)
+		return nil, err
 	}
-	
-	return &model.Order{
-		ID:         newOrder.Id,
-		CreatedAt:  newOrderCreatedAt,
-		TotalPrice: newOrder.TotalPrice,
-		AccountID:  newOrder.AccountId,
-		Products:   orderProducts,
-	}, nil
+	return orderFromProto(r.Quote), nil
+}
+
+func productsToProto(products []*model.OrderedProduct) []*genproto.PostOrderRequest_OrderProduct {
+	protoProducts := []*genproto.PostOrderRequest_OrderProduct{}
+	for _, p := range products {
+		protoProducts = append(protoProducts, &genproto.PostOrderRequest_OrderProduct{
+			ProductId: p.ID,
+			Quantity:  p.Quantity,
+		})
+	}
+	return protoProducts
 }
 
 func (cl *OrderClient) GetOrdersForAccount(c context.Context, accountID string) ([]model.Order, error) {
@@ -83,35 +107,13 @@ func (cl *OrderClient) GetOrdersForAccount(c context.Context, accountID string)
 	// Create response orders
 	orders := []model.Order{}
 	for _, orderProto := range r.Orders {
-		newOrder := model.Order{
-			ID:         orderProto.Id,
-			TotalPrice: orderProto.TotalPrice,
-			AccountID:  orderProto.AccountId,
-		}
-		newOrder.CreatedAt = time.Time{}
-		newOrder.CreatedAt.UnmarshalBinary(orderProto.CreatedAt)
-
-		products := []model.OrderedProduct{}
-		for _, p := range orderProto.Products {
-			products = append(products, model.OrderedProduct{
-				ID:          p.Id,
-				Quantity:    p.Quantity,
-				Name:        p.Name,
-				Description: p.Description,
-				Price:       p.Price,
-	
-			})
-		}
-		newOrder.Products = products
-
-		orders = append(orders, newOrder)
+		orders = append(orders, *orderFromProto(orderProto))
 	}
 	return orders, nil
 }
 
-
-func (cl *OrderClient) DeleteOrder(c context.Context, id string) ( *model.OrderDeleteResponse, error) {
-	r ,err := cl.service.DeleteOrder(c, &genproto.DeleteOrderRequest{Id: id})
+func (cl *OrderClient) DeleteOrder(c context.Context, id string) (*model.OrderDeleteResponse, error) {
+	r, err := cl.service.DeleteOrder(c, &genproto.DeleteOrderRequest{Id: id})
 	if err != nil {
 		log.Printf("failed to delete order with ID %s: %v\n", id, err)
 		return nil, err
@@ -121,4 +123,505 @@ func (cl *OrderClient) DeleteOrder(c context.Context, id string) ( *model.OrderD
 		Message:   r.Message,
 		Success:   r.Success,
 	}, nil
-}
\ No newline at end of file
+}
+
+// ExportOrderLines streams every ordered product to fn
+func (cl *OrderClient) ExportOrderLines(c context.Context, fn func(l *model.OrderLine) error) error {
+	stream, err := cl.service.ExportOrderLines(c, &genproto.ExportOrderLinesRequest{})
+	if err != nil {
+		log.Printf("failed to export order lines: %v\n", err)
+		return err
+	}
+	for {
+		l, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			log.Printf("failed to export order lines: %v\n", err)
+			return err
+		}
+		err = fn(&model.OrderLine{
+			OrderID:   l.OrderId,
+			AccountID: l.AccountId,
+			ProductID: l.ProductId,
+			Quantity:  l.Quantity,
+		})
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// UpdateOrderStatus moves an order to status, the order service rejects
+// changes the status state machine doesn't allow with FailedPrecondition
+func (cl *OrderClient) UpdateOrderStatus(c context.Context, id, status, actor, reason string) (*model.Order, error) {
+	r, err := cl.service.UpdateOrderStatus(c, &genproto.UpdateOrderStatusRequest{
+		Id:     id,
+		Status: status,
+		Actor:  actor,
+		Reason: reason,
+	})
+	if err != nil {
+		log.Printf("failed to update status of order %s: %v\n", id, err)
+		return nil, err
+	}
+	return orderFromProto(r.Order), nil
+}
+
+func (cl *OrderClient) GetOrderStatusHistory(c context.Context, id string) ([]*model.OrderStatusChange, error) {
+	r, err := cl.service.GetOrderStatusHistory(c, &genproto.GetOrderStatusHistoryRequest{Id: id})
+	if err != nil {
+		log.Printf("failed to get status history of order %s: %v\n", id, err)
+		return nil, err
+	}
+	history := []*model.OrderStatusChange{}
+	for _, h := range r.History {
+		change := &model.OrderStatusChange{
+			OrderID: h.OrderId,
+			From:    h.FromStatus,
+			To:      h.ToStatus,
+			Actor:   h.Actor,
+			Reason:  h.Reason,
+		}
+		change.ChangedAt.UnmarshalBinary(h.ChangedAt)
+		history = append(history, change)
+	}
+	return history, nil
+}
+
+func (cl *OrderClient) GetOrder(c context.Context, id string) (*model.Order, error) {
+	r, err := cl.service.GetOrder(c, &genproto.GetOrderRequest{Id: id})
+	if err != nil {
+		log.Printf("failed to get order %s: %v\n", id, err)
+		return nil, err
+	}
+	return orderFromProto(r.Order), nil
+}
+
+// ListOrders returns a page of orders matching filter, newest first, after
+// is the EndCursor of the previous page
+func (cl *OrderClient) ListOrders(c context.Context, filter model.OrderFilter, after string, first int) (*model.OrderPage, error) {
+	req := &genproto.ListOrdersRequest{
+		AccountId: filter.AccountID,
+		Status:    filter.Status,
+		After:     after,
+		First:     uint32(first),
+	}
+	if filter.CreatedAfter != nil {
+		req.CreatedAfter, _ = filter.CreatedAfter.MarshalBinary()
+	}
+	if filter.CreatedBefore != nil {
+		req.CreatedBefore, _ = filter.CreatedBefore.MarshalBinary()
+	}
+	if filter.MinTotal != nil {
+		req.MinTotal = &genproto.Money{Amount: filter.MinTotal.Amount, Currency: filter.MinTotal.Currency}
+	}
+	if filter.MaxTotal != nil {
+		req.MaxTotal = &genproto.Money{Amount: filter.MaxTotal.Amount, Currency: filter.MaxTotal.Currency}
+	}
+
+	r, err := cl.service.ListOrders(c, req)
+	if err != nil {
+		log.Printf("failed to list orders: %v\n", err)
+		return nil, err
+	}
+	page := &model.OrderPage{
+		Orders:      []*model.Order{},
+		EndCursor:   r.EndCursor,
+		HasNextPage: r.HasNextPage,
+	}
+	for _, o := range r.Orders {
+		page.Orders = append(page.Orders, orderFromProto(o))
+	}
+	return page, nil
+}
+
+// CancelOrder cancels a pending or paid order and releases its stock
+func (cl *OrderClient) CancelOrder(c context.Context, id, reason, actor string) (*model.Order, error) {
+	r, err := cl.service.CancelOrder(c, &genproto.CancelOrderRequest{Id: id, Reason: reason, Actor: actor})
+	if err != nil {
+		log.Printf("failed to cancel order %s: %v\n", id, err)
+		return nil, err
+	}
+	return orderFromProto(r.Order), nil
+}
+
+// RefundOrder refunds lines of an order, without lines everything not
+// refunded yet is refunded
+func (cl *OrderClient) RefundOrder(c context.Context, id string, lines []model.RefundLine, reason, actor string) (*model.Order, *model.Refund, error) {
+	req := &genproto.RefundOrderRequest{Id: id, Reason: reason, Actor: actor}
+	for _, l := range lines {
+		req.Lines = append(req.Lines, &genproto.RefundOrderRequest_Line{ProductId: l.ProductID, Quantity: l.Quantity})
+	}
+	r, err := cl.service.RefundOrder(c, req)
+	if err != nil {
+		log.Printf("failed to refund order %s: %v\n", id, err)
+		return nil, nil, err
+	}
+	return orderFromProto(r.Order), refundFromProto(r.Refund), nil
+}
+
+func (cl *OrderClient) GetOrderRefunds(c context.Context, id string) ([]*model.Refund, error) {
+	r, err := cl.service.GetOrderRefunds(c, &genproto.GetOrderRefundsRequest{Id: id})
+	if err != nil {
+		log.Printf("failed to get refunds of order %s: %v\n", id, err)
+		return nil, err
+	}
+	refunds := []*model.Refund{}
+	for _, refund := range r.Refunds {
+		refunds = append(refunds, refundFromProto(refund))
+	}
+	return refunds, nil
+}
+
+// CreateShipment packs lines of an order into a shipment, without lines every
+// unit not shipped yet is packed and without address the order address is used
+func (cl *OrderClient) CreateShipment(c context.Context, orderID, carrier, trackingNumber string, lines []model.ShipmentLine, address *model.Address, actor string) (*model.Order, *model.Shipment, error) {
+	req := &genproto.CreateShipmentRequest{
+		OrderId:         orderID,
+		Carrier:         carrier,
+		TrackingNumber:  trackingNumber,
+		ShippingAddress: addressToProto(address),
+		Actor:           actor,
+	}
+	for _, l := range lines {
+		req.Lines = append(req.Lines, &genproto.Shipment_Line{ProductId: l.ProductID, Quantity: l.Quantity})
+	}
+	r, err := cl.service.CreateShipment(c, req)
+	if err != nil {
+		log.Printf("failed to create shipment of order %s: %v\n", orderID, err)
+		return nil, nil, err
+	}
+	return orderFromProto(r.Order), shipmentFromProto(r.Shipment), nil
+}
+
+// UpdateShipment changes a shipment, empty fields are left unchanged and a
+// zero at means now
+func (cl *OrderClient) UpdateShipment(c context.Context, id, status, carrier, trackingNumber string, at time.Time, actor string) (*model.Order, *model.Shipment, error) {
+	req := &genproto.UpdateShipmentRequest{
+		Id:             id,
+		Status:         status,
+		Carrier:        carrier,
+		TrackingNumber: trackingNumber,
+		Actor:          actor,
+	}
+	if !at.IsZero() {
+		req.At, _ = at.MarshalBinary()
+	}
+	r, err := cl.service.UpdateShipment(c, req)
+	if err != nil {
+		log.Printf("failed to update shipment %s: %v\n", id, err)
+		return nil, nil, err
+	}
+	return orderFromProto(r.Order), shipmentFromProto(r.Shipment), nil
+}
+
+func (cl *OrderClient) GetOrderShipments(c context.Context, id string) ([]*model.Shipment, error) {
+	r, err := cl.service.GetOrderShipments(c, &genproto.GetOrderShipmentsRequest{Id: id})
+	if err != nil {
+		log.Printf("failed to get shipments of order %s: %v\n", id, err)
+		return nil, err
+	}
+	shipments := []*model.Shipment{}
+	for _, shipment := range r.Shipments {
+		shipments = append(shipments, shipmentFromProto(shipment))
+	}
+	return shipments, nil
+}
+
+// GetInvoice returns the invoice of an order, NotFound until the order
+// service issued it when the order was paid
+func (cl *OrderClient) GetInvoice(c context.Context, orderID string) (*model.Invoice, error) {
+	r, err := cl.service.GetInvoice(c, &genproto.GetInvoiceRequest{OrderId: orderID})
+	if err != nil {
+		log.Printf("failed to get invoice of order %s: %v\n", orderID, err)
+		return nil, err
+	}
+	inv := &model.Invoice{
+		ID:      r.Invoice.Id,
+		Number:  r.Invoice.Number,
+		OrderID: r.Invoice.OrderId,
+		Total:   moneyFromProto(r.Invoice.Total),
+		HTMLURL: r.Invoice.HtmlUrl,
+		PDFURL:  r.Invoice.PdfUrl,
+	}
+	inv.IssuedAt.UnmarshalBinary(r.Invoice.IssuedAt)
+	return inv, nil
+}
+
+func shipmentFromProto(s *genproto.Shipment) *model.Shipment {
+	shipment := &model.Shipment{
+		ID:             s.Id,
+		OrderID:        s.OrderId,
+		Carrier:        s.Carrier,
+		TrackingNumber: s.TrackingNumber,
+		Status:         s.Status,
+		Lines:          []model.ShipmentLine{},
+	}
+	if a := addressFromProto(s.Address); a != nil {
+		shipment.Address = *a
+	}
+	if len(s.ShippedAt) > 0 {
+		shipment.ShippedAt = &time.Time{}
+		shipment.ShippedAt.UnmarshalBinary(s.ShippedAt)
+	}
+	if len(s.DeliveredAt) > 0 {
+		shipment.DeliveredAt = &time.Time{}
+		shipment.DeliveredAt.UnmarshalBinary(s.DeliveredAt)
+	}
+	shipment.CreatedAt.UnmarshalBinary(s.CreatedAt)
+	shipment.UpdatedAt.UnmarshalBinary(s.UpdatedAt)
+	for _, l := range s.Lines {
+		shipment.Lines = append(shipment.Lines, model.ShipmentLine{ProductID: l.ProductId, Quantity: l.Quantity})
+	}
+	return shipment
+}
+
+func refundFromProto(r *genproto.Refund) *model.Refund {
+	refund := &model.Refund{
+		ID:      r.Id,
+		OrderID: r.OrderId,
+		Amount:  moneyFromProto(r.Amount),
+		Reason:  r.Reason,
+		Actor:   r.Actor,
+		Lines:   []model.RefundLine{},
+	}
+	refund.CreatedAt.UnmarshalBinary(r.CreatedAt)
+	for _, l := range r.Lines {
+		refund.Lines = append(refund.Lines, model.RefundLine{
+			ProductID: l.ProductId,
+			Quantity:  l.Quantity,
+			Amount:    moneyFromProto(l.Amount),
+		})
+	}
+	return refund
+}
+
+// orderFromProto decodes an order, ordered products are priced in the order currency
+func orderFromProto(o *genproto.Order) *model.Order {
+	order := &model.Order{
+		ID:              o.Id,
+		TotalPrice:      moneyFromProto(o.Total),
+		Currency:        o.Currency,
+		AccountID:       o.AccountId,
+		Status:          o.Status,
+		Products:        []model.OrderedProduct{},
+		ShippingAddress: addressFromProto(o.ShippingAddress),
+		Shipping:        moneyFromProto(o.Shipping),
+		ShippingTax:     moneyFromProto(o.ShippingTax),
+		Tax:             moneyFromProto(o.Tax),
+		TaxInclusive:    o.TaxInclusive,
+	}
+	order.CreatedAt.UnmarshalBinary(o.CreatedAt)
+	for _, p := range o.Products {
+		product := model.OrderedProduct{
+			ID:          p.Id,
+			Name:        p.Name,
+			Description: p.Description,
+			Price:       moneyFromProto(p.UnitPrice),
+			Quantity:    p.Quantity,
+			Tax:         moneyFromProto(p.Tax),
+			WeightGrams: p.WeightGrams,
+		}
+		for _, d := range p.Discounts {
+			product.Discounts = append(product.Discounts, model.Discount{
+				PromotionID: d.PromotionId,
+				Code:        d.Code,
+				Name:        d.Name,
+				Amount:      moneyFromProto(d.Amount),
+			})
+		}
+		order.Products = append(order.Products, product)
+	}
+	return order
+}
+
+func moneyFromProto(m *genproto.Money) currency.Money {
+	if m == nil {
+		return currency.Money{}
+	}
+	return currency.Money{Amount: m.Amount, Currency: m.Currency}
+}
+
+func addressFromProto(a *genproto.Address) *model.Address {
+	if a == nil {
+		return nil
+	}
+	return &model.Address{
+		Name:       a.Name,
+		Line1:      a.Line1,
+		Line2:      a.Line2,
+		City:       a.City,
+		Region:     a.Region,
+		PostalCode: a.PostalCode,
+		Country:    a.Country,
+	}
+}
+
+func addressToProto(a *model.Address) *genproto.Address {
+	if a == nil {
+		return nil
+	}
+	return &genproto.Address{
+		Name:       a.Name,
+		Line1:      a.Line1,
+		Line2:      a.Line2,
+		City:       a.City,
+		Region:     a.Region,
+		PostalCode: a.PostalCode,
+		Country:    a.Country,
+	}
+}
+
+// Checkout places an order and authorizes its payment with paymentMethod,
+// the returned checkout tells whether it completed, failed or is waiting
+// for the payment provider
+func (cl *OrderClient) Checkout(
+	c context.Context,
+	accountID string,
+	currency string,
+	products []*model.OrderedProduct,
+	coupons []string,
+	address *model.Address,
+	paymentMethod string,
+	idempotencyKey string,
+) (*model.Checkout, error) {
+	req := &genproto.CheckoutRequest{
+		AccountId:       accountID,
+		Currency:        currency,
+		Products:        productsToProto(products),
+		Coupons:         coupons,
+		ShippingAddress: addressToProto(address),
+		PaymentMethod:   paymentMethod,
+		IdempotencyKey:  idempotencyKey,
+	}
+	r, err := cl.service.Checkout(c, req)
+	if err != nil {
+		log.Printf("failed to check out: %v\n", err)
+		return nil, err
+	}
+	return checkoutFromProto(r.Checkout), nil
+}
+
+func (cl *OrderClient) GetCheckoutStatus(c context.Context, id string) (*model.Checkout, error) {
+	r, err := cl.service.GetCheckoutStatus(c, &genproto.GetCheckoutStatusRequest{Id: id})
+	if err != nil {
+		log.Printf("failed to get checkout %s: %v\n", id, err)
+		return nil, err
+	}
+	return checkoutFromProto(r.Checkout), nil
+}
+
+func checkoutFromProto(cp *genproto.CheckoutStatus) *model.Checkout {
+	co := &model.Checkout{
+		ID:              cp.Id,
+		OrderID:         cp.OrderId,
+		AccountID:       cp.AccountId,
+		Status:          cp.Status,
+		Error:           cp.Error,
+		PaymentIntentID: cp.PaymentIntentId,
+		Steps:           []model.CheckoutStep{},
+	}
+	co.CreatedAt.UnmarshalBinary(cp.CreatedAt)
+	co.UpdatedAt.UnmarshalBinary(cp.UpdatedAt)
+	for _, sp := range cp.Steps {
+		step := model.CheckoutStep{
+			Name:   sp.Name,
+			Status: sp.Status,
+			Error:  sp.Error,
+		}
+		step.UpdatedAt.UnmarshalBinary(sp.UpdatedAt)
+		co.Steps = append(co.Steps, step)
+	}
+	return co
+}
+
+// CreatePromotion stores a promotion, promotions are created active
+func (cl *OrderClient) CreatePromotion(c context.Context, p *model.Promotion) (*model.Promotion, error) {
+	pp := &genproto.Promotion{
+		Code:              p.Code,
+		Name:              p.Name,
+		Kind:              p.Kind,
+		PercentOff:        p.PercentOff,
+		BuyQuantity:       p.BuyQuantity,
+		GetQuantity:       p.GetQuantity,
+		ProductIds:        p.ProductIDs,
+		MaxUses:           p.MaxUses,
+		MaxUsesPerAccount: p.MaxUsesPerAccount,
+		Stackable:         p.Stackable,
+		Priority:          p.Priority,
+	}
+	if p.AmountOff.Amount != 0 {
+		pp.AmountOff = &genproto.Money{Amount: p.AmountOff.Amount, Currency: p.AmountOff.Currency}
+	}
+	if p.MinSpend.Amount != 0 {
+		pp.MinSpend = &genproto.Money{Amount: p.MinSpend.Amount, Currency: p.MinSpend.Currency}
+	}
+	if p.StartsAt != nil {
+		pp.StartsAt, _ = p.StartsAt.MarshalBinary()
+	}
+	if p.EndsAt != nil {
+		pp.EndsAt, _ = p.EndsAt.MarshalBinary()
+	}
+	r, err := cl.service.CreatePromotion(c, &genproto.CreatePromotionRequest{Promotion: pp})
+	if err != nil {
+		log.Printf("failed to create promotion: %v\n", err)
+		return nil, err
+	}
+	return promotionFromProto(r.Promotion), nil
+}
+
+func (cl *OrderClient) SetPromotionActive(c context.Context, id string, active bool) (*model.Promotion, error) {
+	r, err := cl.service.SetPromotionActive(c, &genproto.SetPromotionActiveRequest{Id: id, Active: active})
+	if err != nil {
+		log.Printf("failed to update promotion %s: %v\n", id, err)
+		return nil, err
+	}
+	return promotionFromProto(r.Promotion), nil
+}
+
+func (cl *OrderClient) ListPromotions(c context.Context) ([]*model.Promotion, error) {
+	r, err := cl.service.ListPromotions(c, &genproto.ListPromotionsRequest{})
+	if err != nil {
+		log.Printf("failed to list promotions: %v\n", err)
+		return nil, err
+	}
+	promotions := []*model.Promotion{}
+	for _, p := range r.Promotions {
+		promotions = append(promotions, promotionFromProto(p))
+	}
+	return promotions, nil
+}
+
+func promotionFromProto(pp *genproto.Promotion) *model.Promotion {
+	p := &model.Promotion{
+		ID:                pp.Id,
+		Code:              pp.Code,
+		Name:              pp.Name,
+		Kind:              pp.Kind,
+		PercentOff:        pp.PercentOff,
+		AmountOff:         moneyFromProto(pp.AmountOff),
+		BuyQuantity:       pp.BuyQuantity,
+		GetQuantity:       pp.GetQuantity,
+		ProductIDs:        pp.ProductIds,
+		MinSpend:          moneyFromProto(pp.MinSpend),
+		MaxUses:           pp.MaxUses,
+		MaxUsesPerAccount: pp.MaxUsesPerAccount,
+		Stackable:         pp.Stackable,
+		Priority:          pp.Priority,
+		Active:            pp.Active,
+		Uses:              pp.Uses,
+	}
+	if len(pp.StartsAt) > 0 {
+		p.StartsAt = &time.Time{}
+		p.StartsAt.UnmarshalBinary(pp.StartsAt)
+	}
+	if len(pp.EndsAt) > 0 {
+		p.EndsAt = &time.Time{}
+		p.EndsAt.UnmarshalBinary(pp.EndsAt)
+	}
+	p.CreatedAt.UnmarshalBinary(pp.CreatedAt)
+	p.UpdatedAt.UnmarshalBinary(pp.UpdatedAt)
+	return p
+}
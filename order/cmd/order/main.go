@@ -1,20 +1,56 @@
 package main
 
 import (
+	"context"
+	"fmt"
 	"log"
+	"net/http"
+	"net/url"
+	"strings"
 	"time"
+
 	"github.com/kelseyhightower/envconfig"
 	"github.com/tinrab/retry"
+	account "github.com/wignn/micro-3/account/client"
+	"github.com/wignn/micro-3/blob"
+	"github.com/wignn/micro-3/currency"
+	inventory "github.com/wignn/micro-3/inventory/client"
+	"github.com/wignn/micro-3/order/checkout"
+	"github.com/wignn/micro-3/order/model"
+	"github.com/wignn/micro-3/order/pricing"
 	"github.com/wignn/micro-3/order/repository"
 	"github.com/wignn/micro-3/order/server"
 	"github.com/wignn/micro-3/order/service"
+	payment "github.com/wignn/micro-3/payment/client"
 )
 
 type Config struct {
-	DatabaseURL string `envconfig:"DATABASE_URL"`
-	AccountURL  string `envconfig:"ACCOUNT_SERVICE_URL"`
-	CatalogURL  string `envconfig:"CATALOG_SERVICE_URL"`
-	PORT        int    `envconfig:"PORT" default:"50051"`
+	DatabaseURL      string        `envconfig:"DATABASE_URL"`
+	AccountURL       string        `envconfig:"ACCOUNT_SERVICE_URL"`
+	CatalogURL       string        `envconfig:"CATALOG_SERVICE_URL"`
+	InventoryURL     string        `envconfig:"INVENTORY_SERVICE_URL"`
+	PORT             int           `envconfig:"PORT" default:"50051"`
+	RatesFile        string        `envconfig:"RATES_FILE"`
+	PricingFile      string        `envconfig:"PRICING_FILE"`
+	DefaultCurrency  string        `envconfig:"DEFAULT_CURRENCY" default:"USD"`
+	MaxLineQuantity  uint32        `envconfig:"MAX_LINE_QUANTITY" default:"100"`
+	MaxOrderQuantity uint32        `envconfig:"MAX_ORDER_QUANTITY" default:"1000"`
+	MaxOrderLines    int           `envconfig:"MAX_ORDER_LINES" default:"50"`
+	PaymentURL       string        `envconfig:"PAYMENT_SERVICE_URL"`
+	CheckoutLease    time.Duration `envconfig:"CHECKOUT_LEASE" default:"1m"`
+	CheckoutTimeout  time.Duration `envconfig:"CHECKOUT_TIMEOUT" default:"30s"`
+	PaymentTimeout   time.Duration `envconfig:"PAYMENT_TIMEOUT" default:"15m"`
+	RecoveryInterval time.Duration `envconfig:"CHECKOUT_RECOVERY_INTERVAL" default:"30s"`
+	InvoiceDir       string        `envconfig:"INVOICE_DIR" default:"/var/lib/order/invoices"`
+	InvoiceBaseURL   string        `envconfig:"INVOICE_BASE_URL" default:"http://localhost:8084/invoices"`
+	InvoicePort      int           `envconfig:"INVOICE_PORT" default:"8084"`
+	InvoicePrefix    string        `envconfig:"INVOICE_PREFIX" default:"INV"`
+	InvoiceInterval  time.Duration `envconfig:"INVOICE_RETRY_INTERVAL" default:"1m"`
+	SellerName       string        `envconfig:"SELLER_NAME" default:"micro-3"`
+	// SellerAddress holds the address lines separated by semicolons
+	SellerAddress string `envconfig:"SELLER_ADDRESS"`
+	SellerEmail   string `envconfig:"SELLER_EMAIL"`
+	SellerTaxID   string `envconfig:"SELLER_TAX_ID"`
 }
 
 func main() {
@@ -22,6 +58,9 @@ func main() {
 	if err := envconfig.Process("", &cfg); err != nil {
 		log.Fatal("Failed to process environment variables:", err)
 	}
+	if cfg.CheckoutTimeout >= cfg.CheckoutLease {
+		log.Fatal("CHECKOUT_TIMEOUT must be shorter than CHECKOUT_LEASE")
+	}
 
 	var r repository.OrderRepository
 	retry.ForeverSleep(2*time.Second, func(_ int) (err error) {
@@ -33,9 +72,114 @@ func main() {
 	})
 	defer r.Close()
 
-	
+	defaultCurrency, err := currency.Normalize(cfg.DefaultCurrency)
+	if err != nil {
+		log.Fatal("Invalid default currency:", err)
+	}
+	rates, err := currency.NewStaticFileProvider(cfg.RatesFile)
+	if err != nil {
+		log.Fatal("Failed to load exchange rates:", err)
+	}
+	converter := currency.NewConverter(rates)
+	pricer, err := pricing.Load(cfg.PricingFile, converter)
+	if err != nil {
+		log.Fatal("Failed to load pricing config:", err)
+	}
 
 	log.Println("listening on port", cfg.PORT)
-	s := service.NewOrderService(r)
-	log.Fatal(server.ListenGRPC(s, cfg.AccountURL, cfg.CatalogURL, cfg.PORT))
+	limits := service.OrderLimits{
+		MaxLineQuantity:  cfg.MaxLineQuantity,
+		MaxOrderQuantity: cfg.MaxOrderQuantity,
+		MaxLines:         cfg.MaxOrderLines,
+	}
+	invoices, err := blob.NewLocalStore(cfg.InvoiceDir, cfg.InvoiceBaseURL)
+	if err != nil {
+		log.Fatal("Failed to open invoice store:", err)
+	}
+	go serveInvoices(invoices, cfg.InvoiceBaseURL, cfg.InvoicePort)
+	seller := model.Party{Name: cfg.SellerName, Email: cfg.SellerEmail, TaxID: cfg.SellerTaxID}
+	for _, line := range strings.Split(cfg.SellerAddress, ";") {
+		if line = strings.TrimSpace(line); line != "" {
+			seller.Address = append(seller.Address, line)
+		}
+	}
+	accountClient, err := account.NewClient(cfg.AccountURL)
+	if err != nil {
+		log.Fatal("Failed to connect to account service:", err)
+	}
+	defer accountClient.Close()
+	paymentClient, err := payment.NewClient(cfg.PaymentURL)
+	if err != nil {
+		log.Fatal("Failed to connect to payment service:", err)
+	}
+	defer paymentClient.Close()
+	s := service.NewOrderService(r, converter, pricer, defaultCurrency, limits, invoices, service.InvoiceConfig{
+		Prefix:   cfg.InvoicePrefix,
+		Seller:   seller,
+		Accounts: accountClient,
+	}, paymentClient)
+	go issueInvoices(s, cfg.InvoiceInterval)
+
+	inventoryClient, err := inventory.NewClient(cfg.InventoryURL)
+	if err != nil {
+		log.Fatal("Failed to connect to inventory service:", err)
+	}
+	defer inventoryClient.Close()
+	o := checkout.NewOrchestrator(r, s, inventoryClient, paymentClient, checkout.Options{
+		Lease:          cfg.CheckoutLease,
+		Timeout:        cfg.CheckoutTimeout,
+		PaymentTimeout: cfg.PaymentTimeout,
+	})
+	go resume(o, cfg.RecoveryInterval)
+
+	log.Fatal(server.ListenGRPC(s, o, cfg.AccountURL, cfg.CatalogURL, cfg.InventoryURL, cfg.PORT))
+}
+
+// serveInvoices exposes the local invoice store under the path of baseURL
+func serveInvoices(invoices *blob.LocalStore, baseURL string, port int) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		log.Fatal("Invalid INVOICE_BASE_URL:", err)
+	}
+	prefix := u.Path
+	if prefix == "" || prefix[len(prefix)-1] != '/' {
+		prefix += "/"
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(prefix, http.StripPrefix(prefix, invoices.Handler()))
+	log.Println("Serving invoices on port", port)
+	log.Fatal(http.ListenAndServe(fmt.Sprintf(":%d", port), mux))
+}
+
+// issueInvoices issues the invoices of paid orders that have none, at startup
+// and then every interval
+func issueInvoices(s service.OrderService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		n, err := s.IssuePendingInvoices(context.Background(), 100)
+		if err != nil {
+			log.Println("failed to issue pending invoices:", err)
+		} else if n > 0 {
+			log.Printf("issued %d pending invoices\n", n)
+		}
+		<-ticker.C
+	}
+}
+
+// resume picks up checkouts left unfinished by a crash at startup and then
+// every interval, checkouts waiting for their payment are polled as well
+func resume(o *checkout.Orchestrator, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		n, err := o.Resume(context.Background(), 20)
+		if err != nil {
+			log.Println("failed to resume checkouts:", err)
+		} else if n > 0 {
+			log.Printf("resumed %d checkouts\n", n)
+		}
+		<-ticker.C
+	}
 }
@@ -21,20 +21,250 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
-type Order struct {
+// Money is an amount in the minor unit of its currency, 1234 USD is $12.34
+type Money struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	CreatedAt     []byte                 `protobuf:"bytes,2,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
-	AccountId     string                 `protobuf:"bytes,3,opt,name=accountId,proto3" json:"accountId,omitempty"`
-	TotalPrice    float64                `protobuf:"fixed64,4,opt,name=totalPrice,proto3" json:"totalPrice,omitempty"`
-	Products      []*Order_OrderProduct  `protobuf:"bytes,5,rep,name=products,proto3" json:"products,omitempty"`
+	Amount        int64                  `protobuf:"varint,1,opt,name=amount,proto3" json:"amount,omitempty"`
+	Currency      string                 `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Money) Reset() {
+	*x = Money{}
+	mi := &file_order_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Money) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Money) ProtoMessage() {}
+
+func (x *Money) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Money.ProtoReflect.Descriptor instead.
+func (*Money) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Money) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *Money) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+// Discount is what a promotion took off an order line
+type Discount struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PromotionId   string                 `protobuf:"bytes,1,opt,name=promotionId,proto3" json:"promotionId,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Amount        *Money                 `protobuf:"bytes,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Discount) Reset() {
+	*x = Discount{}
+	mi := &file_order_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Discount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Discount) ProtoMessage() {}
+
+func (x *Discount) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Discount.ProtoReflect.Descriptor instead.
+func (*Discount) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Discount) GetPromotionId() string {
+	if x != nil {
+		return x.PromotionId
+	}
+	return ""
+}
+
+func (x *Discount) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *Discount) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Discount) GetAmount() *Money {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+// Address is where an order is shipped, country is an ISO 3166-1 alpha-2
+// code and region the subdivision part of an ISO 3166-2 code
+type Address struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Line1         string                 `protobuf:"bytes,2,opt,name=line1,proto3" json:"line1,omitempty"`
+	Line2         string                 `protobuf:"bytes,3,opt,name=line2,proto3" json:"line2,omitempty"`
+	City          string                 `protobuf:"bytes,4,opt,name=city,proto3" json:"city,omitempty"`
+	Region        string                 `protobuf:"bytes,5,opt,name=region,proto3" json:"region,omitempty"`
+	PostalCode    string                 `protobuf:"bytes,6,opt,name=postalCode,proto3" json:"postalCode,omitempty"`
+	Country       string                 `protobuf:"bytes,7,opt,name=country,proto3" json:"country,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Address) Reset() {
+	*x = Address{}
+	mi := &file_order_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Address) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Address) ProtoMessage() {}
+
+func (x *Address) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Address.ProtoReflect.Descriptor instead.
+func (*Address) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Address) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Address) GetLine1() string {
+	if x != nil {
+		return x.Line1
+	}
+	return ""
+}
+
+func (x *Address) GetLine2() string {
+	if x != nil {
+		return x.Line2
+	}
+	return ""
+}
+
+func (x *Address) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *Address) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *Address) GetPostalCode() string {
+	if x != nil {
+		return x.PostalCode
+	}
+	return ""
+}
+
+func (x *Address) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+// total is what the customer pays: the discounted lines plus shipping, plus
+// tax unless prices include it (taxInclusive)
+type Order struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	CreatedAt []byte                 `protobuf:"bytes,2,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	AccountId string                 `protobuf:"bytes,3,opt,name=accountId,proto3" json:"accountId,omitempty"`
+	Products  []*Order_OrderProduct  `protobuf:"bytes,5,rep,name=products,proto3" json:"products,omitempty"`
+	Currency  string                 `protobuf:"bytes,6,opt,name=currency,proto3" json:"currency,omitempty"`
+	Status    string                 `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"`
+	Total     *Money                 `protobuf:"bytes,8,opt,name=total,proto3" json:"total,omitempty"`
+	// subtotal and discount are the sums of the lines before and of their discounts
+	Subtotal     *Money `protobuf:"bytes,9,opt,name=subtotal,proto3" json:"subtotal,omitempty"`
+	Discount     *Money `protobuf:"bytes,10,opt,name=discount,proto3" json:"discount,omitempty"`
+	Shipping     *Money `protobuf:"bytes,11,opt,name=shipping,proto3" json:"shipping,omitempty"`
+	Tax          *Money `protobuf:"bytes,12,opt,name=tax,proto3" json:"tax,omitempty"`
+	TaxInclusive bool   `protobuf:"varint,13,opt,name=taxInclusive,proto3" json:"taxInclusive,omitempty"`
+	// unset for orders that aren't shipped
+	ShippingAddress *Address `protobuf:"bytes,14,opt,name=shippingAddress,proto3" json:"shippingAddress,omitempty"`
+	// the part of tax charged on shipping
+	ShippingTax   *Money `protobuf:"bytes,15,opt,name=shippingTax,proto3" json:"shippingTax,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Order) Reset() {
 	*x = Order{}
-	mi := &file_order_proto_msgTypes[0]
+	mi := &file_order_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -46,7 +276,7 @@ func (x *Order) String() string {
 func (*Order) ProtoMessage() {}
 
 func (x *Order) ProtoReflect() protoreflect.Message {
-	mi := &file_order_proto_msgTypes[0]
+	mi := &file_order_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -59,7 +289,7 @@ func (x *Order) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Order.ProtoReflect.Descriptor instead.
 func (*Order) Descriptor() ([]byte, []int) {
-	return file_order_proto_rawDescGZIP(), []int{0}
+	return file_order_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *Order) GetId() string {
@@ -83,43 +313,2866 @@ func (x *Order) GetAccountId() string {
 	return ""
 }
 
-func (x *Order) GetTotalPrice() float64 {
+func (x *Order) GetProducts() []*Order_OrderProduct {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
+func (x *Order) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *Order) GetStatus() string {
 	if x != nil {
-		return x.TotalPrice
+		return x.Status
 	}
-	return 0
+	return ""
 }
 
-func (x *Order) GetProducts() []*Order_OrderProduct {
+func (x *Order) GetTotal() *Money {
 	if x != nil {
-		return x.Products
+		return x.Total
 	}
 	return nil
 }
 
-type PostOrderRequest struct {
-	state         protoimpl.MessageState           `protogen:"open.v1"`
-	AccountId     string                           `protobuf:"bytes,2,opt,name=accountId,proto3" json:"accountId,omitempty"`
-	Products      []*PostOrderRequest_OrderProduct `protobuf:"bytes,4,rep,name=products,proto3" json:"products,omitempty"`
+func (x *Order) GetSubtotal() *Money {
+	if x != nil {
+		return x.Subtotal
+	}
+	return nil
+}
+
+func (x *Order) GetDiscount() *Money {
+	if x != nil {
+		return x.Discount
+	}
+	return nil
+}
+
+func (x *Order) GetShipping() *Money {
+	if x != nil {
+		return x.Shipping
+	}
+	return nil
+}
+
+func (x *Order) GetTax() *Money {
+	if x != nil {
+		return x.Tax
+	}
+	return nil
+}
+
+func (x *Order) GetTaxInclusive() bool {
+	if x != nil {
+		return x.TaxInclusive
+	}
+	return false
+}
+
+func (x *Order) GetShippingAddress() *Address {
+	if x != nil {
+		return x.ShippingAddress
+	}
+	return nil
+}
+
+func (x *Order) GetShippingTax() *Money {
+	if x != nil {
+		return x.ShippingTax
+	}
+	return nil
+}
+
+type OrderStatusChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=orderId,proto3" json:"orderId,omitempty"`
+	FromStatus    string                 `protobuf:"bytes,2,opt,name=fromStatus,proto3" json:"fromStatus,omitempty"`
+	ToStatus      string                 `protobuf:"bytes,3,opt,name=toStatus,proto3" json:"toStatus,omitempty"`
+	Actor         string                 `protobuf:"bytes,4,opt,name=actor,proto3" json:"actor,omitempty"`
+	Reason        string                 `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	ChangedAt     []byte                 `protobuf:"bytes,6,opt,name=changedAt,proto3" json:"changedAt,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PostOrderRequest) Reset() {
-	*x = PostOrderRequest{}
-	mi := &file_order_proto_msgTypes[1]
+func (x *OrderStatusChange) Reset() {
+	*x = OrderStatusChange{}
+	mi := &file_order_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderStatusChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderStatusChange) ProtoMessage() {}
+
+func (x *OrderStatusChange) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderStatusChange.ProtoReflect.Descriptor instead.
+func (*OrderStatusChange) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *OrderStatusChange) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *OrderStatusChange) GetFromStatus() string {
+	if x != nil {
+		return x.FromStatus
+	}
+	return ""
+}
+
+func (x *OrderStatusChange) GetToStatus() string {
+	if x != nil {
+		return x.ToStatus
+	}
+	return ""
+}
+
+func (x *OrderStatusChange) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *OrderStatusChange) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *OrderStatusChange) GetChangedAt() []byte {
+	if x != nil {
+		return x.ChangedAt
+	}
+	return nil
+}
+
+type PostOrderRequest struct {
+	state     protoimpl.MessageState           `protogen:"open.v1"`
+	AccountId string                           `protobuf:"bytes,2,opt,name=accountId,proto3" json:"accountId,omitempty"`
+	Products  []*PostOrderRequest_OrderProduct `protobuf:"bytes,4,rep,name=products,proto3" json:"products,omitempty"`
+	Currency  string                           `protobuf:"bytes,5,opt,name=currency,proto3" json:"currency,omitempty"`
+	// idempotencyKey makes retries return the order placed by the first
+	// request instead of placing another one
+	IdempotencyKey string `protobuf:"bytes,6,opt,name=idempotencyKey,proto3" json:"idempotencyKey,omitempty"`
+	// coupons are promotion codes, they are case insensitive
+	Coupons []string `protobuf:"bytes,7,rep,name=coupons,proto3" json:"coupons,omitempty"`
+	// orders without an address aren't shipped
+	ShippingAddress *Address `protobuf:"bytes,8,opt,name=shippingAddress,proto3" json:"shippingAddress,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *PostOrderRequest) Reset() {
+	*x = PostOrderRequest{}
+	mi := &file_order_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PostOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostOrderRequest) ProtoMessage() {}
+
+func (x *PostOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostOrderRequest.ProtoReflect.Descriptor instead.
+func (*PostOrderRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *PostOrderRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *PostOrderRequest) GetProducts() []*PostOrderRequest_OrderProduct {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
+func (x *PostOrderRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *PostOrderRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *PostOrderRequest) GetCoupons() []string {
+	if x != nil {
+		return x.Coupons
+	}
+	return nil
+}
+
+func (x *PostOrderRequest) GetShippingAddress() *Address {
+	if x != nil {
+		return x.ShippingAddress
+	}
+	return nil
+}
+
+// QuoteOrderRequest prices a basket like PostOrderRequest without placing an
+// order, accountId is only needed for coupons limited per account and the
+// address only needs a country
+type QuoteOrderRequest struct {
+	state           protoimpl.MessageState           `protogen:"open.v1"`
+	AccountId       string                           `protobuf:"bytes,1,opt,name=accountId,proto3" json:"accountId,omitempty"`
+	Products        []*PostOrderRequest_OrderProduct `protobuf:"bytes,2,rep,name=products,proto3" json:"products,omitempty"`
+	Currency        string                           `protobuf:"bytes,3,opt,name=currency,proto3" json:"currency,omitempty"`
+	Coupons         []string                         `protobuf:"bytes,4,rep,name=coupons,proto3" json:"coupons,omitempty"`
+	ShippingAddress *Address                         `protobuf:"bytes,5,opt,name=shippingAddress,proto3" json:"shippingAddress,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *QuoteOrderRequest) Reset() {
+	*x = QuoteOrderRequest{}
+	mi := &file_order_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QuoteOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuoteOrderRequest) ProtoMessage() {}
+
+func (x *QuoteOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuoteOrderRequest.ProtoReflect.Descriptor instead.
+func (*QuoteOrderRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *QuoteOrderRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *QuoteOrderRequest) GetProducts() []*PostOrderRequest_OrderProduct {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
+func (x *QuoteOrderRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *QuoteOrderRequest) GetCoupons() []string {
+	if x != nil {
+		return x.Coupons
+	}
+	return nil
+}
+
+func (x *QuoteOrderRequest) GetShippingAddress() *Address {
+	if x != nil {
+		return x.ShippingAddress
+	}
+	return nil
+}
+
+// the quote has no id and no status, createdAt is when it was priced
+type QuoteOrderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Quote         *Order                 `protobuf:"bytes,1,opt,name=quote,proto3" json:"quote,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QuoteOrderResponse) Reset() {
+	*x = QuoteOrderResponse{}
+	mi := &file_order_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QuoteOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuoteOrderResponse) ProtoMessage() {}
+
+func (x *QuoteOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuoteOrderResponse.ProtoReflect.Descriptor instead.
+func (*QuoteOrderResponse) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *QuoteOrderResponse) GetQuote() *Order {
+	if x != nil {
+		return x.Quote
+	}
+	return nil
+}
+
+type PostOrderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PostOrderResponse) Reset() {
+	*x = PostOrderResponse{}
+	mi := &file_order_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PostOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostOrderResponse) ProtoMessage() {}
+
+func (x *PostOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostOrderResponse.ProtoReflect.Descriptor instead.
+func (*PostOrderResponse) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *PostOrderResponse) GetOrder() *Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+type GetOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrderRequest) Reset() {
+	*x = GetOrderRequest{}
+	mi := &file_order_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderRequest) ProtoMessage() {}
+
+func (x *GetOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderRequest.ProtoReflect.Descriptor instead.
+func (*GetOrderRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetOrderRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetOrderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrderResponse) Reset() {
+	*x = GetOrderResponse{}
+	mi := &file_order_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderResponse) ProtoMessage() {}
+
+func (x *GetOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderResponse.ProtoReflect.Descriptor instead.
+func (*GetOrderResponse) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetOrderResponse) GetOrder() *Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+// ListOrdersRequest filters orders, empty fields match every order and
+// minTotal and maxTotal only match orders placed in their currency
+type ListOrdersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     string                 `protobuf:"bytes,1,opt,name=accountId,proto3" json:"accountId,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	CreatedAfter  []byte                 `protobuf:"bytes,3,opt,name=createdAfter,proto3" json:"createdAfter,omitempty"`
+	CreatedBefore []byte                 `protobuf:"bytes,4,opt,name=createdBefore,proto3" json:"createdBefore,omitempty"`
+	MinTotal      *Money                 `protobuf:"bytes,5,opt,name=minTotal,proto3" json:"minTotal,omitempty"`
+	MaxTotal      *Money                 `protobuf:"bytes,6,opt,name=maxTotal,proto3" json:"maxTotal,omitempty"`
+	After         string                 `protobuf:"bytes,7,opt,name=after,proto3" json:"after,omitempty"`
+	First         uint32                 `protobuf:"varint,8,opt,name=first,proto3" json:"first,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOrdersRequest) Reset() {
+	*x = ListOrdersRequest{}
+	mi := &file_order_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOrdersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrdersRequest) ProtoMessage() {}
+
+func (x *ListOrdersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrdersRequest.ProtoReflect.Descriptor instead.
+func (*ListOrdersRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ListOrdersRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *ListOrdersRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListOrdersRequest) GetCreatedAfter() []byte {
+	if x != nil {
+		return x.CreatedAfter
+	}
+	return nil
+}
+
+func (x *ListOrdersRequest) GetCreatedBefore() []byte {
+	if x != nil {
+		return x.CreatedBefore
+	}
+	return nil
+}
+
+func (x *ListOrdersRequest) GetMinTotal() *Money {
+	if x != nil {
+		return x.MinTotal
+	}
+	return nil
+}
+
+func (x *ListOrdersRequest) GetMaxTotal() *Money {
+	if x != nil {
+		return x.MaxTotal
+	}
+	return nil
+}
+
+func (x *ListOrdersRequest) GetAfter() string {
+	if x != nil {
+		return x.After
+	}
+	return ""
+}
+
+func (x *ListOrdersRequest) GetFirst() uint32 {
+	if x != nil {
+		return x.First
+	}
+	return 0
+}
+
+type ListOrdersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Orders        []*Order               `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
+	EndCursor     string                 `protobuf:"bytes,2,opt,name=endCursor,proto3" json:"endCursor,omitempty"`
+	HasNextPage   bool                   `protobuf:"varint,3,opt,name=hasNextPage,proto3" json:"hasNextPage,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOrdersResponse) Reset() {
+	*x = ListOrdersResponse{}
+	mi := &file_order_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOrdersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrdersResponse) ProtoMessage() {}
+
+func (x *ListOrdersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrdersResponse.ProtoReflect.Descriptor instead.
+func (*ListOrdersResponse) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ListOrdersResponse) GetOrders() []*Order {
+	if x != nil {
+		return x.Orders
+	}
+	return nil
+}
+
+func (x *ListOrdersResponse) GetEndCursor() string {
+	if x != nil {
+		return x.EndCursor
+	}
+	return ""
+}
+
+func (x *ListOrdersResponse) GetHasNextPage() bool {
+	if x != nil {
+		return x.HasNextPage
+	}
+	return false
+}
+
+type GetOrdersForAccountRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     string                 `protobuf:"bytes,1,opt,name=accountId,proto3" json:"accountId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrdersForAccountRequest) Reset() {
+	*x = GetOrdersForAccountRequest{}
+	mi := &file_order_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrdersForAccountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrdersForAccountRequest) ProtoMessage() {}
+
+func (x *GetOrdersForAccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrdersForAccountRequest.ProtoReflect.Descriptor instead.
+func (*GetOrdersForAccountRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetOrdersForAccountRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+type GetOrdersForAccountResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Orders        []*Order               `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrdersForAccountResponse) Reset() {
+	*x = GetOrdersForAccountResponse{}
+	mi := &file_order_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrdersForAccountResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrdersForAccountResponse) ProtoMessage() {}
+
+func (x *GetOrdersForAccountResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrdersForAccountResponse.ProtoReflect.Descriptor instead.
+func (*GetOrdersForAccountResponse) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetOrdersForAccountResponse) GetOrders() []*Order {
+	if x != nil {
+		return x.Orders
+	}
+	return nil
+}
+
+type DeleteOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteOrderRequest) Reset() {
+	*x = DeleteOrderRequest{}
+	mi := &file_order_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteOrderRequest) ProtoMessage() {}
+
+func (x *DeleteOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteOrderRequest.ProtoReflect.Descriptor instead.
+func (*DeleteOrderRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *DeleteOrderRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteOrderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeletedId     string                 `protobuf:"bytes,1,opt,name=deletedId,proto3" json:"deletedId,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Success       bool                   `protobuf:"varint,3,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteOrderResponse) Reset() {
+	*x = DeleteOrderResponse{}
+	mi := &file_order_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteOrderResponse) ProtoMessage() {}
+
+func (x *DeleteOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteOrderResponse.ProtoReflect.Descriptor instead.
+func (*DeleteOrderResponse) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *DeleteOrderResponse) GetDeletedId() string {
+	if x != nil {
+		return x.DeletedId
+	}
+	return ""
+}
+
+func (x *DeleteOrderResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *DeleteOrderResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type ExportOrderLinesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportOrderLinesRequest) Reset() {
+	*x = ExportOrderLinesRequest{}
+	mi := &file_order_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportOrderLinesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportOrderLinesRequest) ProtoMessage() {}
+
+func (x *ExportOrderLinesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportOrderLinesRequest.ProtoReflect.Descriptor instead.
+func (*ExportOrderLinesRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{17}
+}
+
+type OrderLine struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=orderId,proto3" json:"orderId,omitempty"`
+	AccountId     string                 `protobuf:"bytes,2,opt,name=accountId,proto3" json:"accountId,omitempty"`
+	ProductId     string                 `protobuf:"bytes,3,opt,name=productId,proto3" json:"productId,omitempty"`
+	Quantity      uint32                 `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderLine) Reset() {
+	*x = OrderLine{}
+	mi := &file_order_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderLine) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderLine) ProtoMessage() {}
+
+func (x *OrderLine) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderLine.ProtoReflect.Descriptor instead.
+func (*OrderLine) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *OrderLine) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *OrderLine) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *OrderLine) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *OrderLine) GetQuantity() uint32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+type UpdateOrderStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Actor         string                 `protobuf:"bytes,3,opt,name=actor,proto3" json:"actor,omitempty"`
+	Reason        string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateOrderStatusRequest) Reset() {
+	*x = UpdateOrderStatusRequest{}
+	mi := &file_order_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateOrderStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateOrderStatusRequest) ProtoMessage() {}
+
+func (x *UpdateOrderStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateOrderStatusRequest.ProtoReflect.Descriptor instead.
+func (*UpdateOrderStatusRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *UpdateOrderStatusRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateOrderStatusRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *UpdateOrderStatusRequest) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *UpdateOrderStatusRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type UpdateOrderStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateOrderStatusResponse) Reset() {
+	*x = UpdateOrderStatusResponse{}
+	mi := &file_order_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateOrderStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateOrderStatusResponse) ProtoMessage() {}
+
+func (x *UpdateOrderStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateOrderStatusResponse.ProtoReflect.Descriptor instead.
+func (*UpdateOrderStatusResponse) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *UpdateOrderStatusResponse) GetOrder() *Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+type GetOrderStatusHistoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrderStatusHistoryRequest) Reset() {
+	*x = GetOrderStatusHistoryRequest{}
+	mi := &file_order_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderStatusHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderStatusHistoryRequest) ProtoMessage() {}
+
+func (x *GetOrderStatusHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderStatusHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetOrderStatusHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetOrderStatusHistoryRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetOrderStatusHistoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	History       []*OrderStatusChange   `protobuf:"bytes,1,rep,name=history,proto3" json:"history,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrderStatusHistoryResponse) Reset() {
+	*x = GetOrderStatusHistoryResponse{}
+	mi := &file_order_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderStatusHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderStatusHistoryResponse) ProtoMessage() {}
+
+func (x *GetOrderStatusHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderStatusHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetOrderStatusHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GetOrderStatusHistoryResponse) GetHistory() []*OrderStatusChange {
+	if x != nil {
+		return x.History
+	}
+	return nil
+}
+
+type CancelOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	Actor         string                 `protobuf:"bytes,3,opt,name=actor,proto3" json:"actor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelOrderRequest) Reset() {
+	*x = CancelOrderRequest{}
+	mi := &file_order_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelOrderRequest) ProtoMessage() {}
+
+func (x *CancelOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelOrderRequest.ProtoReflect.Descriptor instead.
+func (*CancelOrderRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *CancelOrderRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CancelOrderRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *CancelOrderRequest) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+type CancelOrderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelOrderResponse) Reset() {
+	*x = CancelOrderResponse{}
+	mi := &file_order_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelOrderResponse) ProtoMessage() {}
+
+func (x *CancelOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelOrderResponse.ProtoReflect.Descriptor instead.
+func (*CancelOrderResponse) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *CancelOrderResponse) GetOrder() *Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+type Refund struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	OrderId       string                 `protobuf:"bytes,2,opt,name=orderId,proto3" json:"orderId,omitempty"`
+	Amount        *Money                 `protobuf:"bytes,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	Reason        string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	Actor         string                 `protobuf:"bytes,5,opt,name=actor,proto3" json:"actor,omitempty"`
+	CreatedAt     []byte                 `protobuf:"bytes,6,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	Lines         []*Refund_Line         `protobuf:"bytes,7,rep,name=lines,proto3" json:"lines,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Refund) Reset() {
+	*x = Refund{}
+	mi := &file_order_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Refund) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Refund) ProtoMessage() {}
+
+func (x *Refund) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Refund.ProtoReflect.Descriptor instead.
+func (*Refund) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *Refund) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Refund) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *Refund) GetAmount() *Money {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+func (x *Refund) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *Refund) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *Refund) GetCreatedAt() []byte {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Refund) GetLines() []*Refund_Line {
+	if x != nil {
+		return x.Lines
+	}
+	return nil
+}
+
+// RefundOrderRequest refunds everything not refunded yet when it has no lines
+type RefundOrderRequest struct {
+	state         protoimpl.MessageState     `protogen:"open.v1"`
+	Id            string                     `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Lines         []*RefundOrderRequest_Line `protobuf:"bytes,2,rep,name=lines,proto3" json:"lines,omitempty"`
+	Reason        string                     `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	Actor         string                     `protobuf:"bytes,4,opt,name=actor,proto3" json:"actor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefundOrderRequest) Reset() {
+	*x = RefundOrderRequest{}
+	mi := &file_order_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefundOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefundOrderRequest) ProtoMessage() {}
+
+func (x *RefundOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefundOrderRequest.ProtoReflect.Descriptor instead.
+func (*RefundOrderRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *RefundOrderRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *RefundOrderRequest) GetLines() []*RefundOrderRequest_Line {
+	if x != nil {
+		return x.Lines
+	}
+	return nil
+}
+
+func (x *RefundOrderRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *RefundOrderRequest) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+type RefundOrderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	Refund        *Refund                `protobuf:"bytes,2,opt,name=refund,proto3" json:"refund,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefundOrderResponse) Reset() {
+	*x = RefundOrderResponse{}
+	mi := &file_order_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefundOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefundOrderResponse) ProtoMessage() {}
+
+func (x *RefundOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefundOrderResponse.ProtoReflect.Descriptor instead.
+func (*RefundOrderResponse) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *RefundOrderResponse) GetOrder() *Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+func (x *RefundOrderResponse) GetRefund() *Refund {
+	if x != nil {
+		return x.Refund
+	}
+	return nil
+}
+
+type GetOrderRefundsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrderRefundsRequest) Reset() {
+	*x = GetOrderRefundsRequest{}
+	mi := &file_order_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderRefundsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderRefundsRequest) ProtoMessage() {}
+
+func (x *GetOrderRefundsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderRefundsRequest.ProtoReflect.Descriptor instead.
+func (*GetOrderRefundsRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *GetOrderRefundsRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetOrderRefundsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Refunds       []*Refund              `protobuf:"bytes,1,rep,name=refunds,proto3" json:"refunds,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrderRefundsResponse) Reset() {
+	*x = GetOrderRefundsResponse{}
+	mi := &file_order_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderRefundsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderRefundsResponse) ProtoMessage() {}
+
+func (x *GetOrderRefundsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderRefundsResponse.ProtoReflect.Descriptor instead.
+func (*GetOrderRefundsResponse) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *GetOrderRefundsResponse) GetRefunds() []*Refund {
+	if x != nil {
+		return x.Refunds
+	}
+	return nil
+}
+
+// Shipment sends some units of an order, shippedAt and deliveredAt are empty
+// until it reaches those statuses
+type Shipment struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	OrderId        string                 `protobuf:"bytes,2,opt,name=orderId,proto3" json:"orderId,omitempty"`
+	Carrier        string                 `protobuf:"bytes,3,opt,name=carrier,proto3" json:"carrier,omitempty"`
+	TrackingNumber string                 `protobuf:"bytes,4,opt,name=trackingNumber,proto3" json:"trackingNumber,omitempty"`
+	Status         string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	Address        *Address               `protobuf:"bytes,6,opt,name=address,proto3" json:"address,omitempty"`
+	Lines          []*Shipment_Line       `protobuf:"bytes,7,rep,name=lines,proto3" json:"lines,omitempty"`
+	ShippedAt      []byte                 `protobuf:"bytes,8,opt,name=shippedAt,proto3" json:"shippedAt,omitempty"`
+	DeliveredAt    []byte                 `protobuf:"bytes,9,opt,name=deliveredAt,proto3" json:"deliveredAt,omitempty"`
+	CreatedAt      []byte                 `protobuf:"bytes,10,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	UpdatedAt      []byte                 `protobuf:"bytes,11,opt,name=updatedAt,proto3" json:"updatedAt,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Shipment) Reset() {
+	*x = Shipment{}
+	mi := &file_order_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Shipment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Shipment) ProtoMessage() {}
+
+func (x *Shipment) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Shipment.ProtoReflect.Descriptor instead.
+func (*Shipment) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *Shipment) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Shipment) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *Shipment) GetCarrier() string {
+	if x != nil {
+		return x.Carrier
+	}
+	return ""
+}
+
+func (x *Shipment) GetTrackingNumber() string {
+	if x != nil {
+		return x.TrackingNumber
+	}
+	return ""
+}
+
+func (x *Shipment) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Shipment) GetAddress() *Address {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+func (x *Shipment) GetLines() []*Shipment_Line {
+	if x != nil {
+		return x.Lines
+	}
+	return nil
+}
+
+func (x *Shipment) GetShippedAt() []byte {
+	if x != nil {
+		return x.ShippedAt
+	}
+	return nil
+}
+
+func (x *Shipment) GetDeliveredAt() []byte {
+	if x != nil {
+		return x.DeliveredAt
+	}
+	return nil
+}
+
+func (x *Shipment) GetCreatedAt() []byte {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Shipment) GetUpdatedAt() []byte {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// CreateShipmentRequest packs every unit not shipped yet when it has no lines,
+// and ships to the address of the order without shippingAddress
+type CreateShipmentRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	OrderId         string                 `protobuf:"bytes,1,opt,name=orderId,proto3" json:"orderId,omitempty"`
+	Carrier         string                 `protobuf:"bytes,2,opt,name=carrier,proto3" json:"carrier,omitempty"`
+	TrackingNumber  string                 `protobuf:"bytes,3,opt,name=trackingNumber,proto3" json:"trackingNumber,omitempty"`
+	Lines           []*Shipment_Line       `protobuf:"bytes,4,rep,name=lines,proto3" json:"lines,omitempty"`
+	ShippingAddress *Address               `protobuf:"bytes,5,opt,name=shippingAddress,proto3" json:"shippingAddress,omitempty"`
+	Actor           string                 `protobuf:"bytes,6,opt,name=actor,proto3" json:"actor,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CreateShipmentRequest) Reset() {
+	*x = CreateShipmentRequest{}
+	mi := &file_order_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateShipmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateShipmentRequest) ProtoMessage() {}
+
+func (x *CreateShipmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateShipmentRequest.ProtoReflect.Descriptor instead.
+func (*CreateShipmentRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *CreateShipmentRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *CreateShipmentRequest) GetCarrier() string {
+	if x != nil {
+		return x.Carrier
+	}
+	return ""
+}
+
+func (x *CreateShipmentRequest) GetTrackingNumber() string {
+	if x != nil {
+		return x.TrackingNumber
+	}
+	return ""
+}
+
+func (x *CreateShipmentRequest) GetLines() []*Shipment_Line {
+	if x != nil {
+		return x.Lines
+	}
+	return nil
+}
+
+func (x *CreateShipmentRequest) GetShippingAddress() *Address {
+	if x != nil {
+		return x.ShippingAddress
+	}
+	return nil
+}
+
+func (x *CreateShipmentRequest) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+// UpdateShipmentRequest leaves empty fields unchanged, at is when the status
+// changed and defaults to now
+type UpdateShipmentRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Status         string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Carrier        string                 `protobuf:"bytes,3,opt,name=carrier,proto3" json:"carrier,omitempty"`
+	TrackingNumber string                 `protobuf:"bytes,4,opt,name=trackingNumber,proto3" json:"trackingNumber,omitempty"`
+	At             []byte                 `protobuf:"bytes,5,opt,name=at,proto3" json:"at,omitempty"`
+	Actor          string                 `protobuf:"bytes,6,opt,name=actor,proto3" json:"actor,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *UpdateShipmentRequest) Reset() {
+	*x = UpdateShipmentRequest{}
+	mi := &file_order_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateShipmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateShipmentRequest) ProtoMessage() {}
+
+func (x *UpdateShipmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateShipmentRequest.ProtoReflect.Descriptor instead.
+func (*UpdateShipmentRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *UpdateShipmentRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateShipmentRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *UpdateShipmentRequest) GetCarrier() string {
+	if x != nil {
+		return x.Carrier
+	}
+	return ""
+}
+
+func (x *UpdateShipmentRequest) GetTrackingNumber() string {
+	if x != nil {
+		return x.TrackingNumber
+	}
+	return ""
+}
+
+func (x *UpdateShipmentRequest) GetAt() []byte {
+	if x != nil {
+		return x.At
+	}
+	return nil
+}
+
+func (x *UpdateShipmentRequest) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+// ShipmentResponse holds the order too, its status follows its shipments
+type ShipmentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	Shipment      *Shipment              `protobuf:"bytes,2,opt,name=shipment,proto3" json:"shipment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShipmentResponse) Reset() {
+	*x = ShipmentResponse{}
+	mi := &file_order_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShipmentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShipmentResponse) ProtoMessage() {}
+
+func (x *ShipmentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShipmentResponse.ProtoReflect.Descriptor instead.
+func (*ShipmentResponse) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ShipmentResponse) GetOrder() *Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+func (x *ShipmentResponse) GetShipment() *Shipment {
+	if x != nil {
+		return x.Shipment
+	}
+	return nil
+}
+
+type GetOrderShipmentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrderShipmentsRequest) Reset() {
+	*x = GetOrderShipmentsRequest{}
+	mi := &file_order_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderShipmentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderShipmentsRequest) ProtoMessage() {}
+
+func (x *GetOrderShipmentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderShipmentsRequest.ProtoReflect.Descriptor instead.
+func (*GetOrderShipmentsRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *GetOrderShipmentsRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetOrderShipmentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Shipments     []*Shipment            `protobuf:"bytes,1,rep,name=shipments,proto3" json:"shipments,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrderShipmentsResponse) Reset() {
+	*x = GetOrderShipmentsResponse{}
+	mi := &file_order_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderShipmentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderShipmentsResponse) ProtoMessage() {}
+
+func (x *GetOrderShipmentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderShipmentsResponse.ProtoReflect.Descriptor instead.
+func (*GetOrderShipmentsResponse) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *GetOrderShipmentsResponse) GetShipments() []*Shipment {
+	if x != nil {
+		return x.Shipments
+	}
+	return nil
+}
+
+// Invoice is the invoice of a paid order, its documents are fetched from
+// htmlUrl and pdfUrl
+type Invoice struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Number        string                 `protobuf:"bytes,2,opt,name=number,proto3" json:"number,omitempty"`
+	OrderId       string                 `protobuf:"bytes,3,opt,name=orderId,proto3" json:"orderId,omitempty"`
+	IssuedAt      []byte                 `protobuf:"bytes,4,opt,name=issuedAt,proto3" json:"issuedAt,omitempty"`
+	Total         *Money                 `protobuf:"bytes,5,opt,name=total,proto3" json:"total,omitempty"`
+	HtmlUrl       string                 `protobuf:"bytes,6,opt,name=htmlUrl,proto3" json:"htmlUrl,omitempty"`
+	PdfUrl        string                 `protobuf:"bytes,7,opt,name=pdfUrl,proto3" json:"pdfUrl,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Invoice) Reset() {
+	*x = Invoice{}
+	mi := &file_order_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Invoice) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Invoice) ProtoMessage() {}
+
+func (x *Invoice) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Invoice.ProtoReflect.Descriptor instead.
+func (*Invoice) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *Invoice) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Invoice) GetNumber() string {
+	if x != nil {
+		return x.Number
+	}
+	return ""
+}
+
+func (x *Invoice) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *Invoice) GetIssuedAt() []byte {
+	if x != nil {
+		return x.IssuedAt
+	}
+	return nil
+}
+
+func (x *Invoice) GetTotal() *Money {
+	if x != nil {
+		return x.Total
+	}
+	return nil
+}
+
+func (x *Invoice) GetHtmlUrl() string {
+	if x != nil {
+		return x.HtmlUrl
+	}
+	return ""
+}
+
+func (x *Invoice) GetPdfUrl() string {
+	if x != nil {
+		return x.PdfUrl
+	}
+	return ""
+}
+
+// GetInvoiceRequest issues the invoice of the order the first time it is
+// requested
+type GetInvoiceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=orderId,proto3" json:"orderId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetInvoiceRequest) Reset() {
+	*x = GetInvoiceRequest{}
+	mi := &file_order_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetInvoiceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInvoiceRequest) ProtoMessage() {}
+
+func (x *GetInvoiceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInvoiceRequest.ProtoReflect.Descriptor instead.
+func (*GetInvoiceRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *GetInvoiceRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+type GetInvoiceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Invoice       *Invoice               `protobuf:"bytes,1,opt,name=invoice,proto3" json:"invoice,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetInvoiceResponse) Reset() {
+	*x = GetInvoiceResponse{}
+	mi := &file_order_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetInvoiceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInvoiceResponse) ProtoMessage() {}
+
+func (x *GetInvoiceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInvoiceResponse.ProtoReflect.Descriptor instead.
+func (*GetInvoiceResponse) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *GetInvoiceResponse) GetInvoice() *Invoice {
+	if x != nil {
+		return x.Invoice
+	}
+	return nil
+}
+
+type CheckoutStep struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	UpdatedAt     []byte                 `protobuf:"bytes,4,opt,name=updatedAt,proto3" json:"updatedAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckoutStep) Reset() {
+	*x = CheckoutStep{}
+	mi := &file_order_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckoutStep) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckoutStep) ProtoMessage() {}
+
+func (x *CheckoutStep) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckoutStep.ProtoReflect.Descriptor instead.
+func (*CheckoutStep) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *CheckoutStep) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CheckoutStep) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *CheckoutStep) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *CheckoutStep) GetUpdatedAt() []byte {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// CheckoutStatus is the progress of a checkout saga, status is running,
+// awaiting_payment, compensating, completed or failed
+type CheckoutStatus struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	OrderId         string                 `protobuf:"bytes,2,opt,name=orderId,proto3" json:"orderId,omitempty"`
+	AccountId       string                 `protobuf:"bytes,3,opt,name=accountId,proto3" json:"accountId,omitempty"`
+	Status          string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	Error           string                 `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	PaymentIntentId string                 `protobuf:"bytes,6,opt,name=paymentIntentId,proto3" json:"paymentIntentId,omitempty"`
+	Steps           []*CheckoutStep        `protobuf:"bytes,7,rep,name=steps,proto3" json:"steps,omitempty"`
+	CreatedAt       []byte                 `protobuf:"bytes,8,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	UpdatedAt       []byte                 `protobuf:"bytes,9,opt,name=updatedAt,proto3" json:"updatedAt,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CheckoutStatus) Reset() {
+	*x = CheckoutStatus{}
+	mi := &file_order_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckoutStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckoutStatus) ProtoMessage() {}
+
+func (x *CheckoutStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckoutStatus.ProtoReflect.Descriptor instead.
+func (*CheckoutStatus) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *CheckoutStatus) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CheckoutStatus) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *CheckoutStatus) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *CheckoutStatus) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *CheckoutStatus) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *CheckoutStatus) GetPaymentIntentId() string {
+	if x != nil {
+		return x.PaymentIntentId
+	}
+	return ""
+}
+
+func (x *CheckoutStatus) GetSteps() []*CheckoutStep {
+	if x != nil {
+		return x.Steps
+	}
+	return nil
+}
+
+func (x *CheckoutStatus) GetCreatedAt() []byte {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *CheckoutStatus) GetUpdatedAt() []byte {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type CheckoutRequest struct {
+	state     protoimpl.MessageState           `protogen:"open.v1"`
+	AccountId string                           `protobuf:"bytes,1,opt,name=accountId,proto3" json:"accountId,omitempty"`
+	Products  []*PostOrderRequest_OrderProduct `protobuf:"bytes,2,rep,name=products,proto3" json:"products,omitempty"`
+	Currency  string                           `protobuf:"bytes,3,opt,name=currency,proto3" json:"currency,omitempty"`
+	// paymentMethod is the provider token authorized for the order total
+	PaymentMethod   string   `protobuf:"bytes,4,opt,name=paymentMethod,proto3" json:"paymentMethod,omitempty"`
+	IdempotencyKey  string   `protobuf:"bytes,5,opt,name=idempotencyKey,proto3" json:"idempotencyKey,omitempty"`
+	Coupons         []string `protobuf:"bytes,6,rep,name=coupons,proto3" json:"coupons,omitempty"`
+	ShippingAddress *Address `protobuf:"bytes,7,opt,name=shippingAddress,proto3" json:"shippingAddress,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CheckoutRequest) Reset() {
+	*x = CheckoutRequest{}
+	mi := &file_order_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckoutRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckoutRequest) ProtoMessage() {}
+
+func (x *CheckoutRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckoutRequest.ProtoReflect.Descriptor instead.
+func (*CheckoutRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *CheckoutRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *CheckoutRequest) GetProducts() []*PostOrderRequest_OrderProduct {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
+func (x *CheckoutRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *CheckoutRequest) GetPaymentMethod() string {
+	if x != nil {
+		return x.PaymentMethod
+	}
+	return ""
+}
+
+func (x *CheckoutRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *CheckoutRequest) GetCoupons() []string {
+	if x != nil {
+		return x.Coupons
+	}
+	return nil
+}
+
+func (x *CheckoutRequest) GetShippingAddress() *Address {
+	if x != nil {
+		return x.ShippingAddress
+	}
+	return nil
+}
+
+type CheckoutResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Checkout      *CheckoutStatus        `protobuf:"bytes,1,opt,name=checkout,proto3" json:"checkout,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckoutResponse) Reset() {
+	*x = CheckoutResponse{}
+	mi := &file_order_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckoutResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckoutResponse) ProtoMessage() {}
+
+func (x *CheckoutResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckoutResponse.ProtoReflect.Descriptor instead.
+func (*CheckoutResponse) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *CheckoutResponse) GetCheckout() *CheckoutStatus {
+	if x != nil {
+		return x.Checkout
+	}
+	return nil
+}
+
+type GetCheckoutStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCheckoutStatusRequest) Reset() {
+	*x = GetCheckoutStatusRequest{}
+	mi := &file_order_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCheckoutStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCheckoutStatusRequest) ProtoMessage() {}
+
+func (x *GetCheckoutStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCheckoutStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetCheckoutStatusRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *GetCheckoutStatusRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetCheckoutStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Checkout      *CheckoutStatus        `protobuf:"bytes,1,opt,name=checkout,proto3" json:"checkout,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCheckoutStatusResponse) Reset() {
+	*x = GetCheckoutStatusResponse{}
+	mi := &file_order_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCheckoutStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCheckoutStatusResponse) ProtoMessage() {}
+
+func (x *GetCheckoutStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCheckoutStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetCheckoutStatusResponse) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *GetCheckoutStatusResponse) GetCheckout() *CheckoutStatus {
+	if x != nil {
+		return x.Checkout
+	}
+	return nil
+}
+
+// Promotion is a discount rule, kind is percentage, fixed or buy_x_get_y.
+// Promotions without a code apply to every order they fit, zero limits and
+// an unset minSpend mean no limit.
+type Promotion struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Id                string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Code              string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	Name              string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Kind              string                 `protobuf:"bytes,4,opt,name=kind,proto3" json:"kind,omitempty"`
+	PercentOff        uint32                 `protobuf:"varint,5,opt,name=percentOff,proto3" json:"percentOff,omitempty"`
+	AmountOff         *Money                 `protobuf:"bytes,6,opt,name=amountOff,proto3" json:"amountOff,omitempty"`
+	BuyQuantity       uint32                 `protobuf:"varint,7,opt,name=buyQuantity,proto3" json:"buyQuantity,omitempty"`
+	GetQuantity       uint32                 `protobuf:"varint,8,opt,name=getQuantity,proto3" json:"getQuantity,omitempty"`
+	ProductIds        []string               `protobuf:"bytes,9,rep,name=productIds,proto3" json:"productIds,omitempty"`
+	MinSpend          *Money                 `protobuf:"bytes,10,opt,name=minSpend,proto3" json:"minSpend,omitempty"`
+	StartsAt          []byte                 `protobuf:"bytes,11,opt,name=startsAt,proto3" json:"startsAt,omitempty"`
+	EndsAt            []byte                 `protobuf:"bytes,12,opt,name=endsAt,proto3" json:"endsAt,omitempty"`
+	MaxUses           uint32                 `protobuf:"varint,13,opt,name=maxUses,proto3" json:"maxUses,omitempty"`
+	MaxUsesPerAccount uint32                 `protobuf:"varint,14,opt,name=maxUsesPerAccount,proto3" json:"maxUsesPerAccount,omitempty"`
+	Stackable         bool                   `protobuf:"varint,15,opt,name=stackable,proto3" json:"stackable,omitempty"`
+	Priority          int32                  `protobuf:"varint,16,opt,name=priority,proto3" json:"priority,omitempty"`
+	Active            bool                   `protobuf:"varint,17,opt,name=active,proto3" json:"active,omitempty"`
+	Uses              uint32                 `protobuf:"varint,18,opt,name=uses,proto3" json:"uses,omitempty"`
+	CreatedAt         []byte                 `protobuf:"bytes,19,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	UpdatedAt         []byte                 `protobuf:"bytes,20,opt,name=updatedAt,proto3" json:"updatedAt,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *Promotion) Reset() {
+	*x = Promotion{}
+	mi := &file_order_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Promotion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Promotion) ProtoMessage() {}
+
+func (x *Promotion) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Promotion.ProtoReflect.Descriptor instead.
+func (*Promotion) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *Promotion) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Promotion) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *Promotion) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Promotion) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *Promotion) GetPercentOff() uint32 {
+	if x != nil {
+		return x.PercentOff
+	}
+	return 0
+}
+
+func (x *Promotion) GetAmountOff() *Money {
+	if x != nil {
+		return x.AmountOff
+	}
+	return nil
+}
+
+func (x *Promotion) GetBuyQuantity() uint32 {
+	if x != nil {
+		return x.BuyQuantity
+	}
+	return 0
+}
+
+func (x *Promotion) GetGetQuantity() uint32 {
+	if x != nil {
+		return x.GetQuantity
+	}
+	return 0
+}
+
+func (x *Promotion) GetProductIds() []string {
+	if x != nil {
+		return x.ProductIds
+	}
+	return nil
+}
+
+func (x *Promotion) GetMinSpend() *Money {
+	if x != nil {
+		return x.MinSpend
+	}
+	return nil
+}
+
+func (x *Promotion) GetStartsAt() []byte {
+	if x != nil {
+		return x.StartsAt
+	}
+	return nil
+}
+
+func (x *Promotion) GetEndsAt() []byte {
+	if x != nil {
+		return x.EndsAt
+	}
+	return nil
+}
+
+func (x *Promotion) GetMaxUses() uint32 {
+	if x != nil {
+		return x.MaxUses
+	}
+	return 0
+}
+
+func (x *Promotion) GetMaxUsesPerAccount() uint32 {
+	if x != nil {
+		return x.MaxUsesPerAccount
+	}
+	return 0
+}
+
+func (x *Promotion) GetStackable() bool {
+	if x != nil {
+		return x.Stackable
+	}
+	return false
+}
+
+func (x *Promotion) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *Promotion) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+func (x *Promotion) GetUses() uint32 {
+	if x != nil {
+		return x.Uses
+	}
+	return 0
+}
+
+func (x *Promotion) GetCreatedAt() []byte {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Promotion) GetUpdatedAt() []byte {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type CreatePromotionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Promotion     *Promotion             `protobuf:"bytes,1,opt,name=promotion,proto3" json:"promotion,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePromotionRequest) Reset() {
+	*x = CreatePromotionRequest{}
+	mi := &file_order_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePromotionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePromotionRequest) ProtoMessage() {}
+
+func (x *CreatePromotionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePromotionRequest.ProtoReflect.Descriptor instead.
+func (*CreatePromotionRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *CreatePromotionRequest) GetPromotion() *Promotion {
+	if x != nil {
+		return x.Promotion
+	}
+	return nil
+}
+
+type SetPromotionActiveRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Active        bool                   `protobuf:"varint,2,opt,name=active,proto3" json:"active,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetPromotionActiveRequest) Reset() {
+	*x = SetPromotionActiveRequest{}
+	mi := &file_order_proto_msgTypes[47]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PostOrderRequest) String() string {
+func (x *SetPromotionActiveRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PostOrderRequest) ProtoMessage() {}
+func (*SetPromotionActiveRequest) ProtoMessage() {}
 
-func (x *PostOrderRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_order_proto_msgTypes[1]
+func (x *SetPromotionActiveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[47]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -130,47 +3183,47 @@ func (x *PostOrderRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PostOrderRequest.ProtoReflect.Descriptor instead.
-func (*PostOrderRequest) Descriptor() ([]byte, []int) {
-	return file_order_proto_rawDescGZIP(), []int{1}
+// Deprecated: Use SetPromotionActiveRequest.ProtoReflect.Descriptor instead.
+func (*SetPromotionActiveRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{47}
 }
 
-func (x *PostOrderRequest) GetAccountId() string {
+func (x *SetPromotionActiveRequest) GetId() string {
 	if x != nil {
-		return x.AccountId
+		return x.Id
 	}
 	return ""
 }
 
-func (x *PostOrderRequest) GetProducts() []*PostOrderRequest_OrderProduct {
+func (x *SetPromotionActiveRequest) GetActive() bool {
 	if x != nil {
-		return x.Products
+		return x.Active
 	}
-	return nil
+	return false
 }
 
-type PostOrderResponse struct {
+type PromotionResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	Promotion     *Promotion             `protobuf:"bytes,1,opt,name=promotion,proto3" json:"promotion,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PostOrderResponse) Reset() {
-	*x = PostOrderResponse{}
-	mi := &file_order_proto_msgTypes[2]
+func (x *PromotionResponse) Reset() {
+	*x = PromotionResponse{}
+	mi := &file_order_proto_msgTypes[48]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PostOrderResponse) String() string {
+func (x *PromotionResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PostOrderResponse) ProtoMessage() {}
+func (*PromotionResponse) ProtoMessage() {}
 
-func (x *PostOrderResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_order_proto_msgTypes[2]
+func (x *PromotionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[48]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -181,40 +3234,39 @@ func (x *PostOrderResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PostOrderResponse.ProtoReflect.Descriptor instead.
-func (*PostOrderResponse) Descriptor() ([]byte, []int) {
-	return file_order_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use PromotionResponse.ProtoReflect.Descriptor instead.
+func (*PromotionResponse) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{48}
 }
 
-func (x *PostOrderResponse) GetOrder() *Order {
+func (x *PromotionResponse) GetPromotion() *Promotion {
 	if x != nil {
-		return x.Order
+		return x.Promotion
 	}
 	return nil
 }
 
-type GetOrderRequest struct {
+type ListPromotionsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetOrderRequest) Reset() {
-	*x = GetOrderRequest{}
-	mi := &file_order_proto_msgTypes[3]
+func (x *ListPromotionsRequest) Reset() {
+	*x = ListPromotionsRequest{}
+	mi := &file_order_proto_msgTypes[49]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetOrderRequest) String() string {
+func (x *ListPromotionsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetOrderRequest) ProtoMessage() {}
+func (*ListPromotionsRequest) ProtoMessage() {}
 
-func (x *GetOrderRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_order_proto_msgTypes[3]
+func (x *ListPromotionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[49]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -225,40 +3277,33 @@ func (x *GetOrderRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetOrderRequest.ProtoReflect.Descriptor instead.
-func (*GetOrderRequest) Descriptor() ([]byte, []int) {
-	return file_order_proto_rawDescGZIP(), []int{3}
-}
-
-func (x *GetOrderRequest) GetId() string {
-	if x != nil {
-		return x.Id
-	}
-	return ""
+// Deprecated: Use ListPromotionsRequest.ProtoReflect.Descriptor instead.
+func (*ListPromotionsRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{49}
 }
 
-type GetOrderResponse struct {
+type ListPromotionsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	Promotions    []*Promotion           `protobuf:"bytes,1,rep,name=promotions,proto3" json:"promotions,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetOrderResponse) Reset() {
-	*x = GetOrderResponse{}
-	mi := &file_order_proto_msgTypes[4]
+func (x *ListPromotionsResponse) Reset() {
+	*x = ListPromotionsResponse{}
+	mi := &file_order_proto_msgTypes[50]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetOrderResponse) String() string {
+func (x *ListPromotionsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetOrderResponse) ProtoMessage() {}
+func (*ListPromotionsResponse) ProtoMessage() {}
 
-func (x *GetOrderResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_order_proto_msgTypes[4]
+func (x *ListPromotionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[50]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -269,40 +3314,49 @@ func (x *GetOrderResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetOrderResponse.ProtoReflect.Descriptor instead.
-func (*GetOrderResponse) Descriptor() ([]byte, []int) {
-	return file_order_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use ListPromotionsResponse.ProtoReflect.Descriptor instead.
+func (*ListPromotionsResponse) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{50}
 }
 
-func (x *GetOrderResponse) GetOrder() *Order {
+func (x *ListPromotionsResponse) GetPromotions() []*Promotion {
 	if x != nil {
-		return x.Order
+		return x.Promotions
 	}
 	return nil
 }
 
-type GetOrdersForAccountRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	AccountId     string                 `protobuf:"bytes,1,opt,name=accountId,proto3" json:"accountId,omitempty"`
+type Order_OrderProduct struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name        string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Quantity    uint32                 `protobuf:"varint,5,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	UnitPrice   *Money                 `protobuf:"bytes,6,opt,name=unitPrice,proto3" json:"unitPrice,omitempty"`
+	Discounts   []*Discount            `protobuf:"bytes,7,rep,name=discounts,proto3" json:"discounts,omitempty"`
+	// tax charged on the discounted line
+	Tax *Money `protobuf:"bytes,8,opt,name=tax,proto3" json:"tax,omitempty"`
+	// of one unit, zero when unknown
+	WeightGrams   int64 `protobuf:"varint,9,opt,name=weightGrams,proto3" json:"weightGrams,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetOrdersForAccountRequest) Reset() {
-	*x = GetOrdersForAccountRequest{}
-	mi := &file_order_proto_msgTypes[5]
+func (x *Order_OrderProduct) Reset() {
+	*x = Order_OrderProduct{}
+	mi := &file_order_proto_msgTypes[51]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetOrdersForAccountRequest) String() string {
+func (x *Order_OrderProduct) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetOrdersForAccountRequest) ProtoMessage() {}
+func (*Order_OrderProduct) ProtoMessage() {}
 
-func (x *GetOrdersForAccountRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_order_proto_msgTypes[5]
+func (x *Order_OrderProduct) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[51]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -313,84 +3367,90 @@ func (x *GetOrdersForAccountRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetOrdersForAccountRequest.ProtoReflect.Descriptor instead.
-func (*GetOrdersForAccountRequest) Descriptor() ([]byte, []int) {
-	return file_order_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use Order_OrderProduct.ProtoReflect.Descriptor instead.
+func (*Order_OrderProduct) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{3, 0}
 }
 
-func (x *GetOrdersForAccountRequest) GetAccountId() string {
+func (x *Order_OrderProduct) GetId() string {
 	if x != nil {
-		return x.AccountId
+		return x.Id
 	}
 	return ""
 }
 
-type GetOrdersForAccountResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Orders        []*Order               `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *Order_OrderProduct) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
 }
 
-func (x *GetOrdersForAccountResponse) Reset() {
-	*x = GetOrdersForAccountResponse{}
-	mi := &file_order_proto_msgTypes[6]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *Order_OrderProduct) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
 }
 
-func (x *GetOrdersForAccountResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *Order_OrderProduct) GetQuantity() uint32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
 }
 
-func (*GetOrdersForAccountResponse) ProtoMessage() {}
-
-func (x *GetOrdersForAccountResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_order_proto_msgTypes[6]
+func (x *Order_OrderProduct) GetUnitPrice() *Money {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.UnitPrice
 	}
-	return mi.MessageOf(x)
+	return nil
 }
 
-// Deprecated: Use GetOrdersForAccountResponse.ProtoReflect.Descriptor instead.
-func (*GetOrdersForAccountResponse) Descriptor() ([]byte, []int) {
-	return file_order_proto_rawDescGZIP(), []int{6}
+func (x *Order_OrderProduct) GetDiscounts() []*Discount {
+	if x != nil {
+		return x.Discounts
+	}
+	return nil
 }
 
-func (x *GetOrdersForAccountResponse) GetOrders() []*Order {
+func (x *Order_OrderProduct) GetTax() *Money {
 	if x != nil {
-		return x.Orders
+		return x.Tax
 	}
 	return nil
 }
 
-type DeleteOrderRequest struct {
+func (x *Order_OrderProduct) GetWeightGrams() int64 {
+	if x != nil {
+		return x.WeightGrams
+	}
+	return 0
+}
+
+type PostOrderRequest_OrderProduct struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ProductId     string                 `protobuf:"bytes,2,opt,name=productId,proto3" json:"productId,omitempty"`
+	Quantity      uint32                 `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteOrderRequest) Reset() {
-	*x = DeleteOrderRequest{}
-	mi := &file_order_proto_msgTypes[7]
+func (x *PostOrderRequest_OrderProduct) Reset() {
+	*x = PostOrderRequest_OrderProduct{}
+	mi := &file_order_proto_msgTypes[52]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteOrderRequest) String() string {
+func (x *PostOrderRequest_OrderProduct) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteOrderRequest) ProtoMessage() {}
+func (*PostOrderRequest_OrderProduct) ProtoMessage() {}
 
-func (x *DeleteOrderRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_order_proto_msgTypes[7]
+func (x *PostOrderRequest_OrderProduct) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[52]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -401,42 +3461,49 @@ func (x *DeleteOrderRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteOrderRequest.ProtoReflect.Descriptor instead.
-func (*DeleteOrderRequest) Descriptor() ([]byte, []int) {
-	return file_order_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use PostOrderRequest_OrderProduct.ProtoReflect.Descriptor instead.
+func (*PostOrderRequest_OrderProduct) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{5, 0}
 }
 
-func (x *DeleteOrderRequest) GetId() string {
+func (x *PostOrderRequest_OrderProduct) GetProductId() string {
 	if x != nil {
-		return x.Id
+		return x.ProductId
 	}
 	return ""
 }
 
-type DeleteOrderResponse struct {
+func (x *PostOrderRequest_OrderProduct) GetQuantity() uint32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+type Refund_Line struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	DeletedId     string                 `protobuf:"bytes,1,opt,name=deletedId,proto3" json:"deletedId,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	Success       bool                   `protobuf:"varint,3,opt,name=success,proto3" json:"success,omitempty"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=productId,proto3" json:"productId,omitempty"`
+	Quantity      uint32                 `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Amount        *Money                 `protobuf:"bytes,3,opt,name=amount,proto3" json:"amount,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteOrderResponse) Reset() {
-	*x = DeleteOrderResponse{}
-	mi := &file_order_proto_msgTypes[8]
+func (x *Refund_Line) Reset() {
+	*x = Refund_Line{}
+	mi := &file_order_proto_msgTypes[53]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteOrderResponse) String() string {
+func (x *Refund_Line) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteOrderResponse) ProtoMessage() {}
+func (*Refund_Line) ProtoMessage() {}
 
-func (x *DeleteOrderResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_order_proto_msgTypes[8]
+func (x *Refund_Line) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[53]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -447,58 +3514,55 @@ func (x *DeleteOrderResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteOrderResponse.ProtoReflect.Descriptor instead.
-func (*DeleteOrderResponse) Descriptor() ([]byte, []int) {
-	return file_order_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use Refund_Line.ProtoReflect.Descriptor instead.
+func (*Refund_Line) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{25, 0}
 }
 
-func (x *DeleteOrderResponse) GetDeletedId() string {
+func (x *Refund_Line) GetProductId() string {
 	if x != nil {
-		return x.DeletedId
+		return x.ProductId
 	}
 	return ""
 }
 
-func (x *DeleteOrderResponse) GetMessage() string {
+func (x *Refund_Line) GetQuantity() uint32 {
 	if x != nil {
-		return x.Message
+		return x.Quantity
 	}
-	return ""
+	return 0
 }
 
-func (x *DeleteOrderResponse) GetSuccess() bool {
+func (x *Refund_Line) GetAmount() *Money {
 	if x != nil {
-		return x.Success
+		return x.Amount
 	}
-	return false
+	return nil
 }
 
-type Order_OrderProduct struct {
+type RefundOrderRequest_Line struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	Price         float64                `protobuf:"fixed64,4,opt,name=price,proto3" json:"price,omitempty"`
-	Quantity      uint32                 `protobuf:"varint,5,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=productId,proto3" json:"productId,omitempty"`
+	Quantity      uint32                 `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Order_OrderProduct) Reset() {
-	*x = Order_OrderProduct{}
-	mi := &file_order_proto_msgTypes[9]
+func (x *RefundOrderRequest_Line) Reset() {
+	*x = RefundOrderRequest_Line{}
+	mi := &file_order_proto_msgTypes[54]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Order_OrderProduct) String() string {
+func (x *RefundOrderRequest_Line) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Order_OrderProduct) ProtoMessage() {}
+func (*RefundOrderRequest_Line) ProtoMessage() {}
 
-func (x *Order_OrderProduct) ProtoReflect() protoreflect.Message {
-	mi := &file_order_proto_msgTypes[9]
+func (x *RefundOrderRequest_Line) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[54]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -509,69 +3573,48 @@ func (x *Order_OrderProduct) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Order_OrderProduct.ProtoReflect.Descriptor instead.
-func (*Order_OrderProduct) Descriptor() ([]byte, []int) {
-	return file_order_proto_rawDescGZIP(), []int{0, 0}
-}
-
-func (x *Order_OrderProduct) GetId() string {
-	if x != nil {
-		return x.Id
-	}
-	return ""
-}
-
-func (x *Order_OrderProduct) GetName() string {
-	if x != nil {
-		return x.Name
-	}
-	return ""
+// Deprecated: Use RefundOrderRequest_Line.ProtoReflect.Descriptor instead.
+func (*RefundOrderRequest_Line) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{26, 0}
 }
 
-func (x *Order_OrderProduct) GetDescription() string {
+func (x *RefundOrderRequest_Line) GetProductId() string {
 	if x != nil {
-		return x.Description
+		return x.ProductId
 	}
 	return ""
 }
 
-func (x *Order_OrderProduct) GetPrice() float64 {
-	if x != nil {
-		return x.Price
-	}
-	return 0
-}
-
-func (x *Order_OrderProduct) GetQuantity() uint32 {
+func (x *RefundOrderRequest_Line) GetQuantity() uint32 {
 	if x != nil {
 		return x.Quantity
 	}
 	return 0
 }
 
-type PostOrderRequest_OrderProduct struct {
+type Shipment_Line struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ProductId     string                 `protobuf:"bytes,2,opt,name=productId,proto3" json:"productId,omitempty"`
-	Quantity      uint32                 `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=productId,proto3" json:"productId,omitempty"`
+	Quantity      uint32                 `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PostOrderRequest_OrderProduct) Reset() {
-	*x = PostOrderRequest_OrderProduct{}
-	mi := &file_order_proto_msgTypes[10]
+func (x *Shipment_Line) Reset() {
+	*x = Shipment_Line{}
+	mi := &file_order_proto_msgTypes[55]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PostOrderRequest_OrderProduct) String() string {
+func (x *Shipment_Line) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PostOrderRequest_OrderProduct) ProtoMessage() {}
+func (*Shipment_Line) ProtoMessage() {}
 
-func (x *PostOrderRequest_OrderProduct) ProtoReflect() protoreflect.Message {
-	mi := &file_order_proto_msgTypes[10]
+func (x *Shipment_Line) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[55]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -582,19 +3625,19 @@ func (x *PostOrderRequest_OrderProduct) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PostOrderRequest_OrderProduct.ProtoReflect.Descriptor instead.
-func (*PostOrderRequest_OrderProduct) Descriptor() ([]byte, []int) {
-	return file_order_proto_rawDescGZIP(), []int{1, 0}
+// Deprecated: Use Shipment_Line.ProtoReflect.Descriptor instead.
+func (*Shipment_Line) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{30, 0}
 }
 
-func (x *PostOrderRequest_OrderProduct) GetProductId() string {
+func (x *Shipment_Line) GetProductId() string {
 	if x != nil {
 		return x.ProductId
 	}
 	return ""
 }
 
-func (x *PostOrderRequest_OrderProduct) GetQuantity() uint32 {
+func (x *Shipment_Line) GetQuantity() uint32 {
 	if x != nil {
 		return x.Quantity
 	}
@@ -605,33 +3648,97 @@ var File_order_proto protoreflect.FileDescriptor
 
 const file_order_proto_rawDesc = "" +
 	"\n" +
-	"\vorder.proto\x12\bgenproto\"\xb6\x02\n" +
+	"\vorder.proto\x12\bgenproto\";\n" +
+	"\x05Money\x12\x16\n" +
+	"\x06amount\x18\x01 \x01(\x03R\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\x02 \x01(\tR\bcurrency\"}\n" +
+	"\bDiscount\x12 \n" +
+	"\vpromotionId\x18\x01 \x01(\tR\vpromotionId\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12'\n" +
+	"\x06amount\x18\x04 \x01(\v2\x0f.genproto.MoneyR\x06amount\"\xaf\x01\n" +
+	"\aAddress\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05line1\x18\x02 \x01(\tR\x05line1\x12\x14\n" +
+	"\x05line2\x18\x03 \x01(\tR\x05line2\x12\x12\n" +
+	"\x04city\x18\x04 \x01(\tR\x04city\x12\x16\n" +
+	"\x06region\x18\x05 \x01(\tR\x06region\x12\x1e\n" +
+	"\n" +
+	"postalCode\x18\x06 \x01(\tR\n" +
+	"postalCode\x12\x18\n" +
+	"\acountry\x18\a \x01(\tR\acountry\"\xde\x06\n" +
 	"\x05Order\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1c\n" +
 	"\tcreatedAt\x18\x02 \x01(\fR\tcreatedAt\x12\x1c\n" +
-	"\taccountId\x18\x03 \x01(\tR\taccountId\x12\x1e\n" +
-	"\n" +
-	"totalPrice\x18\x04 \x01(\x01R\n" +
-	"totalPrice\x128\n" +
-	"\bproducts\x18\x05 \x03(\v2\x1c.genproto.Order.OrderProductR\bproducts\x1a\x86\x01\n" +
+	"\taccountId\x18\x03 \x01(\tR\taccountId\x128\n" +
+	"\bproducts\x18\x05 \x03(\v2\x1c.genproto.Order.OrderProductR\bproducts\x12\x1a\n" +
+	"\bcurrency\x18\x06 \x01(\tR\bcurrency\x12\x16\n" +
+	"\x06status\x18\a \x01(\tR\x06status\x12%\n" +
+	"\x05total\x18\b \x01(\v2\x0f.genproto.MoneyR\x05total\x12+\n" +
+	"\bsubtotal\x18\t \x01(\v2\x0f.genproto.MoneyR\bsubtotal\x12+\n" +
+	"\bdiscount\x18\n" +
+	" \x01(\v2\x0f.genproto.MoneyR\bdiscount\x12+\n" +
+	"\bshipping\x18\v \x01(\v2\x0f.genproto.MoneyR\bshipping\x12!\n" +
+	"\x03tax\x18\f \x01(\v2\x0f.genproto.MoneyR\x03tax\x12\"\n" +
+	"\ftaxInclusive\x18\r \x01(\bR\ftaxInclusive\x12;\n" +
+	"\x0fshippingAddress\x18\x0e \x01(\v2\x11.genproto.AddressR\x0fshippingAddress\x121\n" +
+	"\vshippingTax\x18\x0f \x01(\v2\x0f.genproto.MoneyR\vshippingTax\x1a\xa3\x02\n" +
 	"\fOrderProduct\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
-	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x14\n" +
-	"\x05price\x18\x04 \x01(\x01R\x05price\x12\x1a\n" +
-	"\bquantity\x18\x05 \x01(\rR\bquantity\"\xbf\x01\n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x1a\n" +
+	"\bquantity\x18\x05 \x01(\rR\bquantity\x12-\n" +
+	"\tunitPrice\x18\x06 \x01(\v2\x0f.genproto.MoneyR\tunitPrice\x120\n" +
+	"\tdiscounts\x18\a \x03(\v2\x12.genproto.DiscountR\tdiscounts\x12!\n" +
+	"\x03tax\x18\b \x01(\v2\x0f.genproto.MoneyR\x03tax\x12 \n" +
+	"\vweightGrams\x18\t \x01(\x03R\vweightGramsJ\x04\b\x04\x10\x05R\x05priceJ\x04\b\x04\x10\x05R\n" +
+	"totalPrice\"\xb5\x01\n" +
+	"\x11OrderStatusChange\x12\x18\n" +
+	"\aorderId\x18\x01 \x01(\tR\aorderId\x12\x1e\n" +
+	"\n" +
+	"fromStatus\x18\x02 \x01(\tR\n" +
+	"fromStatus\x12\x1a\n" +
+	"\btoStatus\x18\x03 \x01(\tR\btoStatus\x12\x14\n" +
+	"\x05actor\x18\x04 \x01(\tR\x05actor\x12\x16\n" +
+	"\x06reason\x18\x05 \x01(\tR\x06reason\x12\x1c\n" +
+	"\tchangedAt\x18\x06 \x01(\fR\tchangedAt\"\xda\x02\n" +
 	"\x10PostOrderRequest\x12\x1c\n" +
 	"\taccountId\x18\x02 \x01(\tR\taccountId\x12C\n" +
-	"\bproducts\x18\x04 \x03(\v2'.genproto.PostOrderRequest.OrderProductR\bproducts\x1aH\n" +
+	"\bproducts\x18\x04 \x03(\v2'.genproto.PostOrderRequest.OrderProductR\bproducts\x12\x1a\n" +
+	"\bcurrency\x18\x05 \x01(\tR\bcurrency\x12&\n" +
+	"\x0eidempotencyKey\x18\x06 \x01(\tR\x0eidempotencyKey\x12\x18\n" +
+	"\acoupons\x18\a \x03(\tR\acoupons\x12;\n" +
+	"\x0fshippingAddress\x18\b \x01(\v2\x11.genproto.AddressR\x0fshippingAddress\x1aH\n" +
 	"\fOrderProduct\x12\x1c\n" +
 	"\tproductId\x18\x02 \x01(\tR\tproductId\x12\x1a\n" +
-	"\bquantity\x18\x03 \x01(\rR\bquantity\":\n" +
+	"\bquantity\x18\x03 \x01(\rR\bquantity\"\xe9\x01\n" +
+	"\x11QuoteOrderRequest\x12\x1c\n" +
+	"\taccountId\x18\x01 \x01(\tR\taccountId\x12C\n" +
+	"\bproducts\x18\x02 \x03(\v2'.genproto.PostOrderRequest.OrderProductR\bproducts\x12\x1a\n" +
+	"\bcurrency\x18\x03 \x01(\tR\bcurrency\x12\x18\n" +
+	"\acoupons\x18\x04 \x03(\tR\acoupons\x12;\n" +
+	"\x0fshippingAddress\x18\x05 \x01(\v2\x11.genproto.AddressR\x0fshippingAddress\";\n" +
+	"\x12QuoteOrderResponse\x12%\n" +
+	"\x05quote\x18\x01 \x01(\v2\x0f.genproto.OrderR\x05quote\":\n" +
 	"\x11PostOrderResponse\x12%\n" +
 	"\x05order\x18\x01 \x01(\v2\x0f.genproto.OrderR\x05order\"!\n" +
 	"\x0fGetOrderRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"9\n" +
 	"\x10GetOrderResponse\x12%\n" +
-	"\x05order\x18\x01 \x01(\v2\x0f.genproto.OrderR\x05order\":\n" +
+	"\x05order\x18\x01 \x01(\v2\x0f.genproto.OrderR\x05order\"\x99\x02\n" +
+	"\x11ListOrdersRequest\x12\x1c\n" +
+	"\taccountId\x18\x01 \x01(\tR\taccountId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\"\n" +
+	"\fcreatedAfter\x18\x03 \x01(\fR\fcreatedAfter\x12$\n" +
+	"\rcreatedBefore\x18\x04 \x01(\fR\rcreatedBefore\x12+\n" +
+	"\bminTotal\x18\x05 \x01(\v2\x0f.genproto.MoneyR\bminTotal\x12+\n" +
+	"\bmaxTotal\x18\x06 \x01(\v2\x0f.genproto.MoneyR\bmaxTotal\x12\x14\n" +
+	"\x05after\x18\a \x01(\tR\x05after\x12\x14\n" +
+	"\x05first\x18\b \x01(\rR\x05first\"}\n" +
+	"\x12ListOrdersResponse\x12'\n" +
+	"\x06orders\x18\x01 \x03(\v2\x0f.genproto.OrderR\x06orders\x12\x1c\n" +
+	"\tendCursor\x18\x02 \x01(\tR\tendCursor\x12 \n" +
+	"\vhasNextPage\x18\x03 \x01(\bR\vhasNextPage\":\n" +
 	"\x1aGetOrdersForAccountRequest\x12\x1c\n" +
 	"\taccountId\x18\x01 \x01(\tR\taccountId\"F\n" +
 	"\x1bGetOrdersForAccountResponse\x12'\n" +
@@ -641,11 +3748,198 @@ const file_order_proto_rawDesc = "" +
 	"\x13DeleteOrderResponse\x12\x1c\n" +
 	"\tdeletedId\x18\x01 \x01(\tR\tdeletedId\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12\x18\n" +
-	"\asuccess\x18\x03 \x01(\bR\asuccess2\x8a\x02\n" +
+	"\asuccess\x18\x03 \x01(\bR\asuccess\"\x19\n" +
+	"\x17ExportOrderLinesRequest\"}\n" +
+	"\tOrderLine\x12\x18\n" +
+	"\aorderId\x18\x01 \x01(\tR\aorderId\x12\x1c\n" +
+	"\taccountId\x18\x02 \x01(\tR\taccountId\x12\x1c\n" +
+	"\tproductId\x18\x03 \x01(\tR\tproductId\x12\x1a\n" +
+	"\bquantity\x18\x04 \x01(\rR\bquantity\"p\n" +
+	"\x18UpdateOrderStatusRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x14\n" +
+	"\x05actor\x18\x03 \x01(\tR\x05actor\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\"B\n" +
+	"\x19UpdateOrderStatusResponse\x12%\n" +
+	"\x05order\x18\x01 \x01(\v2\x0f.genproto.OrderR\x05order\".\n" +
+	"\x1cGetOrderStatusHistoryRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"V\n" +
+	"\x1dGetOrderStatusHistoryResponse\x125\n" +
+	"\ahistory\x18\x01 \x03(\v2\x1b.genproto.OrderStatusChangeR\ahistory\"R\n" +
+	"\x12CancelOrderRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\x12\x14\n" +
+	"\x05actor\x18\x03 \x01(\tR\x05actor\"<\n" +
+	"\x13CancelOrderResponse\x12%\n" +
+	"\x05order\x18\x01 \x01(\v2\x0f.genproto.OrderR\x05order\"\xbf\x02\n" +
+	"\x06Refund\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\aorderId\x18\x02 \x01(\tR\aorderId\x12'\n" +
+	"\x06amount\x18\x03 \x01(\v2\x0f.genproto.MoneyR\x06amount\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\x12\x14\n" +
+	"\x05actor\x18\x05 \x01(\tR\x05actor\x12\x1c\n" +
+	"\tcreatedAt\x18\x06 \x01(\fR\tcreatedAt\x12+\n" +
+	"\x05lines\x18\a \x03(\v2\x15.genproto.Refund.LineR\x05lines\x1ai\n" +
+	"\x04Line\x12\x1c\n" +
+	"\tproductId\x18\x01 \x01(\tR\tproductId\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\rR\bquantity\x12'\n" +
+	"\x06amount\x18\x03 \x01(\v2\x0f.genproto.MoneyR\x06amount\"\xcd\x01\n" +
+	"\x12RefundOrderRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x127\n" +
+	"\x05lines\x18\x02 \x03(\v2!.genproto.RefundOrderRequest.LineR\x05lines\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12\x14\n" +
+	"\x05actor\x18\x04 \x01(\tR\x05actor\x1a@\n" +
+	"\x04Line\x12\x1c\n" +
+	"\tproductId\x18\x01 \x01(\tR\tproductId\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\rR\bquantity\"f\n" +
+	"\x13RefundOrderResponse\x12%\n" +
+	"\x05order\x18\x01 \x01(\v2\x0f.genproto.OrderR\x05order\x12(\n" +
+	"\x06refund\x18\x02 \x01(\v2\x10.genproto.RefundR\x06refund\"(\n" +
+	"\x16GetOrderRefundsRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"E\n" +
+	"\x17GetOrderRefundsResponse\x12*\n" +
+	"\arefunds\x18\x01 \x03(\v2\x10.genproto.RefundR\arefunds\"\xa8\x03\n" +
+	"\bShipment\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\aorderId\x18\x02 \x01(\tR\aorderId\x12\x18\n" +
+	"\acarrier\x18\x03 \x01(\tR\acarrier\x12&\n" +
+	"\x0etrackingNumber\x18\x04 \x01(\tR\x0etrackingNumber\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\x12+\n" +
+	"\aaddress\x18\x06 \x01(\v2\x11.genproto.AddressR\aaddress\x12-\n" +
+	"\x05lines\x18\a \x03(\v2\x17.genproto.Shipment.LineR\x05lines\x12\x1c\n" +
+	"\tshippedAt\x18\b \x01(\fR\tshippedAt\x12 \n" +
+	"\vdeliveredAt\x18\t \x01(\fR\vdeliveredAt\x12\x1c\n" +
+	"\tcreatedAt\x18\n" +
+	" \x01(\fR\tcreatedAt\x12\x1c\n" +
+	"\tupdatedAt\x18\v \x01(\fR\tupdatedAt\x1a@\n" +
+	"\x04Line\x12\x1c\n" +
+	"\tproductId\x18\x01 \x01(\tR\tproductId\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\rR\bquantity\"\xf5\x01\n" +
+	"\x15CreateShipmentRequest\x12\x18\n" +
+	"\aorderId\x18\x01 \x01(\tR\aorderId\x12\x18\n" +
+	"\acarrier\x18\x02 \x01(\tR\acarrier\x12&\n" +
+	"\x0etrackingNumber\x18\x03 \x01(\tR\x0etrackingNumber\x12-\n" +
+	"\x05lines\x18\x04 \x03(\v2\x17.genproto.Shipment.LineR\x05lines\x12;\n" +
+	"\x0fshippingAddress\x18\x05 \x01(\v2\x11.genproto.AddressR\x0fshippingAddress\x12\x14\n" +
+	"\x05actor\x18\x06 \x01(\tR\x05actor\"\xa7\x01\n" +
+	"\x15UpdateShipmentRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x18\n" +
+	"\acarrier\x18\x03 \x01(\tR\acarrier\x12&\n" +
+	"\x0etrackingNumber\x18\x04 \x01(\tR\x0etrackingNumber\x12\x0e\n" +
+	"\x02at\x18\x05 \x01(\fR\x02at\x12\x14\n" +
+	"\x05actor\x18\x06 \x01(\tR\x05actor\"i\n" +
+	"\x10ShipmentResponse\x12%\n" +
+	"\x05order\x18\x01 \x01(\v2\x0f.genproto.OrderR\x05order\x12.\n" +
+	"\bshipment\x18\x02 \x01(\v2\x12.genproto.ShipmentR\bshipment\"*\n" +
+	"\x18GetOrderShipmentsRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"M\n" +
+	"\x19GetOrderShipmentsResponse\x120\n" +
+	"\tshipments\x18\x01 \x03(\v2\x12.genproto.ShipmentR\tshipments\"\xc0\x01\n" +
+	"\aInvoice\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06number\x18\x02 \x01(\tR\x06number\x12\x18\n" +
+	"\aorderId\x18\x03 \x01(\tR\aorderId\x12\x1a\n" +
+	"\bissuedAt\x18\x04 \x01(\fR\bissuedAt\x12%\n" +
+	"\x05total\x18\x05 \x01(\v2\x0f.genproto.MoneyR\x05total\x12\x18\n" +
+	"\ahtmlUrl\x18\x06 \x01(\tR\ahtmlUrl\x12\x16\n" +
+	"\x06pdfUrl\x18\a \x01(\tR\x06pdfUrl\"-\n" +
+	"\x11GetInvoiceRequest\x12\x18\n" +
+	"\aorderId\x18\x01 \x01(\tR\aorderId\"A\n" +
+	"\x12GetInvoiceResponse\x12+\n" +
+	"\ainvoice\x18\x01 \x01(\v2\x11.genproto.InvoiceR\ainvoice\"n\n" +
+	"\fCheckoutStep\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\x12\x1c\n" +
+	"\tupdatedAt\x18\x04 \x01(\fR\tupdatedAt\"\x9a\x02\n" +
+	"\x0eCheckoutStatus\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\aorderId\x18\x02 \x01(\tR\aorderId\x12\x1c\n" +
+	"\taccountId\x18\x03 \x01(\tR\taccountId\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12\x14\n" +
+	"\x05error\x18\x05 \x01(\tR\x05error\x12(\n" +
+	"\x0fpaymentIntentId\x18\x06 \x01(\tR\x0fpaymentIntentId\x12,\n" +
+	"\x05steps\x18\a \x03(\v2\x16.genproto.CheckoutStepR\x05steps\x12\x1c\n" +
+	"\tcreatedAt\x18\b \x01(\fR\tcreatedAt\x12\x1c\n" +
+	"\tupdatedAt\x18\t \x01(\fR\tupdatedAt\"\xb5\x02\n" +
+	"\x0fCheckoutRequest\x12\x1c\n" +
+	"\taccountId\x18\x01 \x01(\tR\taccountId\x12C\n" +
+	"\bproducts\x18\x02 \x03(\v2'.genproto.PostOrderRequest.OrderProductR\bproducts\x12\x1a\n" +
+	"\bcurrency\x18\x03 \x01(\tR\bcurrency\x12$\n" +
+	"\rpaymentMethod\x18\x04 \x01(\tR\rpaymentMethod\x12&\n" +
+	"\x0eidempotencyKey\x18\x05 \x01(\tR\x0eidempotencyKey\x12\x18\n" +
+	"\acoupons\x18\x06 \x03(\tR\acoupons\x12;\n" +
+	"\x0fshippingAddress\x18\a \x01(\v2\x11.genproto.AddressR\x0fshippingAddress\"H\n" +
+	"\x10CheckoutResponse\x124\n" +
+	"\bcheckout\x18\x01 \x01(\v2\x18.genproto.CheckoutStatusR\bcheckout\"*\n" +
+	"\x18GetCheckoutStatusRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"Q\n" +
+	"\x19GetCheckoutStatusResponse\x124\n" +
+	"\bcheckout\x18\x01 \x01(\v2\x18.genproto.CheckoutStatusR\bcheckout\"\xd5\x04\n" +
+	"\tPromotion\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x12\n" +
+	"\x04kind\x18\x04 \x01(\tR\x04kind\x12\x1e\n" +
+	"\n" +
+	"percentOff\x18\x05 \x01(\rR\n" +
+	"percentOff\x12-\n" +
+	"\tamountOff\x18\x06 \x01(\v2\x0f.genproto.MoneyR\tamountOff\x12 \n" +
+	"\vbuyQuantity\x18\a \x01(\rR\vbuyQuantity\x12 \n" +
+	"\vgetQuantity\x18\b \x01(\rR\vgetQuantity\x12\x1e\n" +
+	"\n" +
+	"productIds\x18\t \x03(\tR\n" +
+	"productIds\x12+\n" +
+	"\bminSpend\x18\n" +
+	" \x01(\v2\x0f.genproto.MoneyR\bminSpend\x12\x1a\n" +
+	"\bstartsAt\x18\v \x01(\fR\bstartsAt\x12\x16\n" +
+	"\x06endsAt\x18\f \x01(\fR\x06endsAt\x12\x18\n" +
+	"\amaxUses\x18\r \x01(\rR\amaxUses\x12,\n" +
+	"\x11maxUsesPerAccount\x18\x0e \x01(\rR\x11maxUsesPerAccount\x12\x1c\n" +
+	"\tstackable\x18\x0f \x01(\bR\tstackable\x12\x1a\n" +
+	"\bpriority\x18\x10 \x01(\x05R\bpriority\x12\x16\n" +
+	"\x06active\x18\x11 \x01(\bR\x06active\x12\x12\n" +
+	"\x04uses\x18\x12 \x01(\rR\x04uses\x12\x1c\n" +
+	"\tcreatedAt\x18\x13 \x01(\fR\tcreatedAt\x12\x1c\n" +
+	"\tupdatedAt\x18\x14 \x01(\fR\tupdatedAt\"K\n" +
+	"\x16CreatePromotionRequest\x121\n" +
+	"\tpromotion\x18\x01 \x01(\v2\x13.genproto.PromotionR\tpromotion\"C\n" +
+	"\x19SetPromotionActiveRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06active\x18\x02 \x01(\bR\x06active\"F\n" +
+	"\x11PromotionResponse\x121\n" +
+	"\tpromotion\x18\x01 \x01(\v2\x13.genproto.PromotionR\tpromotion\"\x17\n" +
+	"\x15ListPromotionsRequest\"M\n" +
+	"\x16ListPromotionsResponse\x123\n" +
+	"\n" +
+	"promotions\x18\x01 \x03(\v2\x13.genproto.PromotionR\n" +
+	"promotions2\xee\r\n" +
 	"\fOrderService\x12F\n" +
-	"\tPostOrder\x12\x1a.genproto.PostOrderRequest\x1a\x1b.genproto.PostOrderResponse\"\x00\x12d\n" +
-	"\x13GetOrdersForAccount\x12$.genproto.GetOrdersForAccountRequest\x1a%.genproto.GetOrdersForAccountResponse\"\x00\x12L\n" +
-	"\vDeleteOrder\x12\x1c.genproto.DeleteOrderRequest\x1a\x1d.genproto.DeleteOrderResponse\"\x00B)Z'github.com/wignn/micro-3/order/genprotob\x06proto3"
+	"\tPostOrder\x12\x1a.genproto.PostOrderRequest\x1a\x1b.genproto.PostOrderResponse\"\x00\x12I\n" +
+	"\n" +
+	"QuoteOrder\x12\x1b.genproto.QuoteOrderRequest\x1a\x1c.genproto.QuoteOrderResponse\"\x00\x12d\n" +
+	"\x13GetOrdersForAccount\x12$.genproto.GetOrdersForAccountRequest\x1a%.genproto.GetOrdersForAccountResponse\"\x00\x12C\n" +
+	"\bGetOrder\x12\x19.genproto.GetOrderRequest\x1a\x1a.genproto.GetOrderResponse\"\x00\x12I\n" +
+	"\n" +
+	"ListOrders\x12\x1b.genproto.ListOrdersRequest\x1a\x1c.genproto.ListOrdersResponse\"\x00\x12L\n" +
+	"\vDeleteOrder\x12\x1c.genproto.DeleteOrderRequest\x1a\x1d.genproto.DeleteOrderResponse\"\x00\x12N\n" +
+	"\x10ExportOrderLines\x12!.genproto.ExportOrderLinesRequest\x1a\x13.genproto.OrderLine\"\x000\x01\x12^\n" +
+	"\x11UpdateOrderStatus\x12\".genproto.UpdateOrderStatusRequest\x1a#.genproto.UpdateOrderStatusResponse\"\x00\x12j\n" +
+	"\x15GetOrderStatusHistory\x12&.genproto.GetOrderStatusHistoryRequest\x1a'.genproto.GetOrderStatusHistoryResponse\"\x00\x12L\n" +
+	"\vCancelOrder\x12\x1c.genproto.CancelOrderRequest\x1a\x1d.genproto.CancelOrderResponse\"\x00\x12L\n" +
+	"\vRefundOrder\x12\x1c.genproto.RefundOrderRequest\x1a\x1d.genproto.RefundOrderResponse\"\x00\x12X\n" +
+	"\x0fGetOrderRefunds\x12 .genproto.GetOrderRefundsRequest\x1a!.genproto.GetOrderRefundsResponse\"\x00\x12O\n" +
+	"\x0eCreateShipment\x12\x1f.genproto.CreateShipmentRequest\x1a\x1a.genproto.ShipmentResponse\"\x00\x12O\n" +
+	"\x0eUpdateShipment\x12\x1f.genproto.UpdateShipmentRequest\x1a\x1a.genproto.ShipmentResponse\"\x00\x12^\n" +
+	"\x11GetOrderShipments\x12\".genproto.GetOrderShipmentsRequest\x1a#.genproto.GetOrderShipmentsResponse\"\x00\x12I\n" +
+	"\n" +
+	"GetInvoice\x12\x1b.genproto.GetInvoiceRequest\x1a\x1c.genproto.GetInvoiceResponse\"\x00\x12C\n" +
+	"\bCheckout\x12\x19.genproto.CheckoutRequest\x1a\x1a.genproto.CheckoutResponse\"\x00\x12^\n" +
+	"\x11GetCheckoutStatus\x12\".genproto.GetCheckoutStatusRequest\x1a#.genproto.GetCheckoutStatusResponse\"\x00\x12R\n" +
+	"\x0fCreatePromotion\x12 .genproto.CreatePromotionRequest\x1a\x1b.genproto.PromotionResponse\"\x00\x12X\n" +
+	"\x12SetPromotionActive\x12#.genproto.SetPromotionActiveRequest\x1a\x1b.genproto.PromotionResponse\"\x00\x12U\n" +
+	"\x0eListPromotions\x12\x1f.genproto.ListPromotionsRequest\x1a .genproto.ListPromotionsResponse\"\x00B)Z'github.com/wignn/micro-3/order/genprotob\x06proto3"
 
 var (
 	file_order_proto_rawDescOnce sync.Once
@@ -659,37 +3953,165 @@ func file_order_proto_rawDescGZIP() []byte {
 	return file_order_proto_rawDescData
 }
 
-var file_order_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_order_proto_msgTypes = make([]protoimpl.MessageInfo, 56)
 var file_order_proto_goTypes = []any{
-	(*Order)(nil),                         // 0: genproto.Order
-	(*PostOrderRequest)(nil),              // 1: genproto.PostOrderRequest
-	(*PostOrderResponse)(nil),             // 2: genproto.PostOrderResponse
-	(*GetOrderRequest)(nil),               // 3: genproto.GetOrderRequest
-	(*GetOrderResponse)(nil),              // 4: genproto.GetOrderResponse
-	(*GetOrdersForAccountRequest)(nil),    // 5: genproto.GetOrdersForAccountRequest
-	(*GetOrdersForAccountResponse)(nil),   // 6: genproto.GetOrdersForAccountResponse
-	(*DeleteOrderRequest)(nil),            // 7: genproto.DeleteOrderRequest
-	(*DeleteOrderResponse)(nil),           // 8: genproto.DeleteOrderResponse
-	(*Order_OrderProduct)(nil),            // 9: genproto.Order.OrderProduct
-	(*PostOrderRequest_OrderProduct)(nil), // 10: genproto.PostOrderRequest.OrderProduct
+	(*Money)(nil),                         // 0: genproto.Money
+	(*Discount)(nil),                      // 1: genproto.Discount
+	(*Address)(nil),                       // 2: genproto.Address
+	(*Order)(nil),                         // 3: genproto.Order
+	(*OrderStatusChange)(nil),             // 4: genproto.OrderStatusChange
+	(*PostOrderRequest)(nil),              // 5: genproto.PostOrderRequest
+	(*QuoteOrderRequest)(nil),             // 6: genproto.QuoteOrderRequest
+	(*QuoteOrderResponse)(nil),            // 7: genproto.QuoteOrderResponse
+	(*PostOrderResponse)(nil),             // 8: genproto.PostOrderResponse
+	(*GetOrderRequest)(nil),               // 9: genproto.GetOrderRequest
+	(*GetOrderResponse)(nil),              // 10: genproto.GetOrderResponse
+	(*ListOrdersRequest)(nil),             // 11: genproto.ListOrdersRequest
+	(*ListOrdersResponse)(nil),            // 12: genproto.ListOrdersResponse
+	(*GetOrdersForAccountRequest)(nil),    // 13: genproto.GetOrdersForAccountRequest
+	(*GetOrdersForAccountResponse)(nil),   // 14: genproto.GetOrdersForAccountResponse
+	(*DeleteOrderRequest)(nil),            // 15: genproto.DeleteOrderRequest
+	(*DeleteOrderResponse)(nil),           // 16: genproto.DeleteOrderResponse
+	(*ExportOrderLinesRequest)(nil),       // 17: genproto.ExportOrderLinesRequest
+	(*OrderLine)(nil),                     // 18: genproto.OrderLine
+	(*UpdateOrderStatusRequest)(nil),      // 19: genproto.UpdateOrderStatusRequest
+	(*UpdateOrderStatusResponse)(nil),     // 20: genproto.UpdateOrderStatusResponse
+	(*GetOrderStatusHistoryRequest)(nil),  // 21: genproto.GetOrderStatusHistoryRequest
+	(*GetOrderStatusHistoryResponse)(nil), // 22: genproto.GetOrderStatusHistoryResponse
+	(*CancelOrderRequest)(nil),            // 23: genproto.CancelOrderRequest
+	(*CancelOrderResponse)(nil),           // 24: genproto.CancelOrderResponse
+	(*Refund)(nil),                        // 25: genproto.Refund
+	(*RefundOrderRequest)(nil),            // 26: genproto.RefundOrderRequest
+	(*RefundOrderResponse)(nil),           // 27: genproto.RefundOrderResponse
+	(*GetOrderRefundsRequest)(nil),        // 28: genproto.GetOrderRefundsRequest
+	(*GetOrderRefundsResponse)(nil),       // 29: genproto.GetOrderRefundsResponse
+	(*Shipment)(nil),                      // 30: genproto.Shipment
+	(*CreateShipmentRequest)(nil),         // 31: genproto.CreateShipmentRequest
+	(*UpdateShipmentRequest)(nil),         // 32: genproto.UpdateShipmentRequest
+	(*ShipmentResponse)(nil),              // 33: genproto.ShipmentResponse
+	(*GetOrderShipmentsRequest)(nil),      // 34: genproto.GetOrderShipmentsRequest
+	(*GetOrderShipmentsResponse)(nil),     // 35: genproto.GetOrderShipmentsResponse
+	(*Invoice)(nil),                       // 36: genproto.Invoice
+	(*GetInvoiceRequest)(nil),             // 37: genproto.GetInvoiceRequest
+	(*GetInvoiceResponse)(nil),            // 38: genproto.GetInvoiceResponse
+	(*CheckoutStep)(nil),                  // 39: genproto.CheckoutStep
+	(*CheckoutStatus)(nil),                // 40: genproto.CheckoutStatus
+	(*CheckoutRequest)(nil),               // 41: genproto.CheckoutRequest
+	(*CheckoutResponse)(nil),              // 42: genproto.CheckoutResponse
+	(*GetCheckoutStatusRequest)(nil),      // 43: genproto.GetCheckoutStatusRequest
+	(*GetCheckoutStatusResponse)(nil),     // 44: genproto.GetCheckoutStatusResponse
+	(*Promotion)(nil),                     // 45: genproto.Promotion
+	(*CreatePromotionRequest)(nil),        // 46: genproto.CreatePromotionRequest
+	(*SetPromotionActiveRequest)(nil),     // 47: genproto.SetPromotionActiveRequest
+	(*PromotionResponse)(nil),             // 48: genproto.PromotionResponse
+	(*ListPromotionsRequest)(nil),         // 49: genproto.ListPromotionsRequest
+	(*ListPromotionsResponse)(nil),        // 50: genproto.ListPromotionsResponse
+	(*Order_OrderProduct)(nil),            // 51: genproto.Order.OrderProduct
+	(*PostOrderRequest_OrderProduct)(nil), // 52: genproto.PostOrderRequest.OrderProduct
+	(*Refund_Line)(nil),                   // 53: genproto.Refund.Line
+	(*RefundOrderRequest_Line)(nil),       // 54: genproto.RefundOrderRequest.Line
+	(*Shipment_Line)(nil),                 // 55: genproto.Shipment.Line
 }
 var file_order_proto_depIdxs = []int32{
-	9,  // 0: genproto.Order.products:type_name -> genproto.Order.OrderProduct
-	10, // 1: genproto.PostOrderRequest.products:type_name -> genproto.PostOrderRequest.OrderProduct
-	0,  // 2: genproto.PostOrderResponse.order:type_name -> genproto.Order
-	0,  // 3: genproto.GetOrderResponse.order:type_name -> genproto.Order
-	0,  // 4: genproto.GetOrdersForAccountResponse.orders:type_name -> genproto.Order
-	1,  // 5: genproto.OrderService.PostOrder:input_type -> genproto.PostOrderRequest
-	5,  // 6: genproto.OrderService.GetOrdersForAccount:input_type -> genproto.GetOrdersForAccountRequest
-	7,  // 7: genproto.OrderService.DeleteOrder:input_type -> genproto.DeleteOrderRequest
-	2,  // 8: genproto.OrderService.PostOrder:output_type -> genproto.PostOrderResponse
-	6,  // 9: genproto.OrderService.GetOrdersForAccount:output_type -> genproto.GetOrdersForAccountResponse
-	8,  // 10: genproto.OrderService.DeleteOrder:output_type -> genproto.DeleteOrderResponse
-	8,  // [8:11] is the sub-list for method output_type
-	5,  // [5:8] is the sub-list for method input_type
-	5,  // [5:5] is the sub-list for extension type_name
-	5,  // [5:5] is the sub-list for extension extendee
-	0,  // [0:5] is the sub-list for field type_name
+	0,  // 0: genproto.Discount.amount:type_name -> genproto.Money
+	51, // 1: genproto.Order.products:type_name -> genproto.Order.OrderProduct
+	0,  // 2: genproto.Order.total:type_name -> genproto.Money
+	0,  // 3: genproto.Order.subtotal:type_name -> genproto.Money
+	0,  // 4: genproto.Order.discount:type_name -> genproto.Money
+	0,  // 5: genproto.Order.shipping:type_name -> genproto.Money
+	0,  // 6: genproto.Order.tax:type_name -> genproto.Money
+	2,  // 7: genproto.Order.shippingAddress:type_name -> genproto.Address
+	0,  // 8: genproto.Order.shippingTax:type_name -> genproto.Money
+	52, // 9: genproto.PostOrderRequest.products:type_name -> genproto.PostOrderRequest.OrderProduct
+	2,  // 10: genproto.PostOrderRequest.shippingAddress:type_name -> genproto.Address
+	52, // 11: genproto.QuoteOrderRequest.products:type_name -> genproto.PostOrderRequest.OrderProduct
+	2,  // 12: genproto.QuoteOrderRequest.shippingAddress:type_name -> genproto.Address
+	3,  // 13: genproto.QuoteOrderResponse.quote:type_name -> genproto.Order
+	3,  // 14: genproto.PostOrderResponse.order:type_name -> genproto.Order
+	3,  // 15: genproto.GetOrderResponse.order:type_name -> genproto.Order
+	0,  // 16: genproto.ListOrdersRequest.minTotal:type_name -> genproto.Money
+	0,  // 17: genproto.ListOrdersRequest.maxTotal:type_name -> genproto.Money
+	3,  // 18: genproto.ListOrdersResponse.orders:type_name -> genproto.Order
+	3,  // 19: genproto.GetOrdersForAccountResponse.orders:type_name -> genproto.Order
+	3,  // 20: genproto.UpdateOrderStatusResponse.order:type_name -> genproto.Order
+	4,  // 21: genproto.GetOrderStatusHistoryResponse.history:type_name -> genproto.OrderStatusChange
+	3,  // 22: genproto.CancelOrderResponse.order:type_name -> genproto.Order
+	0,  // 23: genproto.Refund.amount:type_name -> genproto.Money
+	53, // 24: genproto.Refund.lines:type_name -> genproto.Refund.Line
+	54, // 25: genproto.RefundOrderRequest.lines:type_name -> genproto.RefundOrderRequest.Line
+	3,  // 26: genproto.RefundOrderResponse.order:type_name -> genproto.Order
+	25, // 27: genproto.RefundOrderResponse.refund:type_name -> genproto.Refund
+	25, // 28: genproto.GetOrderRefundsResponse.refunds:type_name -> genproto.Refund
+	2,  // 29: genproto.Shipment.address:type_name -> genproto.Address
+	55, // 30: genproto.Shipment.lines:type_name -> genproto.Shipment.Line
+	55, // 31: genproto.CreateShipmentRequest.lines:type_name -> genproto.Shipment.Line
+	2,  // 32: genproto.CreateShipmentRequest.shippingAddress:type_name -> genproto.Address
+	3,  // 33: genproto.ShipmentResponse.order:type_name -> genproto.Order
+	30, // 34: genproto.ShipmentResponse.shipment:type_name -> genproto.Shipment
+	30, // 35: genproto.GetOrderShipmentsResponse.shipments:type_name -> genproto.Shipment
+	0,  // 36: genproto.Invoice.total:type_name -> genproto.Money
+	36, // 37: genproto.GetInvoiceResponse.invoice:type_name -> genproto.Invoice
+	39, // 38: genproto.CheckoutStatus.steps:type_name -> genproto.CheckoutStep
+	52, // 39: genproto.CheckoutRequest.products:type_name -> genproto.PostOrderRequest.OrderProduct
+	2,  // 40: genproto.CheckoutRequest.shippingAddress:type_name -> genproto.Address
+	40, // 41: genproto.CheckoutResponse.checkout:type_name -> genproto.CheckoutStatus
+	40, // 42: genproto.GetCheckoutStatusResponse.checkout:type_name -> genproto.CheckoutStatus
+	0,  // 43: genproto.Promotion.amountOff:type_name -> genproto.Money
+	0,  // 44: genproto.Promotion.minSpend:type_name -> genproto.Money
+	45, // 45: genproto.CreatePromotionRequest.promotion:type_name -> genproto.Promotion
+	45, // 46: genproto.PromotionResponse.promotion:type_name -> genproto.Promotion
+	45, // 47: genproto.ListPromotionsResponse.promotions:type_name -> genproto.Promotion
+	0,  // 48: genproto.Order.OrderProduct.unitPrice:type_name -> genproto.Money
+	1,  // 49: genproto.Order.OrderProduct.discounts:type_name -> genproto.Discount
+	0,  // 50: genproto.Order.OrderProduct.tax:type_name -> genproto.Money
+	0,  // 51: genproto.Refund.Line.amount:type_name -> genproto.Money
+	5,  // 52: genproto.OrderService.PostOrder:input_type -> genproto.PostOrderRequest
+	6,  // 53: genproto.OrderService.QuoteOrder:input_type -> genproto.QuoteOrderRequest
+	13, // 54: genproto.OrderService.GetOrdersForAccount:input_type -> genproto.GetOrdersForAccountRequest
+	9,  // 55: genproto.OrderService.GetOrder:input_type -> genproto.GetOrderRequest
+	11, // 56: genproto.OrderService.ListOrders:input_type -> genproto.ListOrdersRequest
+	15, // 57: genproto.OrderService.DeleteOrder:input_type -> genproto.DeleteOrderRequest
+	17, // 58: genproto.OrderService.ExportOrderLines:input_type -> genproto.ExportOrderLinesRequest
+	19, // 59: genproto.OrderService.UpdateOrderStatus:input_type -> genproto.UpdateOrderStatusRequest
+	21, // 60: genproto.OrderService.GetOrderStatusHistory:input_type -> genproto.GetOrderStatusHistoryRequest
+	23, // 61: genproto.OrderService.CancelOrder:input_type -> genproto.CancelOrderRequest
+	26, // 62: genproto.OrderService.RefundOrder:input_type -> genproto.RefundOrderRequest
+	28, // 63: genproto.OrderService.GetOrderRefunds:input_type -> genproto.GetOrderRefundsRequest
+	31, // 64: genproto.OrderService.CreateShipment:input_type -> genproto.CreateShipmentRequest
+	32, // 65: genproto.OrderService.UpdateShipment:input_type -> genproto.UpdateShipmentRequest
+	34, // 66: genproto.OrderService.GetOrderShipments:input_type -> genproto.GetOrderShipmentsRequest
+	37, // 67: genproto.OrderService.GetInvoice:input_type -> genproto.GetInvoiceRequest
+	41, // 68: genproto.OrderService.Checkout:input_type -> genproto.CheckoutRequest
+	43, // 69: genproto.OrderService.GetCheckoutStatus:input_type -> genproto.GetCheckoutStatusRequest
+	46, // 70: genproto.OrderService.CreatePromotion:input_type -> genproto.CreatePromotionRequest
+	47, // 71: genproto.OrderService.SetPromotionActive:input_type -> genproto.SetPromotionActiveRequest
+	49, // 72: genproto.OrderService.ListPromotions:input_type -> genproto.ListPromotionsRequest
+	8,  // 73: genproto.OrderService.PostOrder:output_type -> genproto.PostOrderResponse
+	7,  // 74: genproto.OrderService.QuoteOrder:output_type -> genproto.QuoteOrderResponse
+	14, // 75: genproto.OrderService.GetOrdersForAccount:output_type -> genproto.GetOrdersForAccountResponse
+	10, // 76: genproto.OrderService.GetOrder:output_type -> genproto.GetOrderResponse
+	12, // 77: genproto.OrderService.ListOrders:output_type -> genproto.ListOrdersResponse
+	16, // 78: genproto.OrderService.DeleteOrder:output_type -> genproto.DeleteOrderResponse
+	18, // 79: genproto.OrderService.ExportOrderLines:output_type -> genproto.OrderLine
+	20, // 80: genproto.OrderService.UpdateOrderStatus:output_type -> genproto.UpdateOrderStatusResponse
+	22, // 81: genproto.OrderService.GetOrderStatusHistory:output_type -> genproto.GetOrderStatusHistoryResponse
+	24, // 82: genproto.OrderService.CancelOrder:output_type -> genproto.CancelOrderResponse
+	27, // 83: genproto.OrderService.RefundOrder:output_type -> genproto.RefundOrderResponse
+	29, // 84: genproto.OrderService.GetOrderRefunds:output_type -> genproto.GetOrderRefundsResponse
+	33, // 85: genproto.OrderService.CreateShipment:output_type -> genproto.ShipmentResponse
+	33, // 86: genproto.OrderService.UpdateShipment:output_type -> genproto.ShipmentResponse
+	35, // 87: genproto.OrderService.GetOrderShipments:output_type -> genproto.GetOrderShipmentsResponse
+	38, // 88: genproto.OrderService.GetInvoice:output_type -> genproto.GetInvoiceResponse
+	42, // 89: genproto.OrderService.Checkout:output_type -> genproto.CheckoutResponse
+	44, // 90: genproto.OrderService.GetCheckoutStatus:output_type -> genproto.GetCheckoutStatusResponse
+	48, // 91: genproto.OrderService.CreatePromotion:output_type -> genproto.PromotionResponse
+	48, // 92: genproto.OrderService.SetPromotionActive:output_type -> genproto.PromotionResponse
+	50, // 93: genproto.OrderService.ListPromotions:output_type -> genproto.ListPromotionsResponse
+	73, // [73:94] is the sub-list for method output_type
+	52, // [52:73] is the sub-list for method input_type
+	52, // [52:52] is the sub-list for extension type_name
+	52, // [52:52] is the sub-list for extension extendee
+	0,  // [0:52] is the sub-list for field type_name
 }
 
 func init() { file_order_proto_init() }
@@ -703,7 +4125,7 @@ func file_order_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_order_proto_rawDesc), len(file_order_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   11,
+			NumMessages:   56,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
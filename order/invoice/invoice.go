@@ -0,0 +1,156 @@
+package invoice
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"html/template"
+	"time"
+
+	"github.com/wignn/micro-3/currency"
+	"github.com/wignn/micro-3/order/model"
+)
+
+const dateLayout = "2 January 2006"
+
+//go:embed invoice.html
+var htmlSource string
+
+var htmlTemplate = template.Must(template.New("invoice").Funcs(template.FuncMap{
+	"money": formatMoney,
+	"date":  func(t time.Time) string { return t.Format(dateLayout) },
+}).Parse(htmlSource))
+
+func formatMoney(m currency.Money) string {
+	return m.Decimal() + " " + m.Currency
+}
+
+// RenderHTML renders an invoice being issued as a standalone HTML page
+func RenderHTML(inv *model.Invoice) ([]byte, error) {
+	b := &bytes.Buffer{}
+	if err := htmlTemplate.Execute(b, inv); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// columns of the lines table, the description is left aligned and the
+// other columns end at their right edge
+const (
+	descriptionWidth = 30
+	quantityRight    = 270
+	unitPriceRight   = 345
+	discountRight    = 410
+	taxRight         = 475
+	amountRight      = pageWidth - margin
+	tableSize        = 9
+	lineHeight       = 14
+)
+
+// RenderPDF renders an invoice being issued as a PDF with the same content
+// as its HTML page
+func RenderPDF(inv *model.Invoice) ([]byte, error) {
+	o := inv.Order
+	d := newDocument()
+
+	d.text(bold, 20, margin, d.y, "INVOICE")
+	d.y -= 28
+	for _, l := range []string{
+		"Invoice number: " + inv.Number,
+		"Issued: " + inv.IssuedAt.Format(dateLayout),
+		"Order: " + o.ID,
+	} {
+		d.text(regular, 10, margin, d.y, l)
+		d.y -= lineHeight
+	}
+
+	d.y -= lineHeight
+	d.text(bold, 10, margin, d.y, "From")
+	d.text(bold, 10, pageWidth/2, d.y, "Bill to")
+	d.y -= lineHeight
+	seller, buyer := partyLines(inv.Seller), partyLines(inv.Buyer)
+	for i := 0; i < len(seller) || i < len(buyer); i++ {
+		if i < len(seller) {
+			d.text(regular, 10, margin, d.y, seller[i])
+		}
+		if i < len(buyer) {
+			d.text(regular, 10, pageWidth/2, d.y, buyer[i])
+		}
+		d.y -= lineHeight
+	}
+
+	header := func() {
+		d.text(mono, tableSize, margin, d.y, "Description")
+		d.monoRight(tableSize, quantityRight, d.y, "Qty")
+		d.monoRight(tableSize, unitPriceRight, d.y, "Unit price")
+		d.monoRight(tableSize, discountRight, d.y, "Discount")
+		d.monoRight(tableSize, taxRight, d.y, "Tax")
+		d.monoRight(tableSize, amountRight, d.y, "Amount")
+		d.rule(d.y - 4)
+		d.y -= lineHeight + 2
+	}
+	d.y -= lineHeight
+	header()
+	d.onNewPage = header
+	for _, p := range o.Products {
+		d.need(lineHeight)
+		d.text(mono, tableSize, margin, d.y, truncate(p.Name, descriptionWidth))
+		d.monoRight(tableSize, quantityRight, d.y, fmt.Sprint(p.Quantity))
+		d.monoRight(tableSize, unitPriceRight, d.y, p.Price.Decimal())
+		d.monoRight(tableSize, discountRight, d.y, p.Discount().Decimal())
+		d.monoRight(tableSize, taxRight, d.y, p.Tax.Decimal())
+		d.monoRight(tableSize, amountRight, d.y, p.NetTotal().Decimal())
+		d.y -= lineHeight
+	}
+	d.onNewPage = nil
+
+	totals := [][2]string{
+		{"Subtotal", formatMoney(o.Subtotal())},
+		{"Discount", formatMoney(o.Discount())},
+		{"Shipping", formatMoney(o.Shipping)},
+		{taxLabel(o), formatMoney(o.Tax)},
+		{"Total", formatMoney(o.TotalPrice)},
+	}
+	d.need(lineHeight * float64(len(totals)+2))
+	d.rule(d.y + lineHeight - 4)
+	for i, t := range totals {
+		if i == len(totals)-1 {
+			d.text(bold, 10, unitPriceRight, d.y, t[0])
+		} else {
+			d.text(regular, 10, unitPriceRight, d.y, t[0])
+		}
+		d.monoRight(tableSize, amountRight, d.y, t[1])
+		d.y -= lineHeight
+	}
+	return d.bytes(), nil
+}
+
+func taxLabel(o *model.Order) string {
+	if o.TaxInclusive {
+		return "Tax included"
+	}
+	return "Tax"
+}
+
+func partyLines(p model.Party) []string {
+	lines := []string{}
+	if p.Name != "" {
+		lines = append(lines, p.Name)
+	}
+	lines = append(lines, p.Address...)
+	if p.Email != "" {
+		lines = append(lines, p.Email)
+	}
+	if p.TaxID != "" {
+		lines = append(lines, "Tax ID: "+p.TaxID)
+	}
+	return lines
+}
+
+func truncate(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n-3]) + "..."
+}
@@ -0,0 +1,146 @@
+package invoice
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// A4 in points
+const (
+	pageWidth  = 595.28
+	pageHeight = 841.89
+	margin     = 50
+)
+
+// fonts are standard Type 1 fonts, every PDF reader has them so nothing is
+// embedded. Courier is monospaced, columns of numbers are aligned with it.
+var fonts = []struct{ name, base string }{
+	{"F1", "Helvetica"},
+	{"F2", "Helvetica-Bold"},
+	{"F3", "Courier"},
+}
+
+const (
+	regular = "F1"
+	bold    = "F2"
+	mono    = "F3"
+)
+
+// courierWidth is the advance of every Courier glyph per point of font size
+const courierWidth = 0.6
+
+// document writes text and rules on A4 pages and serializes them as a PDF
+type document struct {
+	pages []*bytes.Buffer
+	page  *bytes.Buffer
+	// y is the baseline of the next line on the current page
+	y float64
+	// onNewPage draws what every following page starts with
+	onNewPage func()
+}
+
+func newDocument() *document {
+	d := &document{}
+	d.newPage()
+	return d
+}
+
+func (d *document) newPage() {
+	d.page = &bytes.Buffer{}
+	d.pages = append(d.pages, d.page)
+	d.y = pageHeight - margin
+	if d.onNewPage != nil {
+		d.onNewPage()
+	}
+}
+
+// need starts a new page unless height points are left on the current one
+func (d *document) need(height float64) {
+	if d.y-height < margin {
+		d.newPage()
+	}
+}
+
+func (d *document) text(font string, size, x, y float64, s string) {
+	fmt.Fprintf(d.page, "BT /%s %.1f Tf %.2f %.2f Td (%s) Tj ET\n", font, size, x, y, escape(s))
+}
+
+// monoRight draws Courier text ending at right
+func (d *document) monoRight(size, right, y float64, s string) {
+	width := float64(len([]rune(s))) * courierWidth * size
+	d.text(mono, size, right-width, y, s)
+}
+
+// rule draws a horizontal line across the page at y
+func (d *document) rule(y float64) {
+	fmt.Fprintf(d.page, "0.5 w %.2f %.2f m %.2f %.2f l S\n", float64(margin), y, pageWidth-margin, y)
+}
+
+// bytes serializes the document: the catalog, the page tree and the fonts
+// come first, then every page followed by its content stream
+func (d *document) bytes() []byte {
+	out := &bytes.Buffer{}
+	offsets := []int{}
+	object := func(format string, args ...interface{}) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(out, "%d 0 obj\n", len(offsets))
+		fmt.Fprintf(out, format, args...)
+		out.WriteString("\nendobj\n")
+	}
+
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	firstPage := 3 + len(fonts)
+	kids := &bytes.Buffer{}
+	for i := range d.pages {
+		fmt.Fprintf(kids, "%d 0 R ", firstPage+2*i)
+	}
+	resources := &bytes.Buffer{}
+	for i, f := range fonts {
+		fmt.Fprintf(resources, "/%s %d 0 R ", f.name, 3+i)
+	}
+
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object("<< /Type /Pages /Kids [%s] /Count %d >>", bytes.TrimSpace(kids.Bytes()), len(d.pages))
+	for _, f := range fonts {
+		object("<< /Type /Font /Subtype /Type1 /BaseFont /%s /Encoding /WinAnsiEncoding >>", f.base)
+	}
+	for i, page := range d.pages {
+		object(
+			"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.2f %.2f] /Resources << /Font << %s>> >> /Contents %d 0 R >>",
+			pageWidth, pageHeight, resources.Bytes(), firstPage+2*i+1,
+		)
+		object("<< /Length %d >>\nstream\n%s\nendstream", page.Len(), page.Bytes())
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+	return out.Bytes()
+}
+
+// escape encodes s in WinAnsiEncoding as a PDF string literal, characters
+// the encoding lacks are replaced by a question mark
+func escape(s string) string {
+	b := &bytes.Buffer{}
+	for _, r := range s {
+		switch {
+		case r == '\\' || r == '(' || r == ')':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r == '€':
+			b.WriteString(`\200`)
+		case r < 0x20:
+			b.WriteByte(' ')
+		case r < 0x80:
+			b.WriteRune(r)
+		case r >= 0xa0 && r <= 0xff:
+			fmt.Fprintf(b, `\%03o`, r)
+		default:
+			b.WriteByte('?')
+		}
+	}
+	return b.String()
+}
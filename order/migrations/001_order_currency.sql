@@ -0,0 +1,6 @@
+-- Adds the currency of orders. Orders placed before were all in USD.
+BEGIN;
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS currency CHAR(3) NOT NULL DEFAULT 'USD';
+
+COMMIT;
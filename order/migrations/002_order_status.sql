@@ -0,0 +1,19 @@
+-- Adds the status of orders and its history. Orders placed before are
+-- pending and have no history.
+BEGIN;
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS status VARCHAR(16) NOT NULL DEFAULT 'pending';
+
+CREATE TABLE IF NOT EXISTS order_status_history (
+  id BIGSERIAL PRIMARY KEY,
+  order_id CHAR(27) NOT NULL REFERENCES orders (id) ON DELETE CASCADE,
+  from_status VARCHAR(16) NOT NULL DEFAULT '',
+  to_status VARCHAR(16) NOT NULL,
+  actor VARCHAR(64) NOT NULL DEFAULT '',
+  reason TEXT NOT NULL DEFAULT '',
+  changed_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS order_status_history_order_id ON order_status_history (order_id, changed_at);
+
+COMMIT;
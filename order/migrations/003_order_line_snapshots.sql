@@ -0,0 +1,10 @@
+-- Adds the product name, description and unit price of order lines. Lines
+-- ordered before have no name and a zero price.
+BEGIN;
+
+ALTER TABLE order_products
+  ADD COLUMN IF NOT EXISTS name VARCHAR(256) NOT NULL DEFAULT '',
+  ADD COLUMN IF NOT EXISTS description TEXT NOT NULL DEFAULT '',
+  ADD COLUMN IF NOT EXISTS price MONEY NOT NULL DEFAULT 0;
+
+COMMIT;
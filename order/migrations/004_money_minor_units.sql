@@ -0,0 +1,20 @@
+-- Moves order amounts from MONEY to integers in the minor unit of the order
+-- currency. MONEY is cast through numeric, which keeps its exact value
+-- whatever lc_monetary is, JPY, KRW and VND have no minor unit.
+BEGIN;
+
+ALTER TABLE orders ADD COLUMN total_minor BIGINT;
+UPDATE orders
+SET total_minor = round(total_price::numeric * CASE WHEN currency IN ('JPY', 'KRW', 'VND') THEN 1 ELSE 100 END)::bigint;
+ALTER TABLE orders
+  ALTER COLUMN total_minor SET NOT NULL,
+  DROP COLUMN total_price;
+
+ALTER TABLE order_products ADD COLUMN price_minor BIGINT NOT NULL DEFAULT 0;
+UPDATE order_products op
+SET price_minor = round(op.price::numeric * CASE WHEN o.currency IN ('JPY', 'KRW', 'VND') THEN 1 ELSE 100 END)::bigint
+FROM orders o
+WHERE o.id = op.order_id;
+ALTER TABLE order_products DROP COLUMN price;
+
+COMMIT;
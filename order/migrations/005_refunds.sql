@@ -0,0 +1,25 @@
+-- Adds the refunds of orders. Orders placed before have none, the first
+-- refund of one starts from all of its units.
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS order_refunds (
+  id CHAR(27) PRIMARY KEY,
+  order_id CHAR(27) NOT NULL REFERENCES orders (id) ON DELETE CASCADE,
+  amount_minor BIGINT NOT NULL,
+  currency CHAR(3) NOT NULL,
+  reason TEXT NOT NULL DEFAULT '',
+  actor VARCHAR(64) NOT NULL DEFAULT '',
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS order_refunds_order_id ON order_refunds (order_id);
+
+CREATE TABLE IF NOT EXISTS order_refund_lines (
+  refund_id CHAR(27) REFERENCES order_refunds (id) ON DELETE CASCADE,
+  product_id CHAR(27) NOT NULL,
+  quantity INT NOT NULL CHECK (quantity > 0),
+  amount_minor BIGINT NOT NULL,
+  PRIMARY KEY (refund_id, product_id)
+);
+
+COMMIT;
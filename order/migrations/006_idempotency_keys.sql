@@ -0,0 +1,14 @@
+-- Adds the idempotency keys of order placement. Orders placed before have
+-- no key, a retry of one places a new order as it did.
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS order_idempotency_keys (
+  account_id CHAR(27) NOT NULL,
+  key VARCHAR(128) NOT NULL,
+  fingerprint CHAR(64) NOT NULL,
+  order_id CHAR(27) NOT NULL REFERENCES orders (id) ON DELETE CASCADE,
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+  PRIMARY KEY (account_id, key)
+);
+
+COMMIT;
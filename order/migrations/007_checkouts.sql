@@ -0,0 +1,38 @@
+-- Adds the checkouts and their steps. Orders placed before weren't checked
+-- out, they have none.
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS checkouts (
+  id CHAR(27) PRIMARY KEY,
+  order_id CHAR(27) NOT NULL UNIQUE,
+  account_id CHAR(27) NOT NULL,
+  -- empty places the order in the default currency
+  currency VARCHAR(3) NOT NULL DEFAULT '',
+  products JSONB NOT NULL,
+  payment_method VARCHAR(128) NOT NULL DEFAULT '',
+  payment_intent_id VARCHAR(27) NOT NULL DEFAULT '',
+  idempotency_key VARCHAR(128) NOT NULL DEFAULT '',
+  fingerprint VARCHAR(64) NOT NULL DEFAULT '',
+  status VARCHAR(16) NOT NULL,
+  error TEXT NOT NULL DEFAULT '',
+  -- the instance running the checkout holds it until then, an expired lease
+  -- lets another instance resume it
+  locked_until TIMESTAMP WITH TIME ZONE NOT NULL,
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+  updated_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS checkouts_unfinished ON checkouts (locked_until)
+  WHERE status NOT IN ('completed', 'failed');
+
+CREATE TABLE IF NOT EXISTS checkout_steps (
+  checkout_id CHAR(27) REFERENCES checkouts (id) ON DELETE CASCADE,
+  position INT NOT NULL,
+  name VARCHAR(32) NOT NULL,
+  status VARCHAR(16) NOT NULL,
+  error TEXT NOT NULL DEFAULT '',
+  updated_at TIMESTAMP WITH TIME ZONE NOT NULL,
+  PRIMARY KEY (checkout_id, name)
+);
+
+COMMIT;
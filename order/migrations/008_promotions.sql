@@ -0,0 +1,58 @@
+-- Adds promotions, their redemptions and the discounts of order lines.
+-- Orders placed before had no discount.
+BEGIN;
+
+ALTER TABLE checkouts ADD COLUMN IF NOT EXISTS coupons TEXT[] NOT NULL DEFAULT '{}';
+
+-- amount_off_minor and min_spend_minor are in currency, zero limits and a
+-- zero minimum spend mean no limit
+CREATE TABLE IF NOT EXISTS promotions (
+  id CHAR(27) PRIMARY KEY,
+  -- empty for promotions applied without a coupon
+  code VARCHAR(32) NOT NULL DEFAULT '',
+  name VARCHAR(128) NOT NULL,
+  kind VARCHAR(16) NOT NULL,
+  percent_off INT NOT NULL DEFAULT 0,
+  amount_off_minor BIGINT NOT NULL DEFAULT 0,
+  min_spend_minor BIGINT NOT NULL DEFAULT 0,
+  currency VARCHAR(3) NOT NULL DEFAULT '',
+  buy_quantity INT NOT NULL DEFAULT 0,
+  get_quantity INT NOT NULL DEFAULT 0,
+  product_ids TEXT[] NOT NULL DEFAULT '{}',
+  starts_at TIMESTAMP WITH TIME ZONE,
+  ends_at TIMESTAMP WITH TIME ZONE,
+  max_uses INT NOT NULL DEFAULT 0,
+  max_uses_per_account INT NOT NULL DEFAULT 0,
+  stackable BOOLEAN NOT NULL DEFAULT FALSE,
+  priority INT NOT NULL DEFAULT 0,
+  active BOOLEAN NOT NULL DEFAULT TRUE,
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+  updated_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS promotions_code ON promotions (code) WHERE code <> '';
+
+-- a redemption counts against the usage limits until its order is cancelled
+CREATE TABLE IF NOT EXISTS promotion_redemptions (
+  promotion_id CHAR(27) NOT NULL REFERENCES promotions (id),
+  order_id CHAR(27) NOT NULL REFERENCES orders (id) ON DELETE CASCADE,
+  account_id CHAR(27) NOT NULL,
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+  PRIMARY KEY (promotion_id, order_id)
+);
+
+CREATE INDEX IF NOT EXISTS promotion_redemptions_account ON promotion_redemptions (promotion_id, account_id);
+
+CREATE TABLE IF NOT EXISTS order_discounts (
+  order_id CHAR(27) NOT NULL REFERENCES orders (id) ON DELETE CASCADE,
+  product_id CHAR(27) NOT NULL,
+  -- discounts of a line are applied in this order
+  position INT NOT NULL,
+  promotion_id CHAR(27) NOT NULL,
+  code VARCHAR(32) NOT NULL DEFAULT '',
+  name VARCHAR(128) NOT NULL,
+  amount_minor BIGINT NOT NULL,
+  PRIMARY KEY (order_id, product_id, promotion_id)
+);
+
+COMMIT;
@@ -0,0 +1,18 @@
+-- Adds the shipping and tax of orders. Orders placed before were neither
+-- shipped nor taxed, their totals are unchanged.
+BEGIN;
+
+ALTER TABLE orders
+  ADD COLUMN shipping_minor BIGINT NOT NULL DEFAULT 0,
+  ADD COLUMN shipping_tax_minor BIGINT NOT NULL DEFAULT 0,
+  ADD COLUMN tax_minor BIGINT NOT NULL DEFAULT 0,
+  ADD COLUMN tax_inclusive BOOLEAN NOT NULL DEFAULT FALSE,
+  ADD COLUMN shipping_address JSONB;
+
+ALTER TABLE order_products
+  ADD COLUMN tax_minor BIGINT NOT NULL DEFAULT 0,
+  ADD COLUMN weight_grams BIGINT NOT NULL DEFAULT 0;
+
+ALTER TABLE checkouts ADD COLUMN shipping_address JSONB;
+
+COMMIT;
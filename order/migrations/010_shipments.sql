@@ -0,0 +1,27 @@
+-- Adds the shipments of orders. Orders placed before have none, their
+-- status is only changed through UpdateOrderStatus as it was.
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS shipments (
+  id CHAR(27) PRIMARY KEY,
+  order_id CHAR(27) NOT NULL REFERENCES orders (id) ON DELETE CASCADE,
+  carrier VARCHAR(64) NOT NULL,
+  tracking_number VARCHAR(128) NOT NULL DEFAULT '',
+  status VARCHAR(16) NOT NULL,
+  address JSONB NOT NULL,
+  shipped_at TIMESTAMP WITH TIME ZONE,
+  delivered_at TIMESTAMP WITH TIME ZONE,
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+  updated_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS shipments_order_id ON shipments (order_id);
+
+CREATE TABLE IF NOT EXISTS shipment_lines (
+  shipment_id CHAR(27) REFERENCES shipments (id) ON DELETE CASCADE,
+  product_id CHAR(27) NOT NULL,
+  quantity INT NOT NULL CHECK (quantity > 0),
+  PRIMARY KEY (shipment_id, product_id)
+);
+
+COMMIT;
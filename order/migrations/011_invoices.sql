@@ -0,0 +1,21 @@
+-- Adds the invoices of orders. Orders paid before are invoiced by the order
+-- service once it runs, dated when they were paid.
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS invoices (
+  id CHAR(27) PRIMARY KEY,
+  number VARCHAR(32) NOT NULL UNIQUE,
+  order_id CHAR(27) NOT NULL UNIQUE REFERENCES orders (id) ON DELETE CASCADE,
+  issued_at TIMESTAMP WITH TIME ZONE NOT NULL,
+  total_minor BIGINT NOT NULL,
+  currency CHAR(3) NOT NULL,
+  html_key VARCHAR(255) NOT NULL,
+  pdf_key VARCHAR(255) NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS invoice_sequences (
+  year INT PRIMARY KEY,
+  last BIGINT NOT NULL
+);
+
+COMMIT;
@@ -0,0 +1,7 @@
+-- Adds the run holding the lease of a checkout. Checkouts started before
+-- have no owner, they are saved again once an instance claims them.
+BEGIN;
+
+ALTER TABLE checkouts ADD COLUMN IF NOT EXISTS lease_owner VARCHAR(27) NOT NULL DEFAULT '';
+
+COMMIT;
@@ -0,0 +1,89 @@
+package model
+
+import "time"
+
+// Checkout statuses
+const (
+	CheckoutRunning = "running"
+	// CheckoutAwaitingPayment waits for the provider to confirm the authorization
+	CheckoutAwaitingPayment = "awaiting_payment"
+	CheckoutCompensating    = "compensating"
+	CheckoutCompleted       = "completed"
+	// CheckoutFailed checkouts were compensated, nothing they did remains
+	CheckoutFailed = "failed"
+)
+
+// Checkout steps in the order they run
+const (
+	StepReserveStock     = "reserve_stock"
+	StepCreateOrder      = "create_order"
+	StepAuthorizePayment = "authorize_payment"
+	StepConfirm          = "confirm"
+)
+
+// Step statuses
+const (
+	StepPending     = "pending"
+	StepDone        = "done"
+	StepFailed      = "failed"
+	StepCompensated = "compensated"
+)
+
+// Checkout is the saga placing an order: stock is reserved, the order is
+// created, the payment is authorized and everything is confirmed. When a
+// step fails the steps before it are compensated in reverse order. Checkouts
+// without a payment method stop after the order is created.
+type Checkout struct {
+	ID              string
+	OrderID         string
+	AccountID       string
+	Currency        string
+	Products        []OrderedProduct
+	Coupons         []string
+	ShippingAddress *Address
+	PaymentMethod   string
+	PaymentIntentID string
+	// IdempotencyKey and Fingerprint are stored with the order, see IdempotencyKey
+	IdempotencyKey string
+	Fingerprint    string
+	Status         string
+	Error          string
+	// LeaseOwner identifies the run holding the checkout, a run whose lease
+	// ran out and was claimed by another can't save it anymore
+	LeaseOwner string
+	Steps      []CheckoutStep
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+type CheckoutStep struct {
+	Name      string
+	Status    string
+	Error     string
+	UpdatedAt time.Time
+}
+
+// Finished tells whether the checkout will not change anymore
+func (c *Checkout) Finished() bool {
+	return c.Status == CheckoutCompleted || c.Status == CheckoutFailed
+}
+
+// Step returns the step with the given name, or nil
+func (c *Checkout) Step(name string) *CheckoutStep {
+	for i := range c.Steps {
+		if c.Steps[i].Name == name {
+			return &c.Steps[i]
+		}
+	}
+	return nil
+}
+
+// NextStep returns the first step that didn't run yet, or nil when every step is done
+func (c *Checkout) NextStep() *CheckoutStep {
+	for i := range c.Steps {
+		if c.Steps[i].Status == StepPending {
+			return &c.Steps[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,26 @@
+package model
+
+import (
+	"time"
+
+	"github.com/wignn/micro-3/currency"
+)
+
+// OrderFilter narrows an order listing, zero fields match every order.
+// MinTotal and MaxTotal only match orders placed in their currency.
+type OrderFilter struct {
+	AccountID     string
+	Status        string
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+	MinTotal      *currency.Money
+	MaxTotal      *currency.Money
+}
+
+// OrderPage is a page of orders, newest first. EndCursor is passed as after
+// to get the next page.
+type OrderPage struct {
+	Orders      []*Order
+	EndCursor   string
+	HasNextPage bool
+}
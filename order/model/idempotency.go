@@ -0,0 +1,14 @@
+package model
+
+import "time"
+
+// IdempotencyKey ties a client supplied key to the order it placed.
+// Fingerprint identifies the request so that a replay with the same key but
+// different content can be told apart from a retry.
+type IdempotencyKey struct {
+	AccountID   string
+	Key         string
+	Fingerprint string
+	OrderID     string
+	CreatedAt   time.Time
+}
@@ -0,0 +1,68 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/wignn/micro-3/currency"
+)
+
+// Invoice is the invoice of an order, numbered Number in the sequence of the
+// year it was issued in. Its documents are stored as blobs under HTMLKey and
+// PDFKey. Order, Seller and Buyer are only set while the invoice is issued,
+// stored invoices keep them in their documents.
+type Invoice struct {
+	ID       string
+	Number   string
+	OrderID  string
+	IssuedAt time.Time
+	Total    currency.Money
+	HTMLKey  string
+	PDFKey   string
+	HTMLURL  string
+	PDFURL   string
+	Order    *Order
+	Seller   Party
+	Buyer    Party
+}
+
+// Party is the seller or the buyer named on an invoice, Address holds the
+// printed lines of its address
+type Party struct {
+	Name    string
+	Email   string
+	TaxID   string
+	Address []string
+}
+
+// InvoiceNumber formats the number of the sequence-th invoice of year
+func InvoiceNumber(prefix string, year int, sequence int64) string {
+	return fmt.Sprintf("%s-%d-%06d", prefix, year, sequence)
+}
+
+// InvoiceableStatuses are the statuses of orders that were paid
+var InvoiceableStatuses = []string{OrderPaid, OrderFulfilled, OrderShipped, OrderDelivered, OrderRefunded}
+
+// Invoiceable reports whether an order in status can be invoiced, that is
+// whether it was paid
+func Invoiceable(status string) bool {
+	for _, s := range InvoiceableStatuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// Lines returns the printed lines of an address, empty parts are left out
+func (a Address) Lines() []string {
+	lines := []string{}
+	city := strings.TrimSpace(strings.Join([]string{a.PostalCode, a.City, a.Region}, " "))
+	for _, l := range []string{a.Name, a.Line1, a.Line2, strings.Join(strings.Fields(city), " "), a.Country} {
+		if l != "" {
+			lines = append(lines, l)
+		}
+	}
+	return lines
+}
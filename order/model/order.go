@@ -1,27 +1,132 @@
 package model
 
-import "time"
+import (
+	"time"
 
+	"github.com/wignn/micro-3/currency"
+)
+
+// Order totals and line prices are in the order currency. TotalPrice is
+// what the customer pays: the discounted lines plus shipping, plus the tax
+// unless prices include it (TaxInclusive).
 type Order struct {
 	ID         string
 	CreatedAt  time.Time
-	TotalPrice float64
+	TotalPrice currency.Money
+	Currency   string
 	AccountID  string
+	Status     string
 	Products   []OrderedProduct
+	// ShippingAddress is nil for orders that aren't shipped
+	ShippingAddress *Address
+	Shipping        currency.Money
+	// ShippingTax is the part of Tax charged on shipping
+	ShippingTax  currency.Money
+	Tax          currency.Money
+	TaxInclusive bool
 }
 
 type OrderedProduct struct {
 	ID          string
 	Name        string
 	Description string
-	Price       float64
+	Price       currency.Money
 	Image       string
 	Quantity    uint32
+	Discounts   []Discount
+	// Tax is charged on the net total of the line
+	Tax         currency.Money
+	WeightGrams int64
+}
+
+// Address is where an order is shipped, Country is an ISO 3166-1 alpha-2
+// code and Region the subdivision part of an ISO 3166-2 code, CA for US-CA
+type Address struct {
+	Name       string
+	Line1      string
+	Line2      string
+	City       string
+	Region     string
+	PostalCode string
+	Country    string
 }
 
+// LineTotal is the unit price times the quantity
+func (p OrderedProduct) LineTotal() currency.Money {
+	return p.Price.Mul(int64(p.Quantity))
+}
+
+// Discount is the sum of the discounts of the line
+func (p OrderedProduct) Discount() currency.Money {
+	d := currency.Money{Currency: p.Price.Currency}
+	for _, discount := range p.Discounts {
+		d.Amount += discount.Amount.Amount
+	}
+	return d
+}
+
+// NetTotal is the line total after discounts, the order total is the sum of
+// the net totals
+func (p OrderedProduct) NetTotal() currency.Money {
+	return currency.Money{Amount: p.LineTotal().Amount - p.Discount().Amount, Currency: p.Price.Currency}
+}
+
+// Subtotal is the sum of the line totals before discounts
+func (o *Order) Subtotal() currency.Money {
+	m := currency.Money{Currency: o.Currency}
+	for _, p := range o.Products {
+		m.Amount += p.LineTotal().Amount
+	}
+	return m
+}
+
+// Discount is the sum of the discounts of every line
+func (o *Order) Discount() currency.Money {
+	m := currency.Money{Currency: o.Currency}
+	for _, p := range o.Products {
+		m.Amount += p.Discount().Amount
+	}
+	return m
+}
+
+// LineCharge is what the customer pays for a line, its net total plus its
+// tax when prices exclude tax
+func (o *Order) LineCharge(p OrderedProduct) currency.Money {
+	m := p.NetTotal()
+	if !o.TaxInclusive {
+		m.Amount += p.Tax.Amount
+	}
+	return m
+}
+
+// ShippingCharge is what the customer pays for shipping
+func (o *Order) ShippingCharge() currency.Money {
+	m := currency.Money{Amount: o.Shipping.Amount, Currency: o.Currency}
+	if !o.TaxInclusive {
+		m.Amount += o.ShippingTax.Amount
+	}
+	return m
+}
+
+// WeightGrams is the weight of every unit ordered
+func (o *Order) WeightGrams() int64 {
+	var w int64
+	for _, p := range o.Products {
+		w += p.WeightGrams * int64(p.Quantity)
+	}
+	return w
+}
+
+// OrderLine is a single ordered product together with the order it belongs to
+type OrderLine struct {
+	OrderID   string
+	AccountID string
+	ProductID string
+	Quantity  uint32
+}
 
 type OrderDeleteResponse struct {
 	DeletedID string
 	Message   string
 	Success   bool
-}
\ No newline at end of file
+}
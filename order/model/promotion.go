@@ -0,0 +1,69 @@
+package model
+
+import (
+	"time"
+
+	"github.com/wignn/micro-3/currency"
+)
+
+const (
+	// PromotionPercentage takes PercentOff percent off the eligible lines
+	PromotionPercentage = "percentage"
+	// PromotionFixed takes AmountOff off the eligible lines, spread over them
+	// in proportion to their amounts
+	PromotionFixed = "fixed"
+	// PromotionBuyXGetY takes PercentOff percent off GetQuantity units for
+	// every BuyQuantity units bought, the cheapest units are discounted
+	PromotionBuyXGetY = "buy_x_get_y"
+)
+
+// Promotion is a discount rule. A promotion with a code is a coupon the
+// customer has to enter, one without is applied to every order it fits.
+// Zero limits and a zero MinSpend mean no limit.
+type Promotion struct {
+	ID          string
+	Code        string
+	Name        string
+	Kind        string
+	PercentOff  uint32
+	AmountOff   currency.Money
+	BuyQuantity uint32
+	GetQuantity uint32
+	// ProductIDs limits the promotion to these products, empty means every product
+	ProductIDs        []string
+	MinSpend          currency.Money
+	StartsAt          *time.Time
+	EndsAt            *time.Time
+	MaxUses           uint32
+	MaxUsesPerAccount uint32
+	// Stackable promotions combine with each other, an order gets either
+	// every stackable promotion it fits or the best promotion that isn't
+	Stackable bool
+	// Priority orders stackable promotions, higher first
+	Priority  int32
+	Active    bool
+	Uses      uint32
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Applies reports whether the promotion covers a product
+func (p *Promotion) Applies(productID string) bool {
+	if len(p.ProductIDs) == 0 {
+		return true
+	}
+	for _, id := range p.ProductIDs {
+		if id == productID {
+			return true
+		}
+	}
+	return false
+}
+
+// Discount is what a promotion took off an ordered product
+type Discount struct {
+	PromotionID string
+	Code        string
+	Name        string
+	Amount      currency.Money
+}
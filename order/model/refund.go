@@ -0,0 +1,27 @@
+package model
+
+import (
+	"time"
+
+	"github.com/wignn/micro-3/currency"
+)
+
+// Refund gives back part or all of an order, Amount is the sum of its lines
+// plus the shipping charge when it gives back the last units
+type Refund struct {
+	ID        string
+	OrderID   string
+	Amount    currency.Money
+	Reason    string
+	Actor     string
+	CreatedAt time.Time
+	Lines     []RefundLine
+}
+
+// RefundLine refunds Quantity units of an ordered product at the price they
+// were ordered at
+type RefundLine struct {
+	ProductID string
+	Quantity  uint32
+	Amount    currency.Money
+}
@@ -0,0 +1,109 @@
+package model
+
+import "time"
+
+const (
+	ShipmentPending   = "pending"
+	ShipmentShipped   = "shipped"
+	ShipmentDelivered = "delivered"
+	ShipmentCancelled = "cancelled"
+)
+
+// shipmentTransitions lists the statuses a shipment can move to from each
+// status, delivered and cancelled shipments are final
+var shipmentTransitions = map[string][]string{
+	ShipmentPending:   {ShipmentShipped, ShipmentCancelled},
+	ShipmentShipped:   {ShipmentDelivered},
+	ShipmentDelivered: {},
+	ShipmentCancelled: {},
+}
+
+// ValidShipmentStatus reports whether status is one of the shipment statuses
+func ValidShipmentStatus(status string) bool {
+	_, ok := shipmentTransitions[status]
+	return ok
+}
+
+// CanShipmentTransition reports whether a shipment in status from may be
+// moved to status to
+func CanShipmentTransition(from, to string) bool {
+	for _, next := range shipmentTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// Shipment sends some units of an order. Address is a snapshot of where the
+// units are sent, later changes to the order don't move them. ShippedAt and
+// DeliveredAt are set when the shipment reaches those statuses.
+type Shipment struct {
+	ID             string
+	OrderID        string
+	Carrier        string
+	TrackingNumber string
+	Status         string
+	Address        Address
+	Lines          []ShipmentLine
+	ShippedAt      *time.Time
+	DeliveredAt    *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+type ShipmentLine struct {
+	ProductID string
+	Quantity  uint32
+}
+
+// ShipmentUpdate changes a shipment, empty fields are left unchanged. At is
+// when the status changed, such as the time a carrier scanned the parcel.
+type ShipmentUpdate struct {
+	ID             string
+	Status         string
+	Carrier        string
+	TrackingNumber string
+	Actor          string
+	At             time.Time
+}
+
+// FulfillmentStatus is the status an order reaches through its shipments:
+// fulfilled once every unit in left, the units not refunded, is in a shipment
+// that isn't cancelled, shipped once all of those shipments left and
+// delivered once all of them arrived. It is empty until every unit is in a
+// shipment.
+func FulfillmentStatus(left map[string]uint32, shipments []*Shipment) string {
+	packed := map[string]uint32{}
+	shipped, delivered, active := true, true, false
+	for _, s := range shipments {
+		if s.Status == ShipmentCancelled {
+			continue
+		}
+		active = true
+		for _, l := range s.Lines {
+			packed[l.ProductID] += l.Quantity
+		}
+		if s.Status == ShipmentPending {
+			shipped = false
+		}
+		if s.Status != ShipmentDelivered {
+			delivered = false
+		}
+	}
+	if !active {
+		return ""
+	}
+	for productID, n := range left {
+		if packed[productID] < n {
+			return ""
+		}
+	}
+	switch {
+	case delivered:
+		return OrderDelivered
+	case shipped:
+		return OrderShipped
+	}
+	return OrderFulfilled
+}
@@ -0,0 +1,52 @@
+package model
+
+import "time"
+
+const (
+	OrderPending   = "pending"
+	OrderPaid      = "paid"
+	OrderFulfilled = "fulfilled"
+	OrderShipped   = "shipped"
+	OrderDelivered = "delivered"
+	OrderCancelled = "cancelled"
+	OrderRefunded  = "refunded"
+)
+
+// orderTransitions lists the statuses an order can move to from each status,
+// cancelled and refunded orders are final
+var orderTransitions = map[string][]string{
+	OrderPending:   {OrderPaid, OrderCancelled},
+	OrderPaid:      {OrderFulfilled, OrderCancelled, OrderRefunded},
+	OrderFulfilled: {OrderShipped, OrderRefunded},
+	OrderShipped:   {OrderDelivered, OrderRefunded},
+	OrderDelivered: {OrderRefunded},
+	OrderCancelled: {},
+	OrderRefunded:  {},
+}
+
+// ValidOrderStatus reports whether status is one of the order statuses
+func ValidOrderStatus(status string) bool {
+	_, ok := orderTransitions[status]
+	return ok
+}
+
+// CanTransition reports whether an order in status from may be moved to status to
+func CanTransition(from, to string) bool {
+	for _, next := range orderTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// OrderStatusChange is an entry of the status history of an order, From is
+// empty for the entry recorded when the order is placed
+type OrderStatusChange struct {
+	OrderID   string
+	From      string
+	To        string
+	Actor     string
+	Reason    string
+	ChangedAt time.Time
+}
@@ -0,0 +1,322 @@
+package pricing
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/wignn/micro-3/currency"
+	"github.com/wignn/micro-3/order/model"
+)
+
+// Tax modes
+const (
+	// TaxExclusive adds the tax to the prices
+	TaxExclusive = "exclusive"
+	// TaxInclusive prices already include the tax, it is taken out of them
+	TaxInclusive = "inclusive"
+)
+
+// AnyDestination matches the countries no zone lists and, for tax, orders
+// that aren't shipped
+const AnyDestination = "*"
+
+var (
+	ErrInvalidConfig = errors.New("invalid pricing config")
+	ErrNoShipping    = errors.New("orders can't be shipped to this country")
+	ErrTooHeavy      = errors.New("order is too heavy to ship to this country")
+)
+
+//go:embed pricing.json
+var defaultConfig []byte
+
+// Config prices shipping and tax, amounts are decimal strings in Currency
+type Config struct {
+	Currency string `json:"currency"`
+	Shipping []Zone `json:"shipping"`
+	Tax      Tax    `json:"tax"`
+}
+
+// Zone ships to a set of countries. A flat rate zone charges FlatRate
+// whatever the order weighs, other zones charge the first rate whose
+// UpToGrams fits the order weight. Orders whose discounted lines reach
+// FreeOver ship for free.
+type Zone struct {
+	Name      string   `json:"name"`
+	Countries []string `json:"countries"`
+	FlatRate  string   `json:"flatRate,omitempty"`
+	Rates     []Rate   `json:"rates,omitempty"`
+	FreeOver  string   `json:"freeOver,omitempty"`
+}
+
+type Rate struct {
+	// UpToGrams is the heaviest order the rate applies to, zero for any weight
+	UpToGrams int64  `json:"upToGrams"`
+	Price     string `json:"price"`
+}
+
+// Tax rates are percentages such as "7.25" keyed by region (US-CA), by
+// country (US) or AnyDestination, the most specific one applies. Destinations
+// without a rate aren't taxed.
+type Tax struct {
+	Mode  string            `json:"mode"`
+	Rates map[string]string `json:"rates"`
+	// Shipping taxes shipping at the rate of the destination
+	Shipping bool `json:"shipping"`
+}
+
+type zone struct {
+	flatRate *currency.Money
+	rates    []rate
+	freeOver *currency.Money
+}
+
+type rate struct {
+	upToGrams int64
+	price     currency.Money
+}
+
+// percentage is num/den of an amount
+type percentage struct {
+	num, den int64
+}
+
+// Pricer runs the pricing pipeline of orders
+type Pricer struct {
+	converter   *currency.Converter
+	zones       map[string]*zone
+	inclusive   bool
+	rates       map[string]percentage
+	taxShipping bool
+}
+
+// Load reads the config from a JSON file, without a path the config bundled
+// with the package is used
+func Load(path string, converter *currency.Converter) (*Pricer, error) {
+	data := defaultConfig
+	if path != "" {
+		var err error
+		if data, err = os.ReadFile(path); err != nil {
+			return nil, err
+		}
+	}
+	config := &Config{}
+	if err := json.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidConfig, err)
+	}
+	return New(config, converter)
+}
+
+func New(config *Config, converter *currency.Converter) (*Pricer, error) {
+	code, err := currency.Normalize(config.Currency)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidConfig, err)
+	}
+	p := &Pricer{
+		converter:   converter,
+		zones:       map[string]*zone{},
+		rates:       map[string]percentage{},
+		taxShipping: config.Tax.Shipping,
+	}
+
+	for _, z := range config.Shipping {
+		parsed, err := parseZone(z, code)
+		if err != nil {
+			return nil, err
+		}
+		for _, country := range z.Countries {
+			country = strings.ToUpper(strings.TrimSpace(country))
+			if _, ok := p.zones[country]; ok {
+				return nil, fmt.Errorf("%w: %s is in more than one shipping zone", ErrInvalidConfig, country)
+			}
+			p.zones[country] = parsed
+		}
+	}
+
+	switch config.Tax.Mode {
+	case TaxExclusive, "":
+	case TaxInclusive:
+		p.inclusive = true
+	default:
+		return nil, fmt.Errorf("%w: unknown tax mode %s", ErrInvalidConfig, config.Tax.Mode)
+	}
+	for region, percent := range config.Tax.Rates {
+		parsed, err := parsePercentage(percent)
+		if err != nil {
+			return nil, fmt.Errorf("%w: tax rate of %s: %v", ErrInvalidConfig, region, err)
+		}
+		p.rates[strings.ToUpper(strings.TrimSpace(region))] = parsed
+	}
+	return p, nil
+}
+
+func parseZone(z Zone, code string) (*zone, error) {
+	invalid := func(format string, args ...interface{}) error {
+		return fmt.Errorf("%w: shipping zone %s: %s", ErrInvalidConfig, z.Name, fmt.Sprintf(format, args...))
+	}
+	parsed := &zone{}
+	if z.FreeOver != "" {
+		m, err := currency.ParseMoney(z.FreeOver, code)
+		if err != nil {
+			return nil, invalid("freeOver: %v", err)
+		}
+		parsed.freeOver = &m
+	}
+	if (z.FlatRate == "") == (len(z.Rates) == 0) {
+		return nil, invalid("needs either a flat rate or weight rates")
+	}
+	if z.FlatRate != "" {
+		m, err := currency.ParseMoney(z.FlatRate, code)
+		if err != nil || m.Amount < 0 {
+			return nil, invalid("invalid flat rate %s", z.FlatRate)
+		}
+		parsed.flatRate = &m
+		return parsed, nil
+	}
+	for _, r := range z.Rates {
+		m, err := currency.ParseMoney(r.Price, code)
+		if err != nil || m.Amount < 0 || r.UpToGrams < 0 {
+			return nil, invalid("invalid rate %s up to %d g", r.Price, r.UpToGrams)
+		}
+		parsed.rates = append(parsed.rates, rate{upToGrams: r.UpToGrams, price: m})
+	}
+	// lighter orders first, the rate without a limit last
+	sort.SliceStable(parsed.rates, func(i, j int) bool {
+		a, b := parsed.rates[i].upToGrams, parsed.rates[j].upToGrams
+		return a != 0 && (b == 0 || a < b)
+	})
+	return parsed, nil
+}
+
+// parsePercentage reads a percentage with at most 4 decimals
+func parsePercentage(s string) (percentage, error) {
+	whole, frac, _ := strings.Cut(strings.TrimSpace(s), ".")
+	if whole == "" || len(frac) > 4 {
+		return percentage{}, errors.New("must be a percentage such as 7.25")
+	}
+	num, err := strconv.ParseInt(whole+frac, 10, 64)
+	if err != nil || num < 0 {
+		return percentage{}, errors.New("must be a percentage such as 7.25")
+	}
+	den := int64(100)
+	for range frac {
+		den *= 10
+	}
+	if num > den {
+		return percentage{}, errors.New("must not exceed 100")
+	}
+	return percentage{num: num, den: den}, nil
+}
+
+// Price prices an order whose lines are in its currency and discounted:
+// shipping is charged for the destination and the weight, tax for the
+// destination, and the total is computed from both
+func (p *Pricer) Price(c context.Context, o *model.Order) error {
+	if err := p.ship(c, o); err != nil {
+		return err
+	}
+	p.tax(o)
+
+	o.TotalPrice = o.ShippingCharge()
+	for _, l := range o.Products {
+		o.TotalPrice.Amount += o.LineCharge(l).Amount
+	}
+	return nil
+}
+
+// ship charges shipping, orders without an address aren't shipped
+func (p *Pricer) ship(c context.Context, o *model.Order) error {
+	o.Shipping = currency.Money{Currency: o.Currency}
+	if o.ShippingAddress == nil {
+		return nil
+	}
+	country := o.ShippingAddress.Country
+	z, ok := p.zones[country]
+	if !ok {
+		if z, ok = p.zones[AnyDestination]; !ok {
+			return fmt.Errorf("%w: %s", ErrNoShipping, country)
+		}
+	}
+
+	if z.freeOver != nil {
+		min, err := p.converter.ConvertMoney(c, *z.freeOver, o.Currency)
+		if err != nil {
+			return err
+		}
+		var net int64
+		for _, l := range o.Products {
+			net += l.NetTotal().Amount
+		}
+		if net >= min.Amount {
+			return nil
+		}
+	}
+
+	price := z.flatRate
+	if price == nil {
+		weight := o.WeightGrams()
+		for i := range z.rates {
+			if z.rates[i].upToGrams == 0 || weight <= z.rates[i].upToGrams {
+				price = &z.rates[i].price
+				break
+			}
+		}
+		if price == nil {
+			return fmt.Errorf("%w: %d g to %s", ErrTooHeavy, weight, country)
+		}
+	}
+	shipping, err := p.converter.ConvertMoney(c, *price, o.Currency)
+	if err != nil {
+		return err
+	}
+	o.Shipping = shipping
+	return nil
+}
+
+// tax charges the rate of the destination on every line and on shipping
+func (p *Pricer) tax(o *model.Order) {
+	o.TaxInclusive = p.inclusive
+	o.Tax = currency.Money{Currency: o.Currency}
+	o.ShippingTax = currency.Money{Currency: o.Currency}
+	r, ok := p.taxRate(o.ShippingAddress)
+	for i := range o.Products {
+		l := &o.Products[i]
+		l.Tax = currency.Money{Currency: o.Currency}
+		if ok {
+			l.Tax = p.taxOf(l.NetTotal(), r)
+		}
+		o.Tax.Amount += l.Tax.Amount
+	}
+	if ok && p.taxShipping {
+		o.ShippingTax = p.taxOf(o.Shipping, r)
+		o.Tax.Amount += o.ShippingTax.Amount
+	}
+}
+
+// taxRate returns the rate of the region, the country or any destination
+func (p *Pricer) taxRate(a *model.Address) (percentage, bool) {
+	keys := []string{AnyDestination}
+	if a != nil {
+		keys = []string{a.Country + "-" + a.Region, a.Country, AnyDestination}
+	}
+	for _, key := range keys {
+		if r, ok := p.rates[key]; ok {
+			return r, true
+		}
+	}
+	return percentage{}, false
+}
+
+// taxOf is the tax on an amount, inclusive amounts hold amount * rate / (1 + rate)
+func (p *Pricer) taxOf(m currency.Money, r percentage) currency.Money {
+	if p.inclusive {
+		return m.MulRatio(r.num, r.den+r.num)
+	}
+	return m.MulRatio(r.num, r.den)
+}
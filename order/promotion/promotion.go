@@ -0,0 +1,312 @@
+// Package promotion decides which promotions an order gets and what they
+// take off each line. It works on orders and promotions in the same
+// currency and leaves loading, converting and counting redemptions to the
+// caller.
+package promotion
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/wignn/micro-3/currency"
+	"github.com/wignn/micro-3/order/model"
+)
+
+var (
+	ErrInvalidPromotion  = errors.New("invalid promotion")
+	ErrInactive          = errors.New("promotion is not active")
+	ErrNotStarted        = errors.New("promotion has not started yet")
+	ErrExpired           = errors.New("promotion has expired")
+	ErrMinimumSpend      = errors.New("order doesn't reach the minimum spend")
+	ErrUsageLimit        = errors.New("promotion has been used up")
+	ErrAccountUsageLimit = errors.New("promotion was already used by this account")
+	ErrNotApplicable     = errors.New("promotion doesn't apply to the ordered products")
+)
+
+var codePattern = regexp.MustCompile(`^[A-Z0-9_-]{3,32}$`)
+
+// NormalizeCode makes coupon codes case insensitive
+func NormalizeCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// Validate checks that a promotion is complete and consistent
+func Validate(p *model.Promotion) error {
+	invalid := func(reason string) error {
+		return fmt.Errorf("%w: %s", ErrInvalidPromotion, reason)
+	}
+	if p.Code != "" && !codePattern.MatchString(p.Code) {
+		return invalid("code must be 3 to 32 letters, digits, dashes or underscores")
+	}
+	if strings.TrimSpace(p.Name) == "" {
+		return invalid("name is required")
+	}
+	switch p.Kind {
+	case model.PromotionPercentage:
+		if p.PercentOff == 0 || p.PercentOff > 100 {
+			return invalid("percentOff must be between 1 and 100")
+		}
+	case model.PromotionFixed:
+		if p.AmountOff.Amount <= 0 || p.AmountOff.Currency == "" {
+			return invalid("amountOff must be positive")
+		}
+	case model.PromotionBuyXGetY:
+		if p.BuyQuantity == 0 || p.GetQuantity == 0 {
+			return invalid("buyQuantity and getQuantity must be at least 1")
+		}
+		if p.PercentOff == 0 || p.PercentOff > 100 {
+			return invalid("percentOff must be between 1 and 100")
+		}
+	default:
+		return invalid("kind must be percentage, fixed or buy_x_get_y")
+	}
+	if p.MinSpend.Amount < 0 || (p.MinSpend.Amount > 0 && p.MinSpend.Currency == "") {
+		return invalid("minSpend must be positive")
+	}
+	if p.StartsAt != nil && p.EndsAt != nil && !p.EndsAt.After(*p.StartsAt) {
+		return invalid("endsAt must be after startsAt")
+	}
+	return nil
+}
+
+// Usage is how often a promotion was redeemed by every account and by the
+// ordering account
+type Usage struct {
+	Total   uint32
+	Account uint32
+}
+
+// Check tells why a promotion can't be applied to an order at a time, it
+// returns nil when it can
+func Check(p *model.Promotion, o *model.Order, usage Usage, at time.Time) error {
+	switch {
+	case !p.Active:
+		return ErrInactive
+	case p.StartsAt != nil && at.Before(*p.StartsAt):
+		return ErrNotStarted
+	case p.EndsAt != nil && !at.Before(*p.EndsAt):
+		return ErrExpired
+	case p.MaxUses > 0 && usage.Total >= p.MaxUses:
+		return ErrUsageLimit
+	case p.MaxUsesPerAccount > 0 && usage.Account >= p.MaxUsesPerAccount:
+		return ErrAccountUsageLimit
+	}
+	if p.MinSpend.Amount > 0 && o.Subtotal().Amount < p.MinSpend.Amount {
+		return fmt.Errorf("%w of %s", ErrMinimumSpend, p.MinSpend)
+	}
+	units := uint32(0)
+	for _, l := range o.Products {
+		if p.Applies(l.ID) {
+			units += l.Quantity
+		}
+	}
+	if units == 0 {
+		return ErrNotApplicable
+	}
+	if p.Kind == model.PromotionBuyXGetY && units < p.BuyQuantity+p.GetQuantity {
+		return fmt.Errorf("%w: buy %d to get %d", ErrNotApplicable, p.BuyQuantity, p.GetQuantity)
+	}
+	return nil
+}
+
+// Apply sets the discounts of the order lines from promotions that passed
+// Check and returns the promotions applied. The stackable promotions are
+// applied together, highest priority first and each to what the previous
+// ones left, unless a promotion that doesn't stack takes more off on its own.
+func Apply(o *model.Order, promotions []*model.Promotion) []*model.Promotion {
+	stackable := []*model.Promotion{}
+	for _, p := range promotions {
+		if p.Stackable {
+			stackable = append(stackable, p)
+		}
+	}
+	sort.SliceStable(stackable, func(i, j int) bool {
+		if stackable[i].Priority != stackable[j].Priority {
+			return stackable[i].Priority > stackable[j].Priority
+		}
+		return stackable[i].ID < stackable[j].ID
+	})
+
+	best, bestPromotions := discounts(o, stackable), stackable
+	for _, p := range promotions {
+		if p.Stackable {
+			continue
+		}
+		candidate := discounts(o, []*model.Promotion{p})
+		if total(candidate) > total(best) {
+			best, bestPromotions = candidate, []*model.Promotion{p}
+		}
+	}
+
+	applied := []*model.Promotion{}
+	for i := range o.Products {
+		o.Products[i].Discounts = best[i]
+	}
+	for _, p := range bestPromotions {
+		for _, lineDiscounts := range best {
+			if containsPromotion(lineDiscounts, p.ID) {
+				applied = append(applied, p)
+				break
+			}
+		}
+	}
+	return applied
+}
+
+// discounts computes the discounts of every line when promotions are
+// applied one after the other
+func discounts(o *model.Order, promotions []*model.Promotion) [][]model.Discount {
+	lines := make([][]model.Discount, len(o.Products))
+	left := make([]int64, len(o.Products))
+	for i, l := range o.Products {
+		lines[i] = []model.Discount{}
+		left[i] = l.LineTotal().Amount
+	}
+	for _, p := range promotions {
+		for i, amount := range amounts(o, p, left) {
+			if amount <= 0 {
+				continue
+			}
+			left[i] -= amount
+			d := model.Discount{PromotionID: p.ID, Code: p.Code, Name: p.Name}
+			d.Amount.Amount, d.Amount.Currency = amount, o.Currency
+			lines[i] = append(lines[i], d)
+		}
+	}
+	return lines
+}
+
+// amounts is what a promotion takes off each line, left is what is left of
+// the lines after the promotions applied before
+func amounts(o *model.Order, p *model.Promotion, left []int64) []int64 {
+	off := make([]int64, len(o.Products))
+	switch p.Kind {
+	case model.PromotionPercentage:
+		for i, l := range o.Products {
+			if p.Applies(l.ID) {
+				off[i] = currency.Money{Amount: left[i], Currency: o.Currency}.MulRatio(int64(p.PercentOff), 100).Amount
+			}
+		}
+
+	case model.PromotionFixed:
+		weights := make([]int64, len(o.Products))
+		eligible := int64(0)
+		for i, l := range o.Products {
+			if p.Applies(l.ID) {
+				weights[i] = left[i]
+				eligible += left[i]
+			}
+		}
+		amount := p.AmountOff.Amount
+		if amount > eligible {
+			amount = eligible
+		}
+		off = split(amount, weights)
+
+	case model.PromotionBuyXGetY:
+		// the units are lined up from the most to the least expensive, in
+		// every full group of X+Y units the last Y are discounted
+		order := []int{}
+		for i, l := range o.Products {
+			if p.Applies(l.ID) {
+				order = append(order, i)
+			}
+		}
+		sort.SliceStable(order, func(a, b int) bool {
+			pa, pb := o.Products[order[a]], o.Products[order[b]]
+			if pa.Price.Amount != pb.Price.Amount {
+				return pa.Price.Amount > pb.Price.Amount
+			}
+			return pa.ID < pb.ID
+		})
+		group := int64(p.BuyQuantity + p.GetQuantity)
+		units := int64(0)
+		for _, i := range order {
+			units += int64(o.Products[i].Quantity)
+		}
+		full := units / group * group
+		discounted := func(n int64) int64 {
+			if n > full {
+				n = full
+			}
+			free := n%group - int64(p.BuyQuantity)
+			if free < 0 {
+				free = 0
+			}
+			return n/group*int64(p.GetQuantity) + free
+		}
+		position := int64(0)
+		for _, i := range order {
+			l := o.Products[i]
+			n := discounted(position+int64(l.Quantity)) - discounted(position)
+			position += int64(l.Quantity)
+			off[i] = l.Price.Mul(n).MulRatio(int64(p.PercentOff), 100).Amount
+		}
+	}
+	for i := range off {
+		if off[i] > left[i] {
+			off[i] = left[i]
+		}
+	}
+	return off
+}
+
+// split divides amount in proportion to weights, the units lost to rounding
+// go to the largest remainders so that the parts add up to amount
+func split(amount int64, weights []int64) []int64 {
+	parts := make([]int64, len(weights))
+	sum := int64(0)
+	for _, w := range weights {
+		sum += w
+	}
+	if sum == 0 || amount == 0 {
+		return parts
+	}
+	remainders := make([]*big.Int, len(weights))
+	given := int64(0)
+	for i, w := range weights {
+		q, r := new(big.Int).QuoRem(
+			new(big.Int).Mul(big.NewInt(amount), big.NewInt(w)),
+			big.NewInt(sum),
+			new(big.Int),
+		)
+		parts[i] = q.Int64()
+		remainders[i] = r
+		given += parts[i]
+	}
+	order := make([]int, len(weights))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return remainders[order[a]].Cmp(remainders[order[b]]) > 0
+	})
+	for _, i := range order[:amount-given] {
+		parts[i]++
+	}
+	return parts
+}
+
+func total(lines [][]model.Discount) int64 {
+	t := int64(0)
+	for _, discounts := range lines {
+		for _, d := range discounts {
+			t += d.Amount.Amount
+		}
+	}
+	return t
+}
+
+func containsPromotion(discounts []model.Discount, id string) bool {
+	for _, d := range discounts {
+		if d.PromotionID == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,259 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/wignn/micro-3/order/model"
+)
+
+var ErrLeaseLost = errors.New("checkout is held by another run")
+
+// CreateCheckout stores a new checkout held by the caller until lockedUntil
+func (r *postgresRepository) CreateCheckout(c context.Context, co *model.Checkout, lockedUntil time.Time) (err error) {
+	products, err := json.Marshal(co.Products)
+	if err != nil {
+		return err
+	}
+	coupons := co.Coupons
+	if coupons == nil {
+		coupons = []string{}
+	}
+	address, err := addressToJSON(co.ShippingAddress)
+	if err != nil {
+		return err
+	}
+	tx, err := r.db.BeginTx(c, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	_, err = tx.ExecContext(
+		c,
+		`INSERT INTO checkouts(id, order_id, account_id, currency, products, coupons, shipping_address, payment_method,
+		payment_intent_id, idempotency_key, fingerprint, status, error, locked_until, lease_owner, created_at, updated_at)
+		VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $16)`,
+		co.ID,
+		co.OrderID,
+		co.AccountID,
+		co.Currency,
+		products,
+		pq.Array(coupons),
+		address,
+		co.PaymentMethod,
+		co.PaymentIntentID,
+		co.IdempotencyKey,
+		co.Fingerprint,
+		co.Status,
+		co.Error,
+		lockedUntil,
+		co.LeaseOwner,
+		co.CreatedAt,
+	)
+	if err != nil {
+		return err
+	}
+	for i, step := range co.Steps {
+		_, err = tx.ExecContext(
+			c,
+			`INSERT INTO checkout_steps(checkout_id, position, name, status, error, updated_at)
+			VALUES($1, $2, $3, $4, $5, $6)`,
+			co.ID,
+			i,
+			step.Name,
+			step.Status,
+			step.Error,
+			step.UpdatedAt,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SaveCheckout stores the progress of a checkout, lockedUntil extends or
+// gives up the caller's hold on it. It fails with ErrLeaseLost when another
+// run claimed the checkout since co was read.
+func (r *postgresRepository) SaveCheckout(c context.Context, co *model.Checkout, lockedUntil time.Time) (err error) {
+	tx, err := r.db.BeginTx(c, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	res, err := tx.ExecContext(
+		c,
+		`UPDATE checkouts
+			SET payment_intent_id = $2, status = $3, error = $4, locked_until = $5, updated_at = $6
+			WHERE id = $1 AND lease_owner = $7`,
+		co.ID,
+		co.PaymentIntentID,
+		co.Status,
+		co.Error,
+		lockedUntil,
+		co.UpdatedAt,
+		co.LeaseOwner,
+	)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrLeaseLost
+	}
+	for _, step := range co.Steps {
+		_, err = tx.ExecContext(
+			c,
+			"UPDATE checkout_steps SET status = $3, error = $4, updated_at = $5 WHERE checkout_id = $1 AND name = $2",
+			co.ID,
+			step.Name,
+			step.Status,
+			step.Error,
+			step.UpdatedAt,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *postgresRepository) GetCheckout(c context.Context, id string) (*model.Checkout, error) {
+	return getCheckout(c, r.db, "id", id)
+}
+
+func (r *postgresRepository) GetCheckoutForOrder(c context.Context, orderID string) (*model.Checkout, error) {
+	return getCheckout(c, r.db, "order_id", orderID)
+}
+
+// ClaimCheckouts takes over at most limit unfinished checkouts whose hold
+// expired, oldest first, and holds them for owner until lockedUntil
+func (r *postgresRepository) ClaimCheckouts(c context.Context, limit int, lockedUntil time.Time, owner string) ([]*model.Checkout, error) {
+	rows, err := r.db.QueryContext(
+		c,
+		`UPDATE checkouts SET locked_until = $3, lease_owner = $5
+		WHERE id IN (
+			SELECT id FROM checkouts
+			WHERE status NOT IN ($1, $2) AND locked_until < now()
+			ORDER BY created_at
+			LIMIT $4
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id`,
+		model.CheckoutCompleted,
+		model.CheckoutFailed,
+		lockedUntil,
+		limit,
+		owner,
+	)
+	if err != nil {
+		return nil, err
+	}
+	ids := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	checkouts := []*model.Checkout{}
+	for _, id := range ids {
+		co, err := r.GetCheckout(c, id)
+		if err != nil {
+			return nil, err
+		}
+		checkouts = append(checkouts, co)
+	}
+	return checkouts, nil
+}
+
+// getCheckout reads a checkout with its steps, column is id or order_id
+func getCheckout(c context.Context, q querier, column, value string) (*model.Checkout, error) {
+	co := &model.Checkout{}
+	var products, address []byte
+	err := q.QueryRowContext(
+		c,
+		`SELECT id, order_id, account_id, currency, products, coupons, shipping_address, payment_method,
+		payment_intent_id, idempotency_key, fingerprint, status, error, lease_owner, created_at, updated_at
+		FROM checkouts
+		WHERE `+column+` = $1`,
+		value,
+	).Scan(
+		&co.ID,
+		&co.OrderID,
+		&co.AccountID,
+		&co.Currency,
+		&products,
+		pq.Array(&co.Coupons),
+		&address,
+		&co.PaymentMethod,
+		&co.PaymentIntentID,
+		&co.IdempotencyKey,
+		&co.Fingerprint,
+		&co.Status,
+		&co.Error,
+		&co.LeaseOwner,
+		&co.CreatedAt,
+		&co.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(products, &co.Products); err != nil {
+		return nil, err
+	}
+	if co.ShippingAddress, err = addressFromJSON(address); err != nil {
+		return nil, err
+	}
+
+	rows, err := q.QueryContext(
+		c,
+		"SELECT name, status, error, updated_at FROM checkout_steps WHERE checkout_id = $1 ORDER BY position",
+		co.ID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	co.Steps = []model.CheckoutStep{}
+	for rows.Next() {
+		step := model.CheckoutStep{}
+		if err := rows.Scan(&step.Name, &step.Status, &step.Error, &step.UpdatedAt); err != nil {
+			return nil, err
+		}
+		co.Steps = append(co.Steps, step)
+	}
+	return co, rows.Err()
+}
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/lib/pq"
+	"github.com/wignn/micro-3/order/model"
+)
+
+var ErrDuplicateKey = errors.New("idempotency key was already used")
+
+func putIdempotencyKey(c context.Context, tx *sql.Tx, key *model.IdempotencyKey) error {
+	_, err := tx.ExecContext(
+		c,
+		`INSERT INTO order_idempotency_keys(account_id, key, fingerprint, order_id, created_at)
+    VALUES($1, $2, $3, $4, $5)`,
+		key.AccountID,
+		key.Key,
+		key.Fingerprint,
+		key.OrderID,
+		key.CreatedAt,
+	)
+	if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "unique_violation" {
+		return ErrDuplicateKey
+	}
+	return err
+}
+
+func (r *postgresRepository) GetIdempotencyKey(c context.Context, accountID, key string) (*model.IdempotencyKey, error) {
+	k := &model.IdempotencyKey{}
+	err := r.db.QueryRowContext(
+		c,
+		`SELECT account_id, key, fingerprint, order_id, created_at
+    FROM order_idempotency_keys WHERE account_id = $1 AND key = $2`,
+		accountID,
+		key,
+	).Scan(&k.AccountID, &k.Key, &k.Fingerprint, &k.OrderID, &k.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return k, nil
+}
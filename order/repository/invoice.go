@@ -0,0 +1,159 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/lib/pq"
+	"github.com/wignn/micro-3/order/model"
+)
+
+var (
+	ErrInvoiceNotFound    = errors.New("invoice not found")
+	ErrNotInvoiceable     = errors.New("order can't be invoiced before it is paid")
+	ErrInvoiceNumberTaken = errors.New("invoice number was taken by another invoice")
+)
+
+const invoiceColumns = `id, number, order_id, issued_at, total_minor, currency, html_key, pdf_key`
+
+// NextInvoiceSequence returns the sequence number the next invoice of year
+// would get, without reserving it
+func (r *postgresRepository) NextInvoiceSequence(c context.Context, year int) (int64, error) {
+	var last int64
+	err := r.db.QueryRowContext(c, "SELECT last FROM invoice_sequences WHERE year = $1", year).Scan(&last)
+	if err != nil && err != sql.ErrNoRows {
+		return 0, err
+	}
+	return last + 1, nil
+}
+
+// CreateInvoice saves the invoice of an order numbered with sequence, the
+// next number of the year it is issued in. The counter only moves when the
+// invoice is saved, so numbers have no gaps; when another invoice took
+// sequence first it fails with ErrInvoiceNumberTaken. An order has a single
+// invoice, when it already has one that invoice is returned instead.
+func (r *postgresRepository) CreateInvoice(c context.Context, inv *model.Invoice, sequence int64) (_ *model.Invoice, err error) {
+	tx, err := r.db.BeginTx(c, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	var status string
+	err = tx.QueryRowContext(c, "SELECT status FROM orders WHERE id = $1 FOR UPDATE", inv.OrderID).Scan(&status)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	existing, err := scanInvoice(tx.QueryRowContext(c, "SELECT "+invoiceColumns+" FROM invoices WHERE order_id = $1", inv.OrderID))
+	if err == nil {
+		return existing, nil
+	}
+	if err != sql.ErrNoRows {
+		return nil, err
+	}
+	if !model.Invoiceable(status) {
+		return nil, ErrNotInvoiceable
+	}
+
+	// the sequence row of the year stays locked until the invoice is saved,
+	// the documents are stored by then so the lock is held briefly
+	var last int64
+	err = tx.QueryRowContext(
+		c,
+		`INSERT INTO invoice_sequences(year, last) VALUES($1, 1)
+    ON CONFLICT (year) DO UPDATE SET last = invoice_sequences.last + 1
+    RETURNING last`,
+		inv.IssuedAt.Year(),
+	).Scan(&last)
+	if err != nil {
+		return nil, err
+	}
+	if last != sequence {
+		return nil, ErrInvoiceNumberTaken
+	}
+
+	_, err = tx.ExecContext(
+		c,
+		`INSERT INTO invoices(id, number, order_id, issued_at, total_minor, currency, html_key, pdf_key)
+    VALUES($1, $2, $3, $4, $5, $6, $7, $8)`,
+		inv.ID,
+		inv.Number,
+		inv.OrderID,
+		inv.IssuedAt,
+		inv.Total.Amount,
+		inv.Total.Currency,
+		inv.HTMLKey,
+		inv.PDFKey,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return inv, nil
+}
+
+func (r *postgresRepository) GetInvoiceForOrder(c context.Context, orderID string) (*model.Invoice, error) {
+	inv, err := scanInvoice(r.db.QueryRowContext(c, "SELECT "+invoiceColumns+" FROM invoices WHERE order_id = $1", orderID))
+	if err == sql.ErrNoRows {
+		return nil, ErrInvoiceNotFound
+	}
+	return inv, err
+}
+
+// ListUninvoicedOrders returns the ids of paid orders without an invoice,
+// the orders paid first come first
+func (r *postgresRepository) ListUninvoicedOrders(c context.Context, limit int) ([]string, error) {
+	rows, err := r.db.QueryContext(
+		c,
+		`SELECT o.id FROM orders o
+    WHERE o.status = ANY($1) AND NOT EXISTS (SELECT 1 FROM invoices i WHERE i.order_id = o.id)
+    ORDER BY (
+      SELECT max(h.changed_at) FROM order_status_history h
+      WHERE h.order_id = o.id AND h.to_status = $2
+    ) NULLS LAST, o.created_at
+    LIMIT $3`,
+		pq.Array(model.InvoiceableStatuses),
+		model.OrderPaid,
+		limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+func scanInvoice(row *sql.Row) (*model.Invoice, error) {
+	inv := &model.Invoice{}
+	if err := row.Scan(
+		&inv.ID,
+		&inv.Number,
+		&inv.OrderID,
+		&inv.IssuedAt,
+		&inv.Total.Amount,
+		&inv.Total.Currency,
+		&inv.HTMLKey,
+		&inv.PDFKey,
+	); err != nil {
+		return nil, err
+	}
+	return inv, nil
+}
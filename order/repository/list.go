@@ -0,0 +1,191 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+	"github.com/wignn/micro-3/order/model"
+)
+
+type querier interface {
+	QueryContext(c context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(c context.Context, query string, args ...interface{}) *sql.Row
+}
+
+const orderColumns = `id, created_at, account_id, total_minor, currency, status,
+  shipping_minor, shipping_tax_minor, tax_minor, tax_inclusive, shipping_address`
+
+func scanOrder(row interface{ Scan(...interface{}) error }) (*model.Order, error) {
+	o := &model.Order{}
+	var address []byte
+	if err := row.Scan(
+		&o.ID,
+		&o.CreatedAt,
+		&o.AccountID,
+		&o.TotalPrice.Amount,
+		&o.Currency,
+		&o.Status,
+		&o.Shipping.Amount,
+		&o.ShippingTax.Amount,
+		&o.Tax.Amount,
+		&o.TaxInclusive,
+		&address,
+	); err != nil {
+		return nil, err
+	}
+	o.TotalPrice.Currency = o.Currency
+	o.Shipping.Currency = o.Currency
+	o.ShippingTax.Currency = o.Currency
+	o.Tax.Currency = o.Currency
+	var err error
+	if o.ShippingAddress, err = addressFromJSON(address); err != nil {
+		return nil, err
+	}
+	o.Products = []model.OrderedProduct{}
+	return o, nil
+}
+
+// addressToJSON encodes an optional address, nil is stored as NULL
+func addressToJSON(a *model.Address) (interface{}, error) {
+	if a == nil {
+		return nil, nil
+	}
+	return json.Marshal(a)
+}
+
+func addressFromJSON(b []byte) (*model.Address, error) {
+	if len(b) == 0 {
+		return nil, nil
+	}
+	a := &model.Address{}
+	if err := json.Unmarshal(b, a); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+func (r *postgresRepository) GetOrder(c context.Context, id string) (*model.Order, error) {
+	o, err := scanOrder(r.db.QueryRowContext(c, "SELECT "+orderColumns+" FROM orders WHERE id = $1", id))
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := loadProducts(c, r.db, []*model.Order{o}); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+// ListOrders returns up to limit orders matching filter, newest first. Order
+// ids are k-sortable so the listing is keyed on the id, afterID is the last
+// order of the previous page.
+func (r *postgresRepository) ListOrders(c context.Context, filter model.OrderFilter, afterID string, limit int) ([]*model.Order, error) {
+	where := []string{}
+	args := []interface{}{}
+	add := func(cond string, arg interface{}) {
+		args = append(args, arg)
+		where = append(where, fmt.Sprintf(cond, len(args)))
+	}
+	if afterID != "" {
+		add("id < $%d", afterID)
+	}
+	if filter.AccountID != "" {
+		add("account_id = $%d", filter.AccountID)
+	}
+	if filter.Status != "" {
+		add("status = $%d", filter.Status)
+	}
+	if filter.CreatedAfter != nil {
+		add("created_at >= $%d", *filter.CreatedAfter)
+	}
+	if filter.CreatedBefore != nil {
+		add("created_at < $%d", *filter.CreatedBefore)
+	}
+	if filter.MinTotal != nil {
+		add("currency = $%d", filter.MinTotal.Currency)
+		add("total_minor >= $%d", filter.MinTotal.Amount)
+	}
+	if filter.MaxTotal != nil {
+		add("currency = $%d", filter.MaxTotal.Currency)
+		add("total_minor <= $%d", filter.MaxTotal.Amount)
+	}
+
+	query := "SELECT " + orderColumns + " FROM orders"
+	if len(where) > 0 {
+		query += " WHERE " + strings.Join(where, " AND ")
+	}
+	args = append(args, limit)
+	query += fmt.Sprintf(" ORDER BY id DESC LIMIT $%d", len(args))
+
+	rows, err := r.db.QueryContext(c, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	orders := []*model.Order{}
+	for rows.Next() {
+		o, err := scanOrder(rows)
+		if err != nil {
+			return nil, err
+		}
+		orders = append(orders, o)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if err := loadProducts(c, r.db, orders); err != nil {
+		return nil, err
+	}
+	return orders, nil
+}
+
+// loadProducts reads the lines of orders with their discounts, they are
+// priced in the order currency
+func loadProducts(c context.Context, q querier, orders []*model.Order) error {
+	if len(orders) == 0 {
+		return nil
+	}
+	byID := map[string]*model.Order{}
+	ids := []string{}
+	for _, o := range orders {
+		byID[o.ID] = o
+		ids = append(ids, o.ID)
+	}
+
+	rows, err := q.QueryContext(
+		c,
+		`SELECT order_id, product_id, quantity, name, description, price_minor, tax_minor, weight_grams
+    FROM order_products WHERE order_id = ANY($1)
+    ORDER BY order_id, product_id`,
+		pq.Array(ids),
+	)
+	if err != nil {
+		return err
+	}
+
+	for rows.Next() {
+		var orderID string
+		p := model.OrderedProduct{}
+		if err := rows.Scan(&orderID, &p.ID, &p.Quantity, &p.Name, &p.Description, &p.Price.Amount, &p.Tax.Amount, &p.WeightGrams); err != nil {
+			rows.Close()
+			return err
+		}
+		o := byID[orderID]
+		p.Price.Currency = o.Currency
+		p.Tax.Currency = o.Currency
+		o.Products = append(o.Products, p)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	// a transaction runs one query at a time
+	return loadDiscounts(c, q, orders)
+}
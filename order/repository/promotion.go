@@ -0,0 +1,318 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/wignn/micro-3/order/model"
+	"github.com/wignn/micro-3/order/promotion"
+)
+
+var (
+	ErrPromotionNotFound = errors.New("promotion not found")
+	ErrDuplicateCode     = errors.New("coupon code is already used by another promotion")
+	// ErrPromotionUsedUp is returned when concurrent orders reached a usage
+	// limit of a promotion first
+	ErrPromotionUsedUp = errors.New("promotion has been used up")
+)
+
+const promotionColumns = `p.id, p.code, p.name, p.kind, p.percent_off, p.amount_off_minor, p.min_spend_minor,
+  p.currency, p.buy_quantity, p.get_quantity, p.product_ids, p.starts_at, p.ends_at, p.max_uses,
+  p.max_uses_per_account, p.stackable, p.priority, p.active, p.created_at, p.updated_at,
+  (SELECT COUNT(*) FROM promotion_redemptions pr WHERE pr.promotion_id = p.id)`
+
+func scanPromotion(row interface{ Scan(...interface{}) error }) (*model.Promotion, error) {
+	p := &model.Promotion{}
+	var currencyCode string
+	var startsAt, endsAt sql.NullTime
+	if err := row.Scan(
+		&p.ID,
+		&p.Code,
+		&p.Name,
+		&p.Kind,
+		&p.PercentOff,
+		&p.AmountOff.Amount,
+		&p.MinSpend.Amount,
+		&currencyCode,
+		&p.BuyQuantity,
+		&p.GetQuantity,
+		pq.Array(&p.ProductIDs),
+		&startsAt,
+		&endsAt,
+		&p.MaxUses,
+		&p.MaxUsesPerAccount,
+		&p.Stackable,
+		&p.Priority,
+		&p.Active,
+		&p.CreatedAt,
+		&p.UpdatedAt,
+		&p.Uses,
+	); err != nil {
+		return nil, err
+	}
+	p.AmountOff.Currency, p.MinSpend.Currency = currencyCode, currencyCode
+	if startsAt.Valid {
+		p.StartsAt = &startsAt.Time
+	}
+	if endsAt.Valid {
+		p.EndsAt = &endsAt.Time
+	}
+	return p, nil
+}
+
+func queryPromotions(c context.Context, q querier, where string, args ...interface{}) ([]*model.Promotion, error) {
+	rows, err := q.QueryContext(c, "SELECT "+promotionColumns+" FROM promotions p WHERE "+where+" ORDER BY p.created_at DESC, p.id", args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	promotions := []*model.Promotion{}
+	for rows.Next() {
+		p, err := scanPromotion(rows)
+		if err != nil {
+			return nil, err
+		}
+		promotions = append(promotions, p)
+	}
+	return promotions, rows.Err()
+}
+
+// promotionCurrency is the currency of the amounts of a promotion
+func promotionCurrency(p *model.Promotion) string {
+	if p.AmountOff.Currency != "" {
+		return p.AmountOff.Currency
+	}
+	return p.MinSpend.Currency
+}
+
+func (r *postgresRepository) CreatePromotion(c context.Context, p *model.Promotion) error {
+	productIDs := p.ProductIDs
+	if productIDs == nil {
+		productIDs = []string{}
+	}
+	_, err := r.db.ExecContext(
+		c,
+		`INSERT INTO promotions(id, code, name, kind, percent_off, amount_off_minor, min_spend_minor, currency,
+      buy_quantity, get_quantity, product_ids, starts_at, ends_at, max_uses, max_uses_per_account,
+      stackable, priority, active, created_at, updated_at)
+    VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20)`,
+		p.ID,
+		p.Code,
+		p.Name,
+		p.Kind,
+		p.PercentOff,
+		p.AmountOff.Amount,
+		p.MinSpend.Amount,
+		promotionCurrency(p),
+		p.BuyQuantity,
+		p.GetQuantity,
+		pq.Array(productIDs),
+		p.StartsAt,
+		p.EndsAt,
+		p.MaxUses,
+		p.MaxUsesPerAccount,
+		p.Stackable,
+		p.Priority,
+		p.Active,
+		p.CreatedAt,
+		p.UpdatedAt,
+	)
+	if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "unique_violation" {
+		return ErrDuplicateCode
+	}
+	return err
+}
+
+// SetPromotionActive turns a promotion on or off, promotions are never
+// deleted as orders refer to them
+func (r *postgresRepository) SetPromotionActive(c context.Context, id string, active bool, at time.Time) (*model.Promotion, error) {
+	res, err := r.db.ExecContext(c, "UPDATE promotions SET active = $1, updated_at = $2 WHERE id = $3", active, at, id)
+	if err != nil {
+		return nil, err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return nil, ErrPromotionNotFound
+	}
+	return r.GetPromotion(c, id)
+}
+
+func (r *postgresRepository) GetPromotion(c context.Context, id string) (*model.Promotion, error) {
+	p, err := scanPromotion(r.db.QueryRowContext(c, "SELECT "+promotionColumns+" FROM promotions p WHERE p.id = $1", id))
+	if err == sql.ErrNoRows {
+		return nil, ErrPromotionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// ListPromotions returns every promotion, newest first
+func (r *postgresRepository) ListPromotions(c context.Context) ([]*model.Promotion, error) {
+	return queryPromotions(c, r.db, "TRUE")
+}
+
+// GetPromotionsByCode returns the promotions of coupon codes whatever their
+// state, so that the caller can tell why a coupon doesn't apply
+func (r *postgresRepository) GetPromotionsByCode(c context.Context, codes []string) ([]*model.Promotion, error) {
+	if len(codes) == 0 {
+		return []*model.Promotion{}, nil
+	}
+	return queryPromotions(c, r.db, "p.code = ANY($1)", pq.Array(codes))
+}
+
+// GetAutomaticPromotions returns the active promotions without a code that
+// are running at a time
+func (r *postgresRepository) GetAutomaticPromotions(c context.Context, at time.Time) ([]*model.Promotion, error) {
+	return queryPromotions(
+		c,
+		r.db,
+		`p.code = '' AND p.active AND (p.starts_at IS NULL OR p.starts_at <= $1) AND (p.ends_at IS NULL OR p.ends_at > $1)`,
+		at,
+	)
+}
+
+// GetPromotionUsage counts the redemptions of promotions, overall and by an account
+func (r *postgresRepository) GetPromotionUsage(c context.Context, ids []string, accountID string) (map[string]promotion.Usage, error) {
+	usage := map[string]promotion.Usage{}
+	if len(ids) == 0 {
+		return usage, nil
+	}
+	rows, err := r.db.QueryContext(
+		c,
+		`SELECT promotion_id, COUNT(*), COUNT(*) FILTER (WHERE account_id = $2)
+    FROM promotion_redemptions WHERE promotion_id = ANY($1)
+    GROUP BY promotion_id`,
+		pq.Array(ids),
+		accountID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id string
+		u := promotion.Usage{}
+		if err := rows.Scan(&id, &u.Total, &u.Account); err != nil {
+			return nil, err
+		}
+		usage[id] = u
+	}
+	return usage, rows.Err()
+}
+
+// putDiscounts stores the line discounts of an order and redeems the
+// promotions they come from. The promotions are locked while their usage is
+// counted so that concurrent orders can't exceed a limit.
+func putDiscounts(c context.Context, tx *sql.Tx, o *model.Order) error {
+	redeemed := map[string]bool{}
+	for _, p := range o.Products {
+		for i, d := range p.Discounts {
+			_, err := tx.ExecContext(
+				c,
+				`INSERT INTO order_discounts(order_id, product_id, position, promotion_id, code, name, amount_minor)
+        VALUES($1, $2, $3, $4, $5, $6, $7)`,
+				o.ID,
+				p.ID,
+				i,
+				d.PromotionID,
+				d.Code,
+				d.Name,
+				d.Amount.Amount,
+			)
+			if err != nil {
+				return err
+			}
+			if redeemed[d.PromotionID] {
+				continue
+			}
+			redeemed[d.PromotionID] = true
+			if err := redeem(c, tx, d.PromotionID, o); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func redeem(c context.Context, tx *sql.Tx, promotionID string, o *model.Order) error {
+	var maxUses, maxUsesPerAccount uint32
+	err := tx.QueryRowContext(
+		c,
+		"SELECT max_uses, max_uses_per_account FROM promotions WHERE id = $1 FOR UPDATE",
+		promotionID,
+	).Scan(&maxUses, &maxUsesPerAccount)
+	if err == sql.ErrNoRows {
+		return ErrPromotionNotFound
+	}
+	if err != nil {
+		return err
+	}
+	var uses, accountUses uint32
+	err = tx.QueryRowContext(
+		c,
+		`SELECT COUNT(*), COUNT(*) FILTER (WHERE account_id = $2)
+    FROM promotion_redemptions WHERE promotion_id = $1`,
+		promotionID,
+		o.AccountID,
+	).Scan(&uses, &accountUses)
+	if err != nil {
+		return err
+	}
+	if (maxUses > 0 && uses >= maxUses) || (maxUsesPerAccount > 0 && accountUses >= maxUsesPerAccount) {
+		return ErrPromotionUsedUp
+	}
+	_, err = tx.ExecContext(
+		c,
+		"INSERT INTO promotion_redemptions(promotion_id, order_id, account_id, created_at) VALUES($1, $2, $3, $4)",
+		promotionID,
+		o.ID,
+		o.AccountID,
+		o.CreatedAt,
+	)
+	return err
+}
+
+// loadDiscounts reads the line discounts of orders whose products are loaded
+func loadDiscounts(c context.Context, q querier, orders []*model.Order) error {
+	if len(orders) == 0 {
+		return nil
+	}
+	byID := map[string]*model.Order{}
+	ids := []string{}
+	for _, o := range orders {
+		byID[o.ID] = o
+		ids = append(ids, o.ID)
+	}
+
+	rows, err := q.QueryContext(
+		c,
+		`SELECT order_id, product_id, promotion_id, code, name, amount_minor
+    FROM order_discounts WHERE order_id = ANY($1)
+    ORDER BY order_id, product_id, position`,
+		pq.Array(ids),
+	)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var orderID, productID string
+		d := model.Discount{}
+		if err := rows.Scan(&orderID, &productID, &d.PromotionID, &d.Code, &d.Name, &d.Amount.Amount); err != nil {
+			return err
+		}
+		o := byID[orderID]
+		d.Amount.Currency = o.Currency
+		for i := range o.Products {
+			if o.Products[i].ID == productID {
+				o.Products[i].Discounts = append(o.Products[i].Discounts, d)
+			}
+		}
+	}
+	return rows.Err()
+}
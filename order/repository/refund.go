@@ -0,0 +1,241 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/wignn/micro-3/currency"
+	"github.com/wignn/micro-3/order/model"
+)
+
+var (
+	ErrNotRefundable      = errors.New("order can't be refunded in its current status")
+	ErrNothingToRefund    = errors.New("order has nothing left to refund")
+	ErrRefundExceedsOrder = errors.New("refund exceeds the quantity left to refund")
+)
+
+// RefundOrder records refund against an order, refund lines are priced at
+// what was paid for them after discounts and with their tax, and a refund
+// without lines refunds everything left. The refund that gives back the last
+// units gives back shipping too and the order moves to refunded. settle gives
+// the priced refund back to the customer before it is committed, the refund
+// isn't recorded when it fails.
+func (r *postgresRepository) RefundOrder(c context.Context, refund *model.Refund, settle func(refund *model.Refund) error) (o *model.Order, err error) {
+	tx, err := r.db.BeginTx(c, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	o, err = scanOrder(tx.QueryRowContext(c, "SELECT "+orderColumns+" FROM orders WHERE id = $1 FOR UPDATE", refund.OrderID))
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !model.CanTransition(o.Status, model.OrderRefunded) {
+		return nil, ErrNotRefundable
+	}
+	if err = loadProducts(c, tx, []*model.Order{o}); err != nil {
+		return nil, err
+	}
+	left, err := refundableQuantities(c, tx, o)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(refund.Lines) == 0 {
+		for _, p := range o.Products {
+			if left[p.ID] > 0 {
+				refund.Lines = append(refund.Lines, model.RefundLine{ProductID: p.ID, Quantity: left[p.ID]})
+			}
+		}
+		if len(refund.Lines) == 0 {
+			return nil, ErrNothingToRefund
+		}
+	}
+
+	products := map[string]model.OrderedProduct{}
+	for _, p := range o.Products {
+		products[p.ID] = p
+	}
+	refund.Amount = currency.Money{Currency: o.Currency}
+	for i := range refund.Lines {
+		l := &refund.Lines[i]
+		if l.Quantity > left[l.ProductID] {
+			return nil, ErrRefundExceedsOrder
+		}
+		// a discounted line is refunded in proportion to what was charged
+		// for it, the rounding is taken back by the last unit so that
+		// refunding every unit gives back exactly what was paid
+		p := products[l.ProductID]
+		net, quantity := o.LineCharge(p), int64(p.Quantity)
+		before := quantity - int64(left[l.ProductID])
+		left[l.ProductID] -= l.Quantity
+		after := quantity - int64(left[l.ProductID])
+		l.Amount = net.MulRatio(after, quantity)
+		l.Amount.Amount -= net.MulRatio(before, quantity).Amount
+		if refund.Amount, err = refund.Amount.Add(l.Amount); err != nil {
+			return nil, err
+		}
+	}
+	refunded := true
+	for _, n := range left {
+		if n > 0 {
+			refunded = false
+		}
+	}
+	if refunded {
+		refund.Amount.Amount += o.ShippingCharge().Amount
+	}
+
+	_, err = tx.ExecContext(
+		c,
+		`INSERT INTO order_refunds(id, order_id, amount_minor, currency, reason, actor, created_at)
+    VALUES($1, $2, $3, $4, $5, $6, $7)`,
+		refund.ID,
+		refund.OrderID,
+		refund.Amount.Amount,
+		refund.Amount.Currency,
+		refund.Reason,
+		refund.Actor,
+		refund.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	for _, l := range refund.Lines {
+		_, err = tx.ExecContext(
+			c,
+			"INSERT INTO order_refund_lines(refund_id, product_id, quantity, amount_minor) VALUES($1, $2, $3, $4)",
+			refund.ID,
+			l.ProductID,
+			l.Quantity,
+			l.Amount.Amount,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if refunded {
+		if err = markRefunded(c, tx, o, refund); err != nil {
+			return nil, err
+		}
+	}
+	if err = settle(refund); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+// markRefunded moves an order whose last units were refunded to refunded and
+// cancels its pending shipments
+func markRefunded(c context.Context, tx *sql.Tx, o *model.Order, refund *model.Refund) error {
+	if _, err := tx.ExecContext(c, "UPDATE orders SET status = $1 WHERE id = $2", model.OrderRefunded, o.ID); err != nil {
+		return err
+	}
+	err := insertStatusChange(c, tx, &model.OrderStatusChange{
+		OrderID:   o.ID,
+		From:      o.Status,
+		To:        model.OrderRefunded,
+		Actor:     refund.Actor,
+		Reason:    refund.Reason,
+		ChangedAt: refund.CreatedAt,
+	})
+	if err != nil {
+		return err
+	}
+	if err = cancelPendingShipments(c, tx, o.ID, refund.CreatedAt); err != nil {
+		return err
+	}
+	o.Status = model.OrderRefunded
+	return nil
+}
+
+// refundableQuantities returns the units of each ordered product that have
+// not been refunded yet
+func refundableQuantities(c context.Context, q querier, o *model.Order) (map[string]uint32, error) {
+	left := map[string]uint32{}
+	for _, p := range o.Products {
+		left[p.ID] += p.Quantity
+	}
+	rows, err := q.QueryContext(
+		c,
+		`SELECT rl.product_id, SUM(rl.quantity)
+    FROM order_refund_lines rl JOIN order_refunds rf ON (rf.id = rl.refund_id)
+    WHERE rf.order_id = $1
+    GROUP BY rl.product_id`,
+		o.ID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var productID string
+		var refunded uint32
+		if err := rows.Scan(&productID, &refunded); err != nil {
+			return nil, err
+		}
+		if refunded >= left[productID] {
+			left[productID] = 0
+		} else {
+			left[productID] -= refunded
+		}
+	}
+	return left, rows.Err()
+}
+
+// GetOrderRefunds returns the refunds of an order, oldest first
+func (r *postgresRepository) GetOrderRefunds(c context.Context, orderID string) ([]*model.Refund, error) {
+	rows, err := r.db.QueryContext(
+		c,
+		`SELECT rf.id, rf.order_id, rf.amount_minor, rf.currency, rf.reason, rf.actor, rf.created_at,
+      rl.product_id, rl.quantity, rl.amount_minor
+    FROM order_refunds rf JOIN order_refund_lines rl ON (rf.id = rl.refund_id)
+    WHERE rf.order_id = $1
+    ORDER BY rf.created_at, rf.id, rl.product_id`,
+		orderID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	refunds := []*model.Refund{}
+	var last *model.Refund
+	for rows.Next() {
+		rf := &model.Refund{}
+		l := model.RefundLine{}
+		if err := rows.Scan(
+			&rf.ID,
+			&rf.OrderID,
+			&rf.Amount.Amount,
+			&rf.Amount.Currency,
+			&rf.Reason,
+			&rf.Actor,
+			&rf.CreatedAt,
+			&l.ProductID,
+			&l.Quantity,
+			&l.Amount.Amount,
+		); err != nil {
+			return nil, err
+		}
+		if last == nil || last.ID != rf.ID {
+			last = rf
+			refunds = append(refunds, rf)
+		}
+		l.Amount.Currency = last.Amount.Currency
+		last.Lines = append(last.Lines, l)
+	}
+	return refunds, rows.Err()
+}
@@ -4,23 +4,51 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"time"
 
 	"github.com/lib/pq"
 	"github.com/wignn/micro-3/order/model"
+	"github.com/wignn/micro-3/order/promotion"
 )
 
 type OrderRepository interface {
 	Close()
-	PutOrder(c context.Context, o *model.Order) error
+	PutOrder(c context.Context, o *model.Order, key *model.IdempotencyKey) error
 	GetOrdersForAccount(c context.Context, accountID string) ([]*model.Order, error)
 	DeleteOrder(c context.Context, id string) error
+	ScanOrderLines(c context.Context, fn func(l *model.OrderLine) error) error
+	UpdateOrderStatus(c context.Context, change *model.OrderStatusChange) (*model.Order, error)
+	GetOrderStatusHistory(c context.Context, orderID string) ([]*model.OrderStatusChange, error)
+	GetOrder(c context.Context, id string) (*model.Order, error)
+	ListOrders(c context.Context, filter model.OrderFilter, afterID string, limit int) ([]*model.Order, error)
+	GetIdempotencyKey(c context.Context, accountID, key string) (*model.IdempotencyKey, error)
+	RefundOrder(c context.Context, refund *model.Refund, settle func(refund *model.Refund) error) (*model.Order, error)
+	GetOrderRefunds(c context.Context, orderID string) ([]*model.Refund, error)
+	CreateShipment(c context.Context, s *model.Shipment, actor string) (*model.Order, error)
+	UpdateShipment(c context.Context, update *model.ShipmentUpdate) (*model.Order, *model.Shipment, error)
+	GetShipments(c context.Context, orderID string) ([]*model.Shipment, error)
+	NextInvoiceSequence(c context.Context, year int) (int64, error)
+	CreateInvoice(c context.Context, inv *model.Invoice, sequence int64) (*model.Invoice, error)
+	GetInvoiceForOrder(c context.Context, orderID string) (*model.Invoice, error)
+	ListUninvoicedOrders(c context.Context, limit int) ([]string, error)
+	CreateCheckout(c context.Context, co *model.Checkout, lockedUntil time.Time) error
+	SaveCheckout(c context.Context, co *model.Checkout, lockedUntil time.Time) error
+	GetCheckout(c context.Context, id string) (*model.Checkout, error)
+	GetCheckoutForOrder(c context.Context, orderID string) (*model.Checkout, error)
+	ClaimCheckouts(c context.Context, limit int, lockedUntil time.Time, owner string) ([]*model.Checkout, error)
+	CreatePromotion(c context.Context, p *model.Promotion) error
+	SetPromotionActive(c context.Context, id string, active bool, at time.Time) (*model.Promotion, error)
+	GetPromotion(c context.Context, id string) (*model.Promotion, error)
+	ListPromotions(c context.Context) ([]*model.Promotion, error)
+	GetPromotionsByCode(c context.Context, codes []string) ([]*model.Promotion, error)
+	GetAutomaticPromotions(c context.Context, at time.Time) ([]*model.Promotion, error)
+	GetPromotionUsage(c context.Context, ids []string, accountID string) (map[string]promotion.Usage, error)
 }
 
 type postgresRepository struct {
 	db *sql.DB
 }
 
-
 func NewOrderPostgresRepository(url string) (OrderRepository, error) {
 	db, err := sql.Open("postgres", url)
 	if err != nil {
@@ -38,7 +66,9 @@ func (r *postgresRepository) Close() {
 	r.db.Close()
 }
 
-func (r *postgresRepository) PutOrder(c context.Context, o *model.Order) (err error) {
+// PutOrder stores an order, the idempotency key is stored with it when given
+// and ErrDuplicateKey is returned when it was already used
+func (r *postgresRepository) PutOrder(c context.Context, o *model.Order, key *model.IdempotencyKey) (err error) {
 	tx, err := r.db.BeginTx(c, nil)
 	if err != nil {
 		return err
@@ -51,23 +81,45 @@ func (r *postgresRepository) PutOrder(c context.Context, o *model.Order) (err er
 		err = tx.Commit()
 	}()
 
-	// order creation
+	// order creation, shipping and tax are stored as they were priced
+	address, err := addressToJSON(o.ShippingAddress)
+	if err != nil {
+		return
+	}
 	_, err = tx.ExecContext(
 		c,
-		"INSERT INTO orders(id, created_at, account_id, total_price) VALUES($1, $2, $3, $4)",
+		`INSERT INTO orders(id, created_at, account_id, total_minor, currency, status,
+    shipping_minor, shipping_tax_minor, tax_minor, tax_inclusive, shipping_address)
+    VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`,
 		o.ID,
 		o.CreatedAt,
 		o.AccountID,
-		o.TotalPrice,
+		o.TotalPrice.Amount,
+		o.Currency,
+		o.Status,
+		o.Shipping.Amount,
+		o.ShippingTax.Amount,
+		o.Tax.Amount,
+		o.TaxInclusive,
+		address,
 	)
 	if err != nil {
 		return
 	}
+	err = insertStatusChange(c, tx, &model.OrderStatusChange{
+		OrderID:   o.ID,
+		To:        o.Status,
+		ChangedAt: o.CreatedAt,
+	})
+	if err != nil {
+		return
+	}
 
-	// order products insertion
-	stmt, _ := tx.PrepareContext(c, pq.CopyIn("order_products", "order_id", "product_id", "quantity"))
+	// order products insertion, name and unit price are kept as they were
+	// when the order was placed
+	stmt, _ := tx.PrepareContext(c, pq.CopyIn("order_products", "order_id", "product_id", "quantity", "name", "description", "price_minor", "tax_minor", "weight_grams"))
 	for _, p := range o.Products {
-		_, err = stmt.ExecContext(c, o.ID, p.ID, p.Quantity)
+		_, err = stmt.ExecContext(c, o.ID, p.ID, p.Quantity, p.Name, p.Description, p.Price.Amount, p.Tax.Amount, p.WeightGrams)
 		if err != nil {
 			return
 		}
@@ -78,24 +130,22 @@ func (r *postgresRepository) PutOrder(c context.Context, o *model.Order) (err er
 	}
 	stmt.Close()
 
-	return 
-}
-
+	// discounts are stored per line, redeeming their promotions fails once
+	// a usage limit is reached
+	if err = putDiscounts(c, tx, o); err != nil {
+		return
+	}
 
+	if key != nil {
+		err = putIdempotencyKey(c, tx, key)
+	}
+	return
+}
 
 func (r *postgresRepository) GetOrdersForAccount(c context.Context, accountID string) ([]*model.Order, error) {
 	rows, err := r.db.QueryContext(
 		c,
-		`SELECT
-      o.id,
-      o.created_at,
-      o.account_id,
-      o.total_price::money::numeric::float8,
-      op.product_id,
-      op.quantity
-    FROM orders o JOIN order_products op ON (o.id = op.order_id)
-    WHERE o.account_id = $1
-    ORDER BY o.id`,
+		"SELECT "+orderColumns+" FROM orders WHERE account_id = $1 ORDER BY id",
 		accountID,
 	)
 	if err != nil {
@@ -104,64 +154,24 @@ func (r *postgresRepository) GetOrdersForAccount(c context.Context, accountID st
 	defer rows.Close()
 
 	orders := []*model.Order{}
-	order := &model.Order{}
-	lastOrder := &model.Order{}
-	orderedProduct := &model.OrderedProduct{}
-	products := []model.OrderedProduct{}
-
-	// Scan rows into Order structs
 	for rows.Next() {
-		if err = rows.Scan(
-			&order.ID,
-			&order.CreatedAt,
-			&order.AccountID,
-			&order.TotalPrice,
-			&orderedProduct.ID,
-			&orderedProduct.Quantity,
-		); err != nil {
+		o, err := scanOrder(rows)
+		if err != nil {
 			return nil, err
 		}
-		// Scan order
-		if lastOrder.ID != "" && lastOrder.ID != order.ID {
-			newOrder := &model.Order{
-				ID:         lastOrder.ID,
-				AccountID:  lastOrder.AccountID,
-				CreatedAt:  lastOrder.CreatedAt,
-				TotalPrice: lastOrder.TotalPrice,
-				Products:   products,
-			}
-			orders = append(orders, newOrder)
-			products = []model.OrderedProduct{}
-		}
-		// Scan products
-		products = append(products, model.OrderedProduct{
-			ID:       orderedProduct.ID,
-			Quantity: orderedProduct.Quantity,
-		})
-
-		*lastOrder = *order
-	}
-
-	// Add last order (or first :D)
-	if lastOrder != nil {
-		newOrder := &model.Order{
-			ID:         lastOrder.ID,
-			AccountID:  lastOrder.AccountID,
-			CreatedAt:  lastOrder.CreatedAt,
-			TotalPrice: lastOrder.TotalPrice,
-			Products:   products,
-		}
-		orders = append(orders, newOrder)
+		orders = append(orders, o)
 	}
-
 	if err = rows.Err(); err != nil {
 		return nil, err
 	}
+	rows.Close()
+	if err = loadProducts(c, r.db, orders); err != nil {
+		return nil, err
+	}
 
 	return orders, nil
 }
 
-
 func (r *postgresRepository) DeleteOrder(c context.Context, id string) error {
 	res, err := r.db.ExecContext(
 		c,
@@ -182,4 +192,29 @@ func (r *postgresRepository) DeleteOrder(c context.Context, id string) error {
 	}
 
 	return nil
-}
\ No newline at end of file
+}
+
+// ScanOrderLines calls fn for every ordered product, ordered by order id
+func (r *postgresRepository) ScanOrderLines(c context.Context, fn func(l *model.OrderLine) error) error {
+	rows, err := r.db.QueryContext(
+		c,
+		`SELECT o.id, o.account_id, op.product_id, op.quantity
+    FROM orders o JOIN order_products op ON (o.id = op.order_id)
+    ORDER BY o.id`,
+	)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		l := &model.OrderLine{}
+		if err := rows.Scan(&l.OrderID, &l.AccountID, &l.ProductID, &l.Quantity); err != nil {
+			return err
+		}
+		if err := fn(l); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
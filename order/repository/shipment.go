@@ -0,0 +1,357 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/wignn/micro-3/order/model"
+)
+
+var (
+	ErrShipmentNotFound          = errors.New("shipment not found")
+	ErrNotShippable              = errors.New("order can't be shipped in its current status")
+	ErrNothingToShip             = errors.New("order has nothing left to ship")
+	ErrShipmentExceedsOrder      = errors.New("shipment exceeds the quantity left to ship")
+	ErrNoShippingAddress         = errors.New("order has no shipping address, the shipment needs one")
+	ErrInvalidShipmentTransition = errors.New("shipment can't move to that status")
+)
+
+// fulfillmentPath is the way an order goes through fulfillment, shipments
+// only ever move orders forward along it
+var fulfillmentPath = []string{model.OrderPaid, model.OrderFulfilled, model.OrderShipped, model.OrderDelivered}
+
+const shipmentColumns = `s.id, s.order_id, s.carrier, s.tracking_number, s.status, s.address,
+  s.shipped_at, s.delivered_at, s.created_at, s.updated_at, sl.product_id, sl.quantity`
+
+// CreateShipment records a shipment of a paid order, a shipment without
+// lines ships every unit that isn't refunded or in another shipment yet. The
+// order is locked so that shipments of the same order are checked one at a
+// time, and it moves to fulfilled once every unit is in a shipment.
+func (r *postgresRepository) CreateShipment(c context.Context, s *model.Shipment, actor string) (o *model.Order, err error) {
+	tx, err := r.db.BeginTx(c, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	o, err = scanOrder(tx.QueryRowContext(c, "SELECT "+orderColumns+" FROM orders WHERE id = $1 FOR UPDATE", s.OrderID))
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if o.Status != model.OrderPaid && o.Status != model.OrderFulfilled && o.Status != model.OrderShipped {
+		return nil, ErrNotShippable
+	}
+	if err = loadProducts(c, tx, []*model.Order{o}); err != nil {
+		return nil, err
+	}
+	left, err := refundableQuantities(c, tx, o)
+	if err != nil {
+		return nil, err
+	}
+	shipments, err := loadShipments(c, tx, o.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	unpacked := map[string]uint32{}
+	for productID, n := range left {
+		unpacked[productID] = n
+	}
+	for _, shipment := range shipments {
+		if shipment.Status == model.ShipmentCancelled {
+			continue
+		}
+		for _, l := range shipment.Lines {
+			if l.Quantity >= unpacked[l.ProductID] {
+				unpacked[l.ProductID] = 0
+			} else {
+				unpacked[l.ProductID] -= l.Quantity
+			}
+		}
+	}
+	if len(s.Lines) == 0 {
+		for _, p := range o.Products {
+			if unpacked[p.ID] > 0 {
+				s.Lines = append(s.Lines, model.ShipmentLine{ProductID: p.ID, Quantity: unpacked[p.ID]})
+			}
+		}
+		if len(s.Lines) == 0 {
+			return nil, ErrNothingToShip
+		}
+	}
+	for _, l := range s.Lines {
+		if l.Quantity > unpacked[l.ProductID] {
+			return nil, ErrShipmentExceedsOrder
+		}
+	}
+	if s.Address == (model.Address{}) {
+		if o.ShippingAddress == nil {
+			return nil, ErrNoShippingAddress
+		}
+		s.Address = *o.ShippingAddress
+	}
+
+	address, err := addressToJSON(&s.Address)
+	if err != nil {
+		return nil, err
+	}
+	_, err = tx.ExecContext(
+		c,
+		`INSERT INTO shipments(id, order_id, carrier, tracking_number, status, address, created_at, updated_at)
+    VALUES($1, $2, $3, $4, $5, $6, $7, $8)`,
+		s.ID,
+		s.OrderID,
+		s.Carrier,
+		s.TrackingNumber,
+		s.Status,
+		address,
+		s.CreatedAt,
+		s.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	for _, l := range s.Lines {
+		_, err = tx.ExecContext(
+			c,
+			"INSERT INTO shipment_lines(shipment_id, product_id, quantity) VALUES($1, $2, $3)",
+			s.ID,
+			l.ProductID,
+			l.Quantity,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	shipments = append(shipments, s)
+	reason := fmt.Sprintf("shipment %s created", s.ID)
+	if err = advanceOrder(c, tx, o, model.FulfillmentStatus(left, shipments), actor, reason, s.CreatedAt); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+// UpdateShipment changes the carrier, the tracking number or the status of
+// a shipment. The order follows its shipments: it moves to shipped once they
+// all left and to delivered once they all arrived.
+func (r *postgresRepository) UpdateShipment(c context.Context, update *model.ShipmentUpdate) (o *model.Order, s *model.Shipment, err error) {
+	var orderID string
+	err = r.db.QueryRowContext(c, "SELECT order_id FROM shipments WHERE id = $1", update.ID).Scan(&orderID)
+	if err == sql.ErrNoRows {
+		return nil, nil, ErrShipmentNotFound
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tx, err := r.db.BeginTx(c, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	// shipments are only changed with their order locked
+	o, err = scanOrder(tx.QueryRowContext(c, "SELECT "+orderColumns+" FROM orders WHERE id = $1 FOR UPDATE", orderID))
+	if err == sql.ErrNoRows {
+		return nil, nil, ErrShipmentNotFound
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if err = loadProducts(c, tx, []*model.Order{o}); err != nil {
+		return nil, nil, err
+	}
+	shipments, err := loadShipments(c, tx, o.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, shipment := range shipments {
+		if shipment.ID == update.ID {
+			s = shipment
+		}
+	}
+	if s == nil {
+		return nil, nil, ErrShipmentNotFound
+	}
+
+	if update.Carrier != "" {
+		s.Carrier = update.Carrier
+	}
+	if update.TrackingNumber != "" {
+		s.TrackingNumber = update.TrackingNumber
+	}
+	if update.Status != "" && update.Status != s.Status {
+		if !model.CanShipmentTransition(s.Status, update.Status) {
+			return nil, nil, ErrInvalidShipmentTransition
+		}
+		s.Status = update.Status
+		at := update.At
+		switch s.Status {
+		case model.ShipmentShipped:
+			s.ShippedAt = &at
+		case model.ShipmentDelivered:
+			s.DeliveredAt = &at
+		}
+	}
+	s.UpdatedAt = update.At
+
+	_, err = tx.ExecContext(
+		c,
+		`UPDATE shipments SET carrier = $1, tracking_number = $2, status = $3,
+      shipped_at = $4, delivered_at = $5, updated_at = $6
+    WHERE id = $7`,
+		s.Carrier,
+		s.TrackingNumber,
+		s.Status,
+		s.ShippedAt,
+		s.DeliveredAt,
+		s.UpdatedAt,
+		s.ID,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	left, err := refundableQuantities(c, tx, o)
+	if err != nil {
+		return nil, nil, err
+	}
+	reason := fmt.Sprintf("shipment %s %s", s.ID, s.Status)
+	if err = advanceOrder(c, tx, o, model.FulfillmentStatus(left, shipments), update.Actor, reason, update.At); err != nil {
+		return nil, nil, err
+	}
+	return o, s, nil
+}
+
+// advanceOrder moves an order along the fulfillment path up to target and
+// records every step. Orders already past target, or that left fulfillment
+// by being cancelled or refunded, are left unchanged.
+func advanceOrder(c context.Context, tx *sql.Tx, o *model.Order, target, actor, reason string, at time.Time) error {
+	from, to := -1, -1
+	for i, status := range fulfillmentPath {
+		if status == o.Status {
+			from = i
+		}
+		if status == target {
+			to = i
+		}
+	}
+	if from < 0 || to <= from {
+		return nil
+	}
+	for _, next := range fulfillmentPath[from+1 : to+1] {
+		if _, err := tx.ExecContext(c, "UPDATE orders SET status = $1 WHERE id = $2", next, o.ID); err != nil {
+			return err
+		}
+		err := insertStatusChange(c, tx, &model.OrderStatusChange{
+			OrderID:   o.ID,
+			From:      o.Status,
+			To:        next,
+			Actor:     actor,
+			Reason:    reason,
+			ChangedAt: at,
+		})
+		if err != nil {
+			return err
+		}
+		o.Status = next
+	}
+	return nil
+}
+
+// cancelPendingShipments cancels the shipments of an order that haven't left
+// yet, for orders that won't be sent anymore
+func cancelPendingShipments(c context.Context, tx *sql.Tx, orderID string, at time.Time) error {
+	_, err := tx.ExecContext(
+		c,
+		"UPDATE shipments SET status = $1, updated_at = $2 WHERE order_id = $3 AND status = $4",
+		model.ShipmentCancelled,
+		at,
+		orderID,
+		model.ShipmentPending,
+	)
+	return err
+}
+
+// GetShipments returns the shipments of an order, oldest first
+func (r *postgresRepository) GetShipments(c context.Context, orderID string) ([]*model.Shipment, error) {
+	return loadShipments(c, r.db, orderID)
+}
+
+func loadShipments(c context.Context, q querier, orderID string) ([]*model.Shipment, error) {
+	rows, err := q.QueryContext(
+		c,
+		`SELECT `+shipmentColumns+`
+    FROM shipments s JOIN shipment_lines sl ON (s.id = sl.shipment_id)
+    WHERE s.order_id = $1
+    ORDER BY s.created_at, s.id, sl.product_id`,
+		orderID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	shipments := []*model.Shipment{}
+	var last *model.Shipment
+	for rows.Next() {
+		s := &model.Shipment{}
+		l := model.ShipmentLine{}
+		var address []byte
+		var shippedAt, deliveredAt sql.NullTime
+		if err := rows.Scan(
+			&s.ID,
+			&s.OrderID,
+			&s.Carrier,
+			&s.TrackingNumber,
+			&s.Status,
+			&address,
+			&shippedAt,
+			&deliveredAt,
+			&s.CreatedAt,
+			&s.UpdatedAt,
+			&l.ProductID,
+			&l.Quantity,
+		); err != nil {
+			return nil, err
+		}
+		if last == nil || last.ID != s.ID {
+			a, err := addressFromJSON(address)
+			if err != nil {
+				return nil, err
+			}
+			if a != nil {
+				s.Address = *a
+			}
+			if shippedAt.Valid {
+				s.ShippedAt = &shippedAt.Time
+			}
+			if deliveredAt.Valid {
+				s.DeliveredAt = &deliveredAt.Time
+			}
+			last = s
+			shipments = append(shipments, s)
+		}
+		last.Lines = append(last.Lines, l)
+	}
+	return shipments, rows.Err()
+}
@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/wignn/micro-3/order/model"
+)
+
+var (
+	ErrNotFound          = errors.New("order not found")
+	ErrInvalidTransition = errors.New("order can't move to that status")
+)
+
+// UpdateOrderStatus moves an order to change.To and records the change, the
+// order row is locked so that concurrent updates are checked one at a time
+func (r *postgresRepository) UpdateOrderStatus(c context.Context, change *model.OrderStatusChange) (o *model.Order, err error) {
+	tx, err := r.db.BeginTx(c, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	o, err = scanOrder(tx.QueryRowContext(c, "SELECT "+orderColumns+" FROM orders WHERE id = $1 FOR UPDATE", change.OrderID))
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !model.CanTransition(o.Status, change.To) {
+		return nil, ErrInvalidTransition
+	}
+
+	if _, err = tx.ExecContext(c, "UPDATE orders SET status = $1 WHERE id = $2", change.To, o.ID); err != nil {
+		return nil, err
+	}
+	change.From = o.Status
+	if err = insertStatusChange(c, tx, change); err != nil {
+		return nil, err
+	}
+	o.Status = change.To
+
+	// the coupons of a cancelled order can be used again
+	if change.To == model.OrderCancelled {
+		if _, err = tx.ExecContext(c, "DELETE FROM promotion_redemptions WHERE order_id = $1", o.ID); err != nil {
+			return nil, err
+		}
+	}
+	if change.To == model.OrderCancelled || change.To == model.OrderRefunded {
+		if err = cancelPendingShipments(c, tx, o.ID, change.ChangedAt); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = loadProducts(c, tx, []*model.Order{o}); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+// GetOrderStatusHistory returns the status changes of an order, oldest first
+func (r *postgresRepository) GetOrderStatusHistory(c context.Context, orderID string) ([]*model.OrderStatusChange, error) {
+	rows, err := r.db.QueryContext(
+		c,
+		`SELECT order_id, from_status, to_status, actor, reason, changed_at
+    FROM order_status_history WHERE order_id = $1
+    ORDER BY changed_at, id`,
+		orderID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	history := []*model.OrderStatusChange{}
+	for rows.Next() {
+		h := &model.OrderStatusChange{}
+		if err := rows.Scan(&h.OrderID, &h.From, &h.To, &h.Actor, &h.Reason, &h.ChangedAt); err != nil {
+			return nil, err
+		}
+		history = append(history, h)
+	}
+	return history, rows.Err()
+}
+
+func insertStatusChange(c context.Context, tx *sql.Tx, h *model.OrderStatusChange) error {
+	_, err := tx.ExecContext(
+		c,
+		`INSERT INTO order_status_history(order_id, from_status, to_status, actor, reason, changed_at)
+    VALUES($1, $2, $3, $4, $5, $6)`,
+		h.OrderID,
+		h.From,
+		h.To,
+		h.Actor,
+		h.Reason,
+		h.ChangedAt,
+	)
+	return err
+}
@@ -0,0 +1,101 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/wignn/micro-3/order/checkout"
+	"github.com/wignn/micro-3/order/genproto"
+	"github.com/wignn/micro-3/order/model"
+	"github.com/wignn/micro-3/order/repository"
+	"github.com/wignn/micro-3/order/service"
+)
+
+// Checkout places an order and authorizes its payment. A declined payment
+// or a stock shortage is not an error, the returned checkout has failed and
+// tells why. A checkout waiting for the provider is finished in the background.
+func (s *grpcServer) Checkout(c context.Context, r *genproto.CheckoutRequest) (*genproto.CheckoutResponse, error) {
+	if r.PaymentMethod == "" {
+		return nil, toStatus(checkout.ErrMissingPaymentMethod)
+	}
+	req, err := s.prepareOrder(c, r.AccountId, r.Currency, r.IdempotencyKey, r.Products, r.Coupons, r.ShippingAddress)
+	if err != nil {
+		return nil, err
+	}
+	if req.replay != nil {
+		return s.replayCheckout(c, req.replay.ID)
+	}
+
+	co := req.checkout()
+	co.PaymentMethod = r.PaymentMethod
+	co, err = s.checkout.Start(c, co)
+	if errors.Is(err, repository.ErrDuplicateKey) {
+		// a concurrent request with the same key won
+		order, err := s.service.ReplayOrder(c, req.key)
+		if err != nil || order == nil {
+			log.Println("Error replaying checkout: ", err)
+			return nil, errors.New("could not check out")
+		}
+		return s.replayCheckout(c, order.ID)
+	}
+	if co == nil {
+		log.Println("Error starting checkout: ", err)
+		return nil, errors.New("could not check out")
+	}
+	if errors.Is(err, service.ErrInvalidOrder) || errors.Is(err, repository.ErrPromotionUsedUp) {
+		// the checkout was undone, the coupons are reported like on PostOrder
+		return nil, toStatus(err)
+	}
+	if err != nil {
+		log.Printf("Checkout %s failed: %v\n", co.ID, err)
+	}
+	return &genproto.CheckoutResponse{Checkout: checkoutToProto(co)}, nil
+}
+
+// replayCheckout returns the checkout that placed an order, orders posted
+// without a checkout can't be replayed as one
+func (s *grpcServer) replayCheckout(c context.Context, orderID string) (*genproto.CheckoutResponse, error) {
+	co, err := s.checkout.GetCheckoutForOrder(c, orderID)
+	if errors.Is(err, repository.ErrNotFound) || (err == nil && co.PaymentMethod == "") {
+		return nil, toStatus(service.ErrIdempotencyMismatch)
+	}
+	if err != nil {
+		log.Println("Error replaying checkout: ", err)
+		return nil, toStatus(err)
+	}
+	return &genproto.CheckoutResponse{Checkout: checkoutToProto(co)}, nil
+}
+
+func (s *grpcServer) GetCheckoutStatus(c context.Context, r *genproto.GetCheckoutStatusRequest) (*genproto.GetCheckoutStatusResponse, error) {
+	co, err := s.checkout.GetCheckout(c, r.Id)
+	if err != nil {
+		log.Printf("Error getting checkout %s: %v\n", r.Id, err)
+		return nil, toStatus(err)
+	}
+	return &genproto.GetCheckoutStatusResponse{Checkout: checkoutToProto(co)}, nil
+}
+
+func checkoutToProto(co *model.Checkout) *genproto.CheckoutStatus {
+	cp := &genproto.CheckoutStatus{
+		Id:              co.ID,
+		OrderId:         co.OrderID,
+		AccountId:       co.AccountID,
+		Status:          co.Status,
+		Error:           co.Error,
+		PaymentIntentId: co.PaymentIntentID,
+		Steps:           []*genproto.CheckoutStep{},
+	}
+	cp.CreatedAt, _ = co.CreatedAt.MarshalBinary()
+	cp.UpdatedAt, _ = co.UpdatedAt.MarshalBinary()
+	for _, step := range co.Steps {
+		sp := &genproto.CheckoutStep{
+			Name:   step.Name,
+			Status: step.Status,
+			Error:  step.Error,
+		}
+		sp.UpdatedAt, _ = step.UpdatedAt.MarshalBinary()
+		cp.Steps = append(cp.Steps, sp)
+	}
+	return cp
+}
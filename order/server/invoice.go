@@ -0,0 +1,28 @@
+package server
+
+import (
+	"context"
+	"log"
+
+	"github.com/wignn/micro-3/order/genproto"
+)
+
+// GetInvoice returns the invoice of an order, invoices are issued when orders
+// are paid so orders that weren't have none
+func (s *grpcServer) GetInvoice(c context.Context, r *genproto.GetInvoiceRequest) (*genproto.GetInvoiceResponse, error) {
+	inv, err := s.service.GetInvoice(c, r.OrderId)
+	if err != nil {
+		log.Println("Error getting invoice: ", err)
+		return nil, toStatus(err)
+	}
+	res := &genproto.GetInvoiceResponse{Invoice: &genproto.Invoice{
+		Id:      inv.ID,
+		Number:  inv.Number,
+		OrderId: inv.OrderID,
+		Total:   moneyToProto(inv.Total),
+		HtmlUrl: inv.HTMLURL,
+		PdfUrl:  inv.PDFURL,
+	}}
+	res.Invoice.IssuedAt, _ = inv.IssuedAt.MarshalBinary()
+	return res, nil
+}
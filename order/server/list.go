@@ -0,0 +1,74 @@
+package server
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/wignn/micro-3/currency"
+	"github.com/wignn/micro-3/order/genproto"
+	"github.com/wignn/micro-3/order/model"
+)
+
+func (s *grpcServer) GetOrder(c context.Context, r *genproto.GetOrderRequest) (*genproto.GetOrderResponse, error) {
+	o, err := s.service.GetOrder(c, r.Id)
+	if err != nil {
+		log.Println("Error getting order: ", err)
+		return nil, toStatus(err)
+	}
+	return &genproto.GetOrderResponse{Order: orderToProto(o)}, nil
+}
+
+func (s *grpcServer) ListOrders(c context.Context, r *genproto.ListOrdersRequest) (*genproto.ListOrdersResponse, error) {
+	filter := model.OrderFilter{
+		AccountID:     r.AccountId,
+		Status:        r.Status,
+		CreatedAfter:  timeFromProto(r.CreatedAfter),
+		CreatedBefore: timeFromProto(r.CreatedBefore),
+	}
+	var err error
+	if filter.MinTotal, err = moneyFilterFromProto(r.MinTotal); err != nil {
+		return nil, toStatus(err)
+	}
+	if filter.MaxTotal, err = moneyFilterFromProto(r.MaxTotal); err != nil {
+		return nil, toStatus(err)
+	}
+
+	page, err := s.service.ListOrders(c, filter, r.After, int(r.First))
+	if err != nil {
+		log.Println("Error listing orders: ", err)
+		return nil, toStatus(err)
+	}
+	res := &genproto.ListOrdersResponse{
+		Orders:      []*genproto.Order{},
+		EndCursor:   page.EndCursor,
+		HasNextPage: page.HasNextPage,
+	}
+	for _, o := range page.Orders {
+		res.Orders = append(res.Orders, orderToProto(o))
+	}
+	return res, nil
+}
+
+// timeFromProto decodes an optional time, empty bytes are nil
+func timeFromProto(b []byte) *time.Time {
+	if len(b) == 0 {
+		return nil
+	}
+	t := &time.Time{}
+	if err := t.UnmarshalBinary(b); err != nil {
+		return nil
+	}
+	return t
+}
+
+func moneyFilterFromProto(m *genproto.Money) (*currency.Money, error) {
+	if m == nil {
+		return nil, nil
+	}
+	code, err := currency.Normalize(m.Currency)
+	if err != nil {
+		return nil, err
+	}
+	return &currency.Money{Amount: m.Amount, Currency: code}, nil
+}
@@ -0,0 +1,106 @@
+package server
+
+import (
+	"context"
+	"log"
+
+	"github.com/wignn/micro-3/currency"
+	"github.com/wignn/micro-3/order/genproto"
+	"github.com/wignn/micro-3/order/model"
+)
+
+func (s *grpcServer) CreatePromotion(c context.Context, r *genproto.CreatePromotionRequest) (*genproto.PromotionResponse, error) {
+	p, err := s.service.CreatePromotion(c, promotionFromProto(r.Promotion))
+	if err != nil {
+		log.Println("Error creating promotion: ", err)
+		return nil, toStatus(err)
+	}
+	return &genproto.PromotionResponse{Promotion: promotionToProto(p)}, nil
+}
+
+// SetPromotionActive turns a promotion on or off, promotions are never
+// deleted as orders refer to them
+func (s *grpcServer) SetPromotionActive(c context.Context, r *genproto.SetPromotionActiveRequest) (*genproto.PromotionResponse, error) {
+	p, err := s.service.SetPromotionActive(c, r.Id, r.Active)
+	if err != nil {
+		log.Printf("Error updating promotion %s: %v\n", r.Id, err)
+		return nil, toStatus(err)
+	}
+	return &genproto.PromotionResponse{Promotion: promotionToProto(p)}, nil
+}
+
+func (s *grpcServer) ListPromotions(c context.Context, r *genproto.ListPromotionsRequest) (*genproto.ListPromotionsResponse, error) {
+	promotions, err := s.service.ListPromotions(c)
+	if err != nil {
+		log.Println("Error listing promotions: ", err)
+		return nil, toStatus(err)
+	}
+	res := &genproto.ListPromotionsResponse{Promotions: []*genproto.Promotion{}}
+	for _, p := range promotions {
+		res.Promotions = append(res.Promotions, promotionToProto(p))
+	}
+	return res, nil
+}
+
+func promotionFromProto(pp *genproto.Promotion) *model.Promotion {
+	if pp == nil {
+		pp = &genproto.Promotion{}
+	}
+	p := &model.Promotion{
+		Code:              pp.Code,
+		Name:              pp.Name,
+		Kind:              pp.Kind,
+		PercentOff:        pp.PercentOff,
+		BuyQuantity:       pp.BuyQuantity,
+		GetQuantity:       pp.GetQuantity,
+		ProductIDs:        pp.ProductIds,
+		MaxUses:           pp.MaxUses,
+		MaxUsesPerAccount: pp.MaxUsesPerAccount,
+		Stackable:         pp.Stackable,
+		Priority:          pp.Priority,
+		Active:            true,
+	}
+	if pp.AmountOff != nil {
+		p.AmountOff = currency.Money{Amount: pp.AmountOff.Amount, Currency: pp.AmountOff.Currency}
+	}
+	if pp.MinSpend != nil {
+		p.MinSpend = currency.Money{Amount: pp.MinSpend.Amount, Currency: pp.MinSpend.Currency}
+	}
+	p.StartsAt = timeFromProto(pp.StartsAt)
+	p.EndsAt = timeFromProto(pp.EndsAt)
+	return p
+}
+
+func promotionToProto(p *model.Promotion) *genproto.Promotion {
+	pp := &genproto.Promotion{
+		Id:                p.ID,
+		Code:              p.Code,
+		Name:              p.Name,
+		Kind:              p.Kind,
+		PercentOff:        p.PercentOff,
+		BuyQuantity:       p.BuyQuantity,
+		GetQuantity:       p.GetQuantity,
+		ProductIds:        p.ProductIDs,
+		MaxUses:           p.MaxUses,
+		MaxUsesPerAccount: p.MaxUsesPerAccount,
+		Stackable:         p.Stackable,
+		Priority:          p.Priority,
+		Active:            p.Active,
+		Uses:              p.Uses,
+	}
+	if p.AmountOff.Amount > 0 {
+		pp.AmountOff = moneyToProto(p.AmountOff)
+	}
+	if p.MinSpend.Amount > 0 {
+		pp.MinSpend = moneyToProto(p.MinSpend)
+	}
+	if p.StartsAt != nil {
+		pp.StartsAt, _ = p.StartsAt.MarshalBinary()
+	}
+	if p.EndsAt != nil {
+		pp.EndsAt, _ = p.EndsAt.MarshalBinary()
+	}
+	pp.CreatedAt, _ = p.CreatedAt.MarshalBinary()
+	pp.UpdatedAt, _ = p.UpdatedAt.MarshalBinary()
+	return pp
+}
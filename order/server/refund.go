@@ -0,0 +1,72 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/wignn/micro-3/order/genproto"
+	"github.com/wignn/micro-3/order/model"
+)
+
+// CancelOrder cancels an order and gives its reserved stock back
+func (s *grpcServer) CancelOrder(c context.Context, r *genproto.CancelOrderRequest) (*genproto.CancelOrderResponse, error) {
+	o, err := s.service.CancelOrder(c, r.Id, r.Reason, r.Actor)
+	if err != nil {
+		log.Println("Error cancelling order: ", err)
+		return nil, toStatus(err)
+	}
+	if err := s.releaseReservation(c, o.ID); err != nil {
+		return nil, errors.New("order was cancelled but its stock could not be released, cancel it again to retry")
+	}
+	return &genproto.CancelOrderResponse{Order: orderToProto(o)}, nil
+}
+
+func (s *grpcServer) RefundOrder(c context.Context, r *genproto.RefundOrderRequest) (*genproto.RefundOrderResponse, error) {
+	lines := []model.RefundLine{}
+	for _, l := range r.Lines {
+		lines = append(lines, model.RefundLine{ProductID: l.ProductId, Quantity: l.Quantity})
+	}
+	o, refund, err := s.service.RefundOrder(c, r.Id, lines, r.Reason, r.Actor)
+	if err != nil {
+		log.Println("Error refunding order: ", err)
+		return nil, toStatus(err)
+	}
+	return &genproto.RefundOrderResponse{
+		Order:  orderToProto(o),
+		Refund: refundToProto(refund),
+	}, nil
+}
+
+func (s *grpcServer) GetOrderRefunds(c context.Context, r *genproto.GetOrderRefundsRequest) (*genproto.GetOrderRefundsResponse, error) {
+	refunds, err := s.service.GetOrderRefunds(c, r.Id)
+	if err != nil {
+		log.Println("Error getting order refunds: ", err)
+		return nil, toStatus(err)
+	}
+	res := &genproto.GetOrderRefundsResponse{Refunds: []*genproto.Refund{}}
+	for _, refund := range refunds {
+		res.Refunds = append(res.Refunds, refundToProto(refund))
+	}
+	return res, nil
+}
+
+func refundToProto(r *model.Refund) *genproto.Refund {
+	rp := &genproto.Refund{
+		Id:      r.ID,
+		OrderId: r.OrderID,
+		Amount:  moneyToProto(r.Amount),
+		Reason:  r.Reason,
+		Actor:   r.Actor,
+		Lines:   []*genproto.Refund_Line{},
+	}
+	rp.CreatedAt, _ = r.CreatedAt.MarshalBinary()
+	for _, l := range r.Lines {
+		rp.Lines = append(rp.Lines, &genproto.Refund_Line{
+			ProductId: l.ProductID,
+			Quantity:  l.Quantity,
+			Amount:    moneyToProto(l.Amount),
+		})
+	}
+	return rp
+}
@@ -9,46 +9,64 @@ import (
 
 	account "github.com/wignn/micro-3/account/client"
 	catalog "github.com/wignn/micro-3/catalog/client"
+	catalogProto "github.com/wignn/micro-3/catalog/genproto"
+	"github.com/wignn/micro-3/currency"
+	inventory "github.com/wignn/micro-3/inventory/client"
+	"github.com/wignn/micro-3/order/checkout"
 	"github.com/wignn/micro-3/order/genproto"
 	"github.com/wignn/micro-3/order/model"
+	"github.com/wignn/micro-3/order/repository"
 	"github.com/wignn/micro-3/order/service"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
 )
 
 type grpcServer struct {
-	service       service.OrderService
-	accountClient *account.AccountClient
-	catalogClient *catalog.CatalogClient
+	service         service.OrderService
+	accountClient   *account.AccountClient
+	catalogClient   *catalog.CatalogClient
+	inventoryClient *inventory.InventoryClient
+	checkout        *checkout.Orchestrator
 	genproto.UnimplementedOrderServiceServer
 }
 
-
-func ListenGRPC(s service.OrderService, accountURL, catalogURL string, port int) error {
+func ListenGRPC(s service.OrderService, o *checkout.Orchestrator, accountURL, catalogURL, inventoryURL string, port int) error {
 	accountClient, err := account.NewClient(accountURL)
 	if err != nil {
 		accountClient.Close()
 		return err
 	}
-	
+
 	catalogClient, err := catalog.NewClient(catalogURL)
 	if err != nil {
 		accountClient.Close()
 		return err
 	}
 
+	inventoryClient, err := inventory.NewClient(inventoryURL)
+	if err != nil {
+		accountClient.Close()
+		catalogClient.Close()
+		return err
+	}
+
 	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
 	if err != nil {
 		accountClient.Close()
-		catalogClient.Close()	
+		catalogClient.Close()
+		inventoryClient.Close()
 		return err
 	}
 
 	serv := grpc.NewServer()
 	genproto.RegisterOrderServiceServer(serv, &grpcServer{
-		service:       s,
-		accountClient: accountClient,
-		catalogClient: catalogClient,
+		service:         s,
+		accountClient:   accountClient,
+		catalogClient:   catalogClient,
+		inventoryClient: inventoryClient,
+		checkout:        o,
 	})
 
 	reflection.Register(serv)
@@ -56,76 +74,218 @@ func ListenGRPC(s service.OrderService, accountURL, catalogURL string, port int)
 }
 
 func (s *grpcServer) PostOrder(c context.Context, r *genproto.PostOrderRequest) (*genproto.PostOrderResponse, error) {
-		// Check if account exists
-	_, err := s.accountClient.GetAccount(c, r.AccountId)
+	req, err := s.prepareOrder(c, r.AccountId, r.Currency, r.IdempotencyKey, r.Products, r.Coupons, r.ShippingAddress)
+	if err != nil {
+		return nil, err
+	}
+	if req.replay != nil {
+		return &genproto.PostOrderResponse{Order: orderToProto(req.replay)}, nil
+	}
+
+	// Stock is reserved and the order created as a checkout so that a
+	// failure or crash half way doesn't leave stock reserved
+	co, err := s.checkout.Start(c, req.checkout())
+	if errors.Is(err, repository.ErrDuplicateKey) {
+		// a concurrent request with the same key won
+		return s.replayResponse(s.service.ReplayOrder(c, req.key))
+	}
+	if errors.Is(err, currency.ErrUnknownCurrency) || errors.Is(err, currency.ErrNoRate) {
+		return nil, err
+	}
+	if errors.Is(err, service.ErrInvalidOrder) || errors.Is(err, repository.ErrPromotionUsedUp) {
+		// a coupon that doesn't exist or doesn't apply
+		return nil, toStatus(err)
+	}
+	if status.Code(err) == codes.FailedPrecondition {
+		// not enough stock
+		return nil, status.Error(codes.FailedPrecondition, status.Convert(err).Message())
+	}
+	if err != nil {
+		log.Println("Error posting order: ", err)
+		return nil, errors.New("could not post order")
+	}
+
+	order, err := s.service.GetOrder(c, co.OrderID)
+	if err != nil {
+		log.Println("Error getting posted order: ", err)
+		return nil, errors.New("could not post order")
+	}
+	return &genproto.PostOrderResponse{
+		Order: orderToProto(order),
+	}, nil
+}
+
+// QuoteOrder prices a basket like PostOrder without reserving stock or
+// placing an order
+func (s *grpcServer) QuoteOrder(c context.Context, r *genproto.QuoteOrderRequest) (*genproto.QuoteOrderResponse, error) {
+	if r.AccountId != "" {
+		if _, err := s.accountClient.GetAccount(c, r.AccountId); err != nil {
+			log.Println("Error getting account: ", err)
+			return nil, errors.New("account not found")
+		}
+	}
+	lines, address, invalid := s.validateRequest(r.Products, r.ShippingAddress, false)
+	products, err := s.lookupProducts(c, lines, invalid)
+	if err != nil {
+		return nil, err
+	}
+
+	quote, err := s.service.QuoteOrder(c, r.AccountId, r.Currency, products, r.Coupons, address)
+	if errors.Is(err, currency.ErrUnknownCurrency) || errors.Is(err, currency.ErrNoRate) {
+		return nil, err
+	}
+	if errors.Is(err, service.ErrInvalidOrder) {
+		return nil, toStatus(err)
+	}
+	if err != nil {
+		log.Println("Error quoting order: ", err)
+		return nil, errors.New("could not quote order")
+	}
+	return &genproto.QuoteOrderResponse{Quote: orderToProto(quote)}, nil
+}
+
+// orderRequest is a checked order request with the catalog details of its products
+type orderRequest struct {
+	accountID string
+	currency  string
+	products  []model.OrderedProduct
+	coupons   []string
+	address   *model.Address
+	key       *model.IdempotencyKey
+	// replay is the order placed by an earlier request with the same key
+	replay *model.Order
+}
+
+func (r *orderRequest) checkout() *model.Checkout {
+	co := &model.Checkout{
+		AccountID:       r.accountID,
+		Currency:        r.currency,
+		Products:        r.products,
+		Coupons:         r.coupons,
+		ShippingAddress: r.address,
+	}
+	if r.key != nil {
+		co.IdempotencyKey = r.key.Key
+		co.Fingerprint = r.key.Fingerprint
+	}
+	return co
+}
+
+// prepareOrder checks the account and the requested lines and looks the
+// products up in the catalog, the returned errors are ready to be sent
+func (s *grpcServer) prepareOrder(
+	c context.Context,
+	accountID, currencyCode, idempotencyKey string,
+	requestedProducts []*genproto.PostOrderRequest_OrderProduct,
+	coupons []string,
+	shippingAddress *genproto.Address,
+) (*orderRequest, error) {
+	// Check if account exists
+	_, err := s.accountClient.GetAccount(c, accountID)
 	if err != nil {
 		log.Println("Error getting account: ", err)
 		return nil, errors.New("account not found")
 	}
 
-	// Get ordered products
+	lines, address, invalid := s.validateRequest(requestedProducts, shippingAddress, true)
+	req := &orderRequest{accountID: accountID, currency: currencyCode, coupons: coupons, address: address}
+
+	// A retried request returns the order placed by the first one
+	if invalid == nil && idempotencyKey != "" {
+		keyed := []model.OrderedProduct{}
+		for _, l := range lines {
+			keyed = append(keyed, model.OrderedProduct{ID: l.ProductID, Quantity: l.Quantity})
+		}
+		req.key, err = service.NewIdempotencyKey(idempotencyKey, accountID, currencyCode, keyed, coupons, address)
+		if err != nil {
+			return nil, toStatus(err)
+		}
+		req.replay, err = s.service.ReplayOrder(c, req.key)
+		if err != nil {
+			log.Println("Error replaying order: ", err)
+			return nil, toStatus(err)
+		}
+		if req.replay != nil {
+			return req, nil
+		}
+	}
+
+	req.products, err = s.lookupProducts(c, lines, invalid)
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// validateRequest merges duplicate lines and checks quantities and the
+// shipping address, a complete address is needed to ship an order but a
+// country is enough to price one. The violations are returned unsent so
+// lookupProducts can report them with the catalog ones.
+func (s *grpcServer) validateRequest(
+	requestedProducts []*genproto.PostOrderRequest_OrderProduct,
+	shippingAddress *genproto.Address,
+	complete bool,
+) ([]service.Line, *model.Address, error) {
+	requested := []service.Line{}
+	for i, p := range requestedProducts {
+		requested = append(requested, service.Line{Index: i, ProductID: p.ProductId, Quantity: p.Quantity})
+	}
+	lines, linesErr := s.service.ValidateLines(requested)
+	address := addressFromProto(shippingAddress)
+	var addressErr error
+	if address != nil {
+		addressErr = s.service.ValidateAddress(address, complete)
+	}
+	return lines, address, service.JoinViolations(linesErr, addressErr)
+}
+
+// lookupProducts prices the lines with the catalog details of their
+// products, unknown and unavailable products are reported together with the
+// violations already found in the request
+func (s *grpcServer) lookupProducts(c context.Context, lines []service.Line, invalid error) ([]model.OrderedProduct, error) {
+	if len(lines) == 0 {
+		return nil, toStatus(invalid)
+	}
 	productIDs := []string{}
-	for _, p := range r.Products {
-		productIDs = append(productIDs, p.ProductId)
+	for _, l := range lines {
+		productIDs = append(productIDs, l.ProductID)
 	}
 	orderedProducts, err := s.catalogClient.GetProducts(c, 0, 0, productIDs, "")
 	if err != nil {
 		log.Println("Error getting products: ", err)
+		if invalid != nil {
+			return nil, toStatus(invalid)
+		}
 		return nil, errors.New("products not found")
 	}
 
-	// Construct products
-	products := []model.OrderedProduct{}
+	// drafts, scheduled, archived and deleted products can't be ordered
+	catalogProducts := map[string]*catalogProto.Product{}
+	available := map[string]bool{}
 	for _, p := range orderedProducts {
-		product := model.OrderedProduct{
-			ID:          p.Id,
-			Quantity:    0,
-			Price:       p.Price,
-			Name:        p.Name,
-			Description: p.Description,
-		}
-		for _, rp := range r.Products {
-			if rp.ProductId == p.Id {
-				product.Quantity = rp.Quantity
-				break
-			}
-		}
-
-		if product.Quantity != 0 {
-			products = append(products, product)
-		}
+		catalogProducts[p.Id] = p
+		available[p.Id] = p.Visible
 	}
-
-	// Call service implementation
-	order, err := s.service.PostOrder(c, r.AccountId, products)
-	if err != nil {
-		log.Println("Error posting order: ", err)
-		return nil, errors.New("could not post order")
+	if err := service.JoinViolations(invalid, s.service.CheckProducts(lines, available)); err != nil {
+		return nil, toStatus(err)
 	}
 
-	// Make response order
-	orderProto := &genproto.Order{
-		Id:         order.ID,
-		AccountId:  order.AccountID,
-		TotalPrice: order.TotalPrice,
-		Products:   []*genproto.Order_OrderProduct{},
-	}
-	orderProto.CreatedAt, _ = order.CreatedAt.MarshalBinary()
-	for _, p := range order.Products {
-		orderProto.Products = append(orderProto.Products, &genproto.Order_OrderProduct{
-			Id:          p.ID,
+	// Construct products
+	products := []model.OrderedProduct{}
+	for _, l := range lines {
+		p := catalogProducts[l.ProductID]
+		products = append(products, model.OrderedProduct{
+			ID:          p.Id,
+			Quantity:    l.Quantity,
+			Price:       currency.FromFloat(p.Price, p.Currency),
 			Name:        p.Name,
 			Description: p.Description,
-			Price:       p.Price,
-			Quantity:    p.Quantity,
+			WeightGrams: p.WeightGrams,
 		})
 	}
-	return &genproto.PostOrderResponse{
-		Order: orderProto,
-	}, nil
+	return products, nil
 }
 
-
 func (s *grpcServer) GetOrdersForAccount(
 	ctx context.Context,
 	r *genproto.GetOrdersForAccountRequest,
@@ -137,65 +297,110 @@ func (s *grpcServer) GetOrdersForAccount(
 		return nil, err
 	}
 
-	// Get all ordered products
-	productIDMap := map[string]bool{}
+	// lines carry the name and price they were ordered at, later catalog
+	// edits don't change past orders
+	orders := []*genproto.Order{}
 	for _, o := range accountOrders {
-		for _, p := range o.Products {
-			productIDMap[p.ID] = true
-		}
-	}
-	productIDs := []string{}
-	for id := range productIDMap {
-		productIDs = append(productIDs, id)
-	}
-	products, err := s.catalogClient.GetProducts(ctx, 0, 0, productIDs, "")
-	if err != nil {
-		log.Println("Error getting account products: ", err)
-		return nil, err
+		orders = append(orders, orderToProto(o))
 	}
+	return &genproto.GetOrdersForAccountResponse{Orders: orders}, nil
+}
 
-	// Construct orders
-	orders := []*genproto.Order{}
-	for _, o := range accountOrders {
-		// Encode order
-		op := &genproto.Order{
-			AccountId:  o.AccountID,
-			Id:         o.ID,
-			TotalPrice: o.TotalPrice,
-			Products:   []*genproto.Order_OrderProduct{},
+func orderToProto(o *model.Order) *genproto.Order {
+	op := &genproto.Order{
+		Id:              o.ID,
+		AccountId:       o.AccountID,
+		Total:           moneyToProto(o.TotalPrice),
+		Currency:        o.Currency,
+		Status:          o.Status,
+		Products:        []*genproto.Order_OrderProduct{},
+		Subtotal:        moneyToProto(o.Subtotal()),
+		Discount:        moneyToProto(o.Discount()),
+		Shipping:        moneyToProto(o.Shipping),
+		ShippingTax:     moneyToProto(o.ShippingTax),
+		Tax:             moneyToProto(o.Tax),
+		TaxInclusive:    o.TaxInclusive,
+		ShippingAddress: addressToProto(o.ShippingAddress),
+	}
+	op.CreatedAt, _ = o.CreatedAt.MarshalBinary()
+	for _, p := range o.Products {
+		pp := &genproto.Order_OrderProduct{
+			Id:          p.ID,
+			Name:        p.Name,
+			Description: p.Description,
+			UnitPrice:   moneyToProto(p.Price),
+			Quantity:    p.Quantity,
+			Discounts:   []*genproto.Discount{},
+			Tax:         moneyToProto(p.Tax),
+			WeightGrams: p.WeightGrams,
 		}
-		op.CreatedAt, _ = o.CreatedAt.MarshalBinary()
-
-		// Decorate orders with products
-		for _, product := range o.Products {
-			// Populate product fields
-			for _, p := range products {
-				if p.Id == product.ID {
-					product.Name = p.Name
-					product.Description = p.Description
-					product.Price = p.Price
-					break
-				}
-			}
-
-			op.Products = append(op.Products, &genproto.Order_OrderProduct{
-				Id:          product.ID,
-				Name:        product.Name,
-				Description: product.Description,
-				Price:       product.Price,
-				Quantity:    product.Quantity,
+		for _, d := range p.Discounts {
+			pp.Discounts = append(pp.Discounts, &genproto.Discount{
+				PromotionId: d.PromotionID,
+				Code:        d.Code,
+				Name:        d.Name,
+				Amount:      moneyToProto(d.Amount),
 			})
 		}
+		op.Products = append(op.Products, pp)
+	}
+	return op
+}
 
-		orders = append(orders, op)
+func moneyToProto(m currency.Money) *genproto.Money {
+	return &genproto.Money{Amount: m.Amount, Currency: m.Currency}
+}
+
+func addressFromProto(a *genproto.Address) *model.Address {
+	if a == nil {
+		return nil
+	}
+	return &model.Address{
+		Name:       a.Name,
+		Line1:      a.Line1,
+		Line2:      a.Line2,
+		City:       a.City,
+		Region:     a.Region,
+		PostalCode: a.PostalCode,
+		Country:    a.Country,
 	}
-	return &genproto.GetOrdersForAccountResponse{Orders: orders}, nil
 }
 
+func addressToProto(a *model.Address) *genproto.Address {
+	if a == nil {
+		return nil
+	}
+	return &genproto.Address{
+		Name:       a.Name,
+		Line1:      a.Line1,
+		Line2:      a.Line2,
+		City:       a.City,
+		Region:     a.Region,
+		PostalCode: a.PostalCode,
+		Country:    a.Country,
+	}
+}
+
+func (s *grpcServer) replayResponse(order *model.Order, err error) (*genproto.PostOrderResponse, error) {
+	if err != nil {
+		log.Println("Error replaying order: ", err)
+		return nil, toStatus(err)
+	}
+	if order == nil {
+		return nil, errors.New("could not post order")
+	}
+	return &genproto.PostOrderResponse{Order: orderToProto(order)}, nil
+}
 
+// DeleteOrder purges an order with its history and refunds, it is meant for
+// admins removing test or erroneous data. Customers cancel orders instead.
 func (s *grpcServer) DeleteOrder(ctx context.Context, r *genproto.DeleteOrderRequest) (*genproto.DeleteOrderResponse, error) {
+	if err := s.releaseReservation(ctx, r.Id); err != nil {
+		return nil, errors.New("could not delete order")
+	}
+
 	err := s.service.DeleteOrder(ctx, r.Id)
-	
+
 	if err != nil {
 		log.Println("Error deleting order: ", err)
 		return nil, errors.New("could not delete order")
@@ -203,7 +408,39 @@ func (s *grpcServer) DeleteOrder(ctx context.Context, r *genproto.DeleteOrderReq
 
 	return &genproto.DeleteOrderResponse{
 		DeletedId: r.Id,
-		Message:   "Order deleted successfully",
+		Message:   "Order purged successfully",
 		Success:   true,
 	}, nil
-}
\ No newline at end of file
+}
+
+// releaseReservation releases the reserved stock of an order, orders placed
+// before stock was tracked have no reservation and committed stock has
+// already left the warehouse
+func (s *grpcServer) releaseReservation(ctx context.Context, orderID string) error {
+	_, err := s.inventoryClient.Release(ctx, orderID)
+	switch status.Code(err) {
+	case codes.OK:
+	case codes.NotFound, codes.FailedPrecondition:
+		log.Printf("Not releasing stock of order %s: %v\n", orderID, err)
+	default:
+		log.Println("Error releasing stock: ", err)
+		return err
+	}
+	return nil
+}
+
+func (s *grpcServer) ExportOrderLines(r *genproto.ExportOrderLinesRequest, stream genproto.OrderService_ExportOrderLinesServer) error {
+	err := s.service.ExportOrderLines(stream.Context(), func(l *model.OrderLine) error {
+		return stream.Send(&genproto.OrderLine{
+			OrderId:   l.OrderID,
+			AccountId: l.AccountID,
+			ProductId: l.ProductID,
+			Quantity:  l.Quantity,
+		})
+	})
+	if err != nil {
+		log.Println("Error exporting order lines: ", err)
+		return err
+	}
+	return nil
+}
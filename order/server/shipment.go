@@ -0,0 +1,79 @@
+package server
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/wignn/micro-3/order/genproto"
+	"github.com/wignn/micro-3/order/model"
+)
+
+func (s *grpcServer) CreateShipment(c context.Context, r *genproto.CreateShipmentRequest) (*genproto.ShipmentResponse, error) {
+	lines := []model.ShipmentLine{}
+	for _, l := range r.Lines {
+		lines = append(lines, model.ShipmentLine{ProductID: l.ProductId, Quantity: l.Quantity})
+	}
+	o, shipment, err := s.service.CreateShipment(c, r.OrderId, r.Carrier, r.TrackingNumber, lines, addressFromProto(r.ShippingAddress), r.Actor)
+	if err != nil {
+		log.Println("Error creating shipment: ", err)
+		return nil, toStatus(err)
+	}
+	return &genproto.ShipmentResponse{
+		Order:    orderToProto(o),
+		Shipment: shipmentToProto(shipment),
+	}, nil
+}
+
+func (s *grpcServer) UpdateShipment(c context.Context, r *genproto.UpdateShipmentRequest) (*genproto.ShipmentResponse, error) {
+	var at time.Time
+	if t := timeFromProto(r.At); t != nil {
+		at = *t
+	}
+	o, shipment, err := s.service.UpdateShipment(c, r.Id, r.Status, r.Carrier, r.TrackingNumber, at, r.Actor)
+	if err != nil {
+		log.Println("Error updating shipment: ", err)
+		return nil, toStatus(err)
+	}
+	return &genproto.ShipmentResponse{
+		Order:    orderToProto(o),
+		Shipment: shipmentToProto(shipment),
+	}, nil
+}
+
+func (s *grpcServer) GetOrderShipments(c context.Context, r *genproto.GetOrderShipmentsRequest) (*genproto.GetOrderShipmentsResponse, error) {
+	shipments, err := s.service.GetShipments(c, r.Id)
+	if err != nil {
+		log.Println("Error getting order shipments: ", err)
+		return nil, toStatus(err)
+	}
+	res := &genproto.GetOrderShipmentsResponse{Shipments: []*genproto.Shipment{}}
+	for _, shipment := range shipments {
+		res.Shipments = append(res.Shipments, shipmentToProto(shipment))
+	}
+	return res, nil
+}
+
+func shipmentToProto(s *model.Shipment) *genproto.Shipment {
+	sp := &genproto.Shipment{
+		Id:             s.ID,
+		OrderId:        s.OrderID,
+		Carrier:        s.Carrier,
+		TrackingNumber: s.TrackingNumber,
+		Status:         s.Status,
+		Address:        addressToProto(&s.Address),
+		Lines:          []*genproto.Shipment_Line{},
+	}
+	if s.ShippedAt != nil {
+		sp.ShippedAt, _ = s.ShippedAt.MarshalBinary()
+	}
+	if s.DeliveredAt != nil {
+		sp.DeliveredAt, _ = s.DeliveredAt.MarshalBinary()
+	}
+	sp.CreatedAt, _ = s.CreatedAt.MarshalBinary()
+	sp.UpdatedAt, _ = s.UpdatedAt.MarshalBinary()
+	for _, l := range s.Lines {
+		sp.Lines = append(sp.Lines, &genproto.Shipment_Line{ProductId: l.ProductID, Quantity: l.Quantity})
+	}
+	return sp
+}
@@ -0,0 +1,113 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/wignn/micro-3/currency"
+	"github.com/wignn/micro-3/order/checkout"
+	"github.com/wignn/micro-3/order/genproto"
+	"github.com/wignn/micro-3/order/promotion"
+	"github.com/wignn/micro-3/order/repository"
+	"github.com/wignn/micro-3/order/service"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// toStatus maps service errors to grpc codes so that callers can tell a
+// missing order, a forbidden status change or a reused idempotency key from a failure
+func toStatus(err error) error {
+	var verr *service.ValidationError
+	switch {
+	case errors.As(err, &verr):
+		return validationStatus(verr)
+	case errors.Is(err, repository.ErrNotFound),
+		errors.Is(err, repository.ErrPromotionNotFound),
+		errors.Is(err, repository.ErrShipmentNotFound),
+		errors.Is(err, repository.ErrInvoiceNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, repository.ErrDuplicateCode):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, repository.ErrInvalidTransition),
+		errors.Is(err, repository.ErrNotRefundable),
+		errors.Is(err, repository.ErrNothingToRefund),
+		errors.Is(err, repository.ErrRefundExceedsOrder),
+		errors.Is(err, repository.ErrPromotionUsedUp),
+		errors.Is(err, repository.ErrNotShippable),
+		errors.Is(err, repository.ErrNothingToShip),
+		errors.Is(err, repository.ErrShipmentExceedsOrder),
+		errors.Is(err, repository.ErrNoShippingAddress),
+		errors.Is(err, repository.ErrInvalidShipmentTransition),
+		errors.Is(err, repository.ErrNotInvoiceable):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, repository.ErrInvoiceNumberTaken):
+		return status.Error(codes.Aborted, err.Error())
+	case errors.Is(err, service.ErrIdempotencyMismatch):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, service.ErrInvalidIdempotencyKey),
+		errors.Is(err, service.ErrMissingOrderID),
+		errors.Is(err, service.ErrInvalidStatus),
+		errors.Is(err, service.ErrInvalidCursor),
+		errors.Is(err, service.ErrInvalidFilter),
+		errors.Is(err, service.ErrInvalidRefundLine),
+		errors.Is(err, service.ErrMissingShipmentID),
+		errors.Is(err, service.ErrMissingCarrier),
+		errors.Is(err, service.ErrInvalidShipmentStatus),
+		errors.Is(err, service.ErrInvalidShipmentLine),
+		errors.Is(err, checkout.ErrMissingPaymentMethod),
+		errors.Is(err, service.ErrMissingPromotionID),
+		errors.Is(err, promotion.ErrInvalidPromotion),
+		errors.Is(err, currency.ErrUnknownCurrency):
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return err
+}
+
+// validationStatus reports every violation as a BadRequest detail so that
+// clients can point at the offending lines
+func validationStatus(verr *service.ValidationError) error {
+	st := status.New(codes.InvalidArgument, verr.Error())
+	details := &errdetails.BadRequest{}
+	for _, v := range verr.Violations {
+		details.FieldViolations = append(details.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       v.Field,
+			Description: v.Description,
+		})
+	}
+	if withDetails, err := st.WithDetails(details); err == nil {
+		st = withDetails
+	}
+	return st.Err()
+}
+
+func (s *grpcServer) UpdateOrderStatus(c context.Context, r *genproto.UpdateOrderStatusRequest) (*genproto.UpdateOrderStatusResponse, error) {
+	o, err := s.service.UpdateOrderStatus(c, r.Id, r.Status, r.Actor, r.Reason)
+	if err != nil {
+		log.Println("Error updating order status: ", err)
+		return nil, toStatus(err)
+	}
+	return &genproto.UpdateOrderStatusResponse{Order: orderToProto(o)}, nil
+}
+
+func (s *grpcServer) GetOrderStatusHistory(c context.Context, r *genproto.GetOrderStatusHistoryRequest) (*genproto.GetOrderStatusHistoryResponse, error) {
+	history, err := s.service.GetOrderStatusHistory(c, r.Id)
+	if err != nil {
+		log.Println("Error getting order status history: ", err)
+		return nil, toStatus(err)
+	}
+	res := &genproto.GetOrderStatusHistoryResponse{History: []*genproto.OrderStatusChange{}}
+	for _, h := range history {
+		change := &genproto.OrderStatusChange{
+			OrderId:    h.OrderID,
+			FromStatus: h.From,
+			ToStatus:   h.To,
+			Actor:      h.Actor,
+			Reason:     h.Reason,
+		}
+		change.ChangedAt, _ = h.ChangedAt.MarshalBinary()
+		res.History = append(res.History, change)
+	}
+	return res, nil
+}
@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/wignn/micro-3/order/model"
+	"github.com/wignn/micro-3/order/repository"
+)
+
+const maxIdempotencyKeyLength = 128
+
+var (
+	ErrInvalidIdempotencyKey = errors.New("idempotency key must be 1 to 128 printable characters")
+	ErrIdempotencyMismatch   = errors.New("idempotency key was already used for a different order")
+)
+
+// NewIdempotencyKey keys an order request, the fingerprint covers the account,
+// currency, ordered quantities, coupons and shipping address and ignores the
+// order of the products and coupons
+func NewIdempotencyKey(key, accountID, code string, products []model.OrderedProduct, coupons []string, address *model.Address) (*model.IdempotencyKey, error) {
+	if key == "" || len(key) > maxIdempotencyKeyLength {
+		return nil, ErrInvalidIdempotencyKey
+	}
+	for _, r := range key {
+		if r < 0x21 || r > 0x7e {
+			return nil, ErrInvalidIdempotencyKey
+		}
+	}
+
+	quantities := map[string]uint32{}
+	for _, p := range products {
+		quantities[p.ID] += p.Quantity
+	}
+	ids := []string{}
+	for id := range quantities {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\n%s\n", accountID, strings.ToUpper(strings.TrimSpace(code)))
+	for _, id := range ids {
+		fmt.Fprintf(h, "%s:%d\n", id, quantities[id])
+	}
+	for _, coupon := range normalizeCoupons(coupons) {
+		fmt.Fprintf(h, "coupon:%s\n", coupon)
+	}
+	if address != nil {
+		fmt.Fprintf(h, "address:%q %q %q %q %q %q %q\n", address.Name, address.Line1, address.Line2, address.City, address.Region, address.PostalCode, address.Country)
+	}
+	return &model.IdempotencyKey{
+		AccountID:   accountID,
+		Key:         key,
+		Fingerprint: hex.EncodeToString(h.Sum(nil)),
+	}, nil
+}
+
+// ReplayOrder returns the order placed with an idempotency key, or nil when
+// the key wasn't used yet
+func (s *orderService) ReplayOrder(c context.Context, key *model.IdempotencyKey) (*model.Order, error) {
+	stored, err := s.repository.GetIdempotencyKey(c, key.AccountID, key.Key)
+	if err == repository.ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if stored.Fingerprint != key.Fingerprint {
+		return nil, ErrIdempotencyMismatch
+	}
+	return s.repository.GetOrder(c, stored.OrderID)
+}
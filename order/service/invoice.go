@@ -0,0 +1,176 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/segmentio/ksuid"
+	account "github.com/wignn/micro-3/account/client"
+	"github.com/wignn/micro-3/order/invoice"
+	"github.com/wignn/micro-3/order/model"
+	"github.com/wignn/micro-3/order/repository"
+)
+
+// maxInvoiceAttempts bounds how often an invoice is rendered again because
+// its number was taken meanwhile
+const maxInvoiceAttempts = 5
+
+// InvoiceConfig names the seller on invoices, which are numbered
+// Prefix-YEAR-000001, buyers are looked up in Accounts
+type InvoiceConfig struct {
+	Prefix   string
+	Seller   model.Party
+	Accounts *account.AccountClient
+}
+
+// GetInvoice returns the invoice issued for an order,
+// repository.ErrInvoiceNotFound until one is issued
+func (s *orderService) GetInvoice(c context.Context, orderID string) (*model.Invoice, error) {
+	if orderID == "" {
+		return nil, ErrMissingOrderID
+	}
+	inv, err := s.repository.GetInvoiceForOrder(c, orderID)
+	if err != nil {
+		return nil, err
+	}
+	s.invoiceURLs(inv)
+	return inv, nil
+}
+
+// IssueInvoice issues the invoice of a paid order to the account that placed
+// it, its HTML and PDF documents are rendered and stored before the invoice
+// is numbered for good. Invoices are dated when they are issued, so numbers
+// follow the order invoices were issued in, also for orders invoiced after
+// a retry. An order has a single invoice, the one already issued is returned
+// unchanged.
+func (s *orderService) IssueInvoice(c context.Context, orderID string) (*model.Invoice, error) {
+	o, err := s.GetOrder(c, orderID)
+	if err != nil {
+		return nil, err
+	}
+	existing, err := s.repository.GetInvoiceForOrder(c, o.ID)
+	if err == nil {
+		s.invoiceURLs(existing)
+		return existing, nil
+	}
+	if err != repository.ErrInvoiceNotFound {
+		return nil, err
+	}
+	if !model.Invoiceable(o.Status) {
+		return nil, repository.ErrNotInvoiceable
+	}
+	buyer, err := s.buyer(c, o)
+	if err != nil {
+		return nil, err
+	}
+
+	// the number is only taken when the invoice is saved, invoices issued at
+	// the same time render again with the next number
+	for attempt := 0; attempt < maxInvoiceAttempts; attempt++ {
+		inv := &model.Invoice{
+			ID:       ksuid.New().String(),
+			OrderID:  o.ID,
+			IssuedAt: time.Now().UTC(),
+			Total:    o.TotalPrice,
+			Order:    o,
+			Seller:   s.invoicing.Seller,
+			Buyer:    buyer,
+		}
+		sequence, err := s.repository.NextInvoiceSequence(c, inv.IssuedAt.Year())
+		if err != nil {
+			return nil, err
+		}
+		if err := s.storeInvoice(c, inv, sequence); err != nil {
+			return nil, err
+		}
+		saved, err := s.repository.CreateInvoice(c, inv, sequence)
+		if err != nil || saved.ID != inv.ID {
+			s.deleteInvoice(c, inv)
+		}
+		if err == repository.ErrInvoiceNumberTaken {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		s.invoiceURLs(saved)
+		return saved, nil
+	}
+	return nil, repository.ErrInvoiceNumberTaken
+}
+
+// IssuePendingInvoices issues the invoices of up to limit paid orders that
+// have none, because issuing failed when they were paid or they were paid
+// before invoices existed. It returns how many were issued.
+func (s *orderService) IssuePendingInvoices(c context.Context, limit int) (int, error) {
+	ids, err := s.repository.ListUninvoicedOrders(c, limit)
+	if err != nil {
+		return 0, err
+	}
+	issued := 0
+	for _, id := range ids {
+		if _, err := s.IssueInvoice(c, id); err != nil {
+			log.Printf("failed to issue invoice of order %s: %v\n", id, err)
+			continue
+		}
+		issued++
+	}
+	return issued, nil
+}
+
+// buyer names the account that placed an order and where it is shipped
+func (s *orderService) buyer(c context.Context, o *model.Order) (model.Party, error) {
+	a, err := s.invoicing.Accounts.GetAccount(c, o.AccountID)
+	if err != nil {
+		return model.Party{}, err
+	}
+	buyer := model.Party{Name: a.Name, Email: a.Email}
+	if o.ShippingAddress != nil {
+		buyer.Address = o.ShippingAddress.Lines()
+	}
+	return buyer, nil
+}
+
+// storeInvoice numbers an invoice and stores its documents. The keys hold the
+// invoice id so that the URLs of invoices can't be guessed from their numbers.
+func (s *orderService) storeInvoice(c context.Context, inv *model.Invoice, sequence int64) error {
+	inv.Number = model.InvoiceNumber(s.invoicing.Prefix, inv.IssuedAt.Year(), sequence)
+	inv.HTMLKey = fmt.Sprintf("invoices/%s/%s.html", inv.ID, inv.Number)
+	inv.PDFKey = fmt.Sprintf("invoices/%s/%s.pdf", inv.ID, inv.Number)
+
+	html, err := invoice.RenderHTML(inv)
+	if err != nil {
+		return err
+	}
+	pdf, err := invoice.RenderPDF(inv)
+	if err != nil {
+		return err
+	}
+	if _, err := s.invoices.Put(c, inv.HTMLKey, bytes.NewReader(html), "text/html; charset=utf-8"); err != nil {
+		return err
+	}
+	if _, err := s.invoices.Put(c, inv.PDFKey, bytes.NewReader(pdf), "application/pdf"); err != nil {
+		if derr := s.invoices.Delete(c, inv.HTMLKey); derr != nil {
+			log.Printf("failed to delete invoice blob %s: %v\n", inv.HTMLKey, derr)
+		}
+		return err
+	}
+	return nil
+}
+
+// deleteInvoice removes the documents of an invoice that wasn't saved
+func (s *orderService) deleteInvoice(c context.Context, inv *model.Invoice) {
+	for _, key := range []string{inv.HTMLKey, inv.PDFKey} {
+		if err := s.invoices.Delete(c, key); err != nil {
+			log.Printf("failed to delete invoice blob %s: %v\n", key, err)
+		}
+	}
+}
+
+func (s *orderService) invoiceURLs(inv *model.Invoice) {
+	inv.HTMLURL = s.invoices.URL(inv.HTMLKey)
+	inv.PDFURL = s.invoices.URL(inv.PDFKey)
+}
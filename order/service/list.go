@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"strings"
+
+	"github.com/wignn/micro-3/order/model"
+)
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+var (
+	ErrInvalidCursor = errors.New("invalid cursor")
+	ErrInvalidFilter = errors.New("invalid order filter")
+)
+
+func (s *orderService) GetOrder(c context.Context, id string) (*model.Order, error) {
+	if id == "" {
+		return nil, ErrMissingOrderID
+	}
+	return s.repository.GetOrder(c, id)
+}
+
+// ListOrders returns a page of orders matching filter, newest first. after is
+// the EndCursor of the previous page and first the page size, 20 when zero.
+func (s *orderService) ListOrders(c context.Context, filter model.OrderFilter, after string, first int) (*model.OrderPage, error) {
+	if first <= 0 {
+		first = defaultPageSize
+	}
+	if first > maxPageSize {
+		first = maxPageSize
+	}
+	afterID, err := decodeCursor(after)
+	if err != nil {
+		return nil, err
+	}
+	if err := normalizeFilter(&filter); err != nil {
+		return nil, err
+	}
+
+	// one more order than asked tells whether there is a next page
+	orders, err := s.repository.ListOrders(c, filter, afterID, first+1)
+	if err != nil {
+		return nil, err
+	}
+	page := &model.OrderPage{Orders: orders}
+	if len(orders) > first {
+		page.Orders, page.HasNextPage = orders[:first], true
+	}
+	if len(page.Orders) > 0 {
+		page.EndCursor = encodeCursor(page.Orders[len(page.Orders)-1].ID)
+	}
+	return page, nil
+}
+
+func normalizeFilter(f *model.OrderFilter) error {
+	if f.Status != "" {
+		f.Status = strings.ToLower(strings.TrimSpace(f.Status))
+		if !model.ValidOrderStatus(f.Status) {
+			return ErrInvalidStatus
+		}
+	}
+	if f.CreatedAfter != nil && f.CreatedBefore != nil && !f.CreatedAfter.Before(*f.CreatedBefore) {
+		return ErrInvalidFilter
+	}
+	if f.MinTotal != nil && f.MaxTotal != nil {
+		if f.MinTotal.Currency != f.MaxTotal.Currency || f.MinTotal.Amount > f.MaxTotal.Amount {
+			return ErrInvalidFilter
+		}
+	}
+	return nil
+}
+
+// cursors are opaque to clients, they wrap the id of the last order of a page
+func encodeCursor(id string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(id))
+}
+
+func decodeCursor(cursor string) (string, error) {
+	if cursor == "" {
+		return "", nil
+	}
+	id, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil || len(id) == 0 {
+		return "", ErrInvalidCursor
+	}
+	return string(id), nil
+}
@@ -0,0 +1,175 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/segmentio/ksuid"
+	"github.com/wignn/micro-3/currency"
+	"github.com/wignn/micro-3/order/model"
+	"github.com/wignn/micro-3/order/promotion"
+)
+
+var ErrMissingPromotionID = errors.New("promotion id is required")
+
+// CreatePromotion stores a promotion, coupon codes are case insensitive and
+// amounts without a currency are in the default currency
+func (s *orderService) CreatePromotion(c context.Context, p *model.Promotion) (*model.Promotion, error) {
+	p.ID = ksuid.New().String()
+	p.Code = promotion.NormalizeCode(p.Code)
+	if p.Kind == model.PromotionBuyXGetY && p.PercentOff == 0 {
+		// buy X get Y free
+		p.PercentOff = 100
+	}
+	code := p.AmountOff.Currency
+	if code == "" {
+		code = p.MinSpend.Currency
+	}
+	if code == "" {
+		code = s.defaultCurrency
+	}
+	code, err := currency.Normalize(code)
+	if err != nil {
+		return nil, err
+	}
+	if (p.AmountOff.Currency != "" && p.AmountOff.Currency != code) || (p.MinSpend.Currency != "" && p.MinSpend.Currency != code) {
+		return nil, fmt.Errorf("%w: amountOff and minSpend must be in the same currency", promotion.ErrInvalidPromotion)
+	}
+	p.AmountOff.Currency, p.MinSpend.Currency = code, code
+	if err := promotion.Validate(p); err != nil {
+		return nil, err
+	}
+	p.CreatedAt = time.Now().UTC()
+	p.UpdatedAt = p.CreatedAt
+	if err := s.repository.CreatePromotion(c, p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (s *orderService) SetPromotionActive(c context.Context, id string, active bool) (*model.Promotion, error) {
+	if id == "" {
+		return nil, ErrMissingPromotionID
+	}
+	return s.repository.SetPromotionActive(c, id, active, time.Now().UTC())
+}
+
+func (s *orderService) ListPromotions(c context.Context) ([]*model.Promotion, error) {
+	return s.repository.ListPromotions(c)
+}
+
+// normalizeCoupons uppercases coupon codes, drops empty and repeated ones
+// and sorts them
+func normalizeCoupons(coupons []string) []string {
+	seen := map[string]bool{}
+	codes := []string{}
+	for _, coupon := range coupons {
+		code := promotion.NormalizeCode(coupon)
+		if code == "" || seen[code] {
+			continue
+		}
+		seen[code] = true
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+// applyPromotions discounts the lines of an order priced in its currency
+// with the running automatic promotions and the given coupons. Coupons that
+// don't exist or don't apply are reported as violations, automatic
+// promotions that don't apply are skipped.
+func (s *orderService) applyPromotions(c context.Context, o *model.Order, coupons []string) error {
+	promotions, err := s.repository.GetAutomaticPromotions(c, o.CreatedAt)
+	if err != nil {
+		return err
+	}
+	automatic := len(promotions)
+	if len(coupons) > 0 {
+		byCode, err := s.repository.GetPromotionsByCode(c, normalizeCoupons(coupons))
+		if err != nil {
+			return err
+		}
+		found := map[string]*model.Promotion{}
+		for _, p := range byCode {
+			found[p.Code] = p
+		}
+		verr := &ValidationError{}
+		for i, coupon := range coupons {
+			p, ok := found[promotion.NormalizeCode(coupon)]
+			if !ok {
+				verr.add(fmt.Sprintf("coupons[%d]", i), "coupon %s doesn't exist", coupon)
+				continue
+			}
+			// a coupon entered twice is applied once
+			delete(found, p.Code)
+			promotions = append(promotions, p)
+		}
+		if err := verr.err(); err != nil {
+			return err
+		}
+	}
+	if len(promotions) == 0 {
+		return nil
+	}
+
+	ids := []string{}
+	for _, p := range promotions {
+		ids = append(ids, p.ID)
+	}
+	usage, err := s.repository.GetPromotionUsage(c, ids, o.AccountID)
+	if err != nil {
+		return err
+	}
+
+	verr := &ValidationError{}
+	applicable := []*model.Promotion{}
+	for i, p := range promotions {
+		p, err := s.convertPromotion(c, p, o.Currency)
+		if err != nil {
+			return err
+		}
+		if err := promotion.Check(p, o, usage[p.ID], o.CreatedAt); err != nil {
+			if i >= automatic {
+				verr.add(couponField(coupons, p.Code), "coupon %s can't be used: %v", p.Code, err)
+			}
+			continue
+		}
+		applicable = append(applicable, p)
+	}
+	if err := verr.err(); err != nil {
+		return err
+	}
+	promotion.Apply(o, applicable)
+	return nil
+}
+
+// convertPromotion returns a copy of a promotion with its amounts in the
+// order currency
+func (s *orderService) convertPromotion(c context.Context, p *model.Promotion, code string) (*model.Promotion, error) {
+	converted := *p
+	var err error
+	if converted.AmountOff.Amount > 0 {
+		if converted.AmountOff, err = s.converter.ConvertMoney(c, p.AmountOff, code); err != nil {
+			return nil, err
+		}
+	}
+	if converted.MinSpend.Amount > 0 {
+		if converted.MinSpend, err = s.converter.ConvertMoney(c, p.MinSpend, code); err != nil {
+			return nil, err
+		}
+	}
+	return &converted, nil
+}
+
+func couponField(coupons []string, code string) string {
+	for i, coupon := range coupons {
+		if promotion.NormalizeCode(coupon) == code {
+			return fmt.Sprintf("coupons[%d]", i)
+		}
+	}
+	return "coupons"
+}
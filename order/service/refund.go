@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/segmentio/ksuid"
+	"github.com/wignn/micro-3/currency"
+	"github.com/wignn/micro-3/order/model"
+	"github.com/wignn/micro-3/order/repository"
+	paymentModel "github.com/wignn/micro-3/payment/model"
+)
+
+var ErrInvalidRefundLine = errors.New("refund lines need a product id and a quantity")
+
+// CancelOrder cancels a pending or paid order, the caller releases its stock.
+// Its payment is given back first, an authorization is voided and a captured
+// amount refunded, so that a failure leaves the order as it was. Cancelling a
+// cancelled order returns it unchanged so that a failed stock release can be
+// retried.
+func (s *orderService) CancelOrder(c context.Context, id, reason, actor string) (*model.Order, error) {
+	o, err := s.GetOrder(c, id)
+	if err != nil {
+		return nil, err
+	}
+	if o.Status == model.OrderCancelled {
+		return o, nil
+	}
+	if !model.CanTransition(o.Status, model.OrderCancelled) {
+		return nil, repository.ErrInvalidTransition
+	}
+
+	p, err := s.activePayment(c, id)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case p == nil, p.Status == paymentModel.IntentRefunded:
+	case p.Status == paymentModel.IntentCaptured:
+		_, err = s.payments.RefundPayment(c, p.ID, currency.Money{}, reason)
+	default:
+		_, err = s.payments.VoidPayment(c, p.ID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s.UpdateOrderStatus(c, id, model.OrderCancelled, actor, reason)
+}
+
+// RefundOrder refunds lines of an order at the price they were ordered at,
+// without lines everything not refunded yet is refunded. Lines of the same
+// product are merged.
+func (s *orderService) RefundOrder(c context.Context, id string, lines []model.RefundLine, reason, actor string) (*model.Order, *model.Refund, error) {
+	if id == "" {
+		return nil, nil, ErrMissingOrderID
+	}
+	refund := &model.Refund{
+		ID:        ksuid.New().String(),
+		OrderID:   id,
+		Reason:    reason,
+		Actor:     actor,
+		CreatedAt: time.Now().UTC(),
+	}
+	index := map[string]int{}
+	for _, l := range lines {
+		if l.ProductID == "" || l.Quantity == 0 {
+			return nil, nil, ErrInvalidRefundLine
+		}
+		if i, ok := index[l.ProductID]; ok {
+			refund.Lines[i].Quantity += l.Quantity
+			continue
+		}
+		index[l.ProductID] = len(refund.Lines)
+		refund.Lines = append(refund.Lines, model.RefundLine{ProductID: l.ProductID, Quantity: l.Quantity})
+	}
+
+	o, err := s.repository.RefundOrder(c, refund, func(refund *model.Refund) error {
+		return s.refundPayment(c, refund)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return o, refund, nil
+}
+
+// refundPayment gives the amount of a refund back through the payment that
+// was captured for its order. Orders marked paid without one were paid
+// outside the payment service and are refunded there too.
+func (s *orderService) refundPayment(c context.Context, refund *model.Refund) error {
+	if refund.Amount.Amount == 0 {
+		return nil
+	}
+	p, err := s.activePayment(c, refund.OrderID)
+	if err != nil {
+		return err
+	}
+	if p == nil || (p.Status != paymentModel.IntentCaptured && p.Status != paymentModel.IntentRefunded) {
+		return nil
+	}
+	_, err = s.payments.RefundPayment(c, p.ID, refund.Amount, refund.Reason)
+	return err
+}
+
+// activePayment returns the payment intent of an order that can still move
+// money, nil when it has none
+func (s *orderService) activePayment(c context.Context, orderID string) (*paymentModel.PaymentIntent, error) {
+	intents, err := s.payments.GetPaymentsForOrder(c, orderID)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range intents {
+		if paymentModel.Active(p.Status) {
+			return p, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *orderService) GetOrderRefunds(c context.Context, id string) ([]*model.Refund, error) {
+	if id == "" {
+		return nil, ErrMissingOrderID
+	}
+	return s.repository.GetOrderRefunds(c, id)
+}
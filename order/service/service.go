@@ -2,61 +2,199 @@ package service
 
 import (
 	"context"
+	"errors"
 	"time"
 
-	"github.com/segmentio/ksuid"
+	"github.com/wignn/micro-3/blob"
+	"github.com/wignn/micro-3/currency"
 	"github.com/wignn/micro-3/order/model"
+	"github.com/wignn/micro-3/order/pricing"
 	"github.com/wignn/micro-3/order/repository"
+	payment "github.com/wignn/micro-3/payment/client"
 )
 
 type OrderService interface {
-	PostOrder(c context.Context, accountID string, products []model.OrderedProduct) (*model.Order, error)
+	PostOrder(c context.Context, id, accountID, currency string, products []model.OrderedProduct, coupons []string, address *model.Address, key *model.IdempotencyKey) (*model.Order, error)
+	QuoteOrder(c context.Context, accountID, currency string, products []model.OrderedProduct, coupons []string, address *model.Address) (*model.Order, error)
+	ReplayOrder(c context.Context, key *model.IdempotencyKey) (*model.Order, error)
+	ValidateLines(lines []Line) ([]Line, error)
+	CheckProducts(lines []Line, available map[string]bool) error
+	ValidateAddress(a *model.Address, complete bool) error
 	GetOrdersForAccount(c context.Context, accountID string) ([]*model.Order, error)
 	DeleteOrder(c context.Context, id string) error
+	ConvertProducts(c context.Context, o *model.Order) error
+	ExportOrderLines(c context.Context, fn func(l *model.OrderLine) error) error
+	UpdateOrderStatus(c context.Context, id, status, actor, reason string) (*model.Order, error)
+	GetOrderStatusHistory(c context.Context, id string) ([]*model.OrderStatusChange, error)
+	GetOrder(c context.Context, id string) (*model.Order, error)
+	ListOrders(c context.Context, filter model.OrderFilter, after string, first int) (*model.OrderPage, error)
+	CancelOrder(c context.Context, id, reason, actor string) (*model.Order, error)
+	RefundOrder(c context.Context, id string, lines []model.RefundLine, reason, actor string) (*model.Order, *model.Refund, error)
+	GetOrderRefunds(c context.Context, id string) ([]*model.Refund, error)
+	CreateShipment(c context.Context, orderID, carrier, trackingNumber string, lines []model.ShipmentLine, address *model.Address, actor string) (*model.Order, *model.Shipment, error)
+	UpdateShipment(c context.Context, id, status, carrier, trackingNumber string, at time.Time, actor string) (*model.Order, *model.Shipment, error)
+	GetShipments(c context.Context, orderID string) ([]*model.Shipment, error)
+	GetInvoice(c context.Context, orderID string) (*model.Invoice, error)
+	IssueInvoice(c context.Context, orderID string) (*model.Invoice, error)
+	IssuePendingInvoices(c context.Context, limit int) (int, error)
+	CreatePromotion(c context.Context, p *model.Promotion) (*model.Promotion, error)
+	SetPromotionActive(c context.Context, id string, active bool) (*model.Promotion, error)
+	ListPromotions(c context.Context) ([]*model.Promotion, error)
 }
 
-
 type orderService struct {
-	repository repository.OrderRepository
+	repository      repository.OrderRepository
+	converter       *currency.Converter
+	pricer          *pricing.Pricer
+	defaultCurrency string
+	limits          OrderLimits
+	invoices        blob.Store
+	invoicing       InvoiceConfig
+	payments        *payment.PaymentClient
 }
 
-
-func NewOrderService(r repository.OrderRepository) OrderService {
-	return &orderService{repository: r}
+// NewOrderService creates the service, orders posted without a currency are
+// placed in defaultCurrency, invoice documents are stored in invoices and
+// cancelled or refunded orders are paid back through payments
+func NewOrderService(r repository.OrderRepository, converter *currency.Converter, pricer *pricing.Pricer, defaultCurrency string, limits OrderLimits, invoices blob.Store, invoicing InvoiceConfig, payments *payment.PaymentClient) OrderService {
+	return &orderService{
+		repository:      r,
+		converter:       converter,
+		pricer:          pricer,
+		defaultCurrency: defaultCurrency,
+		limits:          limits,
+		invoices:        invoices,
+		invoicing:       invoicing,
+		payments:        payments,
+	}
 }
 
-
-
+// PostOrder places an order in the given currency and prices it, see price.
+// Orders without an address aren't shipped.
+// The id is minted by the caller so that stock can be reserved for it first.
+// An idempotency key is stored with the order, repository.ErrDuplicateKey is
+// returned when a concurrent request placed an order with it first.
 func (s orderService) PostOrder(
 	ctx context.Context,
+	id string,
 	accountID string,
+	code string,
 	products []model.OrderedProduct,
+	coupons []string,
+	address *model.Address,
+	key *model.IdempotencyKey,
 ) (*model.Order, error) {
+	if code == "" {
+		code = s.defaultCurrency
+	}
+	code, err := currency.Normalize(code)
+	if err != nil {
+		return nil, err
+	}
+
 	o := &model.Order{
-		ID:        ksuid.New().String(),
-		CreatedAt: time.Now().UTC(),
-		AccountID: accountID,
-		Products:  products,
-	}
-	
-	// Calculate total price
-	o.TotalPrice = 0.0
-	for _, p := range products {
-		o.TotalPrice += p.Price * float64(p.Quantity)
-	}
-	err := s.repository.PutOrder(ctx, o)
-	
+		ID:              id,
+		CreatedAt:       time.Now().UTC(),
+		AccountID:       accountID,
+		Currency:        code,
+		Status:          model.OrderPending,
+		Products:        products,
+		ShippingAddress: address,
+	}
+	if err := s.price(ctx, o, coupons); err != nil {
+		return nil, err
+	}
+	if key != nil {
+		key.OrderID, key.CreatedAt = o.ID, o.CreatedAt
+	}
+	err = s.repository.PutOrder(ctx, o, key)
+
 	if err != nil {
 		return nil, err
 	}
-	
+
 	return o, nil
 }
 
+// QuoteOrder prices a basket like PostOrder without placing an order, the
+// quote has no id and no status. Coupons limited per account are checked
+// against the account when it is given.
+func (s *orderService) QuoteOrder(
+	ctx context.Context,
+	accountID string,
+	code string,
+	products []model.OrderedProduct,
+	coupons []string,
+	address *model.Address,
+) (*model.Order, error) {
+	if code == "" {
+		code = s.defaultCurrency
+	}
+	code, err := currency.Normalize(code)
+	if err != nil {
+		return nil, err
+	}
+
+	o := &model.Order{
+		CreatedAt:       time.Now().UTC(),
+		AccountID:       accountID,
+		Currency:        code,
+		Products:        products,
+		ShippingAddress: address,
+	}
+	if err := s.price(ctx, o, coupons); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+// price runs the pricing pipeline of an order: product prices are converted
+// to the order currency, promotions and coupons discount the lines, then
+// shipping and tax are charged and the total is their exact sum with the
+// discounted lines. A destination that can't be shipped to is reported as a
+// violation.
+func (s *orderService) price(ctx context.Context, o *model.Order, coupons []string) error {
+	if err := s.ConvertProducts(ctx, o); err != nil {
+		return err
+	}
+	if err := s.applyPromotions(ctx, o, coupons); err != nil {
+		return err
+	}
+	err := s.pricer.Price(ctx, o)
+	if errors.Is(err, pricing.ErrNoShipping) || errors.Is(err, pricing.ErrTooHeavy) {
+		verr := &ValidationError{}
+		verr.add("shippingAddress.country", "%v", err)
+		return verr
+	}
+	return err
+}
+
 func (s orderService) GetOrdersForAccount(ctx context.Context, accountID string) ([]*model.Order, error) {
 	return s.repository.GetOrdersForAccount(ctx, accountID)
 }
 
 func (s *orderService) DeleteOrder(ctx context.Context, id string) error {
 	return s.repository.DeleteOrder(ctx, id)
-}
\ No newline at end of file
+}
+
+// ConvertProducts converts every product price to the order currency and rounds
+// it to its minor unit, prices without a currency are assumed to be in it
+func (s *orderService) ConvertProducts(ctx context.Context, o *model.Order) error {
+	for i := range o.Products {
+		p := &o.Products[i]
+		if p.Price.Currency == "" {
+			p.Price.Currency = o.Currency
+		}
+		price, err := s.converter.ConvertMoney(ctx, p.Price, o.Currency)
+		if err != nil {
+			return err
+		}
+		p.Price = price
+	}
+	return nil
+}
+
+// ExportOrderLines walks every ordered product, it feeds offline jobs such as recommendations
+func (s *orderService) ExportOrderLines(ctx context.Context, fn func(l *model.OrderLine) error) error {
+	return s.repository.ScanOrderLines(ctx, fn)
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/segmentio/ksuid"
+	"github.com/wignn/micro-3/order/model"
+	"github.com/wignn/micro-3/order/repository"
+)
+
+var (
+	ErrMissingShipmentID     = errors.New("shipment id is required")
+	ErrMissingCarrier        = errors.New("carrier is required")
+	ErrInvalidShipmentStatus = errors.New("unknown shipment status")
+	ErrInvalidShipmentLine   = errors.New("shipment lines need a product id and a quantity")
+)
+
+// CreateShipment packs lines of an order into a pending shipment, without
+// lines every unit not shipped yet is packed. The shipment is sent to address,
+// or to the address of the order when it is nil. Lines of the same product
+// are merged.
+func (s *orderService) CreateShipment(c context.Context, orderID, carrier, trackingNumber string, lines []model.ShipmentLine, address *model.Address, actor string) (*model.Order, *model.Shipment, error) {
+	if orderID == "" {
+		return nil, nil, ErrMissingOrderID
+	}
+	carrier = strings.TrimSpace(carrier)
+	if carrier == "" {
+		return nil, nil, ErrMissingCarrier
+	}
+	now := time.Now().UTC()
+	shipment := &model.Shipment{
+		ID:             ksuid.New().String(),
+		OrderID:        orderID,
+		Carrier:        carrier,
+		TrackingNumber: strings.TrimSpace(trackingNumber),
+		Status:         model.ShipmentPending,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	if address != nil {
+		if err := s.ValidateAddress(address, true); err != nil {
+			return nil, nil, err
+		}
+		shipment.Address = *address
+	}
+	index := map[string]int{}
+	for _, l := range lines {
+		if l.ProductID == "" || l.Quantity == 0 {
+			return nil, nil, ErrInvalidShipmentLine
+		}
+		if i, ok := index[l.ProductID]; ok {
+			// no order line holds more than MaxUint32 units, a wider sum can't fit
+			quantity := uint64(shipment.Lines[i].Quantity) + uint64(l.Quantity)
+			if quantity > math.MaxUint32 {
+				return nil, nil, repository.ErrShipmentExceedsOrder
+			}
+			shipment.Lines[i].Quantity = uint32(quantity)
+			continue
+		}
+		index[l.ProductID] = len(shipment.Lines)
+		shipment.Lines = append(shipment.Lines, model.ShipmentLine{ProductID: l.ProductID, Quantity: l.Quantity})
+	}
+
+	o, err := s.repository.CreateShipment(c, shipment, actor)
+	if err != nil {
+		return nil, nil, err
+	}
+	return o, shipment, nil
+}
+
+// UpdateShipment changes a shipment, empty fields are left unchanged. at is
+// when the status changed, now when it is zero.
+func (s *orderService) UpdateShipment(c context.Context, id, status, carrier, trackingNumber string, at time.Time, actor string) (*model.Order, *model.Shipment, error) {
+	if id == "" {
+		return nil, nil, ErrMissingShipmentID
+	}
+	status = strings.ToLower(strings.TrimSpace(status))
+	if status != "" && !model.ValidShipmentStatus(status) {
+		return nil, nil, ErrInvalidShipmentStatus
+	}
+	if at.IsZero() {
+		at = time.Now()
+	}
+	return s.repository.UpdateShipment(c, &model.ShipmentUpdate{
+		ID:             id,
+		Status:         status,
+		Carrier:        strings.TrimSpace(carrier),
+		TrackingNumber: strings.TrimSpace(trackingNumber),
+		Actor:          actor,
+		At:             at.UTC(),
+	})
+}
+
+func (s *orderService) GetShipments(c context.Context, orderID string) ([]*model.Shipment, error) {
+	if orderID == "" {
+		return nil, ErrMissingOrderID
+	}
+	return s.repository.GetShipments(c, orderID)
+}
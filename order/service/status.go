@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/wignn/micro-3/order/model"
+)
+
+var (
+	ErrMissingOrderID = errors.New("order id is required")
+	ErrInvalidStatus  = errors.New("unknown order status")
+)
+
+// UpdateOrderStatus moves an order along the status state machine, actor and
+// reason are kept in the status history. Orders are invoiced when they are paid.
+func (s *orderService) UpdateOrderStatus(c context.Context, id, status, actor, reason string) (*model.Order, error) {
+	if id == "" {
+		return nil, ErrMissingOrderID
+	}
+	status = strings.ToLower(strings.TrimSpace(status))
+	if !model.ValidOrderStatus(status) {
+		return nil, ErrInvalidStatus
+	}
+	o, err := s.repository.UpdateOrderStatus(c, &model.OrderStatusChange{
+		OrderID:   id,
+		To:        status,
+		Actor:     actor,
+		Reason:    reason,
+		ChangedAt: time.Now().UTC(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	// the order is paid either way, IssuePendingInvoices retries a failure
+	if status == model.OrderPaid {
+		if _, err := s.IssueInvoice(c, id); err != nil {
+			log.Printf("failed to issue invoice of order %s: %v\n", id, err)
+		}
+	}
+	return o, nil
+}
+
+func (s *orderService) GetOrderStatusHistory(c context.Context, id string) ([]*model.OrderStatusChange, error) {
+	if id == "" {
+		return nil, ErrMissingOrderID
+	}
+	return s.repository.GetOrderStatusHistory(c, id)
+}
@@ -0,0 +1,177 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/wignn/micro-3/order/model"
+)
+
+var ErrInvalidOrder = errors.New("invalid order")
+
+// OrderLimits bounds the size of an order
+type OrderLimits struct {
+	MaxLineQuantity  uint32
+	MaxOrderQuantity uint32
+	MaxLines         int
+}
+
+// Line is a requested order line, Index is its position in the request and
+// names the line in violations
+type Line struct {
+	Index     int
+	ProductID string
+	Quantity  uint32
+}
+
+// Violation describes what is wrong with one field of an order request,
+// Field is a path such as products[2].quantity
+type Violation struct {
+	Field       string
+	Description string
+}
+
+// ValidationError lists every problem found in an order request, it matches
+// ErrInvalidOrder
+type ValidationError struct {
+	Violations []Violation
+}
+
+func (e *ValidationError) Error() string {
+	descriptions := []string{}
+	for _, v := range e.Violations {
+		descriptions = append(descriptions, v.Description)
+	}
+	return ErrInvalidOrder.Error() + ": " + strings.Join(descriptions, "; ")
+}
+
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrInvalidOrder
+}
+
+func (e *ValidationError) add(field, format string, args ...interface{}) {
+	e.Violations = append(e.Violations, Violation{Field: field, Description: fmt.Sprintf(format, args...)})
+}
+
+func (e *ValidationError) err() error {
+	if len(e.Violations) == 0 {
+		return nil
+	}
+	return e
+}
+
+func lineField(i int, field string) string {
+	return fmt.Sprintf("products[%d].%s", i, field)
+}
+
+// ValidateLines merges lines of the same product and checks quantities
+// against the order limits, the merged lines keep the index of the first line
+// of their product. Lines are returned with the violations too so their
+// products can still be checked in the same request.
+func (s *orderService) ValidateLines(lines []Line) ([]Line, error) {
+	verr := &ValidationError{}
+	if len(lines) == 0 {
+		verr.add("products", "an order needs at least one product")
+		return nil, verr
+	}
+
+	// quantities are summed wide so duplicate lines can't wrap around
+	merged := []Line{}
+	quantities := []uint64{}
+	index := map[string]int{}
+	for _, l := range lines {
+		if l.ProductID == "" {
+			verr.add(lineField(l.Index, "productId"), "product id is required")
+			continue
+		}
+		if l.Quantity == 0 {
+			verr.add(lineField(l.Index, "quantity"), "quantity of product %s must be at least 1", l.ProductID)
+		}
+		if i, ok := index[l.ProductID]; ok {
+			quantities[i] += uint64(l.Quantity)
+			continue
+		}
+		index[l.ProductID] = len(merged)
+		merged = append(merged, l)
+		quantities = append(quantities, uint64(l.Quantity))
+	}
+
+	total := uint64(0)
+	for i, l := range merged {
+		quantity := quantities[i]
+		if s.limits.MaxLineQuantity > 0 && quantity > uint64(s.limits.MaxLineQuantity) {
+			verr.add(lineField(l.Index, "quantity"), "quantity of product %s must be at most %d", l.ProductID, s.limits.MaxLineQuantity)
+		} else if quantity > math.MaxUint32 {
+			verr.add(lineField(l.Index, "quantity"), "quantity of product %s must be at most %d", l.ProductID, uint32(math.MaxUint32))
+		}
+		merged[i].Quantity = uint32(quantity)
+		total += quantity
+	}
+	if s.limits.MaxLines > 0 && len(merged) > s.limits.MaxLines {
+		verr.add("products", "an order can have at most %d products", s.limits.MaxLines)
+	}
+	if s.limits.MaxOrderQuantity > 0 && total > uint64(s.limits.MaxOrderQuantity) {
+		verr.add("products", "an order can have at most %d units", s.limits.MaxOrderQuantity)
+	}
+	return merged, verr.err()
+}
+
+// JoinViolations merges the violations of validation errors into one error,
+// any other error is returned as it is
+func JoinViolations(errs ...error) error {
+	verr := &ValidationError{}
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+		var v *ValidationError
+		if !errors.As(err, &v) {
+			return err
+		}
+		verr.Violations = append(verr.Violations, v.Violations...)
+	}
+	return verr.err()
+}
+
+// CheckProducts reports lines whose product doesn't exist or can't be
+// ordered, available holds whether each product found in the catalog can be
+// ordered
+func (s *orderService) CheckProducts(lines []Line, available map[string]bool) error {
+	verr := &ValidationError{}
+	for _, l := range lines {
+		ok, found := available[l.ProductID]
+		if !found {
+			verr.add(lineField(l.Index, "productId"), "product %s doesn't exist", l.ProductID)
+		} else if !ok {
+			verr.add(lineField(l.Index, "productId"), "product %s is not available", l.ProductID)
+		}
+	}
+	return verr.err()
+}
+
+// ValidateAddress trims a shipping address and uppercases its country and
+// region, a complete address also needs a street and a city. Violations are
+// reported under shippingAddress.
+func (s *orderService) ValidateAddress(a *model.Address, complete bool) error {
+	for _, field := range []*string{&a.Name, &a.Line1, &a.Line2, &a.City, &a.Region, &a.PostalCode, &a.Country} {
+		*field = strings.TrimSpace(*field)
+	}
+	a.Country = strings.ToUpper(a.Country)
+	a.Region = strings.ToUpper(a.Region)
+
+	verr := &ValidationError{}
+	if len(a.Country) != 2 || strings.Trim(a.Country, "ABCDEFGHIJKLMNOPQRSTUVWXYZ") != "" {
+		verr.add("shippingAddress.country", "country must be a 2 letter ISO 3166 code")
+	}
+	if complete {
+		if a.Line1 == "" {
+			verr.add("shippingAddress.line1", "street address is required")
+		}
+		if a.City == "" {
+			verr.add("shippingAddress.city", "city is required")
+		}
+	}
+	return verr.err()
+}
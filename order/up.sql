@@ -1,13 +1,200 @@
+-- amounts are integers in the minor unit of the order currency, cents for USD
 CREATE TABLE IF NOT EXISTS orders (
   id CHAR(27) PRIMARY KEY,
   created_at TIMESTAMP WITH TIME ZONE NOT NULL,
   account_id CHAR(27) NOT NULL,
-  total_price MONEY NOT NULL
+  total_minor BIGINT NOT NULL,
+  currency CHAR(3) NOT NULL DEFAULT 'USD',
+  status VARCHAR(16) NOT NULL DEFAULT 'pending',
+  shipping_minor BIGINT NOT NULL DEFAULT 0,
+  -- the part of tax_minor charged on shipping
+  shipping_tax_minor BIGINT NOT NULL DEFAULT 0,
+  tax_minor BIGINT NOT NULL DEFAULT 0,
+  -- prices include the tax, total_minor doesn't add it
+  tax_inclusive BOOLEAN NOT NULL DEFAULT FALSE,
+  -- NULL for orders that aren't shipped
+  shipping_address JSONB
 );
 
 CREATE TABLE IF NOT EXISTS order_products (
   order_id CHAR(27) REFERENCES orders (id) ON DELETE CASCADE,
   product_id CHAR(27),
   quantity INT NOT NULL,
+  name VARCHAR(256) NOT NULL DEFAULT '',
+  description TEXT NOT NULL DEFAULT '',
+  price_minor BIGINT NOT NULL DEFAULT 0,
+  tax_minor BIGINT NOT NULL DEFAULT 0,
+  -- of one unit, zero when unknown
+  weight_grams BIGINT NOT NULL DEFAULT 0,
   PRIMARY KEY (product_id, order_id)
-);
\ No newline at end of file
+);
+
+CREATE TABLE IF NOT EXISTS order_status_history (
+  id BIGSERIAL PRIMARY KEY,
+  order_id CHAR(27) NOT NULL REFERENCES orders (id) ON DELETE CASCADE,
+  from_status VARCHAR(16) NOT NULL DEFAULT '',
+  to_status VARCHAR(16) NOT NULL,
+  actor VARCHAR(64) NOT NULL DEFAULT '',
+  reason TEXT NOT NULL DEFAULT '',
+  changed_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS order_status_history_order_id ON order_status_history (order_id, changed_at);
+
+CREATE TABLE IF NOT EXISTS order_refunds (
+  id CHAR(27) PRIMARY KEY,
+  order_id CHAR(27) NOT NULL REFERENCES orders (id) ON DELETE CASCADE,
+  amount_minor BIGINT NOT NULL,
+  currency CHAR(3) NOT NULL,
+  reason TEXT NOT NULL DEFAULT '',
+  actor VARCHAR(64) NOT NULL DEFAULT '',
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS order_refunds_order_id ON order_refunds (order_id);
+
+CREATE TABLE IF NOT EXISTS order_refund_lines (
+  refund_id CHAR(27) REFERENCES order_refunds (id) ON DELETE CASCADE,
+  product_id CHAR(27) NOT NULL,
+  quantity INT NOT NULL CHECK (quantity > 0),
+  amount_minor BIGINT NOT NULL,
+  PRIMARY KEY (refund_id, product_id)
+);
+
+CREATE TABLE IF NOT EXISTS order_idempotency_keys (
+  account_id CHAR(27) NOT NULL,
+  key VARCHAR(128) NOT NULL,
+  fingerprint CHAR(64) NOT NULL,
+  order_id CHAR(27) NOT NULL REFERENCES orders (id) ON DELETE CASCADE,
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+  PRIMARY KEY (account_id, key)
+);
+
+CREATE TABLE IF NOT EXISTS checkouts (
+  id CHAR(27) PRIMARY KEY,
+  order_id CHAR(27) NOT NULL UNIQUE,
+  account_id CHAR(27) NOT NULL,
+  -- empty places the order in the default currency
+  currency VARCHAR(3) NOT NULL DEFAULT '',
+  products JSONB NOT NULL,
+  coupons TEXT[] NOT NULL DEFAULT '{}',
+  shipping_address JSONB,
+  payment_method VARCHAR(128) NOT NULL DEFAULT '',
+  payment_intent_id VARCHAR(27) NOT NULL DEFAULT '',
+  idempotency_key VARCHAR(128) NOT NULL DEFAULT '',
+  fingerprint VARCHAR(64) NOT NULL DEFAULT '',
+  status VARCHAR(16) NOT NULL,
+  error TEXT NOT NULL DEFAULT '',
+  -- the instance running the checkout holds it until then, an expired lease
+  -- lets another instance resume it
+  locked_until TIMESTAMP WITH TIME ZONE NOT NULL,
+  -- the run holding the lease, only it may save the checkout
+  lease_owner VARCHAR(27) NOT NULL DEFAULT '',
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+  updated_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS checkouts_unfinished ON checkouts (locked_until)
+  WHERE status NOT IN ('completed', 'failed');
+
+CREATE TABLE IF NOT EXISTS checkout_steps (
+  checkout_id CHAR(27) REFERENCES checkouts (id) ON DELETE CASCADE,
+  position INT NOT NULL,
+  name VARCHAR(32) NOT NULL,
+  status VARCHAR(16) NOT NULL,
+  error TEXT NOT NULL DEFAULT '',
+  updated_at TIMESTAMP WITH TIME ZONE NOT NULL,
+  PRIMARY KEY (checkout_id, name)
+);
+
+-- amount_off_minor and min_spend_minor are in currency, zero limits and a
+-- zero minimum spend mean no limit
+CREATE TABLE IF NOT EXISTS promotions (
+  id CHAR(27) PRIMARY KEY,
+  -- empty for promotions applied without a coupon
+  code VARCHAR(32) NOT NULL DEFAULT '',
+  name VARCHAR(128) NOT NULL,
+  kind VARCHAR(16) NOT NULL,
+  percent_off INT NOT NULL DEFAULT 0,
+  amount_off_minor BIGINT NOT NULL DEFAULT 0,
+  min_spend_minor BIGINT NOT NULL DEFAULT 0,
+  currency VARCHAR(3) NOT NULL DEFAULT '',
+  buy_quantity INT NOT NULL DEFAULT 0,
+  get_quantity INT NOT NULL DEFAULT 0,
+  product_ids TEXT[] NOT NULL DEFAULT '{}',
+  starts_at TIMESTAMP WITH TIME ZONE,
+  ends_at TIMESTAMP WITH TIME ZONE,
+  max_uses INT NOT NULL DEFAULT 0,
+  max_uses_per_account INT NOT NULL DEFAULT 0,
+  stackable BOOLEAN NOT NULL DEFAULT FALSE,
+  priority INT NOT NULL DEFAULT 0,
+  active BOOLEAN NOT NULL DEFAULT TRUE,
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+  updated_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS promotions_code ON promotions (code) WHERE code <> '';
+
+-- a redemption counts against the usage limits until its order is cancelled
+CREATE TABLE IF NOT EXISTS promotion_redemptions (
+  promotion_id CHAR(27) NOT NULL REFERENCES promotions (id),
+  order_id CHAR(27) NOT NULL REFERENCES orders (id) ON DELETE CASCADE,
+  account_id CHAR(27) NOT NULL,
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+  PRIMARY KEY (promotion_id, order_id)
+);
+
+CREATE INDEX IF NOT EXISTS promotion_redemptions_account ON promotion_redemptions (promotion_id, account_id);
+
+CREATE TABLE IF NOT EXISTS order_discounts (
+  order_id CHAR(27) NOT NULL REFERENCES orders (id) ON DELETE CASCADE,
+  product_id CHAR(27) NOT NULL,
+  -- discounts of a line are applied in this order
+  position INT NOT NULL,
+  promotion_id CHAR(27) NOT NULL,
+  code VARCHAR(32) NOT NULL DEFAULT '',
+  name VARCHAR(128) NOT NULL,
+  amount_minor BIGINT NOT NULL,
+  PRIMARY KEY (order_id, product_id, promotion_id)
+);
+
+-- a shipment sends some units of an order to a snapshot of its address
+CREATE TABLE IF NOT EXISTS shipments (
+  id CHAR(27) PRIMARY KEY,
+  order_id CHAR(27) NOT NULL REFERENCES orders (id) ON DELETE CASCADE,
+  carrier VARCHAR(64) NOT NULL,
+  tracking_number VARCHAR(128) NOT NULL DEFAULT '',
+  status VARCHAR(16) NOT NULL,
+  address JSONB NOT NULL,
+  shipped_at TIMESTAMP WITH TIME ZONE,
+  delivered_at TIMESTAMP WITH TIME ZONE,
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+  updated_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS shipments_order_id ON shipments (order_id);
+
+CREATE TABLE IF NOT EXISTS shipment_lines (
+  shipment_id CHAR(27) REFERENCES shipments (id) ON DELETE CASCADE,
+  product_id CHAR(27) NOT NULL,
+  quantity INT NOT NULL CHECK (quantity > 0),
+  PRIMARY KEY (shipment_id, product_id)
+);
+
+-- an order has a single invoice, numbered in the sequence of the year it was
+-- issued in; its documents are blobs
+CREATE TABLE IF NOT EXISTS invoices (
+  id CHAR(27) PRIMARY KEY,
+  number VARCHAR(32) NOT NULL UNIQUE,
+  order_id CHAR(27) NOT NULL UNIQUE REFERENCES orders (id) ON DELETE CASCADE,
+  issued_at TIMESTAMP WITH TIME ZONE NOT NULL,
+  total_minor BIGINT NOT NULL,
+  currency CHAR(3) NOT NULL,
+  html_key VARCHAR(255) NOT NULL,
+  pdf_key VARCHAR(255) NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS invoice_sequences (
+  year INT PRIMARY KEY,
+  last BIGINT NOT NULL
+);
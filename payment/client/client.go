@@ -0,0 +1,155 @@
+package client
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/wignn/micro-3/currency"
+	"github.com/wignn/micro-3/payment/genproto"
+	"github.com/wignn/micro-3/payment/model"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+type PaymentClient struct {
+	conn    *grpc.ClientConn
+	service genproto.PaymentServiceClient
+}
+
+func NewClient(url string) (*PaymentClient, error) {
+	conn, err := grpc.NewClient(url, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		log.Printf("failed to connect to payment service: %v\n", err)
+		return nil, err
+	}
+
+	c := genproto.NewPaymentServiceClient(conn)
+	return &PaymentClient{conn: conn, service: c}, nil
+}
+
+func (cl *PaymentClient) Close() {
+	cl.conn.Close()
+}
+
+func moneyToProto(m currency.Money) *genproto.Money {
+	return &genproto.Money{Amount: m.Amount, Currency: m.Currency}
+}
+
+func moneyFromProto(m *genproto.Money) currency.Money {
+	if m == nil {
+		return currency.Money{}
+	}
+	return currency.Money{Amount: m.Amount, Currency: m.Currency}
+}
+
+func intentFromProto(pp *genproto.PaymentIntent) *model.PaymentIntent {
+	p := &model.PaymentIntent{
+		ID:            pp.Id,
+		OrderID:       pp.OrderId,
+		AccountID:     pp.AccountId,
+		Provider:      pp.Provider,
+		ProviderRef:   pp.ProviderRef,
+		Status:        pp.Status,
+		Amount:        moneyFromProto(pp.Amount),
+		Authorized:    moneyFromProto(pp.Authorized),
+		Captured:      moneyFromProto(pp.Captured),
+		Refunded:      moneyFromProto(pp.Refunded),
+		FailureReason: pp.FailureReason,
+		Events:        []model.PaymentEvent{},
+	}
+	p.CreatedAt = time.Time{}
+	p.CreatedAt.UnmarshalBinary(pp.CreatedAt)
+	p.UpdatedAt = time.Time{}
+	p.UpdatedAt.UnmarshalBinary(pp.UpdatedAt)
+	for _, ep := range pp.Events {
+		e := model.PaymentEvent{
+			Type:            ep.Type,
+			Status:          ep.Status,
+			Amount:          moneyFromProto(ep.Amount),
+			ProviderRef:     ep.ProviderRef,
+			ProviderEventID: ep.ProviderEventId,
+			Message:         ep.Message,
+		}
+		e.CreatedAt.UnmarshalBinary(ep.CreatedAt)
+		p.Events = append(p.Events, e)
+	}
+	return p
+}
+
+// CreatePaymentIntent starts the payment of an order, an order that already
+// has an active intent gets that intent back
+func (cl *PaymentClient) CreatePaymentIntent(c context.Context, orderID string) (*model.PaymentIntent, error) {
+	r, err := cl.service.CreatePaymentIntent(c, &genproto.CreatePaymentIntentRequest{OrderId: orderID})
+	if err != nil {
+		log.Printf("failed to create payment intent: %v\n", err)
+		return nil, err
+	}
+	return intentFromProto(r.Intent), nil
+}
+
+// AuthorizePayment returns the intent authorized, failed when the payment
+// method was declined, or still pending when the provider confirms later
+func (cl *PaymentClient) AuthorizePayment(c context.Context, intentID, paymentMethod string) (*model.PaymentIntent, error) {
+	r, err := cl.service.AuthorizePayment(c, &genproto.AuthorizePaymentRequest{IntentId: intentID, PaymentMethod: paymentMethod})
+	if err != nil {
+		log.Printf("failed to authorize payment: %v\n", err)
+		return nil, err
+	}
+	return intentFromProto(r.Intent), nil
+}
+
+// CapturePayment captures amount, a zero amount captures everything authorized
+func (cl *PaymentClient) CapturePayment(c context.Context, intentID string, amount currency.Money) (*model.PaymentIntent, error) {
+	r, err := cl.service.CapturePayment(c, &genproto.CapturePaymentRequest{IntentId: intentID, Amount: moneyToProto(amount)})
+	if err != nil {
+		log.Printf("failed to capture payment: %v\n", err)
+		return nil, err
+	}
+	return intentFromProto(r.Intent), nil
+}
+
+func (cl *PaymentClient) VoidPayment(c context.Context, intentID string) (*model.PaymentIntent, error) {
+	r, err := cl.service.VoidPayment(c, &genproto.VoidPaymentRequest{IntentId: intentID})
+	if err != nil {
+		log.Printf("failed to void payment: %v\n", err)
+		return nil, err
+	}
+	return intentFromProto(r.Intent), nil
+}
+
+// RefundPayment refunds amount, a zero amount refunds everything captured
+func (cl *PaymentClient) RefundPayment(c context.Context, intentID string, amount currency.Money, reason string) (*model.PaymentIntent, error) {
+	r, err := cl.service.RefundPayment(c, &genproto.RefundPaymentRequest{
+		IntentId: intentID,
+		Amount:   moneyToProto(amount),
+		Reason:   reason,
+	})
+	if err != nil {
+		log.Printf("failed to refund payment: %v\n", err)
+		return nil, err
+	}
+	return intentFromProto(r.Intent), nil
+}
+
+func (cl *PaymentClient) GetPaymentIntent(c context.Context, id string) (*model.PaymentIntent, error) {
+	r, err := cl.service.GetPaymentIntent(c, &genproto.GetPaymentIntentRequest{Id: id})
+	if err != nil {
+		log.Printf("failed to get payment intent: %v\n", err)
+		return nil, err
+	}
+	return intentFromProto(r.Intent), nil
+}
+
+func (cl *PaymentClient) GetPaymentsForOrder(c context.Context, orderID string) ([]*model.PaymentIntent, error) {
+	r, err := cl.service.GetPaymentsForOrder(c, &genproto.GetPaymentsForOrderRequest{OrderId: orderID})
+	if err != nil {
+		log.Printf("failed to get payments for order: %v\n", err)
+		return nil, err
+	}
+	intents := []*model.PaymentIntent{}
+	for _, p := range r.Intents {
+		intents = append(intents, intentFromProto(p))
+	}
+	return intents, nil
+}
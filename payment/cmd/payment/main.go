@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/kelseyhightower/envconfig"
+	"github.com/tinrab/retry"
+	order "github.com/wignn/micro-3/order/client"
+	"github.com/wignn/micro-3/payment/provider"
+	"github.com/wignn/micro-3/payment/repository"
+	"github.com/wignn/micro-3/payment/server"
+	"github.com/wignn/micro-3/payment/service"
+)
+
+type Config struct {
+	DatabaseURL      string        `envconfig:"DATABASE_URL"`
+	OrderURL         string        `envconfig:"ORDER_SERVICE_URL"`
+	Provider         string        `envconfig:"PAYMENT_PROVIDER" default:"fake"`
+	WebhookSecret    string        `envconfig:"WEBHOOK_SECRET"`
+	WebhookPort      int           `envconfig:"WEBHOOK_PORT" default:"8082"`
+	FakeWebhookDelay time.Duration `envconfig:"FAKE_WEBHOOK_DELAY" default:"1s"`
+	PORT             int           `envconfig:"PORT" default:"50051"`
+}
+
+func main() {
+	var cfg Config
+	if err := envconfig.Process("", &cfg); err != nil {
+		log.Fatal("Failed to process environment variables:", err)
+	}
+	if cfg.WebhookSecret == "" {
+		log.Fatal("WEBHOOK_SECRET is required")
+	}
+
+	var r repository.PaymentRepository
+	retry.ForeverSleep(2*time.Second, func(_ int) (err error) {
+		r, err = repository.NewPostgresRepository(cfg.DatabaseURL)
+		if err != nil {
+			log.Println(err)
+		}
+		return
+	})
+	defer r.Close()
+
+	orderClient, err := order.NewClient(cfg.OrderURL)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer orderClient.Close()
+
+	var p provider.Provider
+	var fake *provider.Fake
+	switch cfg.Provider {
+	case "fake":
+		fake = provider.NewFake(cfg.WebhookSecret, cfg.FakeWebhookDelay)
+		p = fake
+	default:
+		log.Fatal(provider.ErrUnknownProvider, ": ", cfg.Provider)
+	}
+
+	s := service.NewPaymentService(r, orderClient, p)
+	if fake != nil {
+		// the fake delivers its webhooks in process, signed like real ones
+		fake.OnWebhook(func(payload []byte, signature string) {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			if err := s.HandleWebhook(ctx, fake.Name(), payload, signature); err != nil {
+				log.Println("failed to handle fake webhook:", err)
+			}
+		})
+	}
+	go serveWebhooks(s, cfg.WebhookPort)
+
+	log.Println("listening on port", cfg.PORT)
+	log.Fatal(server.ListenGRPC(s, cfg.PORT))
+}
+
+func serveWebhooks(s service.PaymentService, port int) {
+	mux := http.NewServeMux()
+	mux.Handle("/webhooks/", server.WebhookHandler(s))
+	log.Println("Serving webhooks on port", port)
+	log.Fatal(http.ListenAndServe(fmt.Sprintf(":%d", port), mux))
+}
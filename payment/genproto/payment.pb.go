@@ -0,0 +1,895 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v6.31.0
+// source: payment.proto
+
+package genproto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Money is an exact amount in the minor unit of its currency
+type Money struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Amount        int64                  `protobuf:"varint,1,opt,name=amount,proto3" json:"amount,omitempty"`
+	Currency      string                 `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Money) Reset() {
+	*x = Money{}
+	mi := &file_payment_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Money) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Money) ProtoMessage() {}
+
+func (x *Money) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Money.ProtoReflect.Descriptor instead.
+func (*Money) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Money) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *Money) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+type PaymentEvent struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Type        string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Status      string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Amount      *Money                 `protobuf:"bytes,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	ProviderRef string                 `protobuf:"bytes,4,opt,name=providerRef,proto3" json:"providerRef,omitempty"`
+	// set for events delivered by provider webhooks
+	ProviderEventId string `protobuf:"bytes,5,opt,name=providerEventId,proto3" json:"providerEventId,omitempty"`
+	Message         string `protobuf:"bytes,6,opt,name=message,proto3" json:"message,omitempty"`
+	CreatedAt       []byte `protobuf:"bytes,7,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *PaymentEvent) Reset() {
+	*x = PaymentEvent{}
+	mi := &file_payment_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PaymentEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PaymentEvent) ProtoMessage() {}
+
+func (x *PaymentEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PaymentEvent.ProtoReflect.Descriptor instead.
+func (*PaymentEvent) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PaymentEvent) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *PaymentEvent) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *PaymentEvent) GetAmount() *Money {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+func (x *PaymentEvent) GetProviderRef() string {
+	if x != nil {
+		return x.ProviderRef
+	}
+	return ""
+}
+
+func (x *PaymentEvent) GetProviderEventId() string {
+	if x != nil {
+		return x.ProviderEventId
+	}
+	return ""
+}
+
+func (x *PaymentEvent) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *PaymentEvent) GetCreatedAt() []byte {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type PaymentIntent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	OrderId       string                 `protobuf:"bytes,2,opt,name=orderId,proto3" json:"orderId,omitempty"`
+	AccountId     string                 `protobuf:"bytes,3,opt,name=accountId,proto3" json:"accountId,omitempty"`
+	Provider      string                 `protobuf:"bytes,4,opt,name=provider,proto3" json:"provider,omitempty"`
+	ProviderRef   string                 `protobuf:"bytes,5,opt,name=providerRef,proto3" json:"providerRef,omitempty"`
+	Status        string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	Amount        *Money                 `protobuf:"bytes,7,opt,name=amount,proto3" json:"amount,omitempty"`
+	Authorized    *Money                 `protobuf:"bytes,8,opt,name=authorized,proto3" json:"authorized,omitempty"`
+	Captured      *Money                 `protobuf:"bytes,9,opt,name=captured,proto3" json:"captured,omitempty"`
+	Refunded      *Money                 `protobuf:"bytes,10,opt,name=refunded,proto3" json:"refunded,omitempty"`
+	FailureReason string                 `protobuf:"bytes,11,opt,name=failureReason,proto3" json:"failureReason,omitempty"`
+	CreatedAt     []byte                 `protobuf:"bytes,12,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	UpdatedAt     []byte                 `protobuf:"bytes,13,opt,name=updatedAt,proto3" json:"updatedAt,omitempty"`
+	Events        []*PaymentEvent        `protobuf:"bytes,14,rep,name=events,proto3" json:"events,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PaymentIntent) Reset() {
+	*x = PaymentIntent{}
+	mi := &file_payment_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PaymentIntent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PaymentIntent) ProtoMessage() {}
+
+func (x *PaymentIntent) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PaymentIntent.ProtoReflect.Descriptor instead.
+func (*PaymentIntent) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PaymentIntent) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PaymentIntent) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *PaymentIntent) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *PaymentIntent) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *PaymentIntent) GetProviderRef() string {
+	if x != nil {
+		return x.ProviderRef
+	}
+	return ""
+}
+
+func (x *PaymentIntent) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *PaymentIntent) GetAmount() *Money {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+func (x *PaymentIntent) GetAuthorized() *Money {
+	if x != nil {
+		return x.Authorized
+	}
+	return nil
+}
+
+func (x *PaymentIntent) GetCaptured() *Money {
+	if x != nil {
+		return x.Captured
+	}
+	return nil
+}
+
+func (x *PaymentIntent) GetRefunded() *Money {
+	if x != nil {
+		return x.Refunded
+	}
+	return nil
+}
+
+func (x *PaymentIntent) GetFailureReason() string {
+	if x != nil {
+		return x.FailureReason
+	}
+	return ""
+}
+
+func (x *PaymentIntent) GetCreatedAt() []byte {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *PaymentIntent) GetUpdatedAt() []byte {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *PaymentIntent) GetEvents() []*PaymentEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+type CreatePaymentIntentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=orderId,proto3" json:"orderId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePaymentIntentRequest) Reset() {
+	*x = CreatePaymentIntentRequest{}
+	mi := &file_payment_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePaymentIntentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePaymentIntentRequest) ProtoMessage() {}
+
+func (x *CreatePaymentIntentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePaymentIntentRequest.ProtoReflect.Descriptor instead.
+func (*CreatePaymentIntentRequest) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CreatePaymentIntentRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+type AuthorizePaymentRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	IntentId string                 `protobuf:"bytes,1,opt,name=intentId,proto3" json:"intentId,omitempty"`
+	// provider token of the customer's payment method
+	PaymentMethod string `protobuf:"bytes,2,opt,name=paymentMethod,proto3" json:"paymentMethod,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuthorizePaymentRequest) Reset() {
+	*x = AuthorizePaymentRequest{}
+	mi := &file_payment_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuthorizePaymentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuthorizePaymentRequest) ProtoMessage() {}
+
+func (x *AuthorizePaymentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuthorizePaymentRequest.ProtoReflect.Descriptor instead.
+func (*AuthorizePaymentRequest) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *AuthorizePaymentRequest) GetIntentId() string {
+	if x != nil {
+		return x.IntentId
+	}
+	return ""
+}
+
+func (x *AuthorizePaymentRequest) GetPaymentMethod() string {
+	if x != nil {
+		return x.PaymentMethod
+	}
+	return ""
+}
+
+// without amount the whole authorized amount is captured
+type CapturePaymentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	IntentId      string                 `protobuf:"bytes,1,opt,name=intentId,proto3" json:"intentId,omitempty"`
+	Amount        *Money                 `protobuf:"bytes,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CapturePaymentRequest) Reset() {
+	*x = CapturePaymentRequest{}
+	mi := &file_payment_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CapturePaymentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CapturePaymentRequest) ProtoMessage() {}
+
+func (x *CapturePaymentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CapturePaymentRequest.ProtoReflect.Descriptor instead.
+func (*CapturePaymentRequest) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CapturePaymentRequest) GetIntentId() string {
+	if x != nil {
+		return x.IntentId
+	}
+	return ""
+}
+
+func (x *CapturePaymentRequest) GetAmount() *Money {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+type VoidPaymentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	IntentId      string                 `protobuf:"bytes,1,opt,name=intentId,proto3" json:"intentId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VoidPaymentRequest) Reset() {
+	*x = VoidPaymentRequest{}
+	mi := &file_payment_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VoidPaymentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VoidPaymentRequest) ProtoMessage() {}
+
+func (x *VoidPaymentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VoidPaymentRequest.ProtoReflect.Descriptor instead.
+func (*VoidPaymentRequest) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *VoidPaymentRequest) GetIntentId() string {
+	if x != nil {
+		return x.IntentId
+	}
+	return ""
+}
+
+// without amount everything captured and not yet refunded is refunded
+type RefundPaymentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	IntentId      string                 `protobuf:"bytes,1,opt,name=intentId,proto3" json:"intentId,omitempty"`
+	Amount        *Money                 `protobuf:"bytes,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefundPaymentRequest) Reset() {
+	*x = RefundPaymentRequest{}
+	mi := &file_payment_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefundPaymentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefundPaymentRequest) ProtoMessage() {}
+
+func (x *RefundPaymentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefundPaymentRequest.ProtoReflect.Descriptor instead.
+func (*RefundPaymentRequest) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *RefundPaymentRequest) GetIntentId() string {
+	if x != nil {
+		return x.IntentId
+	}
+	return ""
+}
+
+func (x *RefundPaymentRequest) GetAmount() *Money {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+func (x *RefundPaymentRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type GetPaymentIntentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPaymentIntentRequest) Reset() {
+	*x = GetPaymentIntentRequest{}
+	mi := &file_payment_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPaymentIntentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPaymentIntentRequest) ProtoMessage() {}
+
+func (x *GetPaymentIntentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPaymentIntentRequest.ProtoReflect.Descriptor instead.
+func (*GetPaymentIntentRequest) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetPaymentIntentRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type PaymentIntentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Intent        *PaymentIntent         `protobuf:"bytes,1,opt,name=intent,proto3" json:"intent,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PaymentIntentResponse) Reset() {
+	*x = PaymentIntentResponse{}
+	mi := &file_payment_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PaymentIntentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PaymentIntentResponse) ProtoMessage() {}
+
+func (x *PaymentIntentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PaymentIntentResponse.ProtoReflect.Descriptor instead.
+func (*PaymentIntentResponse) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *PaymentIntentResponse) GetIntent() *PaymentIntent {
+	if x != nil {
+		return x.Intent
+	}
+	return nil
+}
+
+type GetPaymentsForOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=orderId,proto3" json:"orderId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPaymentsForOrderRequest) Reset() {
+	*x = GetPaymentsForOrderRequest{}
+	mi := &file_payment_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPaymentsForOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPaymentsForOrderRequest) ProtoMessage() {}
+
+func (x *GetPaymentsForOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPaymentsForOrderRequest.ProtoReflect.Descriptor instead.
+func (*GetPaymentsForOrderRequest) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetPaymentsForOrderRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+type GetPaymentsForOrderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Intents       []*PaymentIntent       `protobuf:"bytes,1,rep,name=intents,proto3" json:"intents,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPaymentsForOrderResponse) Reset() {
+	*x = GetPaymentsForOrderResponse{}
+	mi := &file_payment_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPaymentsForOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPaymentsForOrderResponse) ProtoMessage() {}
+
+func (x *GetPaymentsForOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPaymentsForOrderResponse.ProtoReflect.Descriptor instead.
+func (*GetPaymentsForOrderResponse) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetPaymentsForOrderResponse) GetIntents() []*PaymentIntent {
+	if x != nil {
+		return x.Intents
+	}
+	return nil
+}
+
+var File_payment_proto protoreflect.FileDescriptor
+
+const file_payment_proto_rawDesc = "" +
+	"\n" +
+	"\rpayment.proto\x12\bgenproto\";\n" +
+	"\x05Money\x12\x16\n" +
+	"\x06amount\x18\x01 \x01(\x03R\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\x02 \x01(\tR\bcurrency\"\xe7\x01\n" +
+	"\fPaymentEvent\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12'\n" +
+	"\x06amount\x18\x03 \x01(\v2\x0f.genproto.MoneyR\x06amount\x12 \n" +
+	"\vproviderRef\x18\x04 \x01(\tR\vproviderRef\x12(\n" +
+	"\x0fproviderEventId\x18\x05 \x01(\tR\x0fproviderEventId\x12\x18\n" +
+	"\amessage\x18\x06 \x01(\tR\amessage\x12\x1c\n" +
+	"\tcreatedAt\x18\a \x01(\fR\tcreatedAt\"\xf3\x03\n" +
+	"\rPaymentIntent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\aorderId\x18\x02 \x01(\tR\aorderId\x12\x1c\n" +
+	"\taccountId\x18\x03 \x01(\tR\taccountId\x12\x1a\n" +
+	"\bprovider\x18\x04 \x01(\tR\bprovider\x12 \n" +
+	"\vproviderRef\x18\x05 \x01(\tR\vproviderRef\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x12'\n" +
+	"\x06amount\x18\a \x01(\v2\x0f.genproto.MoneyR\x06amount\x12/\n" +
+	"\n" +
+	"authorized\x18\b \x01(\v2\x0f.genproto.MoneyR\n" +
+	"authorized\x12+\n" +
+	"\bcaptured\x18\t \x01(\v2\x0f.genproto.MoneyR\bcaptured\x12+\n" +
+	"\brefunded\x18\n" +
+	" \x01(\v2\x0f.genproto.MoneyR\brefunded\x12$\n" +
+	"\rfailureReason\x18\v \x01(\tR\rfailureReason\x12\x1c\n" +
+	"\tcreatedAt\x18\f \x01(\fR\tcreatedAt\x12\x1c\n" +
+	"\tupdatedAt\x18\r \x01(\fR\tupdatedAt\x12.\n" +
+	"\x06events\x18\x0e \x03(\v2\x16.genproto.PaymentEventR\x06events\"6\n" +
+	"\x1aCreatePaymentIntentRequest\x12\x18\n" +
+	"\aorderId\x18\x01 \x01(\tR\aorderId\"[\n" +
+	"\x17AuthorizePaymentRequest\x12\x1a\n" +
+	"\bintentId\x18\x01 \x01(\tR\bintentId\x12$\n" +
+	"\rpaymentMethod\x18\x02 \x01(\tR\rpaymentMethod\"\\\n" +
+	"\x15CapturePaymentRequest\x12\x1a\n" +
+	"\bintentId\x18\x01 \x01(\tR\bintentId\x12'\n" +
+	"\x06amount\x18\x02 \x01(\v2\x0f.genproto.MoneyR\x06amount\"0\n" +
+	"\x12VoidPaymentRequest\x12\x1a\n" +
+	"\bintentId\x18\x01 \x01(\tR\bintentId\"s\n" +
+	"\x14RefundPaymentRequest\x12\x1a\n" +
+	"\bintentId\x18\x01 \x01(\tR\bintentId\x12'\n" +
+	"\x06amount\x18\x02 \x01(\v2\x0f.genproto.MoneyR\x06amount\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\")\n" +
+	"\x17GetPaymentIntentRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"H\n" +
+	"\x15PaymentIntentResponse\x12/\n" +
+	"\x06intent\x18\x01 \x01(\v2\x17.genproto.PaymentIntentR\x06intent\"6\n" +
+	"\x1aGetPaymentsForOrderRequest\x12\x18\n" +
+	"\aorderId\x18\x01 \x01(\tR\aorderId\"P\n" +
+	"\x1bGetPaymentsForOrderResponse\x121\n" +
+	"\aintents\x18\x01 \x03(\v2\x17.genproto.PaymentIntentR\aintents2\xf6\x04\n" +
+	"\x0ePaymentService\x12\\\n" +
+	"\x13CreatePaymentIntent\x12$.genproto.CreatePaymentIntentRequest\x1a\x1f.genproto.PaymentIntentResponse\x12V\n" +
+	"\x10AuthorizePayment\x12!.genproto.AuthorizePaymentRequest\x1a\x1f.genproto.PaymentIntentResponse\x12R\n" +
+	"\x0eCapturePayment\x12\x1f.genproto.CapturePaymentRequest\x1a\x1f.genproto.PaymentIntentResponse\x12L\n" +
+	"\vVoidPayment\x12\x1c.genproto.VoidPaymentRequest\x1a\x1f.genproto.PaymentIntentResponse\x12P\n" +
+	"\rRefundPayment\x12\x1e.genproto.RefundPaymentRequest\x1a\x1f.genproto.PaymentIntentResponse\x12V\n" +
+	"\x10GetPaymentIntent\x12!.genproto.GetPaymentIntentRequest\x1a\x1f.genproto.PaymentIntentResponse\x12b\n" +
+	"\x13GetPaymentsForOrder\x12$.genproto.GetPaymentsForOrderRequest\x1a%.genproto.GetPaymentsForOrderResponseB+Z)github.com/wignn/micro-3/payment/genprotob\x06proto3"
+
+var (
+	file_payment_proto_rawDescOnce sync.Once
+	file_payment_proto_rawDescData []byte
+)
+
+func file_payment_proto_rawDescGZIP() []byte {
+	file_payment_proto_rawDescOnce.Do(func() {
+		file_payment_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_payment_proto_rawDesc), len(file_payment_proto_rawDesc)))
+	})
+	return file_payment_proto_rawDescData
+}
+
+var file_payment_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_payment_proto_goTypes = []any{
+	(*Money)(nil),                       // 0: genproto.Money
+	(*PaymentEvent)(nil),                // 1: genproto.PaymentEvent
+	(*PaymentIntent)(nil),               // 2: genproto.PaymentIntent
+	(*CreatePaymentIntentRequest)(nil),  // 3: genproto.CreatePaymentIntentRequest
+	(*AuthorizePaymentRequest)(nil),     // 4: genproto.AuthorizePaymentRequest
+	(*CapturePaymentRequest)(nil),       // 5: genproto.CapturePaymentRequest
+	(*VoidPaymentRequest)(nil),          // 6: genproto.VoidPaymentRequest
+	(*RefundPaymentRequest)(nil),        // 7: genproto.RefundPaymentRequest
+	(*GetPaymentIntentRequest)(nil),     // 8: genproto.GetPaymentIntentRequest
+	(*PaymentIntentResponse)(nil),       // 9: genproto.PaymentIntentResponse
+	(*GetPaymentsForOrderRequest)(nil),  // 10: genproto.GetPaymentsForOrderRequest
+	(*GetPaymentsForOrderResponse)(nil), // 11: genproto.GetPaymentsForOrderResponse
+}
+var file_payment_proto_depIdxs = []int32{
+	0,  // 0: genproto.PaymentEvent.amount:type_name -> genproto.Money
+	0,  // 1: genproto.PaymentIntent.amount:type_name -> genproto.Money
+	0,  // 2: genproto.PaymentIntent.authorized:type_name -> genproto.Money
+	0,  // 3: genproto.PaymentIntent.captured:type_name -> genproto.Money
+	0,  // 4: genproto.PaymentIntent.refunded:type_name -> genproto.Money
+	1,  // 5: genproto.PaymentIntent.events:type_name -> genproto.PaymentEvent
+	0,  // 6: genproto.CapturePaymentRequest.amount:type_name -> genproto.Money
+	0,  // 7: genproto.RefundPaymentRequest.amount:type_name -> genproto.Money
+	2,  // 8: genproto.PaymentIntentResponse.intent:type_name -> genproto.PaymentIntent
+	2,  // 9: genproto.GetPaymentsForOrderResponse.intents:type_name -> genproto.PaymentIntent
+	3,  // 10: genproto.PaymentService.CreatePaymentIntent:input_type -> genproto.CreatePaymentIntentRequest
+	4,  // 11: genproto.PaymentService.AuthorizePayment:input_type -> genproto.AuthorizePaymentRequest
+	5,  // 12: genproto.PaymentService.CapturePayment:input_type -> genproto.CapturePaymentRequest
+	6,  // 13: genproto.PaymentService.VoidPayment:input_type -> genproto.VoidPaymentRequest
+	7,  // 14: genproto.PaymentService.RefundPayment:input_type -> genproto.RefundPaymentRequest
+	8,  // 15: genproto.PaymentService.GetPaymentIntent:input_type -> genproto.GetPaymentIntentRequest
+	10, // 16: genproto.PaymentService.GetPaymentsForOrder:input_type -> genproto.GetPaymentsForOrderRequest
+	9,  // 17: genproto.PaymentService.CreatePaymentIntent:output_type -> genproto.PaymentIntentResponse
+	9,  // 18: genproto.PaymentService.AuthorizePayment:output_type -> genproto.PaymentIntentResponse
+	9,  // 19: genproto.PaymentService.CapturePayment:output_type -> genproto.PaymentIntentResponse
+	9,  // 20: genproto.PaymentService.VoidPayment:output_type -> genproto.PaymentIntentResponse
+	9,  // 21: genproto.PaymentService.RefundPayment:output_type -> genproto.PaymentIntentResponse
+	9,  // 22: genproto.PaymentService.GetPaymentIntent:output_type -> genproto.PaymentIntentResponse
+	11, // 23: genproto.PaymentService.GetPaymentsForOrder:output_type -> genproto.GetPaymentsForOrderResponse
+	17, // [17:24] is the sub-list for method output_type
+	10, // [10:17] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
+}
+
+func init() { file_payment_proto_init() }
+func file_payment_proto_init() {
+	if File_payment_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_payment_proto_rawDesc), len(file_payment_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   12,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_payment_proto_goTypes,
+		DependencyIndexes: file_payment_proto_depIdxs,
+		MessageInfos:      file_payment_proto_msgTypes,
+	}.Build()
+	File_payment_proto = out.File
+	file_payment_proto_goTypes = nil
+	file_payment_proto_depIdxs = nil
+}
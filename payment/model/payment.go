@@ -0,0 +1,67 @@
+package model
+
+import (
+	"time"
+
+	"github.com/wignn/micro-3/currency"
+)
+
+const (
+	// IntentPending waits for the provider to confirm an authorization
+	IntentPending    = "pending"
+	IntentAuthorized = "authorized"
+	IntentCaptured   = "captured"
+	IntentRefunded   = "refunded"
+	IntentVoided     = "voided"
+	IntentFailed     = "failed"
+)
+
+// Active tells whether the intent can still move money, an order has at most
+// one active intent
+func Active(status string) bool {
+	return status != IntentVoided && status != IntentFailed
+}
+
+// PaymentIntent is the payment of an order through a provider. Amounts are in
+// the order currency: Authorized is held on the customer's card, Captured was
+// taken from it and Refunded was given back.
+type PaymentIntent struct {
+	ID            string
+	OrderID       string
+	AccountID     string
+	Provider      string
+	ProviderRef   string
+	Status        string
+	Amount        currency.Money
+	Authorized    currency.Money
+	Captured      currency.Money
+	Refunded      currency.Money
+	FailureReason string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	Events        []PaymentEvent
+}
+
+// Refundable is the captured amount that was not refunded yet
+func (p *PaymentIntent) Refundable() currency.Money {
+	return currency.Money{Amount: p.Captured.Amount - p.Refunded.Amount, Currency: p.Captured.Currency}
+}
+
+const (
+	EventAuthorization = "authorization"
+	EventCapture       = "capture"
+	EventVoid          = "void"
+	EventRefund        = "refund"
+)
+
+// PaymentEvent records an operation on an intent and its outcome, events
+// delivered by provider webhooks carry the provider's event id
+type PaymentEvent struct {
+	Type            string
+	Status          string
+	Amount          currency.Money
+	ProviderRef     string
+	ProviderEventID string
+	Message         string
+	CreatedAt       time.Time
+}
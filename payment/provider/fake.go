@@ -0,0 +1,117 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/wignn/micro-3/currency"
+)
+
+// Payment methods understood by the fake provider, every other payment
+// method is authorized at once
+const (
+	FakeDecline           = "tok_decline"
+	FakeInsufficientFunds = "tok_insufficient_funds"
+	// FakeAsync authorizations are pending until a webhook confirms them
+	FakeAsync        = "tok_async"
+	FakeAsyncDecline = "tok_async_decline"
+)
+
+// Fake is an in-process provider for development and tests. Its outcomes
+// only depend on the payment method and its references only on the request,
+// so the same calls always give the same results.
+type Fake struct {
+	secret string
+	delay  time.Duration
+
+	mu     sync.Mutex
+	notify func(payload []byte, signature string)
+}
+
+// NewFake creates a fake provider that signs its webhooks with secret and
+// delivers them delay after a pending authorization
+func NewFake(secret string, delay time.Duration) *Fake {
+	return &Fake{secret: secret, delay: delay}
+}
+
+// OnWebhook sets the function webhooks are delivered to, without it pending
+// authorizations stay pending
+func (f *Fake) OnWebhook(fn func(payload []byte, signature string)) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.notify = fn
+}
+
+func (f *Fake) Name() string {
+	return "fake"
+}
+
+func (f *Fake) Authorize(c context.Context, r AuthorizeRequest) (*Result, error) {
+	ref := "auth_" + fakeID(r.IntentID)
+	switch r.PaymentMethod {
+	case FakeDecline:
+		return &Result{Reference: ref, Status: Failed, Message: "card declined"}, nil
+	case FakeInsufficientFunds:
+		return &Result{Reference: ref, Status: Failed, Message: "insufficient funds"}, nil
+	case FakeAsync:
+		f.deliver(Event{ID: "evt_" + fakeID(ref), Type: AuthorizationSucceeded, IntentID: r.IntentID, Reference: ref, Amount: r.Amount})
+		return &Result{Reference: ref, Status: Pending}, nil
+	case FakeAsyncDecline:
+		f.deliver(Event{ID: "evt_" + fakeID(ref), Type: AuthorizationFailed, IntentID: r.IntentID, Reference: ref, Amount: r.Amount, Message: "card declined"})
+		return &Result{Reference: ref, Status: Pending}, nil
+	}
+	return &Result{Reference: ref, Status: Succeeded}, nil
+}
+
+func (f *Fake) Capture(c context.Context, reference string, amount currency.Money, key string) (*Result, error) {
+	return &Result{Reference: "cap_" + fakeID(key), Status: Succeeded}, nil
+}
+
+func (f *Fake) Void(c context.Context, reference, key string) (*Result, error) {
+	return &Result{Reference: "void_" + fakeID(key), Status: Succeeded}, nil
+}
+
+func (f *Fake) Refund(c context.Context, reference string, amount currency.Money, key string) (*Result, error) {
+	return &Result{Reference: "re_" + fakeID(key), Status: Succeeded}, nil
+}
+
+func (f *Fake) ParseWebhook(payload []byte, signature string) (*Event, error) {
+	if err := Verify(f.secret, payload, signature, time.Now()); err != nil {
+		return nil, err
+	}
+	e := &Event{}
+	if err := json.Unmarshal(payload, e); err != nil || e.ID == "" || e.IntentID == "" {
+		return nil, ErrInvalidPayload
+	}
+	return e, nil
+}
+
+// deliver sends a signed event to the webhook function after the delay
+func (f *Fake) deliver(e Event) {
+	f.mu.Lock()
+	notify := f.notify
+	f.mu.Unlock()
+	if notify == nil {
+		log.Printf("no webhook receiver, event %s of %s is dropped\n", e.Type, e.Reference)
+		return
+	}
+	payload, err := json.Marshal(e)
+	if err != nil {
+		log.Println("failed to encode webhook event:", err)
+		return
+	}
+	go func() {
+		time.Sleep(f.delay)
+		notify(payload, Sign(f.secret, payload, time.Now()))
+	}()
+}
+
+func fakeID(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:12])
+}
@@ -0,0 +1,71 @@
+// Package provider abstracts the payment service providers that hold and move
+// the customer's money. Providers answer synchronously when they can and
+// confirm slower operations later through signed webhooks.
+package provider
+
+import (
+	"context"
+	"errors"
+
+	"github.com/wignn/micro-3/currency"
+)
+
+var (
+	ErrUnknownProvider  = errors.New("unknown payment provider")
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+	ErrInvalidPayload   = errors.New("invalid webhook payload")
+)
+
+// Result statuses
+const (
+	Succeeded = "succeeded"
+	Failed    = "failed"
+	// Pending results are settled by a webhook event
+	Pending = "pending"
+)
+
+// Result is the outcome of an operation, Reference identifies the
+// authorization, capture or refund at the provider
+type Result struct {
+	Reference string
+	Status    string
+	Message   string
+}
+
+// AuthorizeRequest holds amount on the payment method, IntentID doubles as
+// the idempotency key of the authorization
+type AuthorizeRequest struct {
+	IntentID      string
+	Amount        currency.Money
+	PaymentMethod string
+}
+
+// Event types delivered by webhooks
+const (
+	AuthorizationSucceeded = "authorization.succeeded"
+	AuthorizationFailed    = "authorization.failed"
+	CaptureSucceeded       = "capture.succeeded"
+)
+
+// Event is a webhook notification about the authorization Reference, IntentID
+// is echoed back from the authorize request
+type Event struct {
+	ID        string         `json:"id"`
+	Type      string         `json:"type"`
+	IntentID  string         `json:"intentId"`
+	Reference string         `json:"reference"`
+	Amount    currency.Money `json:"amount"`
+	Message   string         `json:"message,omitempty"`
+}
+
+// Provider is a payment service provider. The key of capture, void and refund
+// calls makes retries safe, the provider performs an operation once per key.
+type Provider interface {
+	Name() string
+	Authorize(c context.Context, r AuthorizeRequest) (*Result, error)
+	Capture(c context.Context, reference string, amount currency.Money, key string) (*Result, error)
+	Void(c context.Context, reference, key string) (*Result, error)
+	Refund(c context.Context, reference string, amount currency.Money, key string) (*Result, error)
+	// ParseWebhook verifies the signature of a webhook request and decodes its event
+	ParseWebhook(payload []byte, signature string) (*Event, error)
+}
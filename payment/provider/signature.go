@@ -0,0 +1,56 @@
+package provider
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SignatureTolerance is how old a webhook signature may be, older deliveries
+// are rejected as replays
+const SignatureTolerance = 5 * time.Minute
+
+// Sign returns the signature header of a webhook payload sent at t, in the
+// form t=<unix seconds>,v1=<hex HMAC-SHA256 of "<unix seconds>.<payload>">
+func Sign(secret string, payload []byte, t time.Time) string {
+	ts := strconv.FormatInt(t.Unix(), 10)
+	return fmt.Sprintf("t=%s,v1=%s", ts, digest(secret, ts, payload))
+}
+
+// Verify checks a signature header made by Sign, the timestamp must be
+// within SignatureTolerance of now
+func Verify(secret string, payload []byte, header string, now time.Time) error {
+	var ts, sig string
+	for _, part := range strings.Split(header, ",") {
+		k, v, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch k {
+		case "t":
+			ts = v
+		case "v1":
+			sig = v
+		}
+	}
+	unix, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil || sig == "" {
+		return ErrInvalidSignature
+	}
+	if age := now.Sub(time.Unix(unix, 0)); age > SignatureTolerance || age < -SignatureTolerance {
+		return ErrInvalidSignature
+	}
+	if !hmac.Equal([]byte(sig), []byte(digest(secret, ts, payload))) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+func digest(secret, ts string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(ts))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,252 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/wignn/micro-3/currency"
+	"github.com/wignn/micro-3/payment/model"
+)
+
+var (
+	ErrNotFound = errors.New("payment intent not found")
+	// ErrActiveIntentExists is returned when an order already has an intent
+	// that is neither voided nor failed
+	ErrActiveIntentExists = errors.New("order already has an active payment intent")
+	// ErrDuplicateEvent is returned when a webhook event was already applied
+	ErrDuplicateEvent = errors.New("payment event was already applied")
+)
+
+type PaymentRepository interface {
+	Close()
+	CreateIntent(c context.Context, p *model.PaymentIntent) error
+	GetIntent(c context.Context, id string) (*model.PaymentIntent, error)
+	GetIntentsForOrder(c context.Context, orderID string) ([]*model.PaymentIntent, error)
+	UpdateIntent(c context.Context, id string, fn func(p *model.PaymentIntent) (*model.PaymentEvent, error)) (*model.PaymentIntent, error)
+}
+
+type postgresRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresRepository(url string) (PaymentRepository, error) {
+	db, err := sql.Open("postgres", url)
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.Ping()
+	if err != nil {
+		return nil, err
+	}
+	return &postgresRepository{db}, nil
+}
+
+func (r *postgresRepository) Close() {
+	r.db.Close()
+}
+
+// inTx runs fn in a transaction that is committed when fn succeeds
+func (r *postgresRepository) inTx(c context.Context, fn func(tx *sql.Tx) error) (err error) {
+	tx, err := r.db.BeginTx(c, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+	return fn(tx)
+}
+
+func (r *postgresRepository) CreateIntent(c context.Context, p *model.PaymentIntent) error {
+	_, err := r.db.ExecContext(
+		c,
+		`INSERT INTO payment_intents(id, order_id, account_id, provider, provider_ref, status, currency, amount_minor, created_at, updated_at)
+		VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, $9)`,
+		p.ID,
+		p.OrderID,
+		p.AccountID,
+		p.Provider,
+		p.ProviderRef,
+		p.Status,
+		p.Amount.Currency,
+		p.Amount.Amount,
+		p.CreatedAt,
+	)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code.Name() == "unique_violation" {
+		return ErrActiveIntentExists
+	}
+	return err
+}
+
+func (r *postgresRepository) GetIntent(c context.Context, id string) (*model.PaymentIntent, error) {
+	return getIntent(c, r.db, id, false)
+}
+
+// GetIntentsForOrder returns the intents of an order, oldest first
+func (r *postgresRepository) GetIntentsForOrder(c context.Context, orderID string) ([]*model.PaymentIntent, error) {
+	rows, err := r.db.QueryContext(c, "SELECT id FROM payment_intents WHERE order_id = $1 ORDER BY created_at, id", orderID)
+	if err != nil {
+		return nil, err
+	}
+	ids := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	intents := []*model.PaymentIntent{}
+	for _, id := range ids {
+		p, err := r.GetIntent(c, id)
+		if err != nil {
+			return nil, err
+		}
+		intents = append(intents, p)
+	}
+	return intents, nil
+}
+
+// UpdateIntent locks the intent and passes it to fn, which may change it and
+// returns the event describing the change. The intent is stored with the
+// event when fn returns one, a nil event leaves it untouched. Operations on
+// one intent are serialized this way, provider calls included.
+func (r *postgresRepository) UpdateIntent(c context.Context, id string, fn func(p *model.PaymentIntent) (*model.PaymentEvent, error)) (*model.PaymentIntent, error) {
+	err := r.inTx(c, func(tx *sql.Tx) error {
+		p, err := getIntent(c, tx, id, true)
+		if err != nil {
+			return err
+		}
+		e, err := fn(p)
+		if err != nil || e == nil {
+			return err
+		}
+
+		p.UpdatedAt = time.Now().UTC()
+		if _, err := tx.ExecContext(
+			c,
+			`UPDATE payment_intents
+			SET provider_ref = $2, status = $3, authorized_minor = $4, captured_minor = $5, refunded_minor = $6, failure_reason = $7, updated_at = $8
+			WHERE id = $1`,
+			p.ID,
+			p.ProviderRef,
+			p.Status,
+			p.Authorized.Amount,
+			p.Captured.Amount,
+			p.Refunded.Amount,
+			p.FailureReason,
+			p.UpdatedAt,
+		); err != nil {
+			return err
+		}
+		return insertEvent(c, tx, p.ID, e)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r.GetIntent(c, id)
+}
+
+type querier interface {
+	QueryContext(c context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(c context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// getIntent reads an intent with its events, forUpdate locks the intent row
+// until the end of the transaction
+func getIntent(c context.Context, q querier, id string, forUpdate bool) (*model.PaymentIntent, error) {
+	query := `SELECT id, order_id, account_id, provider, provider_ref, status, currency,
+		amount_minor, authorized_minor, captured_minor, refunded_minor, failure_reason, created_at, updated_at
+		FROM payment_intents
+		WHERE id = $1`
+	if forUpdate {
+		query += " FOR UPDATE"
+	}
+	p := &model.PaymentIntent{}
+	var code string
+	err := q.QueryRowContext(c, query, id).Scan(
+		&p.ID,
+		&p.OrderID,
+		&p.AccountID,
+		&p.Provider,
+		&p.ProviderRef,
+		&p.Status,
+		&code,
+		&p.Amount.Amount,
+		&p.Authorized.Amount,
+		&p.Captured.Amount,
+		&p.Refunded.Amount,
+		&p.FailureReason,
+		&p.CreatedAt,
+		&p.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	p.Amount.Currency = code
+	p.Authorized.Currency = code
+	p.Captured.Currency = code
+	p.Refunded.Currency = code
+
+	rows, err := q.QueryContext(
+		c,
+		`SELECT type, status, amount_minor, provider_ref, COALESCE(provider_event_id, ''), message, created_at
+		FROM payment_events
+		WHERE intent_id = $1
+		ORDER BY id`,
+		id,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	p.Events = []model.PaymentEvent{}
+	for rows.Next() {
+		e := model.PaymentEvent{Amount: currency.Money{Currency: code}}
+		if err := rows.Scan(&e.Type, &e.Status, &e.Amount.Amount, &e.ProviderRef, &e.ProviderEventID, &e.Message, &e.CreatedAt); err != nil {
+			return nil, err
+		}
+		p.Events = append(p.Events, e)
+	}
+	return p, rows.Err()
+}
+
+func insertEvent(c context.Context, tx *sql.Tx, intentID string, e *model.PaymentEvent) error {
+	_, err := tx.ExecContext(
+		c,
+		`INSERT INTO payment_events(intent_id, type, status, amount_minor, provider_ref, provider_event_id, message, created_at)
+		VALUES($1, $2, $3, $4, $5, $6, $7, $8)`,
+		intentID,
+		e.Type,
+		e.Status,
+		e.Amount.Amount,
+		e.ProviderRef,
+		sql.NullString{String: e.ProviderEventID, Valid: e.ProviderEventID != ""},
+		e.Message,
+		e.CreatedAt,
+	)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code.Name() == "unique_violation" {
+		return ErrDuplicateEvent
+	}
+	return err
+}
@@ -0,0 +1,168 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+
+	"github.com/wignn/micro-3/currency"
+	"github.com/wignn/micro-3/payment/genproto"
+	"github.com/wignn/micro-3/payment/model"
+	"github.com/wignn/micro-3/payment/provider"
+	"github.com/wignn/micro-3/payment/repository"
+	"github.com/wignn/micro-3/payment/service"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+)
+
+type grpcServer struct {
+	service service.PaymentService
+	genproto.UnimplementedPaymentServiceServer
+}
+
+func ListenGRPC(s service.PaymentService, port int) error {
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	if err != nil {
+		return err
+	}
+	serv := grpc.NewServer()
+	genproto.RegisterPaymentServiceServer(serv, &grpcServer{service: s})
+	reflection.Register(serv)
+	return serv.Serve(lis)
+}
+
+// toStatus maps service errors to grpc codes, errors of the order service are
+// already statuses and pass through unchanged
+func toStatus(err error) error {
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, service.ErrMissingOrderID),
+		errors.Is(err, service.ErrMissingIntentID),
+		errors.Is(err, service.ErrMissingPaymentMethod),
+		errors.Is(err, service.ErrInvalidAmount),
+		errors.Is(err, service.ErrAmountTooLarge):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, service.ErrOrderNotPayable),
+		errors.Is(err, service.ErrInvalidState),
+		errors.Is(err, service.ErrProviderRefused):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, provider.ErrUnknownProvider):
+		return status.Error(codes.Unimplemented, err.Error())
+	}
+	return err
+}
+
+func moneyToProto(m currency.Money) *genproto.Money {
+	return &genproto.Money{Amount: m.Amount, Currency: m.Currency}
+}
+
+func moneyFromProto(m *genproto.Money) currency.Money {
+	if m == nil {
+		return currency.Money{}
+	}
+	return currency.Money{Amount: m.Amount, Currency: m.Currency}
+}
+
+func intentToProto(p *model.PaymentIntent) *genproto.PaymentIntent {
+	pp := &genproto.PaymentIntent{
+		Id:            p.ID,
+		OrderId:       p.OrderID,
+		AccountId:     p.AccountID,
+		Provider:      p.Provider,
+		ProviderRef:   p.ProviderRef,
+		Status:        p.Status,
+		Amount:        moneyToProto(p.Amount),
+		Authorized:    moneyToProto(p.Authorized),
+		Captured:      moneyToProto(p.Captured),
+		Refunded:      moneyToProto(p.Refunded),
+		FailureReason: p.FailureReason,
+		Events:        []*genproto.PaymentEvent{},
+	}
+	pp.CreatedAt, _ = p.CreatedAt.MarshalBinary()
+	pp.UpdatedAt, _ = p.UpdatedAt.MarshalBinary()
+	for _, e := range p.Events {
+		ep := &genproto.PaymentEvent{
+			Type:            e.Type,
+			Status:          e.Status,
+			Amount:          moneyToProto(e.Amount),
+			ProviderRef:     e.ProviderRef,
+			ProviderEventId: e.ProviderEventID,
+			Message:         e.Message,
+		}
+		ep.CreatedAt, _ = e.CreatedAt.MarshalBinary()
+		pp.Events = append(pp.Events, ep)
+	}
+	return pp
+}
+
+func (s *grpcServer) CreatePaymentIntent(c context.Context, r *genproto.CreatePaymentIntentRequest) (*genproto.PaymentIntentResponse, error) {
+	p, err := s.service.CreatePaymentIntent(c, r.OrderId)
+	if err != nil {
+		log.Printf("failed to create payment intent for order %s: %v\n", r.OrderId, err)
+		return nil, toStatus(err)
+	}
+	return &genproto.PaymentIntentResponse{Intent: intentToProto(p)}, nil
+}
+
+func (s *grpcServer) AuthorizePayment(c context.Context, r *genproto.AuthorizePaymentRequest) (*genproto.PaymentIntentResponse, error) {
+	p, err := s.service.AuthorizePayment(c, r.IntentId, r.PaymentMethod)
+	if err != nil {
+		log.Printf("failed to authorize payment %s: %v\n", r.IntentId, err)
+		return nil, toStatus(err)
+	}
+	return &genproto.PaymentIntentResponse{Intent: intentToProto(p)}, nil
+}
+
+func (s *grpcServer) CapturePayment(c context.Context, r *genproto.CapturePaymentRequest) (*genproto.PaymentIntentResponse, error) {
+	p, err := s.service.CapturePayment(c, r.IntentId, moneyFromProto(r.Amount))
+	if err != nil {
+		log.Printf("failed to capture payment %s: %v\n", r.IntentId, err)
+		return nil, toStatus(err)
+	}
+	return &genproto.PaymentIntentResponse{Intent: intentToProto(p)}, nil
+}
+
+func (s *grpcServer) VoidPayment(c context.Context, r *genproto.VoidPaymentRequest) (*genproto.PaymentIntentResponse, error) {
+	p, err := s.service.VoidPayment(c, r.IntentId)
+	if err != nil {
+		log.Printf("failed to void payment %s: %v\n", r.IntentId, err)
+		return nil, toStatus(err)
+	}
+	return &genproto.PaymentIntentResponse{Intent: intentToProto(p)}, nil
+}
+
+func (s *grpcServer) RefundPayment(c context.Context, r *genproto.RefundPaymentRequest) (*genproto.PaymentIntentResponse, error) {
+	p, err := s.service.RefundPayment(c, r.IntentId, moneyFromProto(r.Amount), r.Reason)
+	if err != nil {
+		log.Printf("failed to refund payment %s: %v\n", r.IntentId, err)
+		return nil, toStatus(err)
+	}
+	return &genproto.PaymentIntentResponse{Intent: intentToProto(p)}, nil
+}
+
+func (s *grpcServer) GetPaymentIntent(c context.Context, r *genproto.GetPaymentIntentRequest) (*genproto.PaymentIntentResponse, error) {
+	p, err := s.service.GetPaymentIntent(c, r.Id)
+	if err != nil {
+		log.Printf("failed to get payment %s: %v\n", r.Id, err)
+		return nil, toStatus(err)
+	}
+	return &genproto.PaymentIntentResponse{Intent: intentToProto(p)}, nil
+}
+
+func (s *grpcServer) GetPaymentsForOrder(c context.Context, r *genproto.GetPaymentsForOrderRequest) (*genproto.GetPaymentsForOrderResponse, error) {
+	intents, err := s.service.GetPaymentsForOrder(c, r.OrderId)
+	if err != nil {
+		log.Printf("failed to get payments of order %s: %v\n", r.OrderId, err)
+		return nil, toStatus(err)
+	}
+	res := &genproto.GetPaymentsForOrderResponse{Intents: []*genproto.PaymentIntent{}}
+	for _, p := range intents {
+		res.Intents = append(res.Intents, intentToProto(p))
+	}
+	return res, nil
+}
@@ -0,0 +1,50 @@
+package server
+
+import (
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/wignn/micro-3/payment/provider"
+	"github.com/wignn/micro-3/payment/repository"
+	"github.com/wignn/micro-3/payment/service"
+)
+
+// SignatureHeader carries the webhook signature, see provider.Sign
+const SignatureHeader = "Payment-Signature"
+
+// maxWebhookSize bounds the body of a webhook request
+const maxWebhookSize = 1 << 20
+
+// WebhookHandler receives provider webhooks at POST /webhooks/<provider>.
+// Providers retry deliveries that don't get a 2xx answer, so only requests
+// that can never succeed get a 4xx.
+func WebhookHandler(s service.PaymentService) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		name := strings.TrimPrefix(r.URL.Path, "/webhooks/")
+		payload, err := io.ReadAll(io.LimitReader(r.Body, maxWebhookSize))
+		if err != nil {
+			http.Error(w, "could not read body", http.StatusBadRequest)
+			return
+		}
+
+		err = s.HandleWebhook(r.Context(), name, payload, r.Header.Get(SignatureHeader))
+		switch {
+		case err == nil:
+			w.WriteHeader(http.StatusNoContent)
+		case errors.Is(err, provider.ErrUnknownProvider), errors.Is(err, repository.ErrNotFound):
+			http.Error(w, err.Error(), http.StatusNotFound)
+		case errors.Is(err, provider.ErrInvalidSignature), errors.Is(err, provider.ErrInvalidPayload):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		default:
+			log.Printf("failed to handle %s webhook: %v\n", name, err)
+			http.Error(w, "could not handle webhook", http.StatusInternalServerError)
+		}
+	})
+}
@@ -0,0 +1,411 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/segmentio/ksuid"
+	"github.com/wignn/micro-3/currency"
+	order "github.com/wignn/micro-3/order/client"
+	orderModel "github.com/wignn/micro-3/order/model"
+	"github.com/wignn/micro-3/payment/model"
+	"github.com/wignn/micro-3/payment/provider"
+	"github.com/wignn/micro-3/payment/repository"
+)
+
+var (
+	ErrMissingOrderID       = errors.New("order id is required")
+	ErrMissingIntentID      = errors.New("payment intent id is required")
+	ErrMissingPaymentMethod = errors.New("payment method is required")
+	ErrInvalidAmount        = errors.New("amount must be positive and in the currency of the payment")
+	ErrAmountTooLarge       = errors.New("amount exceeds what can be captured or refunded")
+	ErrOrderNotPayable      = errors.New("order can't be paid in its current status")
+	ErrInvalidState         = errors.New("operation is not allowed in the current payment status")
+	// ErrProviderRefused wraps the reason a provider refused a capture, void or refund
+	ErrProviderRefused = errors.New("payment provider refused the operation")
+)
+
+// actor is recorded on the order status changes made by payments
+const actor = "payment"
+
+type PaymentService interface {
+	CreatePaymentIntent(c context.Context, orderID string) (*model.PaymentIntent, error)
+	AuthorizePayment(c context.Context, intentID, paymentMethod string) (*model.PaymentIntent, error)
+	CapturePayment(c context.Context, intentID string, amount currency.Money) (*model.PaymentIntent, error)
+	VoidPayment(c context.Context, intentID string) (*model.PaymentIntent, error)
+	RefundPayment(c context.Context, intentID string, amount currency.Money, reason string) (*model.PaymentIntent, error)
+	GetPaymentIntent(c context.Context, id string) (*model.PaymentIntent, error)
+	GetPaymentsForOrder(c context.Context, orderID string) ([]*model.PaymentIntent, error)
+	HandleWebhook(c context.Context, providerName string, payload []byte, signature string) error
+}
+
+type paymentService struct {
+	repository  repository.PaymentRepository
+	orderClient *order.OrderClient
+	provider    provider.Provider
+}
+
+func NewPaymentService(r repository.PaymentRepository, orderClient *order.OrderClient, p provider.Provider) PaymentService {
+	return &paymentService{r, orderClient, p}
+}
+
+// CreatePaymentIntent starts the payment of a pending order for its total,
+// while the order has an intent that isn't voided or failed that intent is
+// returned instead
+func (s *paymentService) CreatePaymentIntent(c context.Context, orderID string) (*model.PaymentIntent, error) {
+	if orderID == "" {
+		return nil, ErrMissingOrderID
+	}
+	o, err := s.orderClient.GetOrder(c, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if o.Status != orderModel.OrderPending || o.TotalPrice.Amount <= 0 {
+		return nil, ErrOrderNotPayable
+	}
+
+	now := time.Now().UTC()
+	p := &model.PaymentIntent{
+		ID:        ksuid.New().String(),
+		OrderID:   o.ID,
+		AccountID: o.AccountID,
+		Provider:  s.provider.Name(),
+		Status:    model.IntentPending,
+		Amount:    o.TotalPrice,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	err = s.repository.CreateIntent(c, p)
+	if errors.Is(err, repository.ErrActiveIntentExists) {
+		return s.activeIntent(c, orderID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s.repository.GetIntent(c, p.ID)
+}
+
+func (s *paymentService) activeIntent(c context.Context, orderID string) (*model.PaymentIntent, error) {
+	intents, err := s.repository.GetIntentsForOrder(c, orderID)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range intents {
+		if model.Active(p.Status) {
+			return p, nil
+		}
+	}
+	return nil, repository.ErrNotFound
+}
+
+// AuthorizePayment holds the intent amount on the payment method. A declined
+// payment method fails the intent, the order is paid with a new intent. An
+// authorization the provider confirms later leaves the intent pending until
+// the webhook arrives. Authorizing again returns the intent unchanged.
+func (s *paymentService) AuthorizePayment(c context.Context, intentID, paymentMethod string) (*model.PaymentIntent, error) {
+	if intentID == "" {
+		return nil, ErrMissingIntentID
+	}
+	if paymentMethod == "" {
+		return nil, ErrMissingPaymentMethod
+	}
+	return s.repository.UpdateIntent(c, intentID, func(p *model.PaymentIntent) (*model.PaymentEvent, error) {
+		if p.Status != model.IntentPending {
+			if p.Status == model.IntentAuthorized || p.Status == model.IntentCaptured {
+				return nil, nil
+			}
+			return nil, ErrInvalidState
+		}
+		if p.ProviderRef != "" {
+			// waiting for the webhook
+			return nil, nil
+		}
+
+		res, err := s.provider.Authorize(c, provider.AuthorizeRequest{
+			IntentID:      p.ID,
+			Amount:        p.Amount,
+			PaymentMethod: paymentMethod,
+		})
+		if err != nil {
+			return nil, err
+		}
+		p.ProviderRef = res.Reference
+		switch res.Status {
+		case provider.Succeeded:
+			p.Status = model.IntentAuthorized
+			p.Authorized = p.Amount
+		case provider.Failed:
+			p.Status = model.IntentFailed
+			p.FailureReason = res.Message
+		}
+		return event(model.EventAuthorization, res, p.Amount), nil
+	})
+}
+
+// CapturePayment takes an authorized amount, a zero amount captures all of it.
+// The order is marked paid.
+func (s *paymentService) CapturePayment(c context.Context, intentID string, amount currency.Money) (*model.PaymentIntent, error) {
+	if intentID == "" {
+		return nil, ErrMissingIntentID
+	}
+	var res *provider.Result
+	p, err := s.repository.UpdateIntent(c, intentID, func(p *model.PaymentIntent) (*model.PaymentEvent, error) {
+		if p.Status == model.IntentCaptured && amount.Amount == 0 {
+			return nil, nil
+		}
+		if p.Status != model.IntentAuthorized {
+			return nil, ErrInvalidState
+		}
+		amount, err := checkAmount(amount, p.Authorized)
+		if err != nil {
+			return nil, err
+		}
+
+		res, err = s.provider.Capture(c, p.ProviderRef, amount, p.ID+":capture")
+		if err != nil {
+			return nil, err
+		}
+		if res.Status != provider.Failed {
+			p.Status = model.IntentCaptured
+			p.Captured = amount
+		}
+		return event(model.EventCapture, res, amount), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if res != nil && res.Status == provider.Failed {
+		return p, fmt.Errorf("%w: %s", ErrProviderRefused, res.Message)
+	}
+	s.syncOrder(c, p)
+	return p, nil
+}
+
+// VoidPayment releases an authorization that was not captured. The order is
+// left as it is, orders are cancelled through the order service, which voids
+// their payment.
+func (s *paymentService) VoidPayment(c context.Context, intentID string) (*model.PaymentIntent, error) {
+	if intentID == "" {
+		return nil, ErrMissingIntentID
+	}
+	var res *provider.Result
+	p, err := s.repository.UpdateIntent(c, intentID, func(p *model.PaymentIntent) (*model.PaymentEvent, error) {
+		switch p.Status {
+		case model.IntentVoided:
+			return nil, nil
+		case model.IntentAuthorized:
+		case model.IntentPending:
+			if p.ProviderRef != "" {
+				// the provider may still confirm the authorization
+				return nil, ErrInvalidState
+			}
+		default:
+			return nil, ErrInvalidState
+		}
+
+		if p.ProviderRef == "" {
+			// never sent to the provider
+			p.Status = model.IntentVoided
+			return &model.PaymentEvent{Type: model.EventVoid, Status: provider.Succeeded, CreatedAt: time.Now().UTC()}, nil
+		}
+		var err error
+		res, err = s.provider.Void(c, p.ProviderRef, p.ID+":void")
+		if err != nil {
+			return nil, err
+		}
+		if res.Status != provider.Failed {
+			p.Status = model.IntentVoided
+		}
+		return event(model.EventVoid, res, p.Authorized), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if res != nil && res.Status == provider.Failed {
+		return p, fmt.Errorf("%w: %s", ErrProviderRefused, res.Message)
+	}
+	return p, nil
+}
+
+// RefundPayment gives back part of a captured amount, a zero amount refunds
+// everything not refunded yet. The order is left as it is, orders are refunded
+// through the order service, which records the refunded lines and refunds
+// their payment.
+func (s *paymentService) RefundPayment(c context.Context, intentID string, amount currency.Money, reason string) (*model.PaymentIntent, error) {
+	if intentID == "" {
+		return nil, ErrMissingIntentID
+	}
+	var res *provider.Result
+	p, err := s.repository.UpdateIntent(c, intentID, func(p *model.PaymentIntent) (*model.PaymentEvent, error) {
+		if p.Status != model.IntentCaptured {
+			return nil, ErrInvalidState
+		}
+		amount, err := checkAmount(amount, p.Refundable())
+		if err != nil {
+			return nil, err
+		}
+
+		// the key of a refund retried after a lost response is the same
+		refunds := 0
+		for _, e := range p.Events {
+			if e.Type == model.EventRefund && e.Status != provider.Failed {
+				refunds++
+			}
+		}
+		res, err = s.provider.Refund(c, p.ProviderRef, amount, fmt.Sprintf("%s:refund:%d", p.ID, refunds))
+		if err != nil {
+			return nil, err
+		}
+		if res.Status != provider.Failed {
+			p.Refunded.Amount += amount.Amount
+			if p.Refunded.Amount == p.Captured.Amount {
+				p.Status = model.IntentRefunded
+			}
+		}
+		e := event(model.EventRefund, res, amount)
+		if reason != "" && e.Message == "" {
+			e.Message = reason
+		}
+		return e, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if res != nil && res.Status == provider.Failed {
+		return p, fmt.Errorf("%w: %s", ErrProviderRefused, res.Message)
+	}
+	return p, nil
+}
+
+func (s *paymentService) GetPaymentIntent(c context.Context, id string) (*model.PaymentIntent, error) {
+	if id == "" {
+		return nil, ErrMissingIntentID
+	}
+	return s.repository.GetIntent(c, id)
+}
+
+func (s *paymentService) GetPaymentsForOrder(c context.Context, orderID string) ([]*model.PaymentIntent, error) {
+	if orderID == "" {
+		return nil, ErrMissingOrderID
+	}
+	return s.repository.GetIntentsForOrder(c, orderID)
+}
+
+// HandleWebhook applies a signed provider event, deliveries of an event that
+// was already applied are acknowledged without effect
+func (s *paymentService) HandleWebhook(c context.Context, providerName string, payload []byte, signature string) error {
+	if providerName != s.provider.Name() {
+		return provider.ErrUnknownProvider
+	}
+	e, err := s.provider.ParseWebhook(payload, signature)
+	if err != nil {
+		return err
+	}
+
+	p, err := s.repository.UpdateIntent(c, e.IntentID, func(p *model.PaymentIntent) (*model.PaymentEvent, error) {
+		if p.ProviderRef != e.Reference {
+			return nil, provider.ErrInvalidPayload
+		}
+		for _, applied := range p.Events {
+			if applied.ProviderEventID == e.ID {
+				return nil, nil
+			}
+		}
+
+		status, amount := provider.Succeeded, p.Amount
+		switch {
+		case e.Type == provider.AuthorizationSucceeded && p.Status == model.IntentPending:
+			p.Status = model.IntentAuthorized
+			p.Authorized = p.Amount
+		case e.Type == provider.AuthorizationFailed && p.Status == model.IntentPending:
+			p.Status = model.IntentFailed
+			p.FailureReason = e.Message
+			status = provider.Failed
+		case e.Type == provider.CaptureSucceeded && p.Status == model.IntentAuthorized:
+			p.Status = model.IntentCaptured
+			p.Captured = p.Authorized
+			if e.Amount.Currency == p.Authorized.Currency && e.Amount.Amount > 0 && e.Amount.Amount <= p.Authorized.Amount {
+				p.Captured = e.Amount
+			}
+			amount = p.Captured
+		default:
+			log.Printf("ignoring %s event %s of payment %s in status %s\n", e.Type, e.ID, p.ID, p.Status)
+			return nil, nil
+		}
+		return &model.PaymentEvent{
+			Type:            eventType(e.Type),
+			Status:          status,
+			Amount:          amount,
+			ProviderRef:     e.Reference,
+			ProviderEventID: e.ID,
+			Message:         e.Message,
+			CreatedAt:       time.Now().UTC(),
+		}, nil
+	})
+	if errors.Is(err, repository.ErrDuplicateEvent) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	s.syncOrder(c, p)
+	return nil
+}
+
+// syncOrder marks the order of a captured payment paid. Voids and refunds
+// are started by the order service, which moves the order itself. The
+// payment is already recorded, failures are logged.
+func (s *paymentService) syncOrder(c context.Context, p *model.PaymentIntent) {
+	if p.Status != model.IntentCaptured {
+		return
+	}
+	target, reason := orderModel.OrderPaid, "payment "+p.ID+" captured"
+
+	o, err := s.orderClient.GetOrder(c, p.OrderID)
+	if err != nil {
+		log.Printf("failed to get order %s of payment %s: %v\n", p.OrderID, p.ID, err)
+		return
+	}
+	if o.Status == target || !orderModel.CanTransition(o.Status, target) {
+		return
+	}
+	if _, err = s.orderClient.UpdateOrderStatus(c, p.OrderID, target, actor, reason); err != nil {
+		log.Printf("failed to move order %s to %s: %v\n", p.OrderID, target, err)
+	}
+}
+
+// checkAmount defaults a zero amount to max and rejects amounts above max or
+// in another currency
+func checkAmount(amount, max currency.Money) (currency.Money, error) {
+	if amount.Amount == 0 {
+		return max, nil
+	}
+	if amount.Amount < 0 || amount.Currency != max.Currency {
+		return currency.Money{}, ErrInvalidAmount
+	}
+	if amount.Amount > max.Amount {
+		return currency.Money{}, ErrAmountTooLarge
+	}
+	return amount, nil
+}
+
+func event(eventType string, res *provider.Result, amount currency.Money) *model.PaymentEvent {
+	return &model.PaymentEvent{
+		Type:        eventType,
+		Status:      res.Status,
+		Amount:      amount,
+		ProviderRef: res.Reference,
+		Message:     res.Message,
+		CreatedAt:   time.Now().UTC(),
+	}
+}
+
+func eventType(webhookType string) string {
+	if webhookType == provider.CaptureSucceeded {
+		return model.EventCapture
+	}
+	return model.EventAuthorization
+}
@@ -0,0 +1,36 @@
+CREATE TABLE IF NOT EXISTS payment_intents (
+  id CHAR(27) PRIMARY KEY,
+  order_id CHAR(27) NOT NULL,
+  account_id CHAR(27) NOT NULL,
+  provider VARCHAR(32) NOT NULL,
+  provider_ref VARCHAR(64) NOT NULL DEFAULT '',
+  status VARCHAR(16) NOT NULL,
+  currency CHAR(3) NOT NULL,
+  amount_minor BIGINT NOT NULL CHECK (amount_minor > 0),
+  authorized_minor BIGINT NOT NULL DEFAULT 0,
+  captured_minor BIGINT NOT NULL DEFAULT 0 CHECK (captured_minor <= authorized_minor),
+  refunded_minor BIGINT NOT NULL DEFAULT 0 CHECK (refunded_minor <= captured_minor),
+  failure_reason TEXT NOT NULL DEFAULT '',
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+  updated_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+-- an order has at most one intent that can still move money
+CREATE UNIQUE INDEX IF NOT EXISTS payment_intents_active_order ON payment_intents (order_id)
+  WHERE status NOT IN ('voided', 'failed');
+
+CREATE TABLE IF NOT EXISTS payment_events (
+  id BIGSERIAL PRIMARY KEY,
+  intent_id CHAR(27) REFERENCES payment_intents (id) ON DELETE CASCADE,
+  type VARCHAR(16) NOT NULL,
+  status VARCHAR(16) NOT NULL,
+  amount_minor BIGINT NOT NULL DEFAULT 0,
+  provider_ref VARCHAR(64) NOT NULL DEFAULT '',
+  provider_event_id VARCHAR(64),
+  message TEXT NOT NULL DEFAULT '',
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+-- webhook deliveries are retried, each event is applied once
+CREATE UNIQUE INDEX IF NOT EXISTS payment_events_provider_event ON payment_events (intent_id, provider_event_id)
+  WHERE provider_event_id IS NOT NULL;
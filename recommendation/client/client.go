@@ -0,0 +1,73 @@
+package client
+
+import (
+	"context"
+	"log"
+
+	"github.com/wignn/micro-3/recommendation/genproto"
+	"github.com/wignn/micro-3/recommendation/model"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+type RecommendationClient struct {
+	conn    *grpc.ClientConn
+	service genproto.RecommendationServiceClient
+}
+
+func NewClient(url string) (*RecommendationClient, error) {
+	conn, err := grpc.NewClient(url, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		log.Printf("failed to connect to recommendation service: %v\n", err)
+		return nil, err
+	}
+
+	c := genproto.NewRecommendationServiceClient(conn)
+	return &RecommendationClient{conn: conn, service: c}, nil
+}
+
+func (cl *RecommendationClient) Close() {
+	cl.conn.Close()
+}
+
+func recommendationsFromProto(res *genproto.RecommendationsResponse) []model.Recommendation {
+	recs := []model.Recommendation{}
+	for _, rec := range res.Recommendations {
+		recs = append(recs, model.Recommendation{ProductID: rec.ProductId, Score: rec.Score})
+	}
+	return recs
+}
+
+func (cl *RecommendationClient) GetRelatedProducts(c context.Context, productID string, take uint64) ([]model.Recommendation, error) {
+	res, err := cl.service.GetRelatedProducts(c, &genproto.GetRelatedProductsRequest{
+		ProductId: productID,
+		Take:      take,
+	})
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return recommendationsFromProto(res), nil
+}
+
+func (cl *RecommendationClient) GetRecommendationsForAccount(c context.Context, accountID string, take uint64) ([]model.Recommendation, error) {
+	res, err := cl.service.GetRecommendationsForAccount(c, &genproto.GetRecommendationsForAccountRequest{
+		AccountId: accountID,
+		Take:      take,
+	})
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return recommendationsFromProto(res), nil
+}
+
+// Refresh recomputes the recommendations now instead of waiting for the next run
+func (cl *RecommendationClient) Refresh(c context.Context) (products, accounts uint64, err error) {
+	res, err := cl.service.Refresh(c, &genproto.RefreshRequest{})
+	if err != nil {
+		log.Println(err)
+		return 0, 0, err
+	}
+	return res.Products, res.Accounts, nil
+}
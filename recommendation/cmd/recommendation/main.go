@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/kelseyhightower/envconfig"
+	"github.com/tinrab/retry"
+	catalog "github.com/wignn/micro-3/catalog/client"
+	order "github.com/wignn/micro-3/order/client"
+	"github.com/wignn/micro-3/recommendation/repository"
+	"github.com/wignn/micro-3/recommendation/server"
+	"github.com/wignn/micro-3/recommendation/service"
+)
+
+type Config struct {
+	DatabaseURL     string        `envconfig:"DATABASE_URL"`
+	CatalogURL      string        `envconfig:"CATALOG_SERVICE_URL"`
+	OrderURL        string        `envconfig:"ORDER_SERVICE_URL"`
+	RefreshInterval time.Duration `envconfig:"REFRESH_INTERVAL" default:"1h"`
+	MaxResults      int           `envconfig:"MAX_RESULTS" default:"20"`
+	PORT            int           `envconfig:"PORT" default:"50051"`
+}
+
+func main() {
+	var cfg Config
+	if err := envconfig.Process("", &cfg); err != nil {
+		log.Fatal("Failed to process environment variables:", err)
+	}
+
+	var r repository.RecommendationRepository
+	retry.ForeverSleep(2*time.Second, func(_ int) (err error) {
+		r, err = repository.NewPostgresRepository(cfg.DatabaseURL)
+		if err != nil {
+			log.Println(err)
+		}
+		return
+	})
+	defer r.Close()
+
+	catalogClient, err := catalog.NewClient(cfg.CatalogURL)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer catalogClient.Close()
+
+	orderClient, err := order.NewClient(cfg.OrderURL)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer orderClient.Close()
+
+	s := service.NewRecommendationService(r, catalogClient, orderClient, cfg.MaxResults)
+	go refresh(s, cfg.RefreshInterval)
+
+	log.Println("listening on port", cfg.PORT)
+	log.Fatal(server.ListenGRPC(s, cfg.PORT))
+}
+
+// refresh recomputes the recommendations at startup and then every interval
+func refresh(s service.RecommendationService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), interval)
+		snapshot, err := s.Refresh(ctx)
+		cancel()
+		if err != nil {
+			log.Println("failed to refresh recommendations:", err)
+		} else {
+			log.Printf("refreshed recommendations for %d products and %d accounts\n", len(snapshot.Related), len(snapshot.Accounts))
+		}
+		<-ticker.C
+	}
+}
@@ -0,0 +1,395 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v6.31.0
+// source: recommendation.proto
+
+package genproto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Recommendation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=productId,proto3" json:"productId,omitempty"`
+	Score         float64                `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Recommendation) Reset() {
+	*x = Recommendation{}
+	mi := &file_recommendation_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Recommendation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Recommendation) ProtoMessage() {}
+
+func (x *Recommendation) ProtoReflect() protoreflect.Message {
+	mi := &file_recommendation_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Recommendation.ProtoReflect.Descriptor instead.
+func (*Recommendation) Descriptor() ([]byte, []int) {
+	return file_recommendation_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Recommendation) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *Recommendation) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+type GetRelatedProductsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=productId,proto3" json:"productId,omitempty"`
+	Take          uint64                 `protobuf:"varint,2,opt,name=take,proto3" json:"take,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRelatedProductsRequest) Reset() {
+	*x = GetRelatedProductsRequest{}
+	mi := &file_recommendation_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRelatedProductsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRelatedProductsRequest) ProtoMessage() {}
+
+func (x *GetRelatedProductsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_recommendation_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRelatedProductsRequest.ProtoReflect.Descriptor instead.
+func (*GetRelatedProductsRequest) Descriptor() ([]byte, []int) {
+	return file_recommendation_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetRelatedProductsRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *GetRelatedProductsRequest) GetTake() uint64 {
+	if x != nil {
+		return x.Take
+	}
+	return 0
+}
+
+type GetRecommendationsForAccountRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     string                 `protobuf:"bytes,1,opt,name=accountId,proto3" json:"accountId,omitempty"`
+	Take          uint64                 `protobuf:"varint,2,opt,name=take,proto3" json:"take,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRecommendationsForAccountRequest) Reset() {
+	*x = GetRecommendationsForAccountRequest{}
+	mi := &file_recommendation_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRecommendationsForAccountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRecommendationsForAccountRequest) ProtoMessage() {}
+
+func (x *GetRecommendationsForAccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_recommendation_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRecommendationsForAccountRequest.ProtoReflect.Descriptor instead.
+func (*GetRecommendationsForAccountRequest) Descriptor() ([]byte, []int) {
+	return file_recommendation_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetRecommendationsForAccountRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *GetRecommendationsForAccountRequest) GetTake() uint64 {
+	if x != nil {
+		return x.Take
+	}
+	return 0
+}
+
+type RecommendationsResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Recommendations []*Recommendation      `protobuf:"bytes,1,rep,name=recommendations,proto3" json:"recommendations,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *RecommendationsResponse) Reset() {
+	*x = RecommendationsResponse{}
+	mi := &file_recommendation_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecommendationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecommendationsResponse) ProtoMessage() {}
+
+func (x *RecommendationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_recommendation_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecommendationsResponse.ProtoReflect.Descriptor instead.
+func (*RecommendationsResponse) Descriptor() ([]byte, []int) {
+	return file_recommendation_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *RecommendationsResponse) GetRecommendations() []*Recommendation {
+	if x != nil {
+		return x.Recommendations
+	}
+	return nil
+}
+
+type RefreshRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefreshRequest) Reset() {
+	*x = RefreshRequest{}
+	mi := &file_recommendation_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefreshRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefreshRequest) ProtoMessage() {}
+
+func (x *RefreshRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_recommendation_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefreshRequest.ProtoReflect.Descriptor instead.
+func (*RefreshRequest) Descriptor() ([]byte, []int) {
+	return file_recommendation_proto_rawDescGZIP(), []int{4}
+}
+
+type RefreshResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Products      uint64                 `protobuf:"varint,1,opt,name=products,proto3" json:"products,omitempty"`
+	Accounts      uint64                 `protobuf:"varint,2,opt,name=accounts,proto3" json:"accounts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefreshResponse) Reset() {
+	*x = RefreshResponse{}
+	mi := &file_recommendation_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefreshResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefreshResponse) ProtoMessage() {}
+
+func (x *RefreshResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_recommendation_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefreshResponse.ProtoReflect.Descriptor instead.
+func (*RefreshResponse) Descriptor() ([]byte, []int) {
+	return file_recommendation_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *RefreshResponse) GetProducts() uint64 {
+	if x != nil {
+		return x.Products
+	}
+	return 0
+}
+
+func (x *RefreshResponse) GetAccounts() uint64 {
+	if x != nil {
+		return x.Accounts
+	}
+	return 0
+}
+
+var File_recommendation_proto protoreflect.FileDescriptor
+
+const file_recommendation_proto_rawDesc = "" +
+	"\n" +
+	"\x14recommendation.proto\x12\bgenproto\"D\n" +
+	"\x0eRecommendation\x12\x1c\n" +
+	"\tproductId\x18\x01 \x01(\tR\tproductId\x12\x14\n" +
+	"\x05score\x18\x02 \x01(\x01R\x05score\"M\n" +
+	"\x19GetRelatedProductsRequest\x12\x1c\n" +
+	"\tproductId\x18\x01 \x01(\tR\tproductId\x12\x12\n" +
+	"\x04take\x18\x02 \x01(\x04R\x04take\"W\n" +
+	"#GetRecommendationsForAccountRequest\x12\x1c\n" +
+	"\taccountId\x18\x01 \x01(\tR\taccountId\x12\x12\n" +
+	"\x04take\x18\x02 \x01(\x04R\x04take\"]\n" +
+	"\x17RecommendationsResponse\x12B\n" +
+	"\x0frecommendations\x18\x01 \x03(\v2\x18.genproto.RecommendationR\x0frecommendations\"\x10\n" +
+	"\x0eRefreshRequest\"I\n" +
+	"\x0fRefreshResponse\x12\x1a\n" +
+	"\bproducts\x18\x01 \x01(\x04R\bproducts\x12\x1a\n" +
+	"\baccounts\x18\x02 \x01(\x04R\baccounts2\xa7\x02\n" +
+	"\x15RecommendationService\x12\\\n" +
+	"\x12GetRelatedProducts\x12#.genproto.GetRelatedProductsRequest\x1a!.genproto.RecommendationsResponse\x12p\n" +
+	"\x1cGetRecommendationsForAccount\x12-.genproto.GetRecommendationsForAccountRequest\x1a!.genproto.RecommendationsResponse\x12>\n" +
+	"\aRefresh\x12\x18.genproto.RefreshRequest\x1a\x19.genproto.RefreshResponseB2Z0github.com/wignn/micro-3/recommendation/genprotob\x06proto3"
+
+var (
+	file_recommendation_proto_rawDescOnce sync.Once
+	file_recommendation_proto_rawDescData []byte
+)
+
+func file_recommendation_proto_rawDescGZIP() []byte {
+	file_recommendation_proto_rawDescOnce.Do(func() {
+		file_recommendation_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_recommendation_proto_rawDesc), len(file_recommendation_proto_rawDesc)))
+	})
+	return file_recommendation_proto_rawDescData
+}
+
+var file_recommendation_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_recommendation_proto_goTypes = []any{
+	(*Recommendation)(nil),                      // 0: genproto.Recommendation
+	(*GetRelatedProductsRequest)(nil),           // 1: genproto.GetRelatedProductsRequest
+	(*GetRecommendationsForAccountRequest)(nil), // 2: genproto.GetRecommendationsForAccountRequest
+	(*RecommendationsResponse)(nil),             // 3: genproto.RecommendationsResponse
+	(*RefreshRequest)(nil),                      // 4: genproto.RefreshRequest
+	(*RefreshResponse)(nil),                     // 5: genproto.RefreshResponse
+}
+var file_recommendation_proto_depIdxs = []int32{
+	0, // 0: genproto.RecommendationsResponse.recommendations:type_name -> genproto.Recommendation
+	1, // 1: genproto.RecommendationService.GetRelatedProducts:input_type -> genproto.GetRelatedProductsRequest
+	2, // 2: genproto.RecommendationService.GetRecommendationsForAccount:input_type -> genproto.GetRecommendationsForAccountRequest
+	4, // 3: genproto.RecommendationService.Refresh:input_type -> genproto.RefreshRequest
+	3, // 4: genproto.RecommendationService.GetRelatedProducts:output_type -> genproto.RecommendationsResponse
+	3, // 5: genproto.RecommendationService.GetRecommendationsForAccount:output_type -> genproto.RecommendationsResponse
+	5, // 6: genproto.RecommendationService.Refresh:output_type -> genproto.RefreshResponse
+	4, // [4:7] is the sub-list for method output_type
+	1, // [1:4] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_recommendation_proto_init() }
+func file_recommendation_proto_init() {
+	if File_recommendation_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_recommendation_proto_rawDesc), len(file_recommendation_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_recommendation_proto_goTypes,
+		DependencyIndexes: file_recommendation_proto_depIdxs,
+		MessageInfos:      file_recommendation_proto_msgTypes,
+	}.Build()
+	File_recommendation_proto = out.File
+	file_recommendation_proto_goTypes = nil
+	file_recommendation_proto_depIdxs = nil
+}
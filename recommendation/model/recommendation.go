@@ -0,0 +1,14 @@
+package model
+
+// Recommendation is a product suggested for a product page or an account,
+// higher scores are better
+type Recommendation struct {
+	ProductID string
+	Score     float64
+}
+
+// Snapshot is the output of a recommendation run, it replaces the previous one
+type Snapshot struct {
+	Related  map[string][]Recommendation
+	Accounts map[string][]Recommendation
+}
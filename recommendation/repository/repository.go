@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/lib/pq"
+	"github.com/wignn/micro-3/recommendation/model"
+)
+
+type RecommendationRepository interface {
+	Close()
+	GetRelatedProducts(c context.Context, productID string, take uint64) ([]model.Recommendation, error)
+	GetRecommendationsForAccount(c context.Context, accountID string, take uint64) ([]model.Recommendation, error)
+	ReplaceSnapshot(c context.Context, s *model.Snapshot) error
+}
+
+type postgresRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresRepository(url string) (RecommendationRepository, error) {
+	db, err := sql.Open("postgres", url)
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.Ping()
+	if err != nil {
+		return nil, err
+	}
+	return &postgresRepository{db}, nil
+}
+
+func (r *postgresRepository) Close() {
+	r.db.Close()
+}
+
+func (r *postgresRepository) GetRelatedProducts(c context.Context, productID string, take uint64) ([]model.Recommendation, error) {
+	return r.list(
+		c,
+		"SELECT related_id, score FROM related_products WHERE product_id = $1 ORDER BY score DESC, related_id LIMIT $2",
+		productID,
+		take,
+	)
+}
+
+func (r *postgresRepository) GetRecommendationsForAccount(c context.Context, accountID string, take uint64) ([]model.Recommendation, error) {
+	return r.list(
+		c,
+		"SELECT product_id, score FROM account_recommendations WHERE account_id = $1 ORDER BY score DESC, product_id LIMIT $2",
+		accountID,
+		take,
+	)
+}
+
+func (r *postgresRepository) list(c context.Context, query, id string, take uint64) ([]model.Recommendation, error) {
+	rows, err := r.db.QueryContext(c, query, id, take)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	recommendations := []model.Recommendation{}
+	for rows.Next() {
+		rec := model.Recommendation{}
+		if err := rows.Scan(&rec.ProductID, &rec.Score); err != nil {
+			return nil, err
+		}
+		recommendations = append(recommendations, rec)
+	}
+	return recommendations, rows.Err()
+}
+
+// ReplaceSnapshot swaps the stored recommendations for a new run in a single
+// transaction, readers see either the old or the new snapshot
+func (r *postgresRepository) ReplaceSnapshot(c context.Context, s *model.Snapshot) (err error) {
+	tx, err := r.db.BeginTx(c, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	if err = copyRecommendations(c, tx, "related_products", "product_id", "related_id", s.Related); err != nil {
+		return
+	}
+	err = copyRecommendations(c, tx, "account_recommendations", "account_id", "product_id", s.Accounts)
+	return
+}
+
+// copyRecommendations empties table and bulk inserts recs, the map key goes
+// to keyColumn and the recommended product to productColumn
+func copyRecommendations(c context.Context, tx *sql.Tx, table, keyColumn, productColumn string, recs map[string][]model.Recommendation) error {
+	if _, err := tx.ExecContext(c, "DELETE FROM "+table); err != nil {
+		return err
+	}
+
+	stmt, err := tx.PrepareContext(c, pq.CopyIn(table, keyColumn, productColumn, "score"))
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for id, list := range recs {
+		for _, rec := range list {
+			if _, err := stmt.ExecContext(c, id, rec.ProductID, rec.Score); err != nil {
+				return err
+			}
+		}
+	}
+	_, err = stmt.ExecContext(c)
+	return err
+}
@@ -0,0 +1,81 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+
+	"github.com/wignn/micro-3/recommendation/genproto"
+	"github.com/wignn/micro-3/recommendation/model"
+	"github.com/wignn/micro-3/recommendation/service"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+)
+
+type grpcServer struct {
+	service service.RecommendationService
+	genproto.UnimplementedRecommendationServiceServer
+}
+
+func ListenGRPC(s service.RecommendationService, port int) error {
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	if err != nil {
+		return err
+	}
+	serv := grpc.NewServer()
+	genproto.RegisterRecommendationServiceServer(serv, &grpcServer{service: s})
+	reflection.Register(serv)
+	return serv.Serve(lis)
+}
+
+func toStatus(err error) error {
+	if errors.Is(err, service.ErrMissingID) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return err
+}
+
+func recommendationsToProto(recs []model.Recommendation) *genproto.RecommendationsResponse {
+	res := &genproto.RecommendationsResponse{Recommendations: []*genproto.Recommendation{}}
+	for _, rec := range recs {
+		res.Recommendations = append(res.Recommendations, &genproto.Recommendation{
+			ProductId: rec.ProductID,
+			Score:     rec.Score,
+		})
+	}
+	return res
+}
+
+func (s *grpcServer) GetRelatedProducts(c context.Context, r *genproto.GetRelatedProductsRequest) (*genproto.RecommendationsResponse, error) {
+	recs, err := s.service.GetRelatedProducts(c, r.ProductId, r.Take)
+	if err != nil {
+		log.Println(err)
+		return nil, toStatus(err)
+	}
+	return recommendationsToProto(recs), nil
+}
+
+func (s *grpcServer) GetRecommendationsForAccount(c context.Context, r *genproto.GetRecommendationsForAccountRequest) (*genproto.RecommendationsResponse, error) {
+	recs, err := s.service.GetRecommendationsForAccount(c, r.AccountId, r.Take)
+	if err != nil {
+		log.Println(err)
+		return nil, toStatus(err)
+	}
+	return recommendationsToProto(recs), nil
+}
+
+func (s *grpcServer) Refresh(c context.Context, r *genproto.RefreshRequest) (*genproto.RefreshResponse, error) {
+	snapshot, err := s.service.Refresh(c)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return &genproto.RefreshResponse{
+		Products: uint64(len(snapshot.Related)),
+		Accounts: uint64(len(snapshot.Accounts)),
+	}, nil
+}
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"math"
+	"sort"
+
+	"github.com/wignn/micro-3/recommendation/model"
+)
+
+const (
+	// weights of the two signals in the related products score, both are in [0, 1]
+	cooccurrenceWeight = 0.7
+	similarityWeight   = 0.3
+
+	// maxProductsPerOrder bounds the pairs counted for a single large order
+	maxProductsPerOrder = 50
+)
+
+// cooccurrence counts in how many orders products appear and appear together
+type cooccurrence struct {
+	orders map[string]int
+	pairs  map[string]map[string]int
+}
+
+func newCooccurrence() *cooccurrence {
+	return &cooccurrence{
+		orders: map[string]int{},
+		pairs:  map[string]map[string]int{},
+	}
+}
+
+// addOrder counts the distinct products of one order
+func (co *cooccurrence) addOrder(products []string) {
+	if len(products) > maxProductsPerOrder {
+		products = products[:maxProductsPerOrder]
+	}
+	for i, a := range products {
+		co.orders[a]++
+		for _, b := range products[i+1:] {
+			co.addPair(a, b)
+			co.addPair(b, a)
+		}
+	}
+}
+
+func (co *cooccurrence) addPair(a, b string) {
+	if co.pairs[a] == nil {
+		co.pairs[a] = map[string]int{}
+	}
+	co.pairs[a][b]++
+}
+
+// scores returns the cosine similarity of a with every product bought with it:
+// orders with both divided by the geometric mean of the orders with each
+func (co *cooccurrence) scores(a string) map[string]float64 {
+	scores := map[string]float64{}
+	for b, both := range co.pairs[a] {
+		scores[b] = float64(both) / math.Sqrt(float64(co.orders[a]*co.orders[b]))
+	}
+	return scores
+}
+
+// relatedScores blends co-occurrence with more-like-this similarity, the
+// similarity scores are normalized by the best match of the product
+func relatedScores(bought map[string]float64, similar []model.Recommendation) map[string]float64 {
+	scores := map[string]float64{}
+	for id, score := range bought {
+		scores[id] += cooccurrenceWeight * score
+	}
+	top := 0.0
+	for _, s := range similar {
+		top = math.Max(top, s.Score)
+	}
+	if top > 0 {
+		for _, s := range similar {
+			scores[s.ProductID] += similarityWeight * s.Score / top
+		}
+	}
+	return scores
+}
+
+// accountScores sums the related scores of everything an account bought,
+// products the account already bought are not recommended again
+func accountScores(bought map[string]bool, related map[string][]model.Recommendation) map[string]float64 {
+	scores := map[string]float64{}
+	for id := range bought {
+		for _, rec := range related[id] {
+			if !bought[rec.ProductID] {
+				scores[rec.ProductID] += rec.Score
+			}
+		}
+	}
+	return scores
+}
+
+// top returns the n best scored products, ties are broken by id to keep runs stable
+func top(scores map[string]float64, n int) []model.Recommendation {
+	recs := make([]model.Recommendation, 0, len(scores))
+	for id, score := range scores {
+		if score > 0 {
+			recs = append(recs, model.Recommendation{ProductID: id, Score: score})
+		}
+	}
+	sort.Slice(recs, func(i, j int) bool {
+		if recs[i].Score != recs[j].Score {
+			return recs[i].Score > recs[j].Score
+		}
+		return recs[i].ProductID < recs[j].ProductID
+	})
+	if len(recs) > n {
+		recs = recs[:n]
+	}
+	return recs
+}
@@ -0,0 +1,151 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+
+	catalog "github.com/wignn/micro-3/catalog/client"
+	catalogProto "github.com/wignn/micro-3/catalog/genproto"
+	order "github.com/wignn/micro-3/order/client"
+	orderModel "github.com/wignn/micro-3/order/model"
+	"github.com/wignn/micro-3/recommendation/model"
+	"github.com/wignn/micro-3/recommendation/repository"
+)
+
+var ErrMissingID = errors.New("id is required")
+
+type RecommendationService interface {
+	GetRelatedProducts(c context.Context, productID string, take uint64) ([]model.Recommendation, error)
+	GetRecommendationsForAccount(c context.Context, accountID string, take uint64) ([]model.Recommendation, error)
+	Refresh(c context.Context) (*model.Snapshot, error)
+}
+
+type recommendationService struct {
+	repository    repository.RecommendationRepository
+	catalogClient *catalog.CatalogClient
+	orderClient   *order.OrderClient
+	maxResults    int
+}
+
+// NewRecommendationService creates the service, every run stores at most
+// maxResults recommendations per product and per account
+func NewRecommendationService(r repository.RecommendationRepository, catalogClient *catalog.CatalogClient, orderClient *order.OrderClient, maxResults int) RecommendationService {
+	return &recommendationService{r, catalogClient, orderClient, maxResults}
+}
+
+func (s *recommendationService) take(take uint64) uint64 {
+	if take == 0 || take > uint64(s.maxResults) {
+		return uint64(s.maxResults)
+	}
+	return take
+}
+
+// GetRelatedProducts returns the precomputed related products, products that
+// were created after the last run fall back to a live more-like-this search
+func (s *recommendationService) GetRelatedProducts(c context.Context, productID string, take uint64) ([]model.Recommendation, error) {
+	if productID == "" {
+		return nil, ErrMissingID
+	}
+	take = s.take(take)
+	recs, err := s.repository.GetRelatedProducts(c, productID, take)
+	if err != nil || len(recs) > 0 {
+		return recs, err
+	}
+	return s.similarProducts(c, productID, take)
+}
+
+func (s *recommendationService) GetRecommendationsForAccount(c context.Context, accountID string, take uint64) ([]model.Recommendation, error) {
+	if accountID == "" {
+		return nil, ErrMissingID
+	}
+	return s.repository.GetRecommendationsForAccount(c, accountID, s.take(take))
+}
+
+func (s *recommendationService) similarProducts(c context.Context, productID string, take uint64) ([]model.Recommendation, error) {
+	similar, err := s.catalogClient.GetSimilarProducts(c, productID, take)
+	if err != nil {
+		return nil, err
+	}
+	recs := []model.Recommendation{}
+	for _, p := range similar {
+		recs = append(recs, model.Recommendation{ProductID: p.Product.Id, Score: p.Score})
+	}
+	return recs, nil
+}
+
+// Refresh recomputes every recommendation from the live catalog and the order
+// history and replaces the stored ones
+func (s *recommendationService) Refresh(c context.Context) (*model.Snapshot, error) {
+	live, err := s.liveProducts(c)
+	if err != nil {
+		return nil, err
+	}
+
+	co := newCooccurrence()
+	accounts := map[string]map[string]bool{}
+	lastOrder := ""
+	orderProducts := []string{}
+	err = s.orderClient.ExportOrderLines(c, func(l *orderModel.OrderLine) error {
+		// lines arrive grouped by order
+		if l.OrderID != lastOrder {
+			co.addOrder(orderProducts)
+			lastOrder, orderProducts = l.OrderID, []string{}
+		}
+		if !live[l.ProductID] {
+			return nil
+		}
+		orderProducts = append(orderProducts, l.ProductID)
+		if accounts[l.AccountID] == nil {
+			accounts[l.AccountID] = map[string]bool{}
+		}
+		accounts[l.AccountID][l.ProductID] = true
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	co.addOrder(orderProducts)
+
+	snapshot := &model.Snapshot{
+		Related:  map[string][]model.Recommendation{},
+		Accounts: map[string][]model.Recommendation{},
+	}
+	for id := range live {
+		similar, err := s.similarProducts(c, id, uint64(s.maxResults))
+		if err != nil {
+			// keep the co-occurrence signal when the search fails for a product
+			log.Printf("failed to find products similar to %s: %v\n", id, err)
+		}
+		if recs := top(relatedScores(co.scores(id), similar), s.maxResults); len(recs) > 0 {
+			snapshot.Related[id] = recs
+		}
+	}
+	for accountID, bought := range accounts {
+		if recs := top(accountScores(bought, snapshot.Related), s.maxResults); len(recs) > 0 {
+			snapshot.Accounts[accountID] = recs
+		}
+	}
+
+	if err := s.repository.ReplaceSnapshot(c, snapshot); err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// liveProducts returns the ids of every product that is not soft deleted
+func (s *recommendationService) liveProducts(c context.Context) (map[string]bool, error) {
+	live := map[string]bool{}
+	err := s.catalogClient.ExportProducts(c, catalogProto.ProductFormat_NDJSON, false, func(row string) error {
+		var p struct {
+			ID string `json:"id"`
+		}
+		if err := json.Unmarshal([]byte(row), &p); err != nil {
+			return err
+		}
+		live[p.ID] = true
+		return nil
+	})
+	return live, err
+}
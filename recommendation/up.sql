@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS related_products (
+  product_id CHAR(27) NOT NULL,
+  related_id CHAR(27) NOT NULL,
+  score DOUBLE PRECISION NOT NULL,
+  PRIMARY KEY (product_id, related_id)
+);
+
+CREATE INDEX IF NOT EXISTS related_products_score ON related_products (product_id, score DESC);
+
+CREATE TABLE IF NOT EXISTS account_recommendations (
+  account_id CHAR(27) NOT NULL,
+  product_id CHAR(27) NOT NULL,
+  score DOUBLE PRECISION NOT NULL,
+  PRIMARY KEY (account_id, product_id)
+);
+
+CREATE INDEX IF NOT EXISTS account_recommendations_score ON account_recommendations (account_id, score DESC);